@@ -756,6 +756,31 @@ func (c *Client) RefreshFeedContext(ctx context.Context, feedID int64) error {
 	return err
 }
 
+// BackfillFeed loads older entries from the feed archives and returns the number of entries added.
+func (c *Client) BackfillFeed(feedID int64) (int, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.BackfillFeedContext(ctx, feedID)
+}
+
+// BackfillFeedContext loads older entries from the feed archives and returns the number of entries added.
+func (c *Client) BackfillFeedContext(ctx context.Context, feedID int64) (int, error) {
+	body, err := c.request.Put(ctx, fmt.Sprintf("/v1/feeds/%d/backfill", feedID), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	var result struct {
+		AddedEntries int `json:"added_entries"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return result.AddedEntries, nil
+}
+
 // DeleteFeed removes a feed.
 func (c *Client) DeleteFeed(feedID int64) error {
 	ctx, cancel := withDefaultTimeout()
@@ -791,6 +816,29 @@ func (c *Client) FeedIconContext(ctx context.Context, feedID int64) (*FeedIcon,
 	return feedIcon, nil
 }
 
+// FeedFetchLog gets the most recent fetch logs of a feed.
+func (c *Client) FeedFetchLog(feedID int64) (FeedFetchLogs, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.FeedFetchLogContext(ctx, feedID)
+}
+
+// FeedFetchLogContext gets the most recent fetch logs of a feed.
+func (c *Client) FeedFetchLogContext(ctx context.Context, feedID int64) (FeedFetchLogs, error) {
+	body, err := c.request.Get(ctx, fmt.Sprintf("/v1/feeds/%d/fetch-log", feedID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var fetchLogs FeedFetchLogs
+	if err := json.NewDecoder(body).Decode(&fetchLogs); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return fetchLogs, nil
+}
+
 // FeedEntry gets a single feed entry.
 func (c *Client) FeedEntry(feedID, entryID int64) (*Entry, error) {
 	ctx, cancel := withDefaultTimeout()
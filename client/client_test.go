@@ -940,6 +940,39 @@ func TestFeedIcon(t *testing.T) {
 	}
 }
 
+func TestFeedFetchLog(t *testing.T) {
+	expected := FeedFetchLogs{
+		{
+			ID:           2,
+			FeedID:       1,
+			StatusCode:   http.StatusOK,
+			EffectiveURL: "https://example.org/feed.xml",
+			Redirects:    []string{"http://example.org/feed.xml"},
+			NewEntries:   3,
+		},
+		{
+			ID:          1,
+			FeedID:      1,
+			StatusCode:  http.StatusNotModified,
+			NotModified: true,
+		},
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodGet, "http://mf/v1/feeds/1/fetch-log", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.FeedFetchLogContext(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
 func TestFeedEntry(t *testing.T) {
 	expected := &Entry{
 		ID:    1,
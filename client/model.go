@@ -174,6 +174,10 @@ type Feed struct {
 	NSFW                        bool      `json:"nsfw"`
 	DisableHTTP2                bool      `json:"disable_http2"`
 	ProxyURL                    string    `json:"proxy_url"`
+	Extractor                   string    `json:"extractor"`
+	DetectedExtractor           string    `json:"detected_extractor"`
+	CustomHeaders               string    `json:"custom_headers"`
+	ClientCertificateID         int64     `json:"client_certificate_id"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
@@ -199,6 +203,8 @@ type FeedCreationRequest struct {
 	NSFW                        bool   `json:"nsfw"`
 	DisableHTTP2                bool   `json:"disable_http2"`
 	ProxyURL                    string `json:"proxy_url"`
+	CustomHeaders               string `json:"custom_headers"`
+	ClientCertificateID         int64  `json:"client_certificate_id"`
 }
 
 // FeedModificationRequest represents the request to update a feed.
@@ -226,6 +232,9 @@ type FeedModificationRequest struct {
 	NSFW                        *bool   `json:"nsfw"`
 	DisableHTTP2                *bool   `json:"disable_http2"`
 	ProxyURL                    *string `json:"proxy_url"`
+	Extractor                   *string `json:"extractor"`
+	CustomHeaders               *string `json:"custom_headers"`
+	ClientCertificateID         *int64  `json:"client_certificate_id"`
 }
 
 // FeedIcon represents the feed icon.
@@ -235,6 +244,28 @@ type FeedIcon struct {
 	Data     string `json:"data"`
 }
 
+// FeedFetchLog represents what happened during a feed refresh.
+type FeedFetchLog struct {
+	ID             int64     `json:"id"`
+	FeedID         int64     `json:"feed_id"`
+	FetchedAt      time.Time `json:"fetched_at"`
+	StatusCode     int       `json:"status_code"`
+	EffectiveURL   string    `json:"effective_url"`
+	Redirects      []string  `json:"redirects"`
+	NotModified    bool      `json:"not_modified"`
+	ETag           string    `json:"etag"`
+	LastModified   string    `json:"last_modified"`
+	ContentLength  int64     `json:"content_length"`
+	DurationMs     int64     `json:"duration_ms"`
+	ProxyURL       string    `json:"proxy_url"`
+	NewEntries     int       `json:"new_entries"`
+	UpdatedEntries int       `json:"updated_entries"`
+	ErrorMessage   string    `json:"error_message"`
+}
+
+// FeedFetchLogs represents a list of fetch logs, the most recent first.
+type FeedFetchLogs []*FeedFetchLog
+
 type FeedCounters struct {
 	ReadCounters   map[int64]int `json:"reads"`
 	UnreadCounters map[int64]int `json:"unreads"`
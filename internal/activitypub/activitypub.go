@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/activitypub"
+
+import (
+	"fmt"
+	"html"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+// ProviderName is the name of the integration enabling the ActivityPub actor of a user.
+const ProviderName = "activitypub"
+
+// ContentType is the media type of the ActivityPub documents.
+const ContentType = "application/activity+json"
+
+// PublicCollection is the audience of the public activities.
+const PublicCollection = "https://www.w3.org/ns/activitystreams#Public"
+
+// Types of the objects published for the entries.
+const (
+	ObjectTypeNote    = "Note"
+	ObjectTypeArticle = "Article"
+)
+
+// outboxPageSize is the number of activities of each page of the outbox.
+const outboxPageSize = 20
+
+var (
+	activityStreamsContext = []string{"https://www.w3.org/ns/activitystreams", "https://w3id.org/security/v1"}
+	usernamePattern        = regexp.MustCompile(`^[a-z0-9_]{1,30}$`)
+)
+
+// Actor is the document describing an actor, it is used for the local actors and the remote followers.
+type Actor struct {
+	Context           any        `json:"@context,omitempty"`
+	ID                string     `json:"id"`
+	Type              string     `json:"type"`
+	PreferredUsername string     `json:"preferredUsername,omitempty"`
+	Name              string     `json:"name,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	Inbox             string     `json:"inbox"`
+	Outbox            string     `json:"outbox,omitempty"`
+	Followers         string     `json:"followers,omitempty"`
+	Endpoints         *Endpoints `json:"endpoints,omitempty"`
+	PublicKey         PublicKey  `json:"publicKey"`
+
+	ManuallyApprovesFollowers bool `json:"manuallyApprovesFollowers"`
+}
+
+// Endpoints are the optional endpoints of an actor.
+type Endpoints struct {
+	SharedInbox string `json:"sharedInbox,omitempty"`
+}
+
+// PublicKey is the key verifying the signatures of an actor.
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// Activity is an activity sent by a local actor, the object is a URL or an embedded object.
+type Activity struct {
+	Context   any      `json:"@context,omitempty"`
+	ID        string   `json:"id"`
+	Type      string   `json:"type"`
+	Actor     string   `json:"actor"`
+	Published string   `json:"published,omitempty"`
+	To        []string `json:"to,omitempty"`
+	CC        []string `json:"cc,omitempty"`
+	Object    any      `json:"object"`
+}
+
+// Object is an entry published by a local actor, its identifier is the URL of the shared entry.
+type Object struct {
+	Context      any      `json:"@context,omitempty"`
+	ID           string   `json:"id"`
+	Type         string   `json:"type"`
+	AttributedTo string   `json:"attributedTo,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	Content      string   `json:"content,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Published    string   `json:"published,omitempty"`
+	To           []string `json:"to,omitempty"`
+	CC           []string `json:"cc,omitempty"`
+}
+
+// Collection is a collection or a page of a collection.
+type Collection struct {
+	Context      any         `json:"@context,omitempty"`
+	ID           string      `json:"id"`
+	Type         string      `json:"type"`
+	TotalItems   *int        `json:"totalItems,omitempty"`
+	First        string      `json:"first,omitempty"`
+	Next         string      `json:"next,omitempty"`
+	PartOf       string      `json:"partOf,omitempty"`
+	OrderedItems []*Activity `json:"orderedItems,omitempty"`
+}
+
+// ActorURL returns the identifier of the actor of a user, it does not change with the username.
+func ActorURL(userID int64) string {
+	return fmt.Sprintf("%s/activitypub/actors/%d", config.Opts.BaseURL(), userID)
+}
+
+func inboxURL(userID int64) string {
+	return ActorURL(userID) + "/inbox"
+}
+
+func outboxURL(userID int64) string {
+	return ActorURL(userID) + "/outbox"
+}
+
+func followersURL(userID int64) string {
+	return ActorURL(userID) + "/followers"
+}
+
+func keyID(userID int64) string {
+	return ActorURL(userID) + "#main-key"
+}
+
+// ShareURL returns the URL of a shared entry, it is the identifier of the published object.
+func ShareURL(shareCode string) string {
+	return config.Opts.BaseURL() + "/share/" + shareCode
+}
+
+// Host returns the host of the accounts of the actors.
+func Host() string {
+	baseURL, err := url.Parse(config.Opts.BaseURL())
+	if err != nil {
+		return ""
+	}
+	return baseURL.Host
+}
+
+// Handle returns the account of an actor, as displayed by Mastodon.
+func Handle(username string) string {
+	return "@" + username + "@" + Host()
+}
+
+// IsValidUsername returns true if the username can be used in the account of an actor.
+func IsValidUsername(username string) bool {
+	return usernamePattern.MatchString(username)
+}
+
+// AcceptsActivityJSON returns true if the request asks for an ActivityPub document instead of a web page.
+func AcceptsActivityJSON(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		if mediaType == ContentType || (mediaType == "application/ld+json" && strings.Contains(params["profile"], "activitystreams")) {
+			return true
+		}
+	}
+	return false
+}
+
+func newActor(userID int64, username string, key *model.ActivityPubKey) *Actor {
+	actorURL := ActorURL(userID)
+	return &Actor{
+		Context:           activityStreamsContext,
+		ID:                actorURL,
+		Type:              "Person",
+		PreferredUsername: username,
+		Name:              username,
+		URL:               actorURL,
+		Inbox:             inboxURL(userID),
+		Outbox:            outboxURL(userID),
+		Followers:         followersURL(userID),
+		PublicKey: PublicKey{
+			ID:           keyID(userID),
+			Owner:        actorURL,
+			PublicKeyPem: key.PublicKey,
+		},
+	}
+}
+
+// newObject returns the object of a published entry. The notes link to the shared entry and to the website,
+// the articles contain the content of the entry.
+func newObject(object *model.ActivityPubObject, entry *model.Entry) *Object {
+	shareURL := ShareURL(object.ShareCode)
+	document := &Object{
+		ID:           shareURL,
+		Type:         object.ObjectType,
+		AttributedTo: ActorURL(object.UserID),
+		URL:          shareURL,
+		Published:    object.PublishedAt.UTC().Format(time.RFC3339),
+		To:           []string{PublicCollection},
+		CC:           []string{followersURL(object.UserID)},
+	}
+
+	if object.ObjectType == ObjectTypeArticle {
+		document.Name = entry.Title
+		document.Content = entry.Content
+		return document
+	}
+
+	document.Content = fmt.Sprintf(`<p><a href="%s">%s</a></p>`, html.EscapeString(shareURL), html.EscapeString(entry.Title))
+	if entry.URL != "" {
+		document.Content += fmt.Sprintf(`<p><a href="%s">%s</a></p>`, html.EscapeString(entry.URL), html.EscapeString(entry.URL))
+	}
+	return document
+}
+
+func newCreateActivity(object *model.ActivityPubObject, entry *model.Entry) *Activity {
+	document := newObject(object, entry)
+	return &Activity{
+		Context:   activityStreamsContext,
+		ID:        document.ID + "#create",
+		Type:      "Create",
+		Actor:     document.AttributedTo,
+		Published: document.Published,
+		To:        document.To,
+		CC:        document.CC,
+		Object:    document,
+	}
+}
+
+func newDeleteActivity(object *model.ActivityPubObject) *Activity {
+	shareURL := ShareURL(object.ShareCode)
+	return &Activity{
+		Context: activityStreamsContext,
+		ID:      shareURL + "#delete",
+		Type:    "Delete",
+		Actor:   ActorURL(object.UserID),
+		To:      []string{PublicCollection},
+		CC:      []string{followersURL(object.UserID)},
+		Object:  &Object{ID: shareURL, Type: "Tombstone"},
+	}
+}
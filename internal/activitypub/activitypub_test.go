@@ -0,0 +1,437 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/activitypub"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestMain(m *testing.M) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://reader.example.org")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func newTestSigner(t *testing.T) (*signer, *model.ActivityPubKey) {
+	t.Helper()
+
+	key, err := generateKey(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := newSigner(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, key
+}
+
+func newSignedRequest(t *testing.T, signer *signer, body []byte) *http.Request {
+	t.Helper()
+
+	request := httptest.NewRequest(http.MethodPost, "https://reader.example.org/activitypub/actors/1/inbox", bytes.NewReader(body))
+	if err := signer.sign(request, body); err != nil {
+		t.Fatal(err)
+	}
+	return request
+}
+
+func TestSignAndVerifyRequest(t *testing.T) {
+	signer, key := newTestSigner(t)
+	body := []byte(`{"type":"Follow"}`)
+	request := newSignedRequest(t, signer, body)
+
+	signature, err := parseSignature(request.Header.Get("Signature"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signature.keyID != "https://reader.example.org/activitypub/actors/1#main-key" {
+		t.Errorf(`Unexpected key ID %q`, signature.keyID)
+	}
+
+	publicKey, err := parsePublicKey(key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signature.verify(request, body, publicKey, time.Now()); err != nil {
+		t.Errorf(`Expected the signature to be valid, got %v`, err)
+	}
+
+	if err := signature.verify(request, []byte(`{"type":"Undo"}`), publicKey, time.Now()); !errors.Is(err, errInvalidDigest) {
+		t.Errorf(`Expected an invalid digest for another body, got %v`, err)
+	}
+	if err := signature.verify(request, body, publicKey, time.Now().Add(24*time.Hour)); !errors.Is(err, errExpiredSignature) {
+		t.Errorf(`Expected an expired signature, got %v`, err)
+	}
+
+	request.Header.Set("Host", "other.example.org")
+	request.Host = "other.example.org"
+	if err := signature.verify(request, body, publicKey, time.Now()); !errors.Is(err, errInvalidSignature) {
+		t.Errorf(`Expected an invalid signature for another host, got %v`, err)
+	}
+}
+
+func TestVerifyRequiresSignedDigest(t *testing.T) {
+	signer, key := newTestSigner(t)
+	body := []byte(`{"type":"Follow"}`)
+	request := newSignedRequest(t, signer, body)
+
+	signature, err := parseSignature(request.Header.Get("Signature"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature.headers = []string{"(request-target)", "host", "date"}
+
+	publicKey, err := parsePublicKey(key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signature.verify(request, body, publicKey, time.Now()); !errors.Is(err, errInvalidSignature) {
+		t.Errorf(`Expected the digest to be required, got %v`, err)
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	signature, err := parseSignature(`keyId="https://example.org/users/alice#main-key",algorithm="rsa-sha256",headers="(request-target) Host date",signature="c2lnbmF0dXJl"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signature.keyID != "https://example.org/users/alice#main-key" {
+		t.Errorf(`Unexpected key ID %q`, signature.keyID)
+	}
+	if strings.Join(signature.headers, " ") != "(request-target) host date" {
+		t.Errorf(`Unexpected headers %v`, signature.headers)
+	}
+	if string(signature.signature) != "signature" {
+		t.Errorf(`Unexpected signature %q`, signature.signature)
+	}
+
+	for _, header := range []string{"", `keyId="https://example.org/key"`, `keyId="https://example.org/key",signature="%%%"`, "invalid"} {
+		if _, err := parseSignature(header); err == nil {
+			t.Errorf(`Expected an error for the header %q`, header)
+		}
+	}
+}
+
+func TestAcceptsActivityJSON(t *testing.T) {
+	scenarios := map[string]bool{
+		"application/activity+json": true,
+		`application/ld+json; profile="https://www.w3.org/ns/activitystreams"`: true,
+		"text/html, application/activity+json;q=0.9":                           true,
+		"application/ld+json":             false,
+		"text/html,application/xhtml+xml": false,
+		"":                                false,
+	}
+
+	for accept, expected := range scenarios {
+		request := httptest.NewRequest(http.MethodGet, "/share/code", nil)
+		request.Header.Set("Accept", accept)
+		if got := AcceptsActivityJSON(request); got != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, accept, got, expected)
+		}
+	}
+}
+
+func TestIsValidUsername(t *testing.T) {
+	for _, username := range []string{"alice", "bob_42", strings.Repeat("a", 30)} {
+		if !IsValidUsername(username) {
+			t.Errorf(`Expected %q to be valid`, username)
+		}
+	}
+	for _, username := range []string{"", "Alice", "alice@example.org", "a-b", strings.Repeat("a", 31)} {
+		if IsValidUsername(username) {
+			t.Errorf(`Expected %q to be invalid`, username)
+		}
+	}
+}
+
+func TestParseAccount(t *testing.T) {
+	scenarios := map[string]string{
+		"acct:alice@reader.example.org":  "alice",
+		"acct:@Alice@reader.example.org": "alice",
+		"acct:alice@other.example.org":   "",
+		"acct:alice":                     "",
+		"alice@reader.example.org":       "",
+	}
+
+	for resource, expected := range scenarios {
+		username, found := parseAccount(resource)
+		if username != expected || found != (expected != "") {
+			t.Errorf(`Unexpected username for %q, got %q instead of %q`, resource, username, expected)
+		}
+	}
+}
+
+func TestParseActorURL(t *testing.T) {
+	if userID, found := parseActorURL(ActorURL(42)); !found || userID != 42 {
+		t.Errorf(`Unexpected user %d for the actor URL`, userID)
+	}
+
+	for _, resource := range []string{"https://reader.example.org/activitypub/actors/abc", "https://other.example.org/activitypub/actors/42", ActorURL(42) + "/inbox"} {
+		if _, found := parseActorURL(resource); found {
+			t.Errorf(`Expected no user for %q`, resource)
+		}
+	}
+}
+
+func TestNewObject(t *testing.T) {
+	publishedAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	object := &model.ActivityPubObject{UserID: 1, EntryID: 2, ShareCode: "code", ObjectType: ObjectTypeNote, PublishedAt: publishedAt}
+	entry := &model.Entry{ID: 2, Title: "Tom & Jerry", URL: "https://example.org/article", Content: "<p>Content</p>"}
+
+	note := newObject(object, entry)
+	if note.ID != "https://reader.example.org/share/code" || note.Type != ObjectTypeNote || note.AttributedTo != ActorURL(1) {
+		t.Errorf(`Unexpected note %+v`, note)
+	}
+	if note.Published != "2024-03-01T12:00:00Z" {
+		t.Errorf(`Unexpected publication date %q`, note.Published)
+	}
+	expectedContent := `<p><a href="https://reader.example.org/share/code">Tom &amp; Jerry</a></p><p><a href="https://example.org/article">https://example.org/article</a></p>`
+	if note.Content != expectedContent {
+		t.Errorf(`Unexpected note content %q`, note.Content)
+	}
+
+	object.ObjectType = ObjectTypeArticle
+	article := newObject(object, entry)
+	if article.Type != ObjectTypeArticle || article.Name != entry.Title || article.Content != entry.Content {
+		t.Errorf(`Unexpected article %+v`, article)
+	}
+}
+
+func TestCreateAndDeleteActivities(t *testing.T) {
+	object := &model.ActivityPubObject{UserID: 1, EntryID: 2, ShareCode: "code", ObjectType: ObjectTypeNote, PublishedAt: time.Now()}
+
+	data, err := json.Marshal(newCreateActivity(object, &model.Entry{Title: "Title"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var create struct {
+		ID     string   `json:"id"`
+		Type   string   `json:"type"`
+		Actor  string   `json:"actor"`
+		To     []string `json:"to"`
+		Object struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(data, &create); err != nil {
+		t.Fatal(err)
+	}
+	if create.Type != "Create" || create.Actor != ActorURL(1) || create.Object.ID != ShareURL("code") || create.Object.Type != ObjectTypeNote {
+		t.Errorf(`Unexpected create activity %s`, data)
+	}
+	if len(create.To) != 1 || create.To[0] != PublicCollection {
+		t.Errorf(`Expected a public activity, got %v`, create.To)
+	}
+
+	remove := newDeleteActivity(object)
+	if remove.Type != "Delete" || remove.ID == create.ID || remove.Object.(*Object).ID != ShareURL("code") {
+		t.Errorf(`Unexpected delete activity %+v`, remove)
+	}
+}
+
+func TestObjectID(t *testing.T) {
+	scenarios := map[string]string{
+		`"https://example.org/users/alice"`:                      "https://example.org/users/alice",
+		`{"id":"https://example.org/follows/1","type":"Follow"}`: "https://example.org/follows/1",
+		`[]`: "",
+	}
+
+	for raw, expected := range scenarios {
+		if got := objectID(json.RawMessage(raw)); got != expected {
+			t.Errorf(`Unexpected object ID for %s, got %q instead of %q`, raw, got, expected)
+		}
+	}
+}
+
+func TestWebFingerHandle(t *testing.T) {
+	if handle := Handle("alice"); handle != "@alice@reader.example.org" {
+		t.Errorf(`Unexpected handle %q`, handle)
+	}
+}
+
+// newRemoteServer serves the documents of the remote actors, the requests must be signed.
+func newRemoteServer(t *testing.T, documents map[string]func(serverURL string) any) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document, found := documents[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Signature") == "" {
+			t.Errorf(`The request of %s must be signed`, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", ContentType)
+		json.NewEncoder(w).Encode(document(server.URL))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newRemoteActor(serverURL, username string, key *model.ActivityPubKey) *Actor {
+	actorURL := serverURL + "/users/" + username
+	return &Actor{
+		ID:        actorURL,
+		Type:      "Person",
+		Inbox:     actorURL + "/inbox",
+		PublicKey: PublicKey{ID: actorURL + "#main-key", Owner: actorURL, PublicKeyPem: key.PublicKey},
+	}
+}
+
+func TestVerifyActivity(t *testing.T) {
+	localSigner, _ := newTestSigner(t)
+	aliceSigner, aliceKey := newTestSigner(t)
+	mallorySigner, malloryKey := newTestSigner(t)
+
+	server := newRemoteServer(t, map[string]func(string) any{
+		"/users/alice": func(serverURL string) any {
+			return newRemoteActor(serverURL, "alice", aliceKey)
+		},
+		// The key document of Mallory claims to be the key of Alice.
+		"/users/mallory": func(serverURL string) any {
+			return &PublicKey{ID: serverURL + "/users/alice", Owner: serverURL + "/users/alice", PublicKeyPem: malloryKey.PublicKey}
+		},
+	})
+	aliceSigner.keyID = server.URL + "/users/alice#main-key"
+	mallorySigner.keyID = server.URL + "/users/mallory#main-key"
+
+	body := []byte(`{"type":"Follow","actor":"` + server.URL + `/users/alice"}`)
+	activity := &incomingActivity{Type: "Follow", Actor: server.URL + "/users/alice"}
+
+	remoteActor, err := verifyActivity(newSignedRequest(t, aliceSigner, body), body, activity, localSigner)
+	if err != nil {
+		t.Fatalf(`Expected the activity of Alice to be valid, got %v`, err)
+	}
+	if remoteActor.Inbox != server.URL+"/users/alice/inbox" {
+		t.Errorf(`Unexpected actor: %+v`, remoteActor)
+	}
+
+	if _, err := verifyActivity(newSignedRequest(t, mallorySigner, body), body, activity, localSigner); err == nil {
+		t.Error(`A key document claiming the identity of another actor must be rejected`)
+	}
+}
+
+func TestFetchActorRejectsAnotherActor(t *testing.T) {
+	localSigner, _ := newTestSigner(t)
+	_, aliceKey := newTestSigner(t)
+
+	server := newRemoteServer(t, map[string]func(string) any{
+		"/users/mallory": func(serverURL string) any {
+			return newRemoteActor(serverURL, "alice", aliceKey)
+		},
+	})
+
+	if _, err := fetchActor(server.URL+"/users/mallory", localSigner); err == nil {
+		t.Error(`An actor document describing another actor must be rejected`)
+	}
+}
+
+func TestCheckActorKey(t *testing.T) {
+	_, key := newTestSigner(t)
+	keyID := "https://social.example.org/users/alice#main-key"
+
+	if err := checkActorKey(newRemoteActor("https://social.example.org", "alice", key), keyID); err != nil {
+		t.Errorf(`Expected the key to be valid, got %v`, err)
+	}
+
+	scenarios := map[string]func(actor *Actor){
+		"another key":      func(actor *Actor) { actor.PublicKey.ID = "https://social.example.org/users/bob#main-key" },
+		"another owner":    func(actor *Actor) { actor.PublicKey.Owner = "https://social.example.org/users/bob" },
+		"no public key":    func(actor *Actor) { actor.PublicKey.PublicKeyPem = "" },
+		"another server":   func(actor *Actor) { actor.Inbox = "https://other.example.org/inbox" },
+		"another protocol": func(actor *Actor) { actor.Inbox = "http://social.example.org/users/alice/inbox" },
+	}
+	for name, change := range scenarios {
+		actor := newRemoteActor("https://social.example.org", "alice", key)
+		change(actor)
+		if err := checkActorKey(actor, keyID); err == nil {
+			t.Errorf(`Expected the key to be rejected with %s`, name)
+		}
+	}
+}
+
+// withPublicAddresses resolves all the hosts to a public address during the test.
+func withPublicAddresses(t *testing.T) {
+	t.Helper()
+
+	lookupIP = func(host string) ([]net.IP, error) { return []net.IP{net.ParseIP("203.0.114.10")}, nil }
+	t.Cleanup(func() { lookupIP = net.LookupIP })
+}
+
+func TestCheckInbox(t *testing.T) {
+	withPublicAddresses(t)
+
+	if err := checkInbox("https://social.example.org/inbox", "https://social.example.org/users/alice"); err != nil {
+		t.Errorf(`Expected the inbox to be valid, got %v`, err)
+	}
+
+	if err := checkInbox("https://other.example.org/inbox", "https://social.example.org/users/alice"); err == nil {
+		t.Error(`An inbox on another server must be rejected`)
+	}
+
+	for _, address := range []string{"127.0.0.1", "10.1.2.3", "192.168.1.1", "169.254.169.254", "::1", "fd00::1", "0.0.0.0"} {
+		lookupIP = func(host string) ([]net.IP, error) { return []net.IP{net.ParseIP(address)}, nil }
+		if err := checkInbox("https://social.example.org/inbox", "https://social.example.org/users/alice"); err == nil {
+			t.Errorf(`An inbox resolving to %s must be rejected`, address)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	withPublicAddresses(t)
+	signer, _ := newTestSigner(t)
+
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != ContentType {
+			t.Errorf(`Unexpected request %s with the content type %q`, r.Method, r.Header.Get("Content-Type"))
+		}
+		for _, name := range []string{"Date", "Digest", "Signature"} {
+			if r.Header.Get(name) == "" {
+				t.Errorf(`The %s header must be sent`, name)
+			}
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	activity := &Activity{Type: "Accept", Actor: ActorURL(1)}
+	if err := deliver(server.URL+"/users/alice/inbox", server.URL+"/users/alice", activity, signer); err != nil {
+		t.Fatalf(`Expected the activity to be delivered, got %v`, err)
+	}
+
+	status = http.StatusGone
+	if err := deliver(server.URL+"/users/alice/inbox", server.URL+"/users/alice", activity, signer); !errors.Is(err, errInboxGone) {
+		t.Errorf(`Expected errInboxGone, got %v`, err)
+	}
+
+	if err := deliver(server.URL+"/users/alice/inbox", "https://social.example.org/users/alice", activity, signer); err == nil {
+		t.Error(`An inbox on another server than the follower must be rejected`)
+	}
+}
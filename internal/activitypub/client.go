@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/activitypub"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/version"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+
+	// maxDocumentSize is the maximum size of the documents of the remote actors and of the activities received.
+	maxDocumentSize = 1024 * 1024
+)
+
+// errInboxGone is returned when the inbox of a follower does not exist anymore.
+var errInboxGone = errors.New("activitypub: the inbox does not exist anymore")
+
+// fetchActor fetches the document of a remote actor, the request is signed for the servers requiring it.
+// The document must describe the actor of the URL.
+func fetchActor(actorURL string, signer *signer) (*Actor, error) {
+	var actor Actor
+	if err := fetchDocument(actorURL, signer, &actor); err != nil {
+		return nil, err
+	}
+
+	if actor.ID == "" || actor.Inbox == "" {
+		return nil, fmt.Errorf("activitypub: invalid actor document %s", actorURL)
+	}
+	if actor.ID != actorURL {
+		return nil, fmt.Errorf("activitypub: the actor document %s describes another actor %s", actorURL, actor.ID)
+	}
+	return &actor, nil
+}
+
+// fetchActorKey fetches the actor of an activity and returns it when keyID is its key.
+// The key documents are not fetched: a key is only trusted when the actor document of its owner lists it.
+func fetchActorKey(actorURL, keyID string, signer *signer) (*Actor, error) {
+	actor, err := fetchActor(actorURL, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkActorKey(actor, keyID); err != nil {
+		return nil, err
+	}
+	return actor, nil
+}
+
+// checkActorKey checks that the key of the signature is the key of the actor, and that the actor,
+// its key and its inbox are on the same server.
+func checkActorKey(actor *Actor, keyID string) error {
+	switch {
+	case actor.PublicKey.ID != keyID:
+		return fmt.Errorf("activitypub: the key %s is not the key of the actor %s", keyID, actor.ID)
+	case actor.PublicKey.Owner != actor.ID:
+		return fmt.Errorf("activitypub: the key %s is owned by %s instead of %s", keyID, actor.PublicKey.Owner, actor.ID)
+	case actor.PublicKey.PublicKeyPem == "":
+		return fmt.Errorf("activitypub: no public key in the document of %s", actor.ID)
+	case !isSameOrigin(actor.ID, keyID, actor.Inbox):
+		return fmt.Errorf("activitypub: the actor %s, its key and its inbox are on different servers", actor.ID)
+	}
+	return nil
+}
+
+// isSameOrigin returns true when all the URLs have the same scheme and host.
+func isSameOrigin(rawURLs ...string) bool {
+	var origin string
+	for _, rawURL := range rawURLs {
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil || parsedURL.Host == "" || (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") {
+			return false
+		}
+
+		urlOrigin := parsedURL.Scheme + "://" + strings.ToLower(parsedURL.Host)
+		if origin == "" {
+			origin = urlOrigin
+		} else if urlOrigin != origin {
+			return false
+		}
+	}
+	return origin != ""
+}
+
+// fetchDocument downloads a document with the fetcher, it applies the proxies and the limits of the other requests.
+// The signature headers are computed on a copy of the request, the redirects are not followed since they are not signed.
+func fetchDocument(documentURL string, signer *signer, document any) error {
+	signedRequest, err := http.NewRequest(http.MethodGet, documentURL, nil)
+	if err != nil {
+		return fmt.Errorf("activitypub: unable to create request: %v", err)
+	}
+	if err := signer.sign(signedRequest, nil); err != nil {
+		return err
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(defaultClientTimeout)
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.WithUserAgent("Miniflux/"+version.Version, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithoutRedirects()
+	requestBuilder.WithHeader("Accept", ContentType+`, application/ld+json; profile="https://www.w3.org/ns/activitystreams"`)
+	for _, name := range []string{"Date", "Signature"} {
+		requestBuilder.WithHeader(name, signedRequest.Header.Get(name))
+	}
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(documentURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return fmt.Errorf("activitypub: unable to fetch %s: %w", documentURL, localizedError.Error())
+	}
+	if statusCode := responseHandler.StatusCode(); statusCode < 200 || statusCode > 299 {
+		return fmt.Errorf("activitypub: incorrect response status code %d for %s", statusCode, documentURL)
+	}
+
+	body, localizedError := responseHandler.ReadBody(maxDocumentSize)
+	if localizedError != nil {
+		return fmt.Errorf("activitypub: unable to fetch %s: %w", documentURL, localizedError.Error())
+	}
+
+	if err := json.Unmarshal(body, document); err != nil {
+		return fmt.Errorf("activitypub: unable to decode %s: %v", documentURL, err)
+	}
+	return nil
+}
+
+// deliver sends a signed activity to the inbox of a follower with the fetcher, it applies the proxies and the limits of the other requests.
+// The inbox must be on the server of the follower, the redirects are not followed since they are not signed.
+func deliver(inboxURL, followerURL string, activity any, signer *signer) error {
+	if err := checkInbox(inboxURL, followerURL); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("activitypub: unable to encode activity: %v", err)
+	}
+
+	signedRequest, err := http.NewRequest(http.MethodPost, inboxURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("activitypub: unable to create request: %v", err)
+	}
+	if err := signer.sign(signedRequest, body); err != nil {
+		return err
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(defaultClientTimeout)
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.WithUserAgent("Miniflux/"+version.Version, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithoutRedirects()
+	for _, name := range []string{"Date", "Digest", "Signature"} {
+		requestBuilder.WithHeader(name, signedRequest.Header.Get(name))
+	}
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecutePostRequest(inboxURL, ContentType, body))
+	defer responseHandler.Close()
+
+	// The inboxes usually answer with an empty body, only the errors without response are reported by the fetcher.
+	switch statusCode := responseHandler.StatusCode(); {
+	case statusCode == 0:
+		return fmt.Errorf("activitypub: unable to send activity to %s: %w", inboxURL, responseHandler.LocalizedError().Error())
+	case statusCode == http.StatusGone || statusCode == http.StatusNotFound:
+		return errInboxGone
+	case statusCode < 200 || statusCode > 299:
+		return fmt.Errorf("activitypub: incorrect response status code %d for inbox %s", statusCode, inboxURL)
+	}
+	return nil
+}
+
+// checkInbox returns an error when the inbox is not on the server of the follower,
+// or when its host resolves to a private address: the activities are only sent to public servers.
+func checkInbox(inboxURL, followerURL string) error {
+	if !isSameOrigin(inboxURL, followerURL) {
+		return fmt.Errorf("activitypub: the inbox %s is not on the server of %s", inboxURL, followerURL)
+	}
+
+	parsedURL, err := url.Parse(inboxURL)
+	if err != nil {
+		return fmt.Errorf("activitypub: invalid inbox %s: %v", inboxURL, err)
+	}
+
+	addresses, err := lookupIP(parsedURL.Hostname())
+	if err != nil {
+		return fmt.Errorf("activitypub: unable to resolve the inbox %s: %v", inboxURL, err)
+	}
+	for _, address := range addresses {
+		if !isPublicIP(address) {
+			return fmt.Errorf("activitypub: the inbox %s resolves to the non-public address %s", inboxURL, address)
+		}
+	}
+	return nil
+}
+
+// lookupIP resolves the hosts of the inboxes, the tests replace it to deliver to a local server.
+var lookupIP = net.LookupIP
+
+// isPublicIP returns false for the loopback, private, link-local, multicast and unspecified addresses.
+func isPublicIP(address net.IP) bool {
+	return !address.IsLoopback() &&
+		!address.IsPrivate() &&
+		!address.IsLinkLocalUnicast() &&
+		!address.IsLinkLocalMulticast() &&
+		!address.IsInterfaceLocalMulticast() &&
+		!address.IsMulticast() &&
+		!address.IsUnspecified()
+}
@@ -0,0 +1,448 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/activitypub"
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	jsonResponse "miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+
+	"github.com/gorilla/mux"
+)
+
+// webFingerContentType is the media type of the WebFinger documents.
+const webFingerContentType = "application/jrd+json"
+
+// Serve handles the documents and the inbox of the actors.
+func Serve(router *mux.Router, store *storage.Storage) {
+	h := &handler{store}
+
+	sr := router.PathPrefix("/activitypub/actors/{userID:[0-9]+}").Subrouter()
+	sr.HandleFunc("", h.actor).Name("activityPubActor").Methods(http.MethodGet)
+	sr.HandleFunc("/inbox", h.inbox).Name("activityPubInbox").Methods(http.MethodPost)
+	sr.HandleFunc("/outbox", h.outbox).Name("activityPubOutbox").Methods(http.MethodGet)
+	sr.HandleFunc("/followers", h.followers).Name("activityPubFollowers").Methods(http.MethodGet)
+}
+
+// ServeWebFinger handles the WebFinger requests, it must be served at the root of the host.
+func ServeWebFinger(router *mux.Router, store *storage.Storage) {
+	h := &handler{store}
+	router.HandleFunc("/.well-known/webfinger", h.webFinger).Name("activityPubWebFinger").Methods(http.MethodGet)
+}
+
+// ServeObject writes the object published for a shared entry, for the clients asking for an ActivityPub document.
+func ServeObject(w http.ResponseWriter, r *http.Request, store *storage.Storage, shareCode string) {
+	object, err := store.ActivityPubObjectByShareCode(shareCode)
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+	if object == nil {
+		jsonResponse.NotFound(w, r)
+		return
+	}
+
+	actor, err := loadActor(store, object.UserID)
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+	if actor == nil {
+		jsonResponse.NotFound(w, r)
+		return
+	}
+
+	builder := store.NewEntryQueryBuilder(object.UserID)
+	builder.WithEntryID(object.EntryID)
+	entry, err := builder.GetEntry()
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+	if entry == nil {
+		jsonResponse.NotFound(w, r)
+		return
+	}
+
+	document := newObject(object, entry)
+	document.Context = activityStreamsContext
+	writeDocument(w, r, ContentType, document)
+}
+
+type handler struct {
+	store *storage.Storage
+}
+
+// incomingActivity is an activity received in the inbox, the object is a URL or an embedded object.
+type incomingActivity struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Actor  string          `json:"actor"`
+	Object json.RawMessage `json:"object"`
+}
+
+type webFingerResponse struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases"`
+	Links   []webFingerLink `json:"links"`
+}
+
+type webFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type"`
+	Href string `json:"href"`
+}
+
+func (h *handler) webFinger(w http.ResponseWriter, r *http.Request) {
+	resource := r.URL.Query().Get("resource")
+
+	var userID int64
+	if username, found := parseAccount(resource); found {
+		var err error
+		if userID, err = h.store.ActivityPubUserID(username); err != nil {
+			jsonResponse.ServerError(w, r, err)
+			return
+		}
+	} else if actorUserID, found := parseActorURL(resource); found {
+		userID = actorUserID
+	}
+
+	if userID == 0 {
+		jsonResponse.NotFound(w, r)
+		return
+	}
+
+	actor, err := loadActor(h.store, userID)
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+	if actor == nil {
+		jsonResponse.NotFound(w, r)
+		return
+	}
+
+	writeDocument(w, r, webFingerContentType, &webFingerResponse{
+		Subject: "acct:" + actor.username + "@" + Host(),
+		Aliases: []string{ActorURL(userID)},
+		Links: []webFingerLink{
+			{Rel: "self", Type: ContentType, Href: ActorURL(userID)},
+		},
+	})
+}
+
+func (h *handler) actor(w http.ResponseWriter, r *http.Request) {
+	actor, found := h.loadActor(w, r)
+	if !found {
+		return
+	}
+
+	writeDocument(w, r, ContentType, newActor(actor.userID, actor.username, actor.key))
+}
+
+func (h *handler) outbox(w http.ResponseWriter, r *http.Request) {
+	actor, found := h.loadActor(w, r)
+	if !found {
+		return
+	}
+
+	total, err := h.store.CountActivityPubObjects(actor.userID)
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+
+	collectionURL := outboxURL(actor.userID)
+	if !request.HasQueryParam(r, "page") {
+		writeDocument(w, r, ContentType, &Collection{
+			Context:    activityStreamsContext,
+			ID:         collectionURL,
+			Type:       "OrderedCollection",
+			TotalItems: &total,
+			First:      collectionURL + "?page=1",
+		})
+		return
+	}
+
+	page := max(request.QueryIntParam(r, "page", 1), 1)
+	objects, err := h.store.ActivityPubObjects(actor.userID, (page-1)*outboxPageSize, outboxPageSize)
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+
+	entryIDs := make([]int64, len(objects))
+	for i, object := range objects {
+		entryIDs[i] = object.EntryID
+	}
+
+	entriesByID := make(map[int64]*model.Entry, len(objects))
+	if len(entryIDs) > 0 {
+		builder := h.store.NewEntryQueryBuilder(actor.userID)
+		builder.WithEntryIDs(entryIDs)
+		entries, err := builder.GetEntries()
+		if err != nil {
+			jsonResponse.ServerError(w, r, err)
+			return
+		}
+		for _, entry := range entries {
+			entriesByID[entry.ID] = entry
+		}
+	}
+
+	collectionPage := &Collection{
+		Context:      activityStreamsContext,
+		ID:           collectionURL + "?page=" + strconv.Itoa(page),
+		Type:         "OrderedCollectionPage",
+		PartOf:       collectionURL,
+		OrderedItems: make([]*Activity, 0, len(objects)),
+	}
+	if page*outboxPageSize < total {
+		collectionPage.Next = collectionURL + "?page=" + strconv.Itoa(page+1)
+	}
+	for _, object := range objects {
+		if entry, found := entriesByID[object.EntryID]; found {
+			activity := newCreateActivity(object, entry)
+			activity.Context = nil
+			collectionPage.OrderedItems = append(collectionPage.OrderedItems, activity)
+		}
+	}
+
+	writeDocument(w, r, ContentType, collectionPage)
+}
+
+func (h *handler) followers(w http.ResponseWriter, r *http.Request) {
+	actor, found := h.loadActor(w, r)
+	if !found {
+		return
+	}
+
+	// The followers are not listed, only their number is public.
+	total, err := h.store.CountActivityPubFollowers(actor.userID)
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+
+	writeDocument(w, r, ContentType, &Collection{
+		Context:    activityStreamsContext,
+		ID:         followersURL(actor.userID),
+		Type:       "OrderedCollection",
+		TotalItems: &total,
+	})
+}
+
+func (h *handler) inbox(w http.ResponseWriter, r *http.Request) {
+	actor, found := h.loadActor(w, r)
+	if !found {
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxDocumentSize))
+	if err != nil {
+		jsonResponse.BadRequest(w, r, err)
+		return
+	}
+
+	var activity incomingActivity
+	if err := json.Unmarshal(body, &activity); err != nil {
+		jsonResponse.BadRequest(w, r, err)
+		return
+	}
+
+	remoteActor, err := verifyActivity(r, body, &activity, actor.signer)
+	if err != nil {
+		// The servers send the deletion of their accounts to everyone, their key does not exist anymore.
+		if activity.Type == "Delete" {
+			jsonResponse.Accepted(w, r)
+			return
+		}
+
+		slog.Warn("Refusing ActivityPub activity with an invalid signature",
+			slog.Int64("user_id", actor.userID),
+			slog.String("activity_type", activity.Type),
+			slog.String("activity_actor", activity.Actor),
+			slog.Any("error", err),
+		)
+		jsonResponse.Unauthorized(w, r)
+		return
+	}
+
+	switch activity.Type {
+	case "Follow":
+		if objectID(activity.Object) != ActorURL(actor.userID) {
+			break
+		}
+
+		// The actor document was fetched to verify the signature.
+		follower := &model.ActivityPubFollower{
+			UserID:   actor.userID,
+			ActorURL: remoteActor.ID,
+			InboxURL: remoteActor.Inbox,
+		}
+		if err := checkInbox(follower.InboxURL, follower.ActorURL); err != nil {
+			slog.Warn("Refusing ActivityPub follower with an invalid inbox",
+				slog.Int64("user_id", actor.userID),
+				slog.String("follower", follower.ActorURL),
+				slog.Any("error", err),
+			)
+			break
+		}
+		// The shared inbox is ignored when it is not on the server of the follower.
+		if remoteActor.Endpoints != nil && isSameOrigin(remoteActor.Endpoints.SharedInbox, follower.ActorURL) {
+			follower.SharedInboxURL = remoteActor.Endpoints.SharedInbox
+		}
+		if err := h.store.CreateActivityPubFollower(follower); err != nil {
+			jsonResponse.ServerError(w, r, err)
+			return
+		}
+
+		slog.Debug("New ActivityPub follower", slog.Int64("user_id", actor.userID), slog.String("follower", follower.ActorURL))
+		go acceptFollow(actor, follower, body)
+	case "Undo":
+		var undone incomingActivity
+		if err := json.Unmarshal(activity.Object, &undone); err == nil && undone.Type == "Follow" {
+			if err := h.store.RemoveActivityPubFollower(actor.userID, activity.Actor); err != nil {
+				jsonResponse.ServerError(w, r, err)
+				return
+			}
+		}
+	case "Delete":
+		if objectID(activity.Object) == activity.Actor {
+			if err := h.store.RemoveActivityPubFollower(actor.userID, activity.Actor); err != nil {
+				jsonResponse.ServerError(w, r, err)
+				return
+			}
+		}
+	}
+
+	jsonResponse.Accepted(w, r)
+}
+
+// loadActor writes a not found response when the actor of the route is not enabled.
+func (h *handler) loadActor(w http.ResponseWriter, r *http.Request) (*localActor, bool) {
+	actor, err := loadActor(h.store, request.RouteInt64Param(r, "userID"))
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return nil, false
+	}
+	if actor == nil {
+		jsonResponse.NotFound(w, r)
+		return nil, false
+	}
+	return actor, true
+}
+
+// verifyActivity checks the signature of an activity with the key of its actor, and returns the actor.
+func verifyActivity(r *http.Request, body []byte, activity *incomingActivity, signer *signer) (*Actor, error) {
+	signature, err := parseSignature(r.Header.Get("Signature"))
+	if err != nil {
+		return nil, err
+	}
+
+	if activity.Actor == "" || !isSameOrigin(activity.Actor, signature.keyID) {
+		return nil, errInvalidSignature
+	}
+
+	remoteActor, err := fetchActorKey(activity.Actor, signature.keyID, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := parsePublicKey(remoteActor.PublicKey.PublicKeyPem)
+	if err != nil {
+		return nil, err
+	}
+	if err := signature.verify(r, body, publicKey, time.Now()); err != nil {
+		return nil, err
+	}
+	return remoteActor, nil
+}
+
+func acceptFollow(actor *localActor, follower *model.ActivityPubFollower, follow json.RawMessage) {
+	activity := &Activity{
+		Context: activityStreamsContext,
+		ID:      ActorURL(actor.userID) + "#accepts/" + crypto.GenerateRandomStringHex(16),
+		Type:    "Accept",
+		Actor:   ActorURL(actor.userID),
+		Object:  follow,
+	}
+
+	if err := deliver(follower.InboxURL, follower.ActorURL, activity, actor.signer); err != nil {
+		slog.Warn("Unable to accept ActivityPub follower",
+			slog.Int64("user_id", actor.userID),
+			slog.String("follower", follower.ActorURL),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// objectID returns the identifier of the object of an activity, the object is a URL or an embedded object.
+func objectID(object json.RawMessage) string {
+	var id string
+	if err := json.Unmarshal(object, &id); err == nil {
+		return id
+	}
+
+	var embedded struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(object, &embedded); err == nil {
+		return embedded.ID
+	}
+	return ""
+}
+
+// parseAccount returns the username of an account of the host, such as acct:username@example.org.
+func parseAccount(resource string) (string, bool) {
+	account, found := strings.CutPrefix(resource, "acct:")
+	if !found {
+		return "", false
+	}
+
+	username, host, found := strings.Cut(strings.TrimPrefix(account, "@"), "@")
+	if !found || !strings.EqualFold(host, Host()) {
+		return "", false
+	}
+	return strings.ToLower(username), true
+}
+
+// parseActorURL returns the user of the URL of an actor.
+func parseActorURL(resource string) (int64, bool) {
+	id, found := strings.CutPrefix(resource, config.Opts.BaseURL()+"/activitypub/actors/")
+	if !found {
+		return 0, false
+	}
+
+	userID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, false
+	}
+	return userID, true
+}
+
+func writeDocument(w http.ResponseWriter, r *http.Request, contentType string, document any) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		jsonResponse.ServerError(w, r, err)
+		return
+	}
+
+	builder := response.New(w, r)
+	builder.WithHeader("Content-Type", contentType)
+	builder.WithBody(data)
+	builder.Write()
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/activitypub"
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// Settings of the integration enabling the actor of a user.
+const (
+	UsernameField       = "username"
+	ObjectTypeField     = "object_type"
+	PublishStarredField = "publish_starred"
+)
+
+// localActor is the actor of a user enabling the integration.
+type localActor struct {
+	userID     int64
+	username   string
+	objectType string
+	key        *model.ActivityPubKey
+	signer     *signer
+}
+
+// loadActor returns the actor of a user, nil if the user did not enable it.
+func loadActor(store *storage.Storage, userID int64) (*localActor, error) {
+	settingsList, err := store.IntegrationSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, settings := range settingsList {
+		if settings.Provider == ProviderName && settings.Enabled && settings.Values.String(UsernameField) != "" {
+			return newLocalActor(store, settings)
+		}
+	}
+	return nil, nil
+}
+
+func newLocalActor(store *storage.Storage, settings *model.IntegrationSettings) (*localActor, error) {
+	key, err := store.ActivityPubKey(settings.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		if key, err = generateKey(settings.UserID); err != nil {
+			return nil, err
+		}
+		if key, err = store.CreateActivityPubKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	signer, err := newSigner(key)
+	if err != nil {
+		return nil, err
+	}
+
+	objectType := ObjectTypeNote
+	if settings.Values.String(ObjectTypeField) == ObjectTypeArticle {
+		objectType = ObjectTypeArticle
+	}
+
+	return &localActor{
+		userID:     settings.UserID,
+		username:   settings.Values.String(UsernameField),
+		objectType: objectType,
+		key:        key,
+		signer:     signer,
+	}, nil
+}
+
+// Publish sends a Create activity for each entry to the followers of the actor, the entries are shared if needed.
+// The entries already published are not sent again, starred is false when the user shared the entries.
+func Publish(store *storage.Storage, settings *model.IntegrationSettings, entries model.Entries, starred bool) error {
+	actor, err := newLocalActor(store, settings)
+	if err != nil {
+		return err
+	}
+
+	followers, err := store.ActivityPubFollowers(actor.userID)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		object, err := store.ActivityPubObject(actor.userID, entry.ID)
+		if err != nil {
+			return err
+		}
+		if object != nil {
+			object.Starred = starred
+			if err := store.CreateActivityPubObject(object); err != nil {
+				return err
+			}
+			continue
+		}
+
+		shareCode := entry.ShareCode
+		if shareCode == "" {
+			if shareCode, err = store.EntryShareCode(actor.userID, entry.ID); err != nil {
+				return err
+			}
+		}
+
+		object = &model.ActivityPubObject{
+			UserID:      actor.userID,
+			EntryID:     entry.ID,
+			ShareCode:   shareCode,
+			ObjectType:  actor.objectType,
+			Starred:     starred,
+			PublishedAt: time.Now(),
+		}
+
+		// The object is recorded once delivered, the followers receive the activity again when the delivery is retried.
+		if err := deliverToFollowers(store, actor, followers, newCreateActivity(object, entry)); err != nil {
+			return err
+		}
+		if err := store.CreateActivityPubObject(object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unpublish sends a Delete activity for each published entry to the followers of the actor. When starred is true,
+// only the entries published because they were starred are retracted, and they are unshared.
+func Unpublish(store *storage.Storage, settings *model.IntegrationSettings, entries model.Entries, starred bool) error {
+	actor, err := newLocalActor(store, settings)
+	if err != nil {
+		return err
+	}
+
+	followers, err := store.ActivityPubFollowers(actor.userID)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		object, err := store.ActivityPubObject(actor.userID, entry.ID)
+		if err != nil {
+			return err
+		}
+		if object == nil || (starred && !object.Starred) {
+			continue
+		}
+
+		if err := deliverToFollowers(store, actor, followers, newDeleteActivity(object)); err != nil {
+			return err
+		}
+		if err := store.RemoveActivityPubObject(actor.userID, entry.ID); err != nil {
+			return err
+		}
+		if starred {
+			if err := store.UnshareEntry(actor.userID, entry.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deliverToFollowers sends an activity once to each inbox, the followers sharing an inbox receive a single copy.
+// The followers are removed when their inbox does not exist anymore.
+func deliverToFollowers(store *storage.Storage, actor *localActor, followers []*model.ActivityPubFollower, activity *Activity) error {
+	var inboxes []string
+	followersByInbox := make(map[string][]*model.ActivityPubFollower)
+	for _, follower := range followers {
+		inbox := follower.InboxURL
+		if follower.SharedInboxURL != "" {
+			inbox = follower.SharedInboxURL
+		}
+		if _, found := followersByInbox[inbox]; !found {
+			inboxes = append(inboxes, inbox)
+		}
+		followersByInbox[inbox] = append(followersByInbox[inbox], follower)
+	}
+
+	var errs []error
+	for _, inbox := range inboxes {
+		// The followers sharing an inbox are on the same server.
+		err := deliver(inbox, followersByInbox[inbox][0].ActorURL, activity, actor.signer)
+		switch {
+		case errors.Is(err, errInboxGone):
+			for _, follower := range followersByInbox[inbox] {
+				slog.Debug("Removing ActivityPub follower without inbox",
+					slog.Int64("user_id", actor.userID),
+					slog.String("follower", follower.ActorURL),
+				)
+				if err := store.RemoveActivityPubFollower(actor.userID, follower.ActorURL); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		case err != nil:
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitypub // import "miniflux.app/v2/internal/activitypub"
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// maxSignatureAge is the clock skew accepted for the date of the signed requests.
+const maxSignatureAge = 12 * time.Hour
+
+var (
+	errInvalidSignature = errors.New("activitypub: invalid signature")
+	errInvalidDigest    = errors.New("activitypub: invalid digest")
+	errExpiredSignature = errors.New("activitypub: expired signature")
+)
+
+// signature is the Signature header of a request, as described by the draft of HTTP signatures used by Mastodon.
+type signature struct {
+	keyID     string
+	algorithm string
+	headers   []string
+	signature []byte
+}
+
+// signer signs the requests of a local actor.
+type signer struct {
+	keyID      string
+	privateKey *rsa.PrivateKey
+}
+
+// generateKey generates the key pair of a local actor, the keys are PEM encoded.
+func generateKey(userID int64) (*model.ActivityPubKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("activitypub: unable to generate key: %v", err)
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("activitypub: unable to encode private key: %v", err)
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("activitypub: unable to encode public key: %v", err)
+	}
+
+	return &model.ActivityPubKey{
+		UserID:     userID,
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes})),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes})),
+	}, nil
+}
+
+func newSigner(key *model.ActivityPubKey) (*signer, error) {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, errors.New("activitypub: invalid private key")
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("activitypub: invalid private key: %v", err)
+	}
+
+	rsaKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("activitypub: the private key is not a RSA key")
+	}
+
+	return &signer{keyID: keyID(key.UserID), privateKey: rsaKey}, nil
+}
+
+// parsePublicKey decodes the public key of a remote actor, in the PKIX or in the PKCS #1 format.
+func parsePublicKey(publicKeyPem string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPem))
+	if block == nil {
+		return nil, errors.New("activitypub: invalid public key")
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("activitypub: invalid public key: %v", err)
+	}
+
+	rsaKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("activitypub: the public key is not a RSA key")
+	}
+	return rsaKey, nil
+}
+
+// sign adds the Date, the Digest and the Signature headers to a request, the body is nil for the GET requests.
+func (s *signer) sign(request *http.Request, body []byte) error {
+	request.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+
+	headers := []string{"(request-target)", "host", "date"}
+	if body != nil {
+		request.Header.Set("Digest", digest(body))
+		headers = append(headers, "digest")
+	}
+
+	signingString, err := buildSigningString(request, headers)
+	if err != nil {
+		return err
+	}
+
+	hashed := sha256.Sum256([]byte(signingString))
+	signed, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return fmt.Errorf("activitypub: unable to sign request: %v", err)
+	}
+
+	request.Header.Set("Signature", fmt.Sprintf(`keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		s.keyID,
+		strings.Join(headers, " "),
+		base64.StdEncoding.EncodeToString(signed),
+	))
+	return nil
+}
+
+// parseSignature decodes the Signature header of a request.
+func parseSignature(header string) (*signature, error) {
+	parsed := &signature{headers: []string{"date"}}
+	for _, param := range strings.Split(header, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found {
+			return nil, errInvalidSignature
+		}
+
+		value = strings.Trim(value, `"`)
+		switch name {
+		case "keyId":
+			parsed.keyID = value
+		case "algorithm":
+			parsed.algorithm = value
+		case "headers":
+			parsed.headers = strings.Fields(strings.ToLower(value))
+		case "signature":
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, errInvalidSignature
+			}
+			parsed.signature = decoded
+		}
+	}
+
+	if parsed.keyID == "" || len(parsed.signature) == 0 {
+		return nil, errInvalidSignature
+	}
+	return parsed, nil
+}
+
+// verify checks the signature of a request received at the time now. The signature must cover the request target,
+// the host and the date, and the digest of the body when there is one.
+func (s *signature) verify(request *http.Request, body []byte, publicKey *rsa.PublicKey, now time.Time) error {
+	if s.algorithm != "" && s.algorithm != "rsa-sha256" && s.algorithm != "hs2019" {
+		return errInvalidSignature
+	}
+
+	required := []string{"(request-target)", "host", "date"}
+	if len(body) > 0 {
+		required = append(required, "digest")
+	}
+	for _, header := range required {
+		if !slices.Contains(s.headers, header) {
+			return errInvalidSignature
+		}
+	}
+
+	date, err := http.ParseTime(request.Header.Get("Date"))
+	if err != nil || now.Sub(date).Abs() > maxSignatureAge {
+		return errExpiredSignature
+	}
+
+	if len(body) > 0 && request.Header.Get("Digest") != digest(body) {
+		return errInvalidDigest
+	}
+
+	signingString, err := buildSigningString(request, s.headers)
+	if err != nil {
+		return err
+	}
+
+	hashed := sha256.Sum256([]byte(signingString))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], s.signature); err != nil {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func buildSigningString(request *http.Request, headers []string) (string, error) {
+	lines := make([]string, 0, len(headers))
+	for _, header := range headers {
+		var value string
+		switch header {
+		case "(request-target)":
+			value = strings.ToLower(request.Method) + " " + request.URL.RequestURI()
+		case "host":
+			value = request.Host
+			if value == "" {
+				value = request.URL.Host
+			}
+		default:
+			value = request.Header.Get(header)
+		}
+
+		if value == "" {
+			return "", fmt.Errorf("activitypub: the signed header %q is missing", header)
+		}
+		lines = append(lines, header+": "+value)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -54,10 +54,12 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds/counters", handler.fetchCounters).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/refresh", handler.refreshAllFeeds).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}/refresh", handler.refreshFeed).Methods(http.MethodPut)
+	sr.HandleFunc("/feeds/{feedID}/backfill", handler.backfillFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.getFeed).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}", handler.updateFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.removeFeed).Methods(http.MethodDelete)
 	sr.HandleFunc("/feeds/{feedID}/icon", handler.getIconByFeedID).Methods(http.MethodGet)
+	sr.HandleFunc("/feeds/{feedID}/fetch-log", handler.getFeedFetchLog).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}/mark-all-as-read", handler.markFeedAsRead).Methods(http.MethodPut)
 	sr.HandleFunc("/export", handler.exportFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/import", handler.importFeeds).Methods(http.MethodPost)
@@ -80,6 +82,15 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/api-keys", handler.createAPIKey).Methods(http.MethodPost)
 	sr.HandleFunc("/api-keys", handler.getAPIKeys).Methods(http.MethodGet)
 	sr.HandleFunc("/api-keys/{apiKeyID}", handler.deleteAPIKey).Methods(http.MethodDelete)
+	sr.HandleFunc("/domain-rules", handler.createDomainRule).Methods(http.MethodPost)
+	sr.HandleFunc("/domain-rules", handler.getDomainRules).Methods(http.MethodGet)
+	sr.HandleFunc("/domain-rules/export", handler.exportDomainRules).Methods(http.MethodGet)
+	sr.HandleFunc("/domain-rules/import", handler.importDomainRules).Methods(http.MethodPost)
+	sr.HandleFunc("/domain-rules/{domainRuleID:[0-9]+}", handler.updateDomainRule).Methods(http.MethodPut)
+	sr.HandleFunc("/domain-rules/{domainRuleID:[0-9]+}", handler.removeDomainRule).Methods(http.MethodDelete)
+	sr.HandleFunc("/client-certificates", handler.createClientCertificate).Methods(http.MethodPost)
+	sr.HandleFunc("/client-certificates", handler.getClientCertificates).Methods(http.MethodGet)
+	sr.HandleFunc("/client-certificates/{clientCertificateID:[0-9]+}", handler.removeClientCertificate).Methods(http.MethodDelete)
 }
 
 func (h *handler) versionHandler(w http.ResponseWriter, r *http.Request) {
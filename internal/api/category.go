@@ -12,8 +12,10 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/validator"
+	"miniflux.app/v2/internal/worker"
 )
 
 func (h *handler) createCategory(w http.ResponseWriter, r *http.Request) {
@@ -90,11 +92,14 @@ func (h *handler) markCategoryAsRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = h.store.MarkCategoryAsRead(userID, categoryID, time.Now()); err != nil {
+	entryIDs, err := h.store.MarkCategoryAsRead(userID, categoryID, time.Now())
+	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
+
 	json.NoContent(w, r)
 }
 
@@ -159,7 +164,7 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) {
 		slog.Int("nb_jobs", len(jobs)),
 	)
 
-	go h.pool.Push(jobs)
+	h.pool.PushWithPriority(jobs, worker.PriorityUser)
 
 	json.NoContent(w, r)
 }
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getClientCertificates(w http.ResponseWriter, r *http.Request) {
+	certificates, err := h.store.ClientCertificates(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, certificates)
+}
+
+func (h *handler) createClientCertificate(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var clientCertificateCreationRequest model.ClientCertificateCreationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&clientCertificateCreationRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+	clientCertificateCreationRequest.Name = strings.TrimSpace(clientCertificateCreationRequest.Name)
+
+	if validationErr := validator.ValidateClientCertificateCreation(h.store, userID, &clientCertificateCreationRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	certificate, err := h.store.CreateClientCertificate(userID, &clientCertificateCreationRequest)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, certificate)
+}
+
+func (h *handler) removeClientCertificate(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.RemoveClientCertificate(request.UserID(r), request.RouteInt64Param(r, "clientCertificateID")); err != nil {
+		if errors.Is(err, storage.ErrClientCertificateNotFound) {
+			json.NotFound(w, r)
+			return
+		}
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
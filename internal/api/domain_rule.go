@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getDomainRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.store.DomainRules(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, rules)
+}
+
+func (h *handler) createDomainRule(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	var domainRuleCreationRequest model.DomainRuleCreationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&domainRuleCreationRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateDomainRuleCreation(h.store, user, &domainRuleCreationRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	rule := model.NewDomainRule(user.ID, &domainRuleCreationRequest)
+	if err := h.store.CreateDomainRule(rule); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, rule)
+}
+
+func (h *handler) updateDomainRule(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	rule, err := h.store.DomainRule(user.ID, request.RouteInt64Param(r, "domainRuleID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if !rule.IsEditableBy(user) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	var domainRuleModificationRequest model.DomainRuleModificationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&domainRuleModificationRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateDomainRuleModification(h.store, user, rule, &domainRuleModificationRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	domainRuleModificationRequest.Patch(rule)
+	if err := h.store.UpdateDomainRule(rule); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, rule)
+}
+
+func (h *handler) removeDomainRule(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	rule, err := h.store.DomainRule(user.ID, request.RouteInt64Param(r, "domainRuleID"))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if rule == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if !rule.IsEditableBy(user) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	if err := h.store.RemoveDomainRule(rule.UserID, rule.ID); err != nil {
+		if errors.Is(err, storage.ErrDomainRuleNotFound) {
+			json.NotFound(w, r)
+			return
+		}
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) exportDomainRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.store.DomainRules(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, rules.Export(request.IsAdminUser(r)))
+}
+
+func (h *handler) importDomainRules(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	var domainRuleCreationRequests []model.DomainRuleCreationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&domainRuleCreationRequests); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateDomainRulesImport(user, domainRuleCreationRequests); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	rules := make(model.DomainRules, 0, len(domainRuleCreationRequests))
+	for i := range domainRuleCreationRequests {
+		rules = append(rules, model.NewDomainRule(user.ID, &domainRuleCreationRequests[i]))
+	}
+
+	if err := h.store.ImportDomainRules(rules); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.Created(w, r, map[string]string{"message": "Domain rules imported successfully"})
+}
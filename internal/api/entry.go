@@ -182,21 +182,27 @@ func (h *handler) setEntryStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.SetEntriesStatus(request.UserID(r), entriesStatusUpdateRequest.EntryIDs, entriesStatusUpdateRequest.Status); err != nil {
+	userID := request.UserID(r)
+	if err := h.store.SetEntriesStatus(userID, entriesStatusUpdateRequest.EntryIDs, entriesStatusUpdateRequest.Status); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendEntriesStatusEvent(h.store, userID, entriesStatusUpdateRequest.EntryIDs, entriesStatusUpdateRequest.Status)
+
 	json.NoContent(w, r)
 }
 
 func (h *handler) toggleStarred(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
 	entryID := request.RouteInt64Param(r, "entryID")
-	if err := h.store.ToggleStarred(request.UserID(r), entryID); err != nil {
+	if err := h.store.ToggleStarred(userID, entryID); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendEntriesStarredEvent(h.store, userID, []int64{entryID})
+
 	json.NoContent(w, r)
 }
 
@@ -206,7 +212,13 @@ func (h *handler) saveEntry(w http.ResponseWriter, r *http.Request) {
 	builder.WithEntryID(entryID)
 	builder.WithoutStatus(model.EntryStatusRemoved)
 
-	if !h.store.HasSaveEntry(request.UserID(r)) {
+	settings, err := h.store.IntegrationSettings(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if !integration.HasSaveEntry(settings) {
 		json.BadRequest(w, r, errors.New("no third-party integration enabled"))
 		return
 	}
@@ -222,13 +234,7 @@ func (h *handler) saveEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	settings, err := h.store.Integration(request.UserID(r))
-	if err != nil {
-		json.ServerError(w, r, err)
-		return
-	}
-
-	go integration.SendEntry(entry, settings)
+	integration.SendEntry(h.store, entry, settings)
 
 	json.Accepted(w, r)
 }
@@ -330,7 +336,7 @@ func (h *handler) fetchContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := processor.ProcessEntryWebPage(feed, entry, user); err != nil {
+	if err := processor.ProcessEntryWebPage(h.store, feed, entry, user); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
@@ -12,9 +12,11 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/model"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/validator"
+	"miniflux.app/v2/internal/worker"
 )
 
 func (h *handler) createFeed(w http.ResponseWriter, r *http.Request) {
@@ -68,6 +70,24 @@ func (h *handler) refreshFeed(w http.ResponseWriter, r *http.Request) {
 	json.NoContent(w, r)
 }
 
+func (h *handler) backfillFeed(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	userID := request.UserID(r)
+
+	if !h.store.FeedExists(userID, feedID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	addedEntries, localizedError := feedHandler.BackfillFeed(h.store, userID, feedID)
+	if localizedError != nil {
+		json.ServerError(w, r, localizedError.Error())
+		return
+	}
+
+	json.OK(w, r, &feedBackfillResponse{AddedEntries: addedEntries})
+}
+
 func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 	userID := request.UserID(r)
 
@@ -90,7 +110,7 @@ func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 		slog.Int("nb_jobs", len(jobs)),
 	)
 
-	go h.pool.Push(jobs)
+	h.pool.PushWithPriority(jobs, worker.PriorityUser)
 
 	json.NoContent(w, r)
 }
@@ -146,11 +166,14 @@ func (h *handler) markFeedAsRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.MarkFeedAsRead(userID, feedID, time.Now()); err != nil {
+	entryIDs, err := h.store.MarkFeedAsRead(userID, feedID, time.Now())
+	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
+
 	json.NoContent(w, r)
 }
 
@@ -214,7 +237,7 @@ func (h *handler) getFeed(w http.ResponseWriter, r *http.Request) {
 	json.OK(w, r, feed)
 }
 
-func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
+func (h *handler) getFeedFetchLog(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
 
@@ -223,10 +246,36 @@ func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fetchLogs, err := h.store.FeedFetchLogs(userID, feedID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, fetchLogs)
+}
+
+func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	userID := request.UserID(r)
+
+	feed, err := h.store.FeedByID(userID, feedID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		json.NotFound(w, r)
+		return
+	}
+
 	if err := h.store.RemoveFeed(userID, feedID); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendFeedEvent(h.store, model.EventFeedRemoved, feed)
+
 	json.NoContent(w, r)
 }
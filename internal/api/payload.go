@@ -22,6 +22,10 @@ type feedCreationResponse struct {
 	FeedID int64 `json:"feed_id"`
 }
 
+type feedBackfillResponse struct {
+	AddedEntries int `json:"added_entries"`
+}
+
 type versionResponse struct {
 	Version   string `json:"version"`
 	Commit    string `json:"commit"`
@@ -5,6 +5,7 @@ package api // import "miniflux.app/v2/internal/api"
 
 import (
 	json_parser "encoding/json"
+	"errors"
 	"net/http"
 
 	"miniflux.app/v2/internal/config"
@@ -29,6 +30,22 @@ func (h *handler) discoverSubscriptions(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	var clientCertificate model.ClientCertificate
+	if subscriptionDiscoveryRequest.ClientCertificateID != 0 {
+		certificate, err := h.store.ClientCertificate(request.UserID(r), subscriptionDiscoveryRequest.ClientCertificateID)
+		if err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if certificate == nil {
+			json.BadRequest(w, r, errors.New("client certificate not found"))
+			return
+		}
+
+		clientCertificate = *certificate
+	}
+
 	var rssbridgeURL string
 	var rssbridgeToken string
 	intg, err := h.store.Integration(request.UserID(r))
@@ -37,7 +54,7 @@ func (h *handler) discoverSubscriptions(w http.ResponseWriter, r *http.Request)
 		rssbridgeToken = intg.RSSBridgeToken
 	}
 
-	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder := fetcher.NewRequestBuilder().Interactive()
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(subscriptionDiscoveryRequest.ProxyURL)
@@ -45,6 +62,8 @@ func (h *handler) discoverSubscriptions(w http.ResponseWriter, r *http.Request)
 	requestBuilder.UseCustomApplicationProxyURL(subscriptionDiscoveryRequest.FetchViaProxy)
 	requestBuilder.WithUserAgent(subscriptionDiscoveryRequest.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(subscriptionDiscoveryRequest.Cookie)
+	requestBuilder.WithCustomHeaders(subscriptionDiscoveryRequest.CustomHeaders, subscriptionDiscoveryRequest.URL)
+	requestBuilder.WithClientCertificate(clientCertificate.Certificate, clientCertificate.PrivateKey, clientCertificate.CABundle, subscriptionDiscoveryRequest.URL)
 	requestBuilder.WithUsernameAndPassword(subscriptionDiscoveryRequest.Username, subscriptionDiscoveryRequest.Password)
 	requestBuilder.IgnoreTLSErrors(subscriptionDiscoveryRequest.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(subscriptionDiscoveryRequest.DisableHTTP2)
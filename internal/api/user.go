@@ -10,6 +10,7 @@ import (
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/validator"
 )
@@ -108,11 +109,14 @@ func (h *handler) markUserAsRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.MarkAllAsRead(userID); err != nil {
+	entryIDs, err := h.store.MarkAllAsRead(userID)
+	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
+
 	json.NoContent(w, r)
 }
 
@@ -124,12 +128,28 @@ func (h *handler) getIntegrationsStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	hasIntegrations := h.store.HasSaveEntry(userID)
+	settings, err := h.store.IntegrationSettings(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	hasIntegrations := integration.HasSaveEntry(settings)
+
+	retryingDeliveries, failedDeliveries, err := h.store.IntegrationDeliveryCounts(userID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
 
 	response := struct {
-		HasIntegrations bool `json:"has_integrations"`
+		HasIntegrations    bool `json:"has_integrations"`
+		RetryingDeliveries int  `json:"retrying_deliveries"`
+		FailedDeliveries   int  `json:"failed_deliveries"`
 	}{
-		HasIntegrations: hasIntegrations,
+		HasIntegrations:    hasIntegrations,
+		RetryingDeliveries: retryingDeliveries,
+		FailedDeliveries:   failedDeliveries,
 	}
 
 	json.OK(w, r, response)
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package chatbot // import "miniflux.app/v2/internal/chatbot"
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// retryDelay is the delay before connecting a bot again after an error.
+const retryDelay = 30 * time.Second
+
+// Bots runs a command loop for each chat bot whose commands are enabled by a user.
+type Bots struct {
+	store      *storage.Storage
+	runCommand func(settings *model.IntegrationSettings, command *integration.ChatCommand) *integration.ChatReply
+	retryDelay time.Duration
+
+	mutex sync.Mutex
+	loops map[string]*loop
+}
+
+type loop struct {
+	fingerprint string
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewBots returns the command loops of the chat bots, they are started by Update.
+func NewBots(store *storage.Storage) *Bots {
+	bots := &Bots{
+		store:      store,
+		retryDelay: retryDelay,
+		loops:      make(map[string]*loop),
+	}
+	bots.runCommand = func(settings *model.IntegrationSettings, command *integration.ChatCommand) *integration.ChatReply {
+		return runCommand(store, settings, command)
+	}
+	return bots
+}
+
+// Update starts the loops of the new bots, the loops of the bots disabled or changed since the previous update are stopped.
+func (b *Bots) Update() {
+	var providers []string
+	for _, provider := range integration.Providers() {
+		if _, ok := provider.(integration.ChatBot); ok {
+			providers = append(providers, provider.Name())
+		}
+	}
+
+	settingsList, err := b.store.EnabledIntegrationSettings(providers)
+	if err != nil {
+		slog.Error("Unable to fetch the chat bot integrations", slog.Any("error", err))
+		return
+	}
+
+	b.update(settingsList)
+}
+
+func (b *Bots) update(settingsList []*model.IntegrationSettings) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	enabled := make(map[string]bool)
+	for _, settings := range settingsList {
+		bot, ok := integration.ChatBotEnabled(settings)
+		if !ok {
+			continue
+		}
+
+		key := strconv.FormatInt(settings.UserID, 10) + ":" + settings.Provider
+		enabled[key] = true
+
+		fingerprint := settingsFingerprint(settings)
+		if current, found := b.loops[key]; found {
+			if current.fingerprint == fingerprint {
+				continue
+			}
+			current.stop()
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		current := &loop{fingerprint: fingerprint, cancel: cancel, done: make(chan struct{})}
+		b.loops[key] = current
+		go b.run(ctx, current, bot, settings)
+	}
+
+	for key, current := range b.loops {
+		if !enabled[key] {
+			current.stop()
+			delete(b.loops, key)
+		}
+	}
+}
+
+// Stop stops all the loops, they are started again by the next update.
+func (b *Bots) Stop() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for key, current := range b.loops {
+		current.stop()
+		delete(b.loops, key)
+	}
+}
+
+func (b *Bots) run(ctx context.Context, current *loop, bot integration.ChatBot, settings *model.IntegrationSettings) {
+	defer close(current.done)
+
+	attrs := []any{slog.Int64("user_id", settings.UserID), slog.String("provider", settings.Provider)}
+	slog.Debug("Starting chat bot", attrs...)
+
+	for ctx.Err() == nil {
+		session, err := bot.NewChatSession(settings)
+		if err != nil {
+			slog.Warn("Unable to connect chat bot", append(attrs, slog.Any("error", err))...)
+		} else {
+			err = b.receiveCommands(ctx, session, settings)
+			session.Close()
+			if ctx.Err() == nil {
+				slog.Warn("Unable to receive chat bot commands", append(attrs, slog.Any("error", err))...)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(b.retryDelay):
+		}
+	}
+
+	slog.Debug("Stopped chat bot", attrs...)
+}
+
+func (b *Bots) receiveCommands(ctx context.Context, session integration.ChatSession, settings *model.IntegrationSettings) error {
+	for {
+		commands, err := session.ReceiveCommands(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, command := range commands {
+			reply := b.runCommand(settings, command)
+			if reply == nil {
+				continue
+			}
+			if err := session.Reply(command, reply); err != nil {
+				slog.Warn("Unable to reply to chat bot command",
+					slog.Int64("user_id", settings.UserID),
+					slog.String("provider", settings.Provider),
+					slog.String("command", command.Name),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}
+}
+
+func (l *loop) stop() {
+	l.cancel()
+	<-l.done
+}
+
+// settingsFingerprint changes when the connection of the bot changes, pausing the notifications keeps the loop running.
+func settingsFingerprint(settings *model.IntegrationSettings) string {
+	values := maps.Clone(settings.Values)
+	delete(values, integration.ChatBotPausedField)
+
+	encodedValues, _ := json.Marshal(values)
+	return string(encodedValues)
+}
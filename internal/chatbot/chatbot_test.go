@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package chatbot // import "miniflux.app/v2/internal/chatbot"
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+type fakeChatBot struct {
+	mutex    sync.Mutex
+	sessions int
+	closed   int
+	replies  chan string
+}
+
+func (*fakeChatBot) Name() string {
+	return "fake_chat_bot"
+}
+
+func (*fakeChatBot) ConfigSchema() *integration.ConfigSchema {
+	return &integration.ConfigSchema{Title: "Fake Chat Bot"}
+}
+
+func (*fakeChatBot) SaveEntry(*model.IntegrationSettings, *model.Entry) error {
+	return nil
+}
+
+func (*fakeChatBot) PushEntries(*model.IntegrationSettings, *model.Feed, model.Entries) error {
+	return nil
+}
+
+func (*fakeChatBot) Validate(*model.IntegrationSettings) *locale.LocalizedError {
+	return nil
+}
+
+func (b *fakeChatBot) NewChatSession(*model.IntegrationSettings) (integration.ChatSession, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.sessions++
+	return &fakeChatSession{bot: b}, nil
+}
+
+func (b *fakeChatBot) counts() (int, int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.sessions, b.closed
+}
+
+type fakeChatSession struct {
+	bot      *fakeChatBot
+	received bool
+}
+
+func (s *fakeChatSession) ReceiveCommands(ctx context.Context) ([]*integration.ChatCommand, error) {
+	if !s.received {
+		s.received = true
+		return []*integration.ChatCommand{{Name: "unread"}}, nil
+	}
+
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (s *fakeChatSession) Reply(command *integration.ChatCommand, reply *integration.ChatReply) error {
+	s.bot.replies <- command.Name + ": " + reply.Text
+	return nil
+}
+
+func (s *fakeChatSession) Close() {
+	s.bot.mutex.Lock()
+	defer s.bot.mutex.Unlock()
+
+	s.bot.closed++
+}
+
+func TestBotsUpdate(t *testing.T) {
+	bot := &fakeChatBot{replies: make(chan string, 10)}
+	integration.Register(bot)
+
+	bots := &Bots{
+		retryDelay: time.Millisecond,
+		loops:      make(map[string]*loop),
+		runCommand: func(settings *model.IntegrationSettings, command *integration.ChatCommand) *integration.ChatReply {
+			return &integration.ChatReply{Text: settings.Values.String("token")}
+		},
+	}
+
+	settings := &model.IntegrationSettings{UserID: 1, Provider: "fake_chat_bot", Enabled: true, Values: model.IntegrationValues{"commands": true, "token": "first"}}
+	bots.update([]*model.IntegrationSettings{settings})
+	expectReply(t, bot, "unread: first")
+
+	// Pausing the new entries keeps the loop running.
+	paused := &model.IntegrationSettings{UserID: 1, Provider: "fake_chat_bot", Enabled: true, Values: model.IntegrationValues{"commands": true, "token": "first", "paused": true}}
+	bots.update([]*model.IntegrationSettings{paused})
+	if sessions, closed := bot.counts(); sessions != 1 || closed != 0 {
+		t.Errorf("Expected the same session, got %d sessions and %d closed", sessions, closed)
+	}
+
+	changed := &model.IntegrationSettings{UserID: 1, Provider: "fake_chat_bot", Enabled: true, Values: model.IntegrationValues{"commands": true, "token": "second"}}
+	bots.update([]*model.IntegrationSettings{changed})
+	expectReply(t, bot, "unread: second")
+	if sessions, closed := bot.counts(); sessions != 2 || closed != 1 {
+		t.Errorf("Expected a new session, got %d sessions and %d closed", sessions, closed)
+	}
+
+	disabled := &model.IntegrationSettings{UserID: 1, Provider: "fake_chat_bot", Enabled: true, Values: model.IntegrationValues{"token": "second"}}
+	bots.update([]*model.IntegrationSettings{disabled})
+	if sessions, closed := bot.counts(); sessions != 2 || closed != 2 || len(bots.loops) != 0 {
+		t.Errorf("Expected the loop to be stopped, got %d sessions, %d closed and %d loops", sessions, closed, len(bots.loops))
+	}
+
+	bots.update([]*model.IntegrationSettings{settings})
+	expectReply(t, bot, "unread: first")
+	bots.Stop()
+	if sessions, closed := bot.counts(); sessions != 3 || closed != 3 || len(bots.loops) != 0 {
+		t.Errorf("Expected all the loops to be stopped, got %d sessions, %d closed and %d loops", sessions, closed, len(bots.loops))
+	}
+}
+
+func expectReply(t *testing.T, bot *fakeChatBot, expected string) {
+	t.Helper()
+
+	select {
+	case reply := <-bot.replies:
+		if reply != expected {
+			t.Errorf("Expected reply %q, got %q", expected, reply)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected reply %q", expected)
+	}
+}
+
+func TestUnreadEntriesReply(t *testing.T) {
+	entries := model.Entries{
+		{ID: 12, Title: "Tom & Jerry", URL: "https://example.org/1?a=1&b=2", Feed: &model.Feed{Title: "Cartoons <TV>"}},
+		{ID: 13, Title: "Second", URL: "https://example.org/2", Feed: &model.Feed{Title: "News"}},
+	}
+
+	reply := unreadEntriesReply(locale.NewPrinter("en_US"), 25, entries)
+
+	expectedText := "25 unread entries:\n#12 Tom & Jerry - Cartoons <TV>\n#13 Second - News"
+	if reply.Text != expectedText {
+		t.Errorf("Unexpected text reply:\n%s", reply.Text)
+	}
+
+	expectedHTML := "<b>25 unread entries:</b>\n" +
+		`#12 <a href="https://example.org/1?a=1&amp;b=2">Tom &amp; Jerry</a> - <i>Cartoons &lt;TV&gt;</i>` + "\n" +
+		`#13 <a href="https://example.org/2">Second</a> - <i>News</i>`
+	if reply.HTML != expectedHTML {
+		t.Errorf("Unexpected HTML reply:\n%s", reply.HTML)
+	}
+}
@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package chatbot // import "miniflux.app/v2/internal/chatbot"
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/reader/subscription"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+// unreadEntriesLimit is the maximum number of entries listed by the unread command, the most recent ones are listed.
+const unreadEntriesLimit = 10
+
+// command is a command of the user with the context needed to run it.
+type command struct {
+	store    *storage.Storage
+	settings *model.IntegrationSettings
+	user     *model.User
+	printer  *locale.Printer
+}
+
+// runCommand returns nil when the reply cannot be sent, the error is logged.
+func runCommand(store *storage.Storage, settings *model.IntegrationSettings, chatCommand *integration.ChatCommand) *integration.ChatReply {
+	user, err := store.UserByID(settings.UserID)
+	if err != nil || user == nil {
+		slog.Error("Unable to fetch the user of the chat bot",
+			slog.Int64("user_id", settings.UserID),
+			slog.String("provider", settings.Provider),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+
+	c := &command{store: store, settings: settings, user: user, printer: locale.NewPrinter(user.Language)}
+	slog.Debug("Running chat bot command",
+		slog.Int64("user_id", user.ID),
+		slog.String("provider", settings.Provider),
+		slog.String("command", chatCommand.Name),
+	)
+
+	var reply *integration.ChatReply
+	switch chatCommand.Name {
+	case "start", "help":
+		reply = c.textReply("chatbot.help")
+	case "unread":
+		reply, err = c.unread(chatCommand.Arguments)
+	case "read", "star", "unstar", "save":
+		reply, err = c.updateEntry(chatCommand.Name, chatCommand.Arguments)
+	case "subscribe":
+		reply, err = c.subscribe(chatCommand.Arguments)
+	case "pause", "resume":
+		reply, err = c.pause(chatCommand.Name == "pause")
+	default:
+		reply = c.textReply("chatbot.unknown_command")
+	}
+
+	if err != nil {
+		slog.Error("Unable to run chat bot command",
+			slog.Int64("user_id", user.ID),
+			slog.String("provider", settings.Provider),
+			slog.String("command", chatCommand.Name),
+			slog.Any("error", err),
+		)
+		return c.textReply("chatbot.command_failed")
+	}
+	return reply
+}
+
+// unread lists the unread entries of the user, or of a category when its title is given.
+func (c *command) unread(categoryTitle string) (*integration.ChatReply, error) {
+	builder := c.store.NewEntryQueryBuilder(c.user.ID)
+	builder.WithStatus(model.EntryStatusUnread)
+
+	if categoryTitle != "" {
+		categories, err := c.store.Categories(c.user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var category *model.Category
+		for i := range categories {
+			if strings.EqualFold(categories[i].Title, categoryTitle) {
+				category = &categories[i]
+				break
+			}
+		}
+		if category == nil {
+			return c.textReply("chatbot.category_not_found", categoryTitle), nil
+		}
+		builder.WithCategoryID(category.ID)
+	}
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return c.textReply("chatbot.no_unread_entries"), nil
+	}
+
+	builder.WithSorting(c.user.EntryOrder, c.user.EntryDirection)
+	builder.WithSorting("id", c.user.EntryDirection)
+	builder.WithLimit(unreadEntriesLimit)
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	return unreadEntriesReply(c.printer, count, entries), nil
+}
+
+// updateEntry marks an entry as read, stars or unstars it, or saves it to the integrations.
+func (c *command) updateEntry(action, arguments string) (*integration.ChatReply, error) {
+	entryID, err := strconv.ParseInt(strings.TrimPrefix(arguments, "#"), 10, 64)
+	if err != nil || entryID <= 0 {
+		return c.textReply("chatbot.missing_entry_id"), nil
+	}
+
+	builder := c.store.NewEntryQueryBuilder(c.user.ID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	entry, err := builder.GetEntry()
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return c.textReply("chatbot.entry_not_found", entryID), nil
+	}
+
+	entryIDs := []int64{entry.ID}
+	switch action {
+	case "read":
+		if err := c.store.SetEntriesStatus(c.user.ID, entryIDs, model.EntryStatusRead); err != nil {
+			return nil, err
+		}
+		integration.SendEntriesStatusEvent(c.store, c.user.ID, entryIDs, model.EntryStatusRead)
+		return c.entryReply("chatbot.entry_marked_as_read", entry), nil
+	case "star", "unstar":
+		starred := action == "star"
+		if err := c.store.SetEntriesStarredState(c.user.ID, entryIDs, starred); err != nil {
+			return nil, err
+		}
+		integration.SendEntriesStarredEvent(c.store, c.user.ID, entryIDs)
+		if starred {
+			return c.entryReply("chatbot.entry_starred", entry), nil
+		}
+		return c.entryReply("chatbot.entry_unstarred", entry), nil
+	default:
+		userIntegrations, err := c.store.IntegrationSettings(c.user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !integration.HasSaveEntry(userIntegrations) {
+			return c.textReply("chatbot.no_save_integration"), nil
+		}
+		integration.SendEntry(c.store, entry, userIntegrations)
+		return c.entryReply("chatbot.entry_saved", entry), nil
+	}
+}
+
+// subscribe creates the feed of a website in the first category of the user.
+func (c *command) subscribe(websiteURL string) (*integration.ChatReply, error) {
+	if !validator.IsValidURL(websiteURL) {
+		return c.textReply("chatbot.missing_url"), nil
+	}
+
+	category, err := c.store.FirstCategory(c.user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("chatbot: user #%d has no category", c.user.ID)
+	}
+
+	var rssBridgeURL string
+	var rssBridgeToken string
+	if intg, err := c.store.Integration(c.user.ID); err == nil && intg != nil && intg.RSSBridgeEnabled {
+		rssBridgeURL = intg.RSSBridgeURL
+		rssBridgeToken = intg.RSSBridgeToken
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.WithUserAgent("", config.Opts.HTTPClientUserAgent())
+
+	subscriptionFinder := subscription.NewSubscriptionFinder(requestBuilder)
+	subscriptions, localizedError := subscriptionFinder.FindSubscriptions(websiteURL, rssBridgeURL, rssBridgeToken)
+	if localizedError != nil {
+		return c.textReply("chatbot.subscription_failed", localizedError.Translate(c.user.Language)), nil
+	}
+	if len(subscriptions) == 0 {
+		return c.textReply("chatbot.subscription_failed", locale.NewLocalizedError("error.subscription_not_found").Translate(c.user.Language)), nil
+	}
+
+	feedCreationRequest := model.FeedCreationRequest{
+		CategoryID: category.ID,
+		FeedURL:    subscriptions[0].URL,
+	}
+
+	var feed *model.Feed
+	if len(subscriptions) == 1 && subscriptionFinder.IsFeedAlreadyDownloaded() {
+		feed, localizedError = feedHandler.CreateFeedFromSubscriptionDiscovery(c.store, c.user.ID, &model.FeedCreationRequestFromSubscriptionDiscovery{
+			Content:             subscriptionFinder.FeedResponseInfo().Content,
+			ETag:                subscriptionFinder.FeedResponseInfo().ETag,
+			LastModified:        subscriptionFinder.FeedResponseInfo().LastModified,
+			FeedCreationRequest: feedCreationRequest,
+		})
+	} else {
+		feed, localizedError = feedHandler.CreateFeed(c.store, c.user.ID, &feedCreationRequest)
+	}
+	if localizedError != nil {
+		return c.textReply("chatbot.subscription_failed", localizedError.Translate(c.user.Language)), nil
+	}
+
+	return &integration.ChatReply{
+		Text: c.printer.Printf("chatbot.subscribed", feed.Title),
+		HTML: c.printer.Printf("chatbot.subscribed", htmlLink(feed.SiteURL, feed.Title)),
+	}, nil
+}
+
+// pause stops or resumes the new entries sent to the chat, the settings are read again to keep the changes of the user.
+func (c *command) pause(paused bool) (*integration.ChatReply, error) {
+	userIntegrations, err := c.store.IntegrationSettings(c.user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, settings := range userIntegrations {
+		if settings.Provider != c.settings.Provider {
+			continue
+		}
+
+		if paused {
+			settings.Values[integration.ChatBotPausedField] = true
+		} else {
+			delete(settings.Values, integration.ChatBotPausedField)
+		}
+		if err := c.store.UpdateIntegrationSettings(settings); err != nil {
+			return nil, err
+		}
+	}
+
+	if paused {
+		return c.textReply("chatbot.notifications_paused"), nil
+	}
+	return c.textReply("chatbot.notifications_resumed"), nil
+}
+
+func (c *command) textReply(key string, args ...any) *integration.ChatReply {
+	text := c.printer.Printf(key, args...)
+	return &integration.ChatReply{Text: text, HTML: html.EscapeString(text)}
+}
+
+func (c *command) entryReply(key string, entry *model.Entry) *integration.ChatReply {
+	return &integration.ChatReply{
+		Text: c.printer.Printf(key, entry.Title),
+		HTML: c.printer.Printf(key, htmlLink(entry.URL, entry.Title)),
+	}
+}
+
+// unreadEntriesReply lists the entries with their ID, the ID is the argument of the other commands.
+func unreadEntriesReply(printer *locale.Printer, count int, entries model.Entries) *integration.ChatReply {
+	title := printer.Plural("chatbot.unread_entries", count, count)
+	textLines := []string{title}
+	htmlLines := []string{"<b>" + html.EscapeString(title) + "</b>"}
+
+	for _, entry := range entries {
+		textLines = append(textLines, fmt.Sprintf("#%d %s - %s", entry.ID, entry.Title, entry.Feed.Title))
+		htmlLines = append(htmlLines, fmt.Sprintf("#%d %s - <i>%s</i>", entry.ID, htmlLink(entry.URL, entry.Title), html.EscapeString(entry.Feed.Title)))
+	}
+
+	return &integration.ChatReply{
+		Text: strings.Join(textLines, "\n"),
+		HTML: strings.Join(htmlLines, "\n"),
+	}
+}
+
+func htmlLink(url, text string) string {
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+}
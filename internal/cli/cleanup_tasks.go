@@ -61,6 +61,13 @@ func runCleanupTasks(store *storage.Storage) {
 			slog.Int64("removed_entries_content_cleared", contentAffected))
 	}
 
+	if rowsAffected, err := store.CleanOldIntegrationDeliveries(config.Opts.IntegrationDeliveryRetentionInterval()); err != nil {
+		slog.Error("Unable to remove old integration deliveries", slog.Any("error", err))
+	} else {
+		slog.Info("Integration deliveries cleanup completed",
+			slog.Int64("integration_deliveries_removed", rowsAffected))
+	}
+
 	if err := store.CleanupMedia(); err != nil {
 		slog.Error("Unable to cleanup media records",
 			slog.Any("error", err),
@@ -13,6 +13,7 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/database"
+	"miniflux.app/v2/internal/hostlimiter"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/proxyrotator"
 	"miniflux.app/v2/internal/storage"
@@ -318,12 +319,35 @@ func Parse() {
 		createAdminUserFromEnvironmentVariables(store)
 	}
 
+	if config.Opts.EncryptionKey() == "" {
+		if feedsCount, err := store.CountAllFeedsWithCustomHeaders(); err != nil {
+			slog.Error("Unable to count the feeds with custom headers", slog.Any("error", err))
+		} else if feedsCount > 0 {
+			slog.Warn("Feeds have custom headers but no encryption key is configured, they are stored in clear text or cannot be decrypted",
+				slog.Int("feeds_count", feedsCount),
+			)
+		}
+	}
+
 	if config.Opts.HasHTTPClientProxiesConfigured() {
 		slog.Info("Initializing proxy rotation", slog.Int("proxies_count", len(config.Opts.HTTPClientProxies())))
 		proxyrotator.ProxyRotatorInstance, err = proxyrotator.NewProxyRotator(config.Opts.HTTPClientProxies())
 		if err != nil {
 			printErrorAndExit(fmt.Errorf("unable to initialize proxy rotator: %v", err))
 		}
+		proxyrotator.ProxyRotatorInstance.WithStickyHosts(config.Opts.HTTPClientProxiesStickyHosts())
+	}
+
+	hostlimiter.HostLimiterInstance = hostlimiter.NewHostLimiter(
+		store,
+		config.Opts.HostRateLimitRequestsPerMinute(),
+		config.Opts.HostRateLimitBurst(),
+		config.Opts.HostRateLimitMaxWait(),
+	)
+	if hostLimits, err := store.HostLimits(); err != nil {
+		slog.Error("Unable to restore host limits", slog.Any("error", err))
+	} else {
+		hostlimiter.HostLimiterInstance.Restore(hostLimits)
 	}
 
 	if flagRefreshFeeds {
@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	"miniflux.app/v2/internal/cluster"
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/server"
 	"miniflux.app/v2/internal/metric"
@@ -29,8 +30,11 @@ func startDaemon(store *storage.Storage) {
 
 	pool := worker.NewPool(store, config.Opts.WorkerPoolSize())
 
+	var elector *cluster.Elector
 	if config.Opts.HasSchedulerService() && !config.Opts.HasMaintenanceMode() {
-		runScheduler(store, pool)
+		elector = cluster.NewElector(store, config.Opts.InstanceID(), config.Opts.LeaderLeaseDuration(), schedulerRoles()...)
+		elector.Start()
+		runScheduler(store, pool, elector)
 	}
 
 	var httpServers []*http.Server
@@ -92,5 +96,15 @@ func startDaemon(store *storage.Storage) {
 		slog.Debug("No HTTP servers to shut down.")
 	}
 
+	if elector != nil {
+		slog.Debug("Releasing scheduler roles...")
+		elector.Stop()
+	}
+
+	slog.Debug("Draining the worker pool...")
+	if err := pool.Shutdown(ctx); err != nil {
+		slog.Error("Worker pool shutdown error", slog.Any("error", err))
+	}
+
 	slog.Debug("Process gracefully stopped")
 }
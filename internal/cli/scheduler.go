@@ -7,12 +7,28 @@ import (
 	"log/slog"
 	"time"
 
+	"miniflux.app/v2/internal/chatbot"
+	"miniflux.app/v2/internal/cluster"
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/websub"
 	"miniflux.app/v2/internal/worker"
 )
 
-func runScheduler(store *storage.Storage, pool *worker.Pool) {
+// Claimed feeds are skipped by the other instances during this delay,
+// the refresh sets the actual next check date.
+const feedJobClaimDuration = 15 * time.Minute
+
+const (
+	integrationDeliveryFrequency = time.Minute
+	integrationDeliveryBatchSize = 50
+	integrationDigestFrequency   = 5 * time.Minute
+	integrationSyncFrequency     = 5 * time.Minute
+	chatBotFrequency             = time.Minute
+)
+
+func runScheduler(store *storage.Storage, pool *worker.Pool, elector *cluster.Elector) {
 	slog.Debug(`Starting background scheduler...`)
 
 	go store.CreateMediasRunOnce()
@@ -28,12 +44,34 @@ func runScheduler(store *storage.Storage, pool *worker.Pool) {
 
 	go cleanupScheduler(
 		store,
+		elector,
 		config.Opts.CleanupFrequency(),
 	)
 
+	go integrationDeliveryScheduler(store, integrationDeliveryFrequency, integrationDeliveryBatchSize)
+	go integrationDigestScheduler(store, integrationDigestFrequency)
+	go integrationSyncScheduler(store, integrationSyncFrequency)
+	go chatBotScheduler(store, elector, chatBotFrequency)
+
+	if config.Opts.HasCacheService() {
+		go cacheScheduler(store, elector, config.Opts.CacheInterval())
+	}
+
+	if config.Opts.HasWebSub() {
+		go webSubScheduler(store, elector, config.Opts.PollingFrequency(), config.Opts.BatchSize())
+	}
+}
+
+// schedulerRoles returns the roles held by a single instance when several instances share the database.
+func schedulerRoles() []string {
+	roles := []string{cluster.RoleCleanup, cluster.RoleChatBots}
 	if config.Opts.HasCacheService() {
-		go cacheScheduler(store, config.Opts.CacheInterval())
+		roles = append(roles, cluster.RoleCache)
+	}
+	if config.Opts.HasWebSub() {
+		roles = append(roles, cluster.RoleWebSub)
 	}
+	return roles
 }
 
 func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency time.Duration, batchSize, errorLimit, limitPerHost int) {
@@ -44,9 +82,10 @@ func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency time.Dur
 		batchBuilder.WithErrorLimit(errorLimit)
 		batchBuilder.WithoutDisabledFeeds()
 		batchBuilder.WithNextCheckExpired()
+		batchBuilder.WithoutThrottledHosts()
 		batchBuilder.WithLimitPerHost(limitPerHost)
 
-		if jobs, err := batchBuilder.FetchJobs(); err != nil {
+		if jobs, err := batchBuilder.ClaimJobs(feedJobClaimDuration); err != nil {
 			slog.Error("Unable to fetch jobs from database", slog.Any("error", err))
 		} else if len(jobs) > 0 {
 			slog.Debug("Feed URLs in this batch", slog.Any("feed_urls", jobs.FeedURLs()))
@@ -55,15 +94,23 @@ func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency time.Dur
 	}
 }
 
-func cleanupScheduler(store *storage.Storage, frequency time.Duration) {
+func cleanupScheduler(store *storage.Storage, elector *cluster.Elector, frequency time.Duration) {
 	for range time.Tick(frequency) {
+		if !elector.IsLeader(cluster.RoleCleanup) {
+			slog.Debug("Skipping cleanup tasks, another instance holds the role")
+			continue
+		}
 		runCleanupTasks(store)
 	}
 }
 
-func cacheScheduler(store *storage.Storage, frequency time.Duration) {
+func cacheScheduler(store *storage.Storage, elector *cluster.Elector, frequency time.Duration) {
 	c := time.Tick(frequency)
 	for range c {
+		if !elector.IsLeader(cluster.RoleCache) {
+			slog.Debug("Skipping cache tasks, another instance holds the role")
+			continue
+		}
 		if err := store.ValidateCaches(); err != nil {
 			slog.Error("scheduler: unable to validate csaches]", slog.Any("error", err))
 		}
@@ -72,3 +119,45 @@ func cacheScheduler(store *storage.Storage, frequency time.Duration) {
 		}
 	}
 }
+
+func webSubScheduler(store *storage.Storage, elector *cluster.Elector, frequency time.Duration, batchSize int) {
+	for range time.Tick(frequency) {
+		if !elector.IsLeader(cluster.RoleWebSub) {
+			continue
+		}
+		websub.RenewSubscriptions(store, batchSize)
+	}
+}
+
+// Pending deliveries are claimed, so every instance can retry them.
+func integrationDeliveryScheduler(store *storage.Storage, frequency time.Duration, batchSize int) {
+	for range time.Tick(frequency) {
+		integration.RetryDeliveries(store, batchSize)
+	}
+}
+
+// The digest dates are updated before sending, so every instance can send the digests.
+func integrationDigestScheduler(store *storage.Storage, frequency time.Duration) {
+	for range time.Tick(frequency) {
+		integration.SendDigests(store)
+	}
+}
+
+// The sync dates are updated before importing, so every instance can import the bookmarks.
+func integrationSyncScheduler(store *storage.Storage, frequency time.Duration) {
+	for range time.Tick(frequency) {
+		integration.SyncBookmarks(store)
+	}
+}
+
+// The bots are started again when the settings of the users change.
+func chatBotScheduler(store *storage.Storage, elector *cluster.Elector, frequency time.Duration) {
+	bots := chatbot.NewBots(store)
+	for range time.Tick(frequency) {
+		if !elector.IsLeader(cluster.RoleChatBots) {
+			bots.Stop()
+			continue
+		}
+		bots.Update()
+	}
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster // import "miniflux.app/v2/internal/cluster"
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Roles held by a single instance when several instances share the same database.
+const (
+	RoleCleanup = "cleanup"
+	RoleCache   = "cache"
+	RoleWebSub  = "websub"
+
+	// RoleChatBots reads the commands of the chat bots, the chats do not support several readers.
+	RoleChatBots = "chatbots"
+)
+
+// Store keeps the leases shared by the instances.
+type Store interface {
+	AcquireLease(name, holder string, duration time.Duration) (bool, error)
+	ReleaseLease(name, holder string) error
+}
+
+// Elector competes for roles through database leases and renews the leases it holds.
+type Elector struct {
+	store         Store
+	instanceID    string
+	leaseDuration time.Duration
+	roles         []string
+	expiresAt     map[string]time.Time
+	mutex         sync.Mutex
+	stop          chan struct{}
+	done          chan struct{}
+	now           func() time.Time
+}
+
+// NewElector creates an elector competing for the given roles.
+func NewElector(store Store, instanceID string, leaseDuration time.Duration, roles ...string) *Elector {
+	return &Elector{
+		store:         store,
+		instanceID:    instanceID,
+		leaseDuration: leaseDuration,
+		roles:         roles,
+		expiresAt:     make(map[string]time.Time),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+		now:           time.Now,
+	}
+}
+
+// Start acquires the available roles and keeps renewing them in the background.
+func (e *Elector) Start() {
+	e.campaign()
+
+	go func() {
+		defer close(e.done)
+
+		ticker := time.NewTicker(e.leaseDuration / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-e.stop:
+				return
+			case <-ticker.C:
+				e.campaign()
+			}
+		}
+	}()
+}
+
+// Stop releases the roles held by this instance so another one can take over immediately.
+func (e *Elector) Stop() {
+	close(e.stop)
+	<-e.done
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for role, expiresAt := range e.expiresAt {
+		if expiresAt.After(e.now()) {
+			if err := e.store.ReleaseLease(role, e.instanceID); err != nil {
+				slog.Error("Unable to release scheduler role", slog.String("role", role), slog.Any("error", err))
+			}
+		}
+		delete(e.expiresAt, role)
+	}
+}
+
+// IsLeader returns true if this instance holds the role.
+// The role is considered lost when the lease could not be renewed before its expiration.
+func (e *Elector) IsLeader(role string) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.expiresAt[role].After(e.now())
+}
+
+func (e *Elector) campaign() {
+	for _, role := range e.roles {
+		// The local expiration is computed before the request to stay on the safe side of the database clock.
+		expiresAt := e.now().Add(e.leaseDuration)
+		wasLeader := e.IsLeader(role)
+
+		acquired, err := e.store.AcquireLease(role, e.instanceID, e.leaseDuration)
+		if err != nil {
+			slog.Error("Unable to acquire scheduler role", slog.String("role", role), slog.Any("error", err))
+			continue
+		}
+
+		e.mutex.Lock()
+		if acquired {
+			e.expiresAt[role] = expiresAt
+		} else {
+			delete(e.expiresAt, role)
+		}
+		e.mutex.Unlock()
+
+		if acquired != wasLeader {
+			slog.Info("Scheduler role changed",
+				slog.String("role", role),
+				slog.String("instance_id", e.instanceID),
+				slog.Bool("leader", acquired),
+			)
+		}
+	}
+}
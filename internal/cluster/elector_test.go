@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster // import "miniflux.app/v2/internal/cluster"
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type lease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+type fakeStore struct {
+	now    *time.Time
+	leases map[string]lease
+	err    error
+}
+
+func (f *fakeStore) AcquireLease(name, holder string, duration time.Duration) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+
+	if current, found := f.leases[name]; found && current.holder != holder && current.expiresAt.After(*f.now) {
+		return false, nil
+	}
+
+	f.leases[name] = lease{holder: holder, expiresAt: f.now.Add(duration)}
+	return true, nil
+}
+
+func (f *fakeStore) ReleaseLease(name, holder string) error {
+	if f.leases[name].holder == holder {
+		delete(f.leases, name)
+	}
+	return nil
+}
+
+func newTestElectors() (*Elector, *Elector, *fakeStore, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &fakeStore{now: &now, leases: make(map[string]lease)}
+
+	first := NewElector(store, "first", time.Minute, RoleCleanup, RoleCache)
+	first.now = func() time.Time { return now }
+	second := NewElector(store, "second", time.Minute, RoleCleanup, RoleCache)
+	second.now = func() time.Time { return now }
+
+	return first, second, store, &now
+}
+
+func TestOnlyOneInstanceHoldsARole(t *testing.T) {
+	first, second, _, _ := newTestElectors()
+
+	first.campaign()
+	second.campaign()
+
+	if !first.IsLeader(RoleCleanup) || !first.IsLeader(RoleCache) {
+		t.Fatal(`The first instance should hold both roles`)
+	}
+
+	if second.IsLeader(RoleCleanup) || second.IsLeader(RoleCache) {
+		t.Fatal(`The second instance should not hold any role`)
+	}
+
+	// Renewing keeps the roles.
+	first.campaign()
+	if !first.IsLeader(RoleCleanup) {
+		t.Fatal(`The first instance should keep its role`)
+	}
+}
+
+func TestRoleIsTakenOverWhenLeaseExpires(t *testing.T) {
+	first, second, _, now := newTestElectors()
+
+	first.campaign()
+	*now = now.Add(2 * time.Minute)
+
+	if first.IsLeader(RoleCleanup) {
+		t.Fatal(`The first instance should lose its role once the lease expired`)
+	}
+
+	second.campaign()
+	if !second.IsLeader(RoleCleanup) {
+		t.Fatal(`The second instance should take over the role`)
+	}
+
+	first.campaign()
+	if first.IsLeader(RoleCleanup) {
+		t.Fatal(`The first instance should not get the role back`)
+	}
+}
+
+func TestRoleIsLostWhenRenewalFails(t *testing.T) {
+	first, _, store, now := newTestElectors()
+
+	first.campaign()
+
+	store.err = errors.New("database unavailable")
+	*now = now.Add(30 * time.Second)
+	first.campaign()
+	if !first.IsLeader(RoleCleanup) {
+		t.Fatal(`The role should be kept until the lease expires`)
+	}
+
+	*now = now.Add(31 * time.Second)
+	if first.IsLeader(RoleCleanup) {
+		t.Fatal(`The role should be lost once the lease expired`)
+	}
+}
+
+func TestStopReleasesRoles(t *testing.T) {
+	first, second, _, _ := newTestElectors()
+
+	first.Start()
+	first.Stop()
+
+	if first.IsLeader(RoleCleanup) {
+		t.Fatal(`The stopped instance should not hold any role`)
+	}
+
+	second.campaign()
+	if !second.IsLeader(RoleCleanup) {
+		t.Fatal(`The second instance should take over immediately`)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"maps"
 	"net/url"
+	"os"
 	"slices"
 	"strings"
 	"time"
@@ -255,6 +256,30 @@ func NewConfigOptions() *configOptions {
 					return validateGreaterThan(rawValue, 0)
 				},
 			},
+			"HOST_RATE_LIMIT_BURST": {
+				ParsedIntValue: 10,
+				RawValue:       "10",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"HOST_RATE_LIMIT_MAX_WAIT": {
+				ParsedDuration: 10 * time.Second,
+				RawValue:       "10",
+				ValueType:      secondType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"HOST_RATE_LIMIT_REQUESTS_PER_MINUTE": {
+				ParsedIntValue: 60,
+				RawValue:       "60",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
 			"HTTP_CLIENT_MAX_BODY_SIZE": {
 				ParsedInt64Value: 15,
 				RawValue:         "15",
@@ -269,6 +294,11 @@ func NewConfigOptions() *configOptions {
 				ValueType:        stringListType,
 				Secret:           true,
 			},
+			"HTTP_CLIENT_PROXIES_STICKY_HOSTS": {
+				ParsedBoolValue: false,
+				RawValue:        "0",
+				ValueType:       boolType,
+			},
 			"HTTP_CLIENT_PROXY": {
 				ParsedURLValue: nil,
 				RawValue:       "",
@@ -506,7 +536,7 @@ func NewConfigOptions() *configOptions {
 				RawValue:          "round_robin",
 				ValueType:         stringType,
 				Validator: func(rawValue string) error {
-					return validateChoices(rawValue, []string{"round_robin", "entry_frequency"})
+					return validateChoices(rawValue, []string{"round_robin", "entry_frequency", "publishing_pattern"})
 				},
 			},
 			"PORT": {
@@ -615,6 +645,154 @@ func NewConfigOptions() *configOptions {
 				ValueType:         stringType,
 				// Validator:         func(rawValue string) error { return nil },
 			},
+			"BACKFILL_MAX_ENTRIES": {
+				ParsedIntValue: 100,
+				RawValue:       "100",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"ACTIVITYPUB": {
+				ParsedBoolValue: false,
+				RawValue:        "0",
+				ValueType:       boolType,
+			},
+			"WEBSUB": {
+				ParsedBoolValue: false,
+				RawValue:        "0",
+				ValueType:       boolType,
+			},
+			"WEBSUB_LEASE_DAYS": {
+				ParsedDuration: time.Hour * 24 * 10,
+				RawValue:       "10",
+				ValueType:      dayType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"WEBSUB_POLLING_FREQUENCY_HOURS": {
+				ParsedDuration: time.Hour * 24,
+				RawValue:       "24",
+				ValueType:      hourType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"FETCH_LOG_SIZE": {
+				ParsedIntValue: 20,
+				RawValue:       "20",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"INSTANCE_ID": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+			},
+			"LEADER_LEASE_DURATION": {
+				ParsedDuration: 60 * time.Second,
+				RawValue:       "60",
+				ValueType:      secondType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 10)
+				},
+			},
+			"PERMANENT_REDIRECT_THRESHOLD": {
+				ParsedIntValue: 3,
+				RawValue:       "3",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"ENCRYPTION_KEY": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+				Secret:            true,
+			},
+			"ENCRYPTION_KEY_FILE": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         secretFileType,
+				TargetKey:         "ENCRYPTION_KEY",
+			},
+			"DEAD_FEED_NOTIFICATION_DAYS": {
+				ParsedIntValue: 60,
+				RawValue:       "60",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"MEDIA_PROXY_WRITE_THROUGH": {
+				ParsedBoolValue: false,
+				RawValue:        "0",
+				ValueType:       boolType,
+			},
+			"INTEGRATION_DELIVERY_MAX_ATTEMPTS": {
+				ParsedIntValue: 8,
+				RawValue:       "8",
+				ValueType:      intType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"INTEGRATION_DELIVERY_RETENTION_DAYS": {
+				ParsedDuration: time.Hour * 24 * 30,
+				RawValue:       "30",
+				ValueType:      dayType,
+			},
+			"EREADER_DIRECTORY": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+			},
+			"NOTION_OAUTH2_CLIENT_ID": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+			},
+			"NOTION_OAUTH2_CLIENT_SECRET": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+				Secret:            true,
+			},
+			"NOTION_OAUTH2_CLIENT_SECRET_FILE": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         secretFileType,
+				TargetKey:         "NOTION_OAUTH2_CLIENT_SECRET",
+			},
+			"RAINDROP_OAUTH2_CLIENT_ID": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+			},
+			"RAINDROP_OAUTH2_CLIENT_SECRET": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         stringType,
+				Secret:            true,
+			},
+			"RAINDROP_OAUTH2_CLIENT_SECRET_FILE": {
+				ParsedStringValue: "",
+				RawValue:          "",
+				ValueType:         secretFileType,
+				TargetKey:         "RAINDROP_OAUTH2_CLIENT_SECRET",
+			},
+			"INTEGRATION_BOOKMARK_SYNC_INTERVAL": {
+				ParsedDuration: time.Minute * 30,
+				RawValue:       "30",
+				ValueType:      minuteType,
+				Validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 5)
+				},
+			},
 		},
 	}
 }
@@ -783,6 +961,22 @@ func (c *configOptions) HTTPClientProxies() []string {
 	return c.options["HTTP_CLIENT_PROXIES"].ParsedStringList
 }
 
+func (c *configOptions) HostRateLimitBurst() int {
+	return c.options["HOST_RATE_LIMIT_BURST"].ParsedIntValue
+}
+
+func (c *configOptions) HostRateLimitMaxWait() time.Duration {
+	return c.options["HOST_RATE_LIMIT_MAX_WAIT"].ParsedDuration
+}
+
+func (c *configOptions) HostRateLimitRequestsPerMinute() int {
+	return c.options["HOST_RATE_LIMIT_REQUESTS_PER_MINUTE"].ParsedIntValue
+}
+
+func (c *configOptions) HTTPClientProxiesStickyHosts() bool {
+	return c.options["HTTP_CLIENT_PROXIES_STICKY_HOSTS"].ParsedBoolValue
+}
+
 func (c *configOptions) HTTPClientProxyURL() *url.URL {
 	return c.options["HTTP_CLIENT_PROXY"].ParsedURLValue
 }
@@ -1020,6 +1214,113 @@ func (c *configOptions) CacheLocation() string {
 	return c.options["CACHE_LOCATION"].ParsedStringValue
 }
 
+// MediaProxyWriteThrough returns true if the media proxy stores the media of the feeds with media caching enabled on first access.
+func (c *configOptions) MediaProxyWriteThrough() bool {
+	return c.options["MEDIA_PROXY_WRITE_THROUGH"].ParsedBoolValue
+}
+
+// BackfillMaxEntries returns the maximum number of older entries loaded from the feed archives, zero disables the backfill.
+func (c *configOptions) BackfillMaxEntries() int {
+	return c.options["BACKFILL_MAX_ENTRIES"].ParsedIntValue
+}
+
+// HasActivityPub returns true if the users can publish their shared entries with an ActivityPub actor.
+func (c *configOptions) HasActivityPub() bool {
+	return c.options["ACTIVITYPUB"].ParsedBoolValue && !c.options["DISABLE_HTTP_SERVICE"].ParsedBoolValue
+}
+
+// HasWebSub returns true if feeds advertising a hub should be subscribed through WebSub.
+func (c *configOptions) HasWebSub() bool {
+	return c.options["WEBSUB"].ParsedBoolValue && !c.options["DISABLE_HTTP_SERVICE"].ParsedBoolValue
+}
+
+// WebSubLeaseDuration returns the lease duration requested from WebSub hubs.
+func (c *configOptions) WebSubLeaseDuration() time.Duration {
+	return c.options["WEBSUB_LEASE_DAYS"].ParsedDuration
+}
+
+// WebSubPollingFrequency returns the polling interval of feeds with an active WebSub subscription.
+func (c *configOptions) WebSubPollingFrequency() time.Duration {
+	return c.options["WEBSUB_POLLING_FREQUENCY_HOURS"].ParsedDuration
+}
+
+// FetchLogSize returns the number of fetch logs kept for each feed, zero disables the fetch log.
+func (c *configOptions) FetchLogSize() int {
+	return c.options["FETCH_LOG_SIZE"].ParsedIntValue
+}
+
+// InstanceID returns the name identifying this process among the instances sharing the database.
+func (c *configOptions) InstanceID() string {
+	if instanceID := c.options["INSTANCE_ID"].ParsedStringValue; instanceID != "" {
+		return instanceID
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "miniflux"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// LeaderLeaseDuration returns how long an instance keeps a scheduler role without renewing it.
+func (c *configOptions) LeaderLeaseDuration() time.Duration {
+	return c.options["LEADER_LEASE_DURATION"].ParsedDuration
+}
+
+// PermanentRedirectThreshold returns the number of consecutive permanent redirects before the feed URL is updated, zero disables the update.
+func (c *configOptions) PermanentRedirectThreshold() int {
+	return c.options["PERMANENT_REDIRECT_THRESHOLD"].ParsedIntValue
+}
+
+// DeadFeedNotificationDays returns the number of days a feed must be gone before the user is notified, zero disables the notification.
+func (c *configOptions) DeadFeedNotificationDays() int {
+	return c.options["DEAD_FEED_NOTIFICATION_DAYS"].ParsedIntValue
+}
+
+// IntegrationDeliveryMaxAttempts returns the number of attempts before an integration delivery is dead-lettered.
+func (c *configOptions) IntegrationDeliveryMaxAttempts() int {
+	return c.options["INTEGRATION_DELIVERY_MAX_ATTEMPTS"].ParsedIntValue
+}
+
+// IntegrationDeliveryRetentionInterval returns how long the finished integration deliveries are kept.
+func (c *configOptions) IntegrationDeliveryRetentionInterval() time.Duration {
+	return c.options["INTEGRATION_DELIVERY_RETENTION_DAYS"].ParsedDuration
+}
+
+// IntegrationBookmarkSyncInterval returns the delay between two imports of the bookmarks of an integration.
+func (c *configOptions) IntegrationBookmarkSyncInterval() time.Duration {
+	return c.options["INTEGRATION_BOOKMARK_SYNC_INTERVAL"].ParsedDuration
+}
+
+// EReaderDirectory returns the directory where the e-reader integration writes the books, empty disables the local folder destination.
+func (c *configOptions) EReaderDirectory() string {
+	return c.options["EREADER_DIRECTORY"].ParsedStringValue
+}
+
+// NotionOAuth2ClientID returns the client ID of the Notion public integration, empty disables the Notion connection.
+func (c *configOptions) NotionOAuth2ClientID() string {
+	return c.options["NOTION_OAUTH2_CLIENT_ID"].ParsedStringValue
+}
+
+func (c *configOptions) NotionOAuth2ClientSecret() string {
+	return c.options["NOTION_OAUTH2_CLIENT_SECRET"].ParsedStringValue
+}
+
+// RaindropOAuth2ClientID returns the client ID of the Raindrop application, empty disables the Raindrop connection.
+func (c *configOptions) RaindropOAuth2ClientID() string {
+	return c.options["RAINDROP_OAUTH2_CLIENT_ID"].ParsedStringValue
+}
+
+func (c *configOptions) RaindropOAuth2ClientSecret() string {
+	return c.options["RAINDROP_OAUTH2_CLIENT_SECRET"].ParsedStringValue
+}
+
+// EncryptionKey returns the secret used to encrypt sensitive feed settings and integration tokens in the database.
+// The encryption is opt-in, the settings are stored in clear text when the key is empty.
+func (c *configOptions) EncryptionKey() string {
+	return c.options["ENCRYPTION_KEY"].ParsedStringValue
+}
+
 func (c *configOptions) ConfigMap(redactSecret bool) []*optionPair {
 	sortedKeys := slices.Sorted(maps.Keys(c.options))
 	sortedOptions := make([]*optionPair, 0, len(sortedKeys))
@@ -1657,9 +1657,9 @@ func TestConfigMap(t *testing.T) {
 		t.Fatal("Expected ConfigMap to contain configuration options")
 	}
 
-	// The first option should be "ADMIN_PASSWORD"
-	if configMap[0].Key != "ADMIN_PASSWORD" {
-		t.Fatalf("Expected first config option to be 'ADMIN_PASSWORD', got '%s'", configMap[0].Key)
+	// The first option should be "ACTIVITYPUB"
+	if configMap[0].Key != "ACTIVITYPUB" {
+		t.Fatalf("Expected first config option to be 'ACTIVITYPUB', got '%s'", configMap[0].Key)
 	}
 }
 
@@ -1676,13 +1676,13 @@ func TestConfigMapWithRedactedSecrets(t *testing.T) {
 		t.Fatal("Expected ConfigMap to contain configuration options")
 	}
 
-	// The first option should be "ADMIN_PASSWORD"
-	if configMap[0].Key != "ADMIN_PASSWORD" {
-		t.Fatalf("Expected first config option to be 'ADMIN_PASSWORD', got '%s'", configMap[0].Key)
+	// The second option should be "ADMIN_PASSWORD"
+	if configMap[1].Key != "ADMIN_PASSWORD" {
+		t.Fatalf("Expected second config option to be 'ADMIN_PASSWORD', got '%s'", configMap[1].Key)
 	}
 
 	// The value should be redacted
-	if configMap[0].Value != "<redacted>" {
-		t.Fatalf("Expected ADMIN_PASSWORD value to be redacted, got '%s'", configMap[0].Value)
+	if configMap[1].Value != "<redacted>" {
+		t.Fatalf("Expected ADMIN_PASSWORD value to be redacted, got '%s'", configMap[1].Value)
 	}
 }
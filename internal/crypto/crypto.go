@@ -4,11 +4,15 @@
 package crypto // import "miniflux.app/v2/internal/crypto"
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash/fnv"
 
@@ -59,3 +63,50 @@ func GenerateUUID() string {
 func ConstantTimeCmp(a, b string) bool {
 	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
 }
+
+// Encrypt encrypts a value with AES-GCM, using a key derived from the secret.
+// The result is encoded in base64 and includes the random nonce.
+func Encrypt(secret, plaintext string) (string, error) {
+	aead, err := newAEAD(secret)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := GenerateRandomBytes(aead.NonceSize())
+	ciphertext := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts a value returned by Encrypt with the same secret.
+func Decrypt(secret, encrypted string) (string, error) {
+	aead, err := newAEAD(secret)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("crypto: invalid encrypted value: %w", err)
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return "", errors.New("crypto: encrypted value too short")
+	}
+
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("crypto: unable to decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(secret string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package crypto // import "miniflux.app/v2/internal/crypto"
+
+import "testing"
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypted, err := Encrypt("secret", "Authorization: Bearer token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encrypted == "Authorization: Bearer token" {
+		t.Fatal(`The value is not encrypted`)
+	}
+
+	if other, _ := Encrypt("secret", "Authorization: Bearer token"); other == encrypted {
+		t.Error(`Encrypting the same value twice should use a different nonce`)
+	}
+
+	decrypted, err := Decrypt("secret", encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decrypted != "Authorization: Bearer token" {
+		t.Errorf(`Unexpected decrypted value: %q`, decrypted)
+	}
+}
+
+func TestDecryptWithInvalidValues(t *testing.T) {
+	encrypted, err := Encrypt("secret", "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Decrypt("another secret", encrypted); err == nil {
+		t.Error(`Decrypting with another secret should fail`)
+	}
+
+	for _, value := range []string{"", "not base64!", "c2hvcnQ="} {
+		if _, err := Decrypt("secret", value); err == nil {
+			t.Errorf(`Decrypting %q should fail`, value)
+		}
+	}
+}
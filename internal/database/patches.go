@@ -83,6 +83,367 @@ func patch(db *sql.DB) error {
 			return err
 		}
 	}
+	// Global rules have no owner, personal rules belong to a user.
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS domain_rules (
+			id bigserial not null,
+			user_id int references users(id) on delete cascade,
+			domain text not null,
+			scraper_rules text not null default '',
+			rewrite_rules text not null default '',
+			created_at timestamp with time zone not null default now(),
+			updated_at timestamp with time zone not null default now(),
+			primary key (id)
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS domain_rules_user_id_domain_idx ON domain_rules ((coalesce(user_id, 0)), domain);`)
+	if err != nil {
+		return err
+	}
+	if !columnExists(tx, "feeds", "extractor") {
+		_, err = tx.Exec("alter table feeds add column extractor text not null default '';")
+		if err != nil {
+			return err
+		}
+	}
+	if !columnExists(tx, "feeds", "detected_extractor") {
+		_, err = tx.Exec("alter table feeds add column detected_extractor text not null default '';")
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS websub_subscriptions (
+			feed_id bigint not null references feeds(id) on delete cascade,
+			hub_url text not null,
+			topic_url text not null,
+			secret text not null default '',
+			state text not null default 'pending',
+			lease_expires_at timestamp with time zone,
+			created_at timestamp with time zone not null default now(),
+			updated_at timestamp with time zone not null default now(),
+			primary key (feed_id)
+		);`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS host_limits (
+			host text not null,
+			blocked_until timestamp with time zone not null,
+			backoff_seconds int not null default 0,
+			updated_at timestamp with time zone not null default now(),
+			primary key (host)
+		);`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS scheduler_leases (
+			name text not null,
+			holder text not null,
+			expires_at timestamp with time zone not null,
+			acquired_at timestamp with time zone not null default now(),
+			primary key (name)
+		);`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS feed_fetch_logs (
+			id bigserial not null,
+			feed_id bigint not null,
+			fetched_at timestamp with time zone not null default now(),
+			status_code int not null default 0,
+			effective_url text not null default '',
+			redirects text[] not null default '{}',
+			not_modified bool not null default 'f',
+			etag text not null default '',
+			last_modified text not null default '',
+			content_length bigint not null default 0,
+			duration_ms bigint not null default 0,
+			proxy_url text not null default '',
+			new_entries int not null default 0,
+			updated_entries int not null default 0,
+			error_message text not null default '',
+			primary key (id),
+			foreign key (feed_id) references feeds(id) on delete cascade
+		);
+		CREATE INDEX IF NOT EXISTS feed_fetch_logs_feed_id_idx ON feed_fetch_logs(feed_id, id);`)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS feed_health (
+			feed_id bigint not null,
+			redirect_url text not null default '',
+			redirect_count int not null default 0,
+			previous_feed_url text not null default '',
+			moved_at timestamp with time zone,
+			dead_since timestamp with time zone,
+			dead_notified_at timestamp with time zone,
+			alternative_urls text[] not null default '{}',
+			primary key (feed_id),
+			foreign key (feed_id) references feeds(id) on delete cascade
+		);`)
+	if err != nil {
+		return err
+	}
+	if !columnExists(tx, "feeds", "custom_headers") {
+		_, err = tx.Exec("alter table feeds add column custom_headers text not null default '';")
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS client_certificates (
+			id bigserial not null,
+			user_id int not null,
+			name text not null,
+			certificate text not null default '',
+			private_key text not null default '',
+			ca_bundle text not null default '',
+			created_at timestamp with time zone not null default now(),
+			primary key (id),
+			unique (user_id, name),
+			foreign key (user_id) references users(id) on delete cascade
+		);`)
+	if err != nil {
+		return err
+	}
+	if !columnExists(tx, "feeds", "client_certificate_id") {
+		_, err = tx.Exec("alter table feeds add column client_certificate_id bigint references client_certificates(id) on delete set null;")
+		if err != nil {
+			return err
+		}
+	}
+	if !tableExists(tx, "integration_providers") {
+		_, err = tx.Exec(`CREATE TABLE integration_providers (
+				user_id int not null,
+				provider text not null,
+				enabled bool not null default 'f',
+				settings jsonb not null default '{}',
+				primary key (user_id, provider),
+				foreign key (user_id) references users(id) on delete cascade
+			);`)
+		if err != nil {
+			return err
+		}
+
+		// Move the settings of the providers from the columns of the integrations table,
+		// the columns are left in place so that a downgrade keeps the settings.
+		for _, provider := range []struct {
+			name           string
+			enabledColumn  string
+			settingsFields string
+		}{
+			{"apprise", `apprise_enabled`, `'url', nullif(apprise_url, ''), 'services_url', nullif(apprise_services_url, '')`},
+			{"archiveorg", `archiveorg_enabled`, ``},
+			{"betula", `betula_enabled`, `'url', nullif(betula_url, ''), 'token', nullif(betula_token, '')`},
+			{"cubox", `cubox_enabled`, `'api_link', nullif(cubox_api_link, '')`},
+			{"discord", `discord_enabled`, `'webhook_link', nullif(discord_webhook_link, '')`},
+			{"espial", `espial_enabled`, `'url', nullif(espial_url, ''), 'api_key', nullif(espial_api_key, ''), 'tags', nullif(espial_tags, '')`},
+			{"instapaper", `instapaper_enabled`, `'username', nullif(instapaper_username, ''), 'password', nullif(instapaper_password, '')`},
+			{"karakeep", `karakeep_enabled`, `'api_key', nullif(karakeep_api_key, ''), 'url', nullif(karakeep_url, ''), 'tags', nullif(karakeep_tags, '')`},
+			{"linkace", `linkace_enabled`, `'url', nullif(linkace_url, ''), 'api_key', nullif(linkace_api_key, ''), 'tags', nullif(linkace_tags, ''), 'is_private', CASE WHEN linkace_is_private THEN true END, 'check_disabled', CASE WHEN linkace_check_disabled THEN true END`},
+			{"linkding", `linkding_enabled`, `'url', nullif(linkding_url, ''), 'api_key', nullif(linkding_api_key, ''), 'tags', nullif(linkding_tags, ''), 'mark_as_unread', CASE WHEN linkding_mark_as_unread THEN true END`},
+			{"linktaco", `linktaco_enabled`, `'api_token', nullif(linktaco_api_token, ''), 'org_slug', nullif(linktaco_org_slug, ''), 'tags', nullif(linktaco_tags, ''), 'visibility', nullif(linktaco_visibility, '')`},
+			{"linkwarden", `linkwarden_enabled`, `'url', nullif(linkwarden_url, ''), 'api_key', nullif(linkwarden_api_key, ''), 'collection_id', linkwarden_collection_id`},
+			{"matrix_bot", `matrix_bot_enabled`, `'user', nullif(matrix_bot_user, ''), 'password', nullif(matrix_bot_password, ''), 'url', nullif(matrix_bot_url, ''), 'chat_id', nullif(matrix_bot_chat_id, '')`},
+			{"notion", `notion_enabled`, `'token', nullif(notion_token, ''), 'page_id', nullif(notion_page_id, '')`},
+			{"ntfy", `ntfy_enabled`, `'topic', nullif(ntfy_topic, ''), 'url', nullif(ntfy_url, ''), 'api_token', nullif(ntfy_api_token, ''), 'username', nullif(ntfy_username, ''), 'password', nullif(ntfy_password, ''), 'icon_url', nullif(ntfy_icon_url, ''), 'internal_links', CASE WHEN ntfy_internal_links THEN true END`},
+			{"nunux_keeper", `nunux_keeper_enabled`, `'url', nullif(nunux_keeper_url, ''), 'api_key', nullif(nunux_keeper_api_key, '')`},
+			{"omnivore", `omnivore_enabled`, `'api_key', nullif(omnivore_api_key, ''), 'url', nullif(omnivore_url, '')`},
+			{"pinboard", `pinboard_enabled`, `'token', nullif(pinboard_token, ''), 'tags', nullif(pinboard_tags, ''), 'mark_as_unread', CASE WHEN pinboard_mark_as_unread THEN true END`},
+			{"pushover", `pushover_enabled`, `'token', nullif(pushover_token, ''), 'user', nullif(pushover_user, ''), 'device', nullif(pushover_device, ''), 'prefix', nullif(pushover_prefix, '')`},
+			{"raindrop", `raindrop_enabled`, `'token', nullif(raindrop_token, ''), 'collection_id', nullif(raindrop_collection_id, ''), 'tags', nullif(raindrop_tags, '')`},
+			{"readeck", `readeck_enabled`, `'only_url', CASE WHEN readeck_only_url THEN true END, 'url', nullif(readeck_url, ''), 'api_key', nullif(readeck_api_key, ''), 'labels', nullif(readeck_labels, '')`},
+			{"readwise", `readwise_enabled`, `'api_key', nullif(readwise_api_key, '')`},
+			{"shaarli", `shaarli_enabled`, `'url', nullif(shaarli_url, ''), 'api_secret', nullif(shaarli_api_secret, '')`},
+			{"shiori", `shiori_enabled`, `'url', nullif(shiori_url, ''), 'username', nullif(shiori_username, ''), 'password', nullif(shiori_password, '')`},
+			{"slack", `slack_enabled`, `'webhook_link', nullif(slack_webhook_link, '')`},
+			{"telegram_bot", `telegram_bot_enabled`, `'token', nullif(telegram_bot_token, ''), 'chat_id', nullif(telegram_bot_chat_id, ''), 'topic_id', telegram_bot_topic_id, 'disable_web_page_preview', CASE WHEN telegram_bot_disable_web_page_preview THEN true END, 'disable_notification', CASE WHEN telegram_bot_disable_notification THEN true END, 'disable_buttons', CASE WHEN telegram_bot_disable_buttons THEN true END`},
+			{"wallabag", `wallabag_enabled`, `'only_url', CASE WHEN wallabag_only_url THEN true END, 'url', nullif(wallabag_url, ''), 'client_id', nullif(wallabag_client_id, ''), 'client_secret', nullif(wallabag_client_secret, ''), 'username', nullif(wallabag_username, ''), 'password', nullif(wallabag_password, ''), 'tags', nullif(wallabag_tags, '')`},
+			{"webhook", `webhook_enabled`, `'url', nullif(webhook_url, ''), 'secret', nullif(webhook_secret, '')`},
+		} {
+			settings := "'{}'::jsonb"
+			if provider.settingsFields != "" {
+				settings = "jsonb_strip_nulls(jsonb_build_object(" + provider.settingsFields + "))"
+			}
+			_, err = tx.Exec(`
+				INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT user_id, $1, coalesce(`+provider.enabledColumn+`, 'f'), `+settings+`
+				FROM integrations`,
+				provider.name,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS integration_deliveries (
+			id bigserial not null,
+			user_id int not null,
+			provider text not null,
+			kind text not null,
+			feed_id bigint not null,
+			entry_ids bigint[] not null default '{}',
+			status text not null default 'pending',
+			attempts int not null default 0,
+			last_error text not null default '',
+			next_attempt_at timestamp with time zone not null default now(),
+			created_at timestamp with time zone not null default now(),
+			updated_at timestamp with time zone not null default now(),
+			primary key (id),
+			foreign key (user_id) references users(id) on delete cascade,
+			foreign key (feed_id) references feeds(id) on delete cascade
+		);
+		CREATE INDEX IF NOT EXISTS integration_deliveries_pending_idx ON integration_deliveries(next_attempt_at) WHERE status = 'pending';
+		CREATE INDEX IF NOT EXISTS integration_deliveries_user_id_idx ON integration_deliveries(user_id, id);`)
+	if err != nil {
+		return err
+	}
+	// Webhooks configured before the events could be chosen keep receiving the new and saved entries.
+	_, err = tx.Exec(`
+		UPDATE integration_providers
+		SET settings = settings || '{"event_new_entries": true, "event_save_entry": true}'::jsonb
+		WHERE provider = 'webhook' AND NOT settings ?| array[
+			'event_new_entries', 'event_save_entry', 'event_entry_read', 'event_entry_unread',
+			'event_entry_starred', 'event_entry_unstarred', 'event_entry_tags_changed',
+			'event_feed_created', 'event_feed_removed', 'event_feed_error', 'event_feed_recovered'
+		]`)
+	if err != nil {
+		return err
+	}
+	// The digests are not related to a single feed.
+	_, err = tx.Exec(`
+		ALTER TABLE integration_deliveries ALTER COLUMN feed_id DROP NOT NULL;
+		ALTER TABLE integration_providers ADD COLUMN IF NOT EXISTS last_digest_at timestamp with time zone;`)
+	if err != nil {
+		return err
+	}
+	// The OAuth2 tokens of the integrations are encrypted with ENCRYPTION_KEY.
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS integration_tokens (
+			user_id int not null,
+			provider text not null,
+			access_token text not null,
+			refresh_token text not null default '',
+			token_type text not null default '',
+			expires_at timestamp with time zone,
+			created_at timestamp with time zone not null default now(),
+			updated_at timestamp with time zone not null default now(),
+			primary key (user_id, provider),
+			foreign key (user_id) references users(id) on delete cascade
+		);`)
+	if err != nil {
+		return err
+	}
+	// The bookmarks imported from the integrations are linked to the entries to send back the starred state.
+	_, err = tx.Exec(`
+		ALTER TABLE integration_providers ADD COLUMN IF NOT EXISTS last_sync_at timestamp with time zone;
+		CREATE TABLE IF NOT EXISTS integration_bookmarks (
+			user_id int not null,
+			provider text not null,
+			bookmark_id text not null,
+			entry_id bigint not null,
+			primary key (user_id, provider, bookmark_id),
+			foreign key (user_id) references users(id) on delete cascade,
+			foreign key (entry_id) references entries(id) on delete cascade
+		);
+		CREATE INDEX IF NOT EXISTS integration_bookmarks_entry_idx ON integration_bookmarks(entry_id);`)
+	if err != nil {
+		return err
+	}
+	// The notification routes replace the notification settings of the feeds,
+	// the columns of the feeds are left in place so that a downgrade keeps the settings.
+	if !tableExists(tx, "notification_routes") {
+		_, err = tx.Exec(`
+			CREATE TABLE notification_routes (
+				id bigserial not null,
+				user_id int not null,
+				provider text not null,
+				category_id int,
+				feed_id bigint,
+				filter_rules text not null default '',
+				target text not null default '',
+				priority int not null default 0,
+				created_at timestamp with time zone not null default now(),
+				updated_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+			CREATE INDEX notification_routes_user_id_idx ON notification_routes(user_id);
+			ALTER TABLE integration_deliveries ADD COLUMN IF NOT EXISTS route_id bigint REFERENCES notification_routes(id) ON DELETE SET NULL;`)
+		if err != nil {
+			return err
+		}
+
+		// Ntfy and Pushover only notified the feeds enabling them, the priority of Ntfy goes from 1 to 5.
+		// The other feeds kept receiving the entries of Apprise and of the webhooks with the settings of the user.
+		_, err = tx.Exec(`
+			INSERT INTO notification_routes (user_id, provider, feed_id, target, priority)
+			SELECT user_id, 'ntfy', id, coalesce(ntfy_topic, ''), least(greatest(coalesce(ntfy_priority, 3) - 3, -2), 2)
+			FROM feeds WHERE ntfy_enabled;
+			INSERT INTO notification_routes (user_id, provider, feed_id, priority)
+			SELECT user_id, 'pushover', id, least(greatest(coalesce(pushover_priority, 0), -2), 2)
+			FROM feeds WHERE pushover_enabled;
+			INSERT INTO notification_routes (user_id, provider)
+			SELECT DISTINCT user_id, 'apprise' FROM feeds WHERE apprise_service_urls <> '';
+			INSERT INTO notification_routes (user_id, provider, feed_id, target)
+			SELECT user_id, 'apprise', id, apprise_service_urls FROM feeds WHERE apprise_service_urls <> '';
+			INSERT INTO notification_routes (user_id, provider)
+			SELECT DISTINCT user_id, 'webhook' FROM feeds WHERE webhook_url <> '';
+			INSERT INTO notification_routes (user_id, provider, feed_id, target)
+			SELECT user_id, 'webhook', id, webhook_url FROM feeds WHERE webhook_url <> '';`)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The key of the ActivityPub actor of each user, its followers and the entries published to them.
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS activitypub_keys (
+			user_id int not null,
+			public_key text not null,
+			private_key text not null,
+			created_at timestamp with time zone not null default now(),
+			primary key (user_id),
+			foreign key (user_id) references users(id) on delete cascade
+		);
+		CREATE TABLE IF NOT EXISTS activitypub_followers (
+			id bigserial not null,
+			user_id int not null,
+			actor_url text not null,
+			inbox_url text not null,
+			shared_inbox_url text not null default '',
+			created_at timestamp with time zone not null default now(),
+			primary key (id),
+			unique (user_id, actor_url),
+			foreign key (user_id) references users(id) on delete cascade
+		);
+		CREATE TABLE IF NOT EXISTS activitypub_objects (
+			user_id int not null,
+			entry_id bigint not null,
+			share_code text not null,
+			object_type text not null,
+			starred bool not null default 'f',
+			published_at timestamp with time zone not null default now(),
+			primary key (user_id, entry_id),
+			foreign key (user_id) references users(id) on delete cascade,
+			foreign key (entry_id) references entries(id) on delete cascade
+		);
+		CREATE INDEX IF NOT EXISTS activitypub_objects_share_code_idx ON activitypub_objects(share_code);
+	`)
+	if err != nil {
+		return err
+	}
+
+	// The archive page where the next backfill of a feed resumes.
+	_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN IF NOT EXISTS backfill_url text not null default '';`)
+	if err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
@@ -94,3 +455,12 @@ func columnExists(tx *sql.Tx, table string, column string) bool {
 	tx.QueryRow(query, table, column).Scan(&result)
 	return result == 1
 }
+
+func tableExists(tx *sql.Tx, table string) bool {
+	var result int
+	query := `SELECT 1
+		FROM information_schema.tables
+		WHERE table_name=$1;`
+	tx.QueryRow(query, table).Scan(&result)
+	return result == 1
+}
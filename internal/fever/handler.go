@@ -443,13 +443,17 @@ func (h *handler) handleWriteItems(w http.ResponseWriter, r *http.Request) {
 			slog.Int64("user_id", userID),
 			slog.Int64("entry_id", entryID),
 		)
-		h.store.SetEntriesStatus(userID, []int64{entryID}, model.EntryStatusRead)
+		if err := h.store.SetEntriesStatus(userID, []int64{entryID}, model.EntryStatusRead); err == nil {
+			integration.SendEntriesStatusEvent(h.store, userID, []int64{entryID}, model.EntryStatusRead)
+		}
 	case "unread":
 		slog.Debug("[Fever] Mark entry as unread",
 			slog.Int64("user_id", userID),
 			slog.Int64("entry_id", entryID),
 		)
-		h.store.SetEntriesStatus(userID, []int64{entryID}, model.EntryStatusUnread)
+		if err := h.store.SetEntriesStatus(userID, []int64{entryID}, model.EntryStatusUnread); err == nil {
+			integration.SendEntriesStatusEvent(h.store, userID, []int64{entryID}, model.EntryStatusUnread)
+		}
 	case "saved":
 		slog.Debug("[Fever] Mark entry as saved",
 			slog.Int64("user_id", userID),
@@ -459,16 +463,15 @@ func (h *handler) handleWriteItems(w http.ResponseWriter, r *http.Request) {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStarredEvent(h.store, userID, []int64{entryID})
 
-		settings, err := h.store.Integration(userID)
+		settings, err := h.store.IntegrationSettings(userID)
 		if err != nil {
 			json.ServerError(w, r, err)
 			return
 		}
 
-		go func() {
-			integration.SendEntry(entry, settings)
-		}()
+		integration.SendEntry(h.store, entry, settings)
 	case "unsaved":
 		slog.Debug("[Fever] Mark entry as unsaved",
 			slog.Int64("user_id", userID),
@@ -478,6 +481,7 @@ func (h *handler) handleWriteItems(w http.ResponseWriter, r *http.Request) {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStarredEvent(h.store, userID, []int64{entryID})
 	}
 
 	json.OK(w, r, newBaseResponse())
@@ -505,14 +509,18 @@ func (h *handler) handleWriteFeeds(w http.ResponseWriter, r *http.Request) {
 	}
 
 	go func() {
-		if err := h.store.MarkFeedAsRead(userID, feedID, before); err != nil {
+		entryIDs, err := h.store.MarkFeedAsRead(userID, feedID, before)
+		if err != nil {
 			slog.Error("[Fever] Unable to mark feed as read",
 				slog.Int64("user_id", userID),
 				slog.Int64("feed_id", feedID),
 				slog.Time("before_ts", before),
 				slog.Any("error", err),
 			)
+			return
 		}
+
+		integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
 	}()
 
 	json.OK(w, r, newBaseResponse())
@@ -540,12 +548,13 @@ func (h *handler) handleWriteGroups(w http.ResponseWriter, r *http.Request) {
 	}
 
 	go func() {
+		var entryIDs []int64
 		var err error
 
 		if groupID == 0 {
-			err = h.store.MarkAllAsRead(userID)
+			entryIDs, err = h.store.MarkAllAsRead(userID)
 		} else {
-			err = h.store.MarkCategoryAsRead(userID, groupID, before)
+			entryIDs, err = h.store.MarkCategoryAsRead(userID, groupID, before)
 		}
 
 		if err != nil {
@@ -555,7 +564,10 @@ func (h *handler) handleWriteGroups(w http.ResponseWriter, r *http.Request) {
 				slog.Time("before_ts", before),
 				slog.Any("error", err),
 			)
+			return
 		}
+
+		integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
 	}()
 
 	json.OK(w, r, newBaseResponse())
@@ -345,6 +345,7 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStatusEvent(h.store, userID, readEntryIDs, model.EntryStatusRead)
 	}
 
 	if len(unreadEntryIDs) > 0 {
@@ -353,6 +354,7 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStatusEvent(h.store, userID, unreadEntryIDs, model.EntryStatusUnread)
 	}
 
 	if len(unstarredEntryIDs) > 0 {
@@ -361,6 +363,7 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStarredEvent(h.store, userID, unstarredEntryIDs)
 	}
 
 	if len(starredEntryIDs) > 0 {
@@ -369,20 +372,18 @@ func (h *handler) editTagHandler(w http.ResponseWriter, r *http.Request) {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStarredEvent(h.store, userID, starredEntryIDs)
 	}
 
 	if len(entries) > 0 {
-		settings, err := h.store.Integration(userID)
+		settings, err := h.store.IntegrationSettings(userID)
 		if err != nil {
 			json.ServerError(w, r, err)
 			return
 		}
 
 		for _, entry := range entries {
-			e := entry
-			go func() {
-				integration.SendEntry(e, settings)
-			}()
+			integration.SendEntry(h.store, entry, settings)
 		}
 	}
 
@@ -520,10 +521,17 @@ func unsubscribe(streams []Stream, store *storage.Storage, userID int64) error {
 		if err != nil {
 			return err
 		}
+		feed, err := store.FeedByID(userID, feedID)
+		if err != nil {
+			return err
+		}
 		err = store.RemoveFeed(userID, feedID)
 		if err != nil {
 			return err
 		}
+		if feed != nil {
+			integration.SendFeedEvent(store, model.EventFeedRemoved, feed)
+		}
 	}
 	return nil
 }
@@ -1311,11 +1319,12 @@ func (h *handler) markAllAsReadHandler(w http.ResponseWriter, r *http.Request) {
 			json.BadRequest(w, r, err)
 			return
 		}
-		err = h.store.MarkFeedAsRead(userID, feedID, before)
+		entryIDs, err := h.store.MarkFeedAsRead(userID, feedID, before)
 		if err != nil {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
 	case LabelStream:
 		category, err := h.store.CategoryByTitle(userID, stream.ID)
 		if err != nil {
@@ -1326,15 +1335,19 @@ func (h *handler) markAllAsReadHandler(w http.ResponseWriter, r *http.Request) {
 			json.NotFound(w, r)
 			return
 		}
-		if err := h.store.MarkCategoryAsRead(userID, category.ID, before); err != nil {
+		entryIDs, err := h.store.MarkCategoryAsRead(userID, category.ID, before)
+		if err != nil {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
 	case ReadingListStream:
-		if err = h.store.MarkAllAsReadBeforeDate(userID, before); err != nil {
+		entryIDs, err := h.store.MarkAllAsReadBeforeDate(userID, before)
+		if err != nil {
 			json.ServerError(w, r, err)
 			return
 		}
+		integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
 	}
 
 	sendOkayResponse(w)
@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hostlimiter // import "miniflux.app/v2/internal/hostlimiter"
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const (
+	initialBackoff = time.Minute
+	maxBackoff     = 6 * time.Hour
+
+	// The request rate of a host is halved each time it asks to slow down, down to this fraction of the configured rate.
+	minRateFactor = 1.0 / 16
+
+	// The request rate recovers by this fraction of the configured rate after each successful request.
+	rateRecoveryStep = 0.1
+
+	// Hosts without requests during this delay, and no longer blocked, are forgotten.
+	idleHostTimeout = time.Hour
+)
+
+var HostLimiterInstance *HostLimiter
+
+// Store persists the blocked hosts across restarts.
+type Store interface {
+	SaveHostLimit(limit *model.HostLimit) error
+	RemoveHostLimit(host string) error
+}
+
+// ThrottledError is returned when a request is not sent to avoid overloading the host.
+type ThrottledError struct {
+	Host    string
+	RetryAt time.Time
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("hostlimiter: too many requests to %s, retry after %s", e.Host, e.RetryAt.Format(time.RFC3339))
+}
+
+// HostLimiter applies a token bucket per host, shared by every kind of outgoing request.
+// Hosts answering with 429 or 503 status codes are blocked for the Retry-After delay,
+// or an exponential backoff, and their request rate is reduced.
+type HostLimiter struct {
+	store             Store
+	requestsPerMinute float64
+	burst             float64
+	maxWait           time.Duration
+	hosts             map[string]*hostState
+	lastEviction      time.Time
+	mutex             sync.Mutex
+	now               func() time.Time
+	sleep             func(time.Duration)
+}
+
+type hostState struct {
+	tokens       float64
+	lastRefill   time.Time
+	rateFactor   float64
+	blockedUntil time.Time
+	backoff      time.Duration
+	lastUsed     time.Time
+}
+
+// NewHostLimiter creates a limiter allowing the given number of requests per minute and per host.
+// A zero rate disables the token bucket, hosts asking to slow down are still blocked.
+func NewHostLimiter(store Store, requestsPerMinute, burst int, maxWait time.Duration) *HostLimiter {
+	return &HostLimiter{
+		store:             store,
+		requestsPerMinute: float64(requestsPerMinute),
+		burst:             float64(max(burst, 1)),
+		maxWait:           maxWait,
+		hosts:             make(map[string]*hostState),
+		now:               time.Now,
+		sleep:             time.Sleep,
+	}
+}
+
+// Restore loads the blocked hosts saved before a restart.
+func (h *HostLimiter) Restore(limits []*model.HostLimit) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, limit := range limits {
+		state := h.hostState(limit.Host)
+		state.blockedUntil = limit.BlockedUntil
+		state.backoff = limit.Backoff
+		state.rateFactor = minRateFactor
+	}
+}
+
+// Wait blocks until a request can be sent to the host.
+// It returns a ThrottledError when the host is blocked or when the wait would exceed the maximum delay.
+func (h *HostLimiter) Wait(host string) error {
+	wait, err := h.reserve(host, h.maxWait)
+	if err != nil || wait == 0 {
+		return err
+	}
+
+	h.sleep(wait)
+	return nil
+}
+
+// TryAcquire is the non-blocking version of Wait for the interactive requests,
+// it returns a ThrottledError instead of waiting for the host.
+func (h *HostLimiter) TryAcquire(host string) error {
+	_, err := h.reserve(host, 0)
+	return err
+}
+
+// reserve takes a token for the host and returns the delay before the request can be sent.
+func (h *HostLimiter) reserve(host string, maxWait time.Duration) (time.Duration, error) {
+	if host == "" {
+		return 0, nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	now := h.now()
+	h.evictIdleHosts(now)
+	state := h.hostState(host)
+
+	if state.blockedUntil.After(now) {
+		return 0, &ThrottledError{Host: host, RetryAt: state.blockedUntil}
+	}
+
+	if h.requestsPerMinute == 0 {
+		return 0, nil
+	}
+
+	tokensPerMinute := h.requestsPerMinute * state.rateFactor
+	state.tokens = min(h.burst, state.tokens+now.Sub(state.lastRefill).Minutes()*tokensPerMinute)
+	state.lastRefill = now
+
+	if state.tokens >= 1 {
+		state.tokens--
+		return 0, nil
+	}
+
+	wait := time.Duration((1 - state.tokens) / tokensPerMinute * float64(time.Minute))
+	if wait > maxWait {
+		return 0, &ThrottledError{Host: host, RetryAt: now.Add(wait)}
+	}
+
+	// Reserve the token, concurrent requests queue behind this one.
+	state.tokens--
+	return wait, nil
+}
+
+// ReportResponse adapts the host state to the response status code.
+func (h *HostLimiter) ReportResponse(host string, statusCode int, retryAfter time.Duration) {
+	if host == "" {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	state := h.hostState(host)
+
+	switch {
+	case statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable:
+		if retryAfter > 0 {
+			state.backoff = min(retryAfter, maxBackoff)
+		} else if state.backoff == 0 {
+			state.backoff = initialBackoff
+		} else {
+			state.backoff = min(state.backoff*2, maxBackoff)
+		}
+		state.blockedUntil = h.now().Add(state.backoff)
+		state.rateFactor = max(state.rateFactor/2, minRateFactor)
+		state.tokens = 0
+
+		slog.Warn("Host asked to slow down",
+			slog.String("host", host),
+			slog.Int("status_code", statusCode),
+			slog.Duration("backoff", state.backoff),
+			slog.Time("blocked_until", state.blockedUntil),
+		)
+
+		h.persist(&model.HostLimit{Host: host, BlockedUntil: state.blockedUntil, Backoff: state.backoff})
+	case statusCode < 500:
+		state.rateFactor = min(state.rateFactor+rateRecoveryStep, 1)
+		if state.backoff > 0 {
+			state.backoff = 0
+			state.blockedUntil = time.Time{}
+			h.persist(&model.HostLimit{Host: host})
+		}
+	}
+}
+
+// RetryAt returns the date until which the host is blocked, or the zero time.
+func (h *HostLimiter) RetryAt(host string) time.Time {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if state, found := h.hosts[host]; found && state.blockedUntil.After(h.now()) {
+		return state.blockedUntil
+	}
+	return time.Time{}
+}
+
+// hostState must be called with the mutex locked.
+func (h *HostLimiter) hostState(host string) *hostState {
+	now := h.now()
+	state, found := h.hosts[host]
+	if !found {
+		state = &hostState{tokens: h.burst, lastRefill: now, rateFactor: 1}
+		h.hosts[host] = state
+	}
+	state.lastUsed = now
+	return state
+}
+
+// evictIdleHosts must be called with the mutex locked, it runs at most once per idle delay.
+// An idle host has a full bucket again, and its backoff is kept until its block has expired for the idle delay.
+func (h *HostLimiter) evictIdleHosts(now time.Time) {
+	if now.Sub(h.lastEviction) < idleHostTimeout {
+		return
+	}
+	h.lastEviction = now
+
+	for host, state := range h.hosts {
+		if now.Sub(state.lastUsed) >= idleHostTimeout && now.Sub(state.blockedUntil) >= idleHostTimeout {
+			delete(h.hosts, host)
+		}
+	}
+}
+
+func (h *HostLimiter) persist(limit *model.HostLimit) {
+	if h.store == nil {
+		return
+	}
+
+	go func() {
+		var err error
+		if limit.BlockedUntil.IsZero() {
+			err = h.store.RemoveHostLimit(limit.Host)
+		} else {
+			err = h.store.SaveHostLimit(limit)
+		}
+
+		if err != nil {
+			slog.Error("Unable to persist host limit", slog.String("host", limit.Host), slog.Any("error", err))
+		}
+	}()
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hostlimiter // import "miniflux.app/v2/internal/hostlimiter"
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func newTestLimiter(requestsPerMinute, burst int, maxWait time.Duration) (*HostLimiter, *time.Time, *time.Duration) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept time.Duration
+
+	limiter := NewHostLimiter(nil, requestsPerMinute, burst, maxWait)
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(d time.Duration) { slept += d; now = now.Add(d) }
+	return limiter, &now, &slept
+}
+
+func TestWaitConsumesBurst(t *testing.T) {
+	limiter, _, slept := newTestLimiter(60, 3, 10*time.Second)
+
+	for range 3 {
+		if err := limiter.Wait("example.org"); err != nil {
+			t.Fatalf(`Unexpected error: %v`, err)
+		}
+	}
+
+	if *slept != 0 {
+		t.Fatalf(`The burst should not wait, slept %v`, *slept)
+	}
+
+	if err := limiter.Wait("example.org"); err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if *slept != time.Second {
+		t.Fatalf(`Expected to wait for one token, slept %v`, *slept)
+	}
+
+	// Other hosts have their own bucket.
+	if err := limiter.Wait("example.net"); err != nil || *slept != time.Second {
+		t.Fatalf(`Unexpected wait for another host: %v, slept %v`, err, *slept)
+	}
+}
+
+func TestWaitGivesUpAfterMaxWait(t *testing.T) {
+	limiter, _, _ := newTestLimiter(1, 1, 10*time.Second)
+
+	if err := limiter.Wait("example.org"); err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	var throttledErr *ThrottledError
+	if err := limiter.Wait("example.org"); !errors.As(err, &throttledErr) {
+		t.Fatalf(`Expected a throttled error, got %v`, err)
+	}
+
+	if throttledErr.Host != "example.org" {
+		t.Errorf(`Unexpected host: %q`, throttledErr.Host)
+	}
+}
+
+func TestWaitWithoutRateLimit(t *testing.T) {
+	limiter, _, slept := newTestLimiter(0, 1, 0)
+
+	for range 100 {
+		if err := limiter.Wait("example.org"); err != nil {
+			t.Fatalf(`Unexpected error: %v`, err)
+		}
+	}
+
+	if *slept != 0 {
+		t.Fatalf(`Expected no wait, slept %v`, *slept)
+	}
+}
+
+func TestReportResponseBlocksHostWithRetryAfter(t *testing.T) {
+	limiter, now, _ := newTestLimiter(60, 10, 10*time.Second)
+
+	limiter.ReportResponse("example.org", http.StatusTooManyRequests, 2*time.Minute)
+
+	var throttledErr *ThrottledError
+	if err := limiter.Wait("example.org"); !errors.As(err, &throttledErr) {
+		t.Fatalf(`Expected a throttled error, got %v`, err)
+	}
+
+	if expected := now.Add(2 * time.Minute); !throttledErr.RetryAt.Equal(expected) || !limiter.RetryAt("example.org").Equal(expected) {
+		t.Fatalf(`Unexpected retry date: %v`, throttledErr.RetryAt)
+	}
+
+	*now = now.Add(3 * time.Minute)
+	if err := limiter.Wait("example.org"); err != nil {
+		t.Fatalf(`The host should be unblocked: %v`, err)
+	}
+
+	if !limiter.RetryAt("example.org").IsZero() {
+		t.Fatalf(`The host should not be blocked anymore`)
+	}
+}
+
+func TestReportResponseBacksOffExponentially(t *testing.T) {
+	limiter, _, _ := newTestLimiter(60, 10, 10*time.Second)
+
+	for _, expected := range []time.Duration{initialBackoff, 2 * initialBackoff, 4 * initialBackoff} {
+		limiter.ReportResponse("example.org", http.StatusServiceUnavailable, 0)
+		if backoff := limiter.hosts["example.org"].backoff; backoff != expected {
+			t.Fatalf(`Expected a backoff of %v, got %v`, expected, backoff)
+		}
+	}
+
+	if factor := limiter.hosts["example.org"].rateFactor; factor != 1.0/8 {
+		t.Fatalf(`Expected the rate to be reduced, got factor %v`, factor)
+	}
+
+	limiter.ReportResponse("example.org", http.StatusOK, 0)
+	state := limiter.hosts["example.org"]
+	if state.backoff != 0 || !state.blockedUntil.IsZero() {
+		t.Fatalf(`A successful response should reset the backoff`)
+	}
+
+	if state.rateFactor <= 1.0/8 {
+		t.Fatalf(`A successful response should increase the rate, got factor %v`, state.rateFactor)
+	}
+}
+
+func TestReportResponseCapsRetryAfter(t *testing.T) {
+	limiter, _, _ := newTestLimiter(60, 10, 10*time.Second)
+
+	limiter.ReportResponse("example.org", http.StatusTooManyRequests, 48*time.Hour)
+	if backoff := limiter.hosts["example.org"].backoff; backoff != maxBackoff {
+		t.Fatalf(`Expected the backoff to be capped, got %v`, backoff)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	limiter, now, _ := newTestLimiter(60, 10, 10*time.Second)
+
+	limiter.Restore([]*model.HostLimit{{Host: "example.org", BlockedUntil: now.Add(time.Hour), Backoff: time.Hour}})
+
+	var throttledErr *ThrottledError
+	if err := limiter.Wait("example.org"); !errors.As(err, &throttledErr) {
+		t.Fatalf(`Expected a throttled error, got %v`, err)
+	}
+}
+
+type fakeStore struct {
+	saved   chan *model.HostLimit
+	removed chan string
+}
+
+func (f *fakeStore) SaveHostLimit(limit *model.HostLimit) error {
+	f.saved <- limit
+	return nil
+}
+
+func (f *fakeStore) RemoveHostLimit(host string) error {
+	f.removed <- host
+	return nil
+}
+
+func TestReportResponsePersistsState(t *testing.T) {
+	store := &fakeStore{saved: make(chan *model.HostLimit, 1), removed: make(chan string, 1)}
+	limiter := NewHostLimiter(store, 60, 10, time.Second)
+
+	limiter.ReportResponse("example.org", http.StatusTooManyRequests, time.Minute)
+	if limit := <-store.saved; limit.Host != "example.org" || limit.Backoff != time.Minute {
+		t.Fatalf(`Unexpected saved limit: %+v`, limit)
+	}
+
+	limiter.ReportResponse("example.org", http.StatusOK, 0)
+	if host := <-store.removed; host != "example.org" {
+		t.Fatalf(`Unexpected removed host: %q`, host)
+	}
+}
+
+func TestTryAcquireDoesNotWait(t *testing.T) {
+	limiter, _, slept := newTestLimiter(60, 1, 10*time.Second)
+
+	if err := limiter.TryAcquire("example.org"); err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	var throttledErr *ThrottledError
+	if err := limiter.TryAcquire("example.org"); !errors.As(err, &throttledErr) {
+		t.Fatalf(`Expected a ThrottledError, got %v`, err)
+	}
+
+	if *slept != 0 {
+		t.Fatalf(`TryAcquire should never wait, slept %v`, *slept)
+	}
+
+	// The failed attempt did not take the token of the next request.
+	if err := limiter.Wait("example.org"); err != nil || *slept != time.Second {
+		t.Fatalf(`Unexpected wait: %v, slept %v`, err, *slept)
+	}
+}
+
+func TestIdleHostsAreEvicted(t *testing.T) {
+	limiter, now, _ := newTestLimiter(60, 1, 10*time.Second)
+
+	limiter.Wait("idle.example.org")
+	limiter.Wait("blocked.example.org")
+	limiter.ReportResponse("blocked.example.org", http.StatusTooManyRequests, 3*time.Hour)
+
+	*now = now.Add(2 * time.Hour)
+	limiter.Wait("example.org")
+
+	if _, found := limiter.hosts["idle.example.org"]; found {
+		t.Error(`The idle host should be evicted`)
+	}
+
+	if _, found := limiter.hosts["blocked.example.org"]; !found {
+		t.Error(`The blocked host should be kept`)
+	}
+
+	if len(limiter.hosts) != 2 {
+		t.Errorf(`Unexpected number of hosts: %d`, len(limiter.hosts))
+	}
+}
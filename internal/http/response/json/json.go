@@ -44,6 +44,21 @@ func Created(w http.ResponseWriter, r *http.Request, body any) {
 	builder.Write()
 }
 
+// Attachment forces the JSON document to be downloaded by the web browser.
+func Attachment(w http.ResponseWriter, r *http.Request, filename string, body any) {
+	responseBody, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		ServerError(w, r, err)
+		return
+	}
+
+	builder := response.New(w, r)
+	builder.WithHeader("Content-Type", contentTypeHeader)
+	builder.WithAttachment(filename)
+	builder.WithBody(responseBody)
+	builder.Write()
+}
+
 // NoContent sends a no content response to the client.
 func NoContent(w http.ResponseWriter, r *http.Request) {
 	builder := response.New(w, r)
@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 
+	"miniflux.app/v2/internal/activitypub"
 	"miniflux.app/v2/internal/api"
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/fever"
@@ -21,6 +22,7 @@ import (
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui"
 	"miniflux.app/v2/internal/version"
+	"miniflux.app/v2/internal/websub"
 	"miniflux.app/v2/internal/worker"
 
 	"github.com/gorilla/mux"
@@ -223,6 +225,9 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 	router.HandleFunc("/healthz", livenessProbe).Name("healthz")
 	router.HandleFunc("/readiness", readinessProbe).Name("readiness")
 	router.HandleFunc("/readyz", readinessProbe).Name("readyz")
+	if config.Opts.HasActivityPub() {
+		activitypub.ServeWebFinger(router, store)
+	}
 
 	var subrouter *mux.Router
 	if config.Opts.BasePath() != "" {
@@ -246,6 +251,12 @@ func setupHandler(store *storage.Storage, pool *worker.Pool) *mux.Router {
 	if config.Opts.HasAPI() {
 		api.Serve(subrouter, store, pool)
 	}
+	if config.Opts.HasWebSub() {
+		websub.Serve(subrouter, store)
+	}
+	if config.Opts.HasActivityPub() {
+		activitypub.Serve(subrouter, store)
+	}
 	ui.Serve(subrouter, store, pool)
 
 	subrouter.HandleFunc("/healthcheck", readinessProbe).Name("healthcheck")
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"miniflux.app/v2/internal/filesystem"
+	"miniflux.app/v2/internal/integration/epub"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/media"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
+)
+
+const (
+	// bookEntriesLimit is the maximum number of starred entries of a book, the most recently published ones are kept.
+	bookEntriesLimit = 100
+
+	// bookImageMaxSize is the maximum size of an image embedded in a book, the larger images are removed.
+	bookImageMaxSize = 5 * 1024 * 1024
+)
+
+// BookSender is implemented by the providers receiving the entries as an EPUB book.
+// The entries saved by the user are sent as a book of one chapter.
+type BookSender interface {
+	// SendBook sends the EPUB file, the filename is derived from the title.
+	SendBook(settings *model.IntegrationSettings, title, filename string, content []byte) error
+}
+
+// ErrNoBookIntegration is returned when the user has not enabled an integration receiving books.
+var ErrNoBookIntegration = errors.New("integration: no integration receiving books")
+
+// HasBookSender returns true if one of the enabled providers receives books.
+func HasBookSender(settings []*model.IntegrationSettings) bool {
+	for _, providerSettings := range settings {
+		if !providerSettings.Enabled {
+			continue
+		}
+		if _, ok := ProviderByName(providerSettings.Provider).(BookSender); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SendStarredBook records the delivery of the starred entries as a book to each provider receiving books
+// and sends it in the background, it returns the number of entries of the book.
+func SendStarredBook(store *storage.Storage, user *model.User) (int, error) {
+	userIntegrations, err := store.IntegrationSettings(user.ID)
+	if err != nil {
+		return 0, err
+	}
+	if !HasBookSender(userIntegrations) {
+		return 0, ErrNoBookIntegration
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	builder.WithStarred(true)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("published_at", "DESC")
+	builder.WithLimit(bookEntriesLimit)
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	entryIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		entryIDs[i] = entry.ID
+	}
+
+	for _, settings := range userIntegrations {
+		provider := enabledProvider(settings)
+		sender, ok := provider.(BookSender)
+		if !ok {
+			continue
+		}
+
+		delivery := newDelivery(store, settings, model.DeliveryKindBook, 0, entryIDs)
+		go attemptDelivery(store, delivery, func() error {
+			return sendStarredBook(store, provider, sender, settings, user, entries)
+		})
+	}
+	return len(entries), nil
+}
+
+func sendStarredBook(store *storage.Storage, provider Provider, sender BookSender, settings *model.IntegrationSettings, user *model.User, entries model.Entries) error {
+	title := locale.NewPrinter(user.Language).Printf("ereader.starred_book_title", timezone.Now(user.Timezone).Format("2006-01-02"))
+	return sendBook(store, provider, sender, settings, user, title, entries)
+}
+
+func sendBook(store *storage.Storage, provider Provider, sender BookSender, settings *model.IntegrationSettings, user *model.User, title string, entries model.Entries) error {
+	attrs := logAttributes(provider, settings, slog.Int("nb_entries", len(entries)))
+	slog.Debug("Sending book to "+provider.ConfigSchema().Title, attrs...)
+
+	book := &epub.Book{
+		Identifier: fmt.Sprintf("urn:miniflux:%d:%d:%d", user.ID, entries[0].ID, time.Now().Unix()),
+		Title:      title,
+		Author:     "Miniflux",
+		Language:   user.Language,
+		Date:       time.Now(),
+	}
+	for _, entry := range entries {
+		book.Chapters = append(book.Chapters, &epub.Chapter{
+			Title:   entry.Title,
+			Author:  entry.Author,
+			URL:     entry.URL,
+			Date:    timezone.Convert(user.Timezone, entry.Date),
+			Content: entry.Content,
+		})
+	}
+
+	var buffer bytes.Buffer
+	if err := book.Write(&buffer, bookImageLoader(store)); err != nil {
+		slog.Error("Unable to create book for "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+		return err
+	}
+
+	if err := sender.SendBook(settings, title, bookFilename(title), buffer.Bytes()); err != nil {
+		slog.Warn("Unable to send book to "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+		return err
+	}
+	return nil
+}
+
+// bookImageLoader loads the images from the media cache, the other images are downloaded.
+func bookImageLoader(store *storage.Storage) epub.ImageLoader {
+	return func(imageURL string) ([]byte, string, error) {
+		if !strings.HasPrefix(imageURL, "http://") && !strings.HasPrefix(imageURL, "https://") {
+			return nil, "", fmt.Errorf("integration: unsupported image URL %q", imageURL)
+		}
+
+		cached, err := store.MediaByURL(imageURL)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(cached.Content) > 0 {
+			return cached.Content, cached.MimeType, nil
+		}
+
+		if file, err := filesystem.MediaFileByHash(cached.URLHash); err == nil {
+			defer file.Close()
+			content, err := io.ReadAll(io.LimitReader(file, bookImageMaxSize+1))
+			if err == nil && len(content) > 0 && len(content) <= bookImageMaxSize {
+				return content, cached.MimeType, nil
+			}
+		}
+
+		// The referrer of the entry is known when the media was found in the entries.
+		downloaded := &model.Media{URL: imageURL, Referrer: cached.Referrer}
+		if err := media.FindMedia(downloaded); err != nil {
+			slog.Debug("Unable to download book image", slog.String("image_url", imageURL), slog.Any("error", err))
+			return nil, "", err
+		}
+		if len(downloaded.Content) > bookImageMaxSize {
+			return nil, "", fmt.Errorf("integration: the image %q is too large", imageURL)
+		}
+		return downloaded.Content, downloaded.MimeType, nil
+	}
+}
+
+// bookFilename returns a filename valid on the usual file systems.
+func bookFilename(title string) string {
+	filename := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+
+	if runes := []rune(filename); len(runes) > 100 {
+		filename = strings.TrimSpace(string(runes[:100]))
+	}
+	filename = strings.Trim(filename, ". ")
+
+	if filename == "" {
+		filename = "miniflux"
+	}
+	return filename + ".epub"
+}
@@ -0,0 +1,352 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/storage"
+)
+
+const (
+	// bookmarkSyncField is the setting enabling the import of the bookmarks of a provider.
+	bookmarkSyncField = "sync_bookmarks"
+
+	// bookmarkSyncLimit is the maximum number of bookmarks imported by the first sync, only the most recent ones are imported.
+	// The following syncs import all the bookmarks changed since the previous one.
+	bookmarkSyncLimit = 500
+
+	// bookmarkSyncOverlap covers the clock difference with the services, importing a bookmark twice changes nothing.
+	bookmarkSyncOverlap = 5 * time.Minute
+)
+
+// Bookmark is a bookmark of a third-party service, the content is HTML.
+type Bookmark struct {
+	ID      string
+	URL     string
+	Title   string
+	Content string
+	Author  string
+	Tags    []string
+	Starred bool
+	Date    time.Time
+}
+
+// BookmarkSyncer is implemented by the providers importing the bookmarks of the users in their saved feed.
+// The starred state of the imported entries is sent back to the bookmarks.
+type BookmarkSyncer interface {
+	// FetchBookmarks returns the bookmarks changed since the given date, all the bookmarks when it is zero.
+	// At most limit bookmarks are returned, the most recent ones, there is no limit when it is zero.
+	FetchBookmarks(settings *model.IntegrationSettings, since time.Time, limit int) ([]*Bookmark, error)
+
+	// SetBookmarkStarred updates the bookmark when the user stars or unstars its entry.
+	SetBookmarkStarred(settings *model.IntegrationSettings, bookmarkID string, starred bool) error
+}
+
+// SyncBookmarks imports the bookmarks of the providers synchronized with Miniflux.
+// The sync date is updated before importing, so the instances sharing the database do not import the bookmarks twice.
+func SyncBookmarks(store *storage.Storage) {
+	var providers []string
+	for _, provider := range Providers() {
+		if _, ok := provider.(BookmarkSyncer); ok {
+			providers = append(providers, provider.Name())
+		}
+	}
+
+	settingsList, err := store.EnabledIntegrationSettings(providers)
+	if err != nil {
+		slog.Error("Unable to fetch the bookmark integrations", slog.Any("error", err))
+		return
+	}
+
+	for _, settings := range settingsList {
+		syncBookmarks(store, settings)
+	}
+}
+
+func syncBookmarks(store *storage.Storage, settings *model.IntegrationSettings) {
+	provider := enabledProvider(settings)
+	syncer, ok := bookmarkSyncer(provider, settings)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	previous := settings.LastSyncAt
+	if previous != nil && now.Sub(*previous) < config.Opts.IntegrationBookmarkSyncInterval() {
+		return
+	}
+
+	if claimed, err := store.UpdateIntegrationSyncDate(settings, previous, &now); err != nil || !claimed {
+		if err != nil {
+			slog.Error("Unable to update the bookmark sync date", slog.Int64("user_id", settings.UserID), slog.Any("error", err))
+		}
+		return
+	}
+
+	var since time.Time
+	if previous != nil {
+		since = previous.Add(-bookmarkSyncOverlap)
+	}
+
+	attrs := logAttributes(provider, settings)
+	slog.Debug("Importing bookmarks from "+provider.ConfigSchema().Title, attrs...)
+
+	if err := importBookmarks(store, provider, syncer, settings, since); err != nil {
+		slog.Warn("Unable to import bookmarks from "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+
+		// The bookmarks changed since the previous import are fetched again by the next attempt.
+		if _, err := store.UpdateIntegrationSyncDate(settings, &now, previous); err != nil {
+			slog.Error("Unable to update the bookmark sync date", slog.Int64("user_id", settings.UserID), slog.Any("error", err))
+		}
+	}
+}
+
+// importBookmarks creates the entries of the new bookmarks in the saved feed, the bookmarks of existing entries are
+// linked to them. The starred state of the entries is updated without notifying the providers.
+func importBookmarks(store *storage.Storage, provider Provider, syncer BookmarkSyncer, settings *model.IntegrationSettings, since time.Time) error {
+	bookmarks, err := syncer.FetchBookmarks(settings, since, bookmarkFetchLimit(since))
+	if err != nil {
+		return err
+	}
+	if len(bookmarks) == 0 {
+		return nil
+	}
+
+	user, err := store.UserByID(settings.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("integration: user #%d not found", settings.UserID)
+	}
+
+	bookmarkURLs := make([]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		bookmarkURLs = append(bookmarkURLs, bookmark.URL)
+	}
+
+	entries, err := store.EntriesByURL(user.ID, bookmarkURLs)
+	if err != nil {
+		return err
+	}
+
+	var savedFeedID int64
+	var created int
+	for _, bookmark := range bookmarks {
+		if bookmark.ID == "" || bookmark.URL == "" {
+			continue
+		}
+
+		entry, found := entries[bookmark.URL]
+		if !found {
+			if savedFeedID == 0 {
+				if savedFeedID, err = savedFeed(store, user); err != nil {
+					return err
+				}
+			}
+
+			entry = newBookmarkEntry(user, savedFeedID, bookmark)
+			if err := createEntry(store, entry); err != nil {
+				return err
+			}
+			entries[bookmark.URL] = entry
+			created++
+		}
+
+		if entry.Starred != bookmark.Starred {
+			if err := store.SetEntriesStarredState(user.ID, []int64{entry.ID}, bookmark.Starred); err != nil {
+				return err
+			}
+			entry.Starred = bookmark.Starred
+		}
+
+		if err := store.SaveIntegrationBookmark(user.ID, provider.Name(), bookmark.ID, entry.ID); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("Imported bookmarks from "+provider.ConfigSchema().Title,
+		slog.Int64("user_id", user.ID),
+		slog.Int("nb_bookmarks", len(bookmarks)),
+		slog.Int("nb_created_entries", created),
+	)
+	return nil
+}
+
+// bookmarkFetchLimit returns the maximum number of bookmarks fetched. The limit only applies to the first sync:
+// the sync date is moved forward after each import, so the changes cut by a limit would never be imported.
+func bookmarkFetchLimit(since time.Time) int {
+	if since.IsZero() {
+		return bookmarkSyncLimit
+	}
+	return 0
+}
+
+// savedFeed returns the ID of the saved feed of the user, it is created in the first category on the first import.
+func savedFeed(store *storage.Storage, user *model.User) (int64, error) {
+	feedID, err := store.FeedIDByURL(user.ID, model.SavedFeedURL)
+	if err != nil || feedID > 0 {
+		return feedID, err
+	}
+
+	category, err := store.FirstCategory(user.ID)
+	if err != nil {
+		return 0, err
+	}
+	if category == nil {
+		return 0, fmt.Errorf("integration: user #%d has no category for the saved feed", user.ID)
+	}
+
+	feed := &model.Feed{
+		UserID:   user.ID,
+		Category: category,
+		FeedURL:  model.SavedFeedURL,
+		SiteURL:  config.Opts.RootURL(),
+		Title:    locale.NewPrinter(user.Language).Print("integration.saved_feed_title"),
+		Disabled: true,
+	}
+	if err := store.CreateFeed(feed); err != nil {
+		return 0, err
+	}
+	return feed.ID, nil
+}
+
+func newBookmarkEntry(user *model.User, feedID int64, bookmark *Bookmark) *model.Entry {
+	entry := &model.Entry{
+		UserID:  user.ID,
+		FeedID:  feedID,
+		Hash:    crypto.SHA256(bookmark.URL),
+		URL:     bookmark.URL,
+		Title:   strings.TrimSpace(bookmark.Title),
+		Author:  bookmark.Author,
+		Tags:    bookmark.Tags,
+		Date:    bookmark.Date,
+		Status:  model.EntryStatusUnread,
+		Content: sanitizer.SanitizeHTML(bookmark.URL, bookmark.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab}),
+	}
+	if entry.Title == "" {
+		entry.Title = bookmark.URL
+	}
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+	return entry
+}
+
+func createEntry(store *storage.Storage, entry *model.Entry) error {
+	tx, err := store.Begin()
+	if err != nil {
+		return err
+	}
+	if err := store.CreateEntry(tx, entry); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// sendBookmarksStarred records the delivery of the starred state of the entries to the bookmarks linked to them.
+func sendBookmarksStarred(store *storage.Storage, userID int64, entryIDs []int64) {
+	if len(entryIDs) == 0 {
+		return
+	}
+
+	userIntegrations, err := store.IntegrationSettings(userID)
+	if err != nil {
+		slog.Error("Unable to fetch integrations",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	for _, settings := range userIntegrations {
+		provider := enabledProvider(settings)
+		syncer, ok := bookmarkSyncer(provider, settings)
+		if !ok {
+			continue
+		}
+
+		bookmarkIDs, err := store.IntegrationBookmarkIDs(userID, provider.Name(), entryIDs)
+		if err != nil {
+			slog.Error("Unable to fetch the bookmarks of the entries",
+				slog.Int64("user_id", userID),
+				slog.String("provider", provider.Name()),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		if len(bookmarkIDs) == 0 {
+			continue
+		}
+
+		linkedEntryIDs := make([]int64, 0, len(bookmarkIDs))
+		for entryID := range bookmarkIDs {
+			linkedEntryIDs = append(linkedEntryIDs, entryID)
+		}
+		slices.Sort(linkedEntryIDs)
+
+		delivery := newDelivery(store, settings, model.DeliveryKindBookmarkStarred, 0, linkedEntryIDs)
+		go attemptDelivery(store, delivery, func() error {
+			return sendBookmarksStarredState(store, provider, syncer, settings, linkedEntryIDs)
+		})
+	}
+}
+
+// sendBookmarksStarredState sends the current starred state of the entries, a retried delivery sends the latest state.
+func sendBookmarksStarredState(store *storage.Storage, provider Provider, syncer BookmarkSyncer, settings *model.IntegrationSettings, entryIDs []int64) error {
+	builder := store.NewEntryQueryBuilder(settings.UserID)
+	builder.WithEntryIDs(entryIDs)
+	builder.WithSorting("id", "ASC")
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return err
+	}
+
+	bookmarkIDs, err := store.IntegrationBookmarkIDs(settings.UserID, provider.Name(), entryIDs)
+	if err != nil {
+		return err
+	}
+
+	attrs := logAttributes(provider, settings, slog.Int("nb_entries", len(entries)))
+	slog.Debug("Sending starred state to "+provider.ConfigSchema().Title, attrs...)
+
+	for _, entry := range entries {
+		for _, bookmarkID := range bookmarkIDs[entry.ID] {
+			if err := syncer.SetBookmarkStarred(settings, bookmarkID, entry.Starred); err != nil {
+				slog.Warn("Unable to send starred state to "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bookmarkSyncer returns the provider when the user enabled the import of its bookmarks.
+func bookmarkSyncer(provider Provider, settings *model.IntegrationSettings) (BookmarkSyncer, bool) {
+	syncer, ok := provider.(BookmarkSyncer)
+	return syncer, ok && settings.Values.Bool(bookmarkSyncField)
+}
+
+// bookmarkTextContent converts the plain text description of a bookmark to HTML.
+func bookmarkTextContent(text string) string {
+	var content strings.Builder
+	for paragraph := range strings.SplitSeq(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			content.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>") + "</p>")
+		}
+	}
+	return content.String()
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestLinkdingFetchBookmarks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" {
+			t.Errorf("Unexpected authorization header: %q", r.Header.Get("Authorization"))
+		}
+
+		switch r.URL.Path {
+		case "/api/bookmarks/":
+			w.Write([]byte(`{"next":null,"results":[{"id":1,"url":"https://example.org/1","title":"","website_title":"Website","description":"Line 1\nLine 2","tag_names":["news"],"is_archived":false,"date_added":"2025-10-18T10:00:00Z"}]}`))
+		case "/api/bookmarks/archived/":
+			w.Write([]byte(`{"next":null,"results":[{"id":2,"url":"https://example.org/2","title":"Archived","is_archived":true,"date_added":"2025-10-18T10:00:00Z"}]}`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"url": server.URL, "api_key": "secret"}}
+	syncer := ProviderByName("linkding").(BookmarkSyncer)
+
+	bookmarks, err := syncer.FetchBookmarks(settings, time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(bookmarks) != 2 {
+		t.Fatalf("Expected two bookmarks, got %d", len(bookmarks))
+	}
+
+	if bookmark := bookmarks[0]; bookmark.ID != "1" || bookmark.Title != "Website" || bookmark.Content != "<p>Line 1<br>Line 2</p>" || !bookmark.Starred {
+		t.Errorf("Unexpected bookmark: %+v", bookmark)
+	}
+	if bookmark := bookmarks[1]; bookmark.ID != "2" || bookmark.Title != "Archived" || bookmark.Starred {
+		t.Errorf("Unexpected archived bookmark: %+v", bookmark)
+	}
+
+	if bookmarks, err := syncer.FetchBookmarks(settings, time.Time{}, 1); err != nil || len(bookmarks) != 1 {
+		t.Errorf("Expected one bookmark with a limit, got %d: %v", len(bookmarks), err)
+	}
+
+	if bookmarks, err := syncer.FetchBookmarks(settings, time.Now(), 0); err != nil || len(bookmarks) != 2 {
+		t.Errorf("Expected all the bookmarks without a limit, got %d: %v", len(bookmarks), err)
+	}
+}
+
+func TestBookmarkFetchLimit(t *testing.T) {
+	if limit := bookmarkFetchLimit(time.Time{}); limit != bookmarkSyncLimit {
+		t.Errorf("The first sync must be limited, got %d", limit)
+	}
+	if limit := bookmarkFetchLimit(time.Now()); limit != 0 {
+		t.Errorf("The following syncs must import all the changed bookmarks, got %d", limit)
+	}
+}
+
+func TestLinkdingSetBookmarkStarred(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Unexpected method: %s", r.Method)
+		}
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"url": server.URL, "api_key": "secret"}}
+	syncer := ProviderByName("linkding").(BookmarkSyncer)
+
+	if err := syncer.SetBookmarkStarred(settings, "1", false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := syncer.SetBookmarkStarred(settings, "1", true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(paths) != 2 || paths[0] != "/api/bookmarks/1/archive/" || paths[1] != "/api/bookmarks/1/unarchive/" {
+		t.Errorf("Unexpected requests: %v", paths)
+	}
+}
+
+func TestReadeckFetchBookmarks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/bookmarks" {
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+		}
+		if since := r.URL.Query().Get("updated_since"); since != "2025-10-18T10:00:00Z" {
+			t.Errorf("Unexpected updated_since parameter: %q", since)
+		}
+		w.Write([]byte(`[{"id":"abc","url":"https://example.org/1","title":"Title","description":"<b>Text</b>","authors":["Alice","Bob"],"labels":["news"],"is_marked":true,"created":"2025-10-18T10:00:00Z"}]`))
+	}))
+	defer server.Close()
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"url": server.URL, "api_key": "secret"}}
+	syncer := ProviderByName("readeck").(BookmarkSyncer)
+
+	bookmarks, err := syncer.FetchBookmarks(settings, time.Date(2025, time.October, 18, 10, 0, 0, 0, time.UTC), 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(bookmarks) != 1 {
+		t.Fatalf("Expected one bookmark, got %d", len(bookmarks))
+	}
+
+	bookmark := bookmarks[0]
+	if bookmark.ID != "abc" || bookmark.Author != "Alice, Bob" || bookmark.Content != "<p>&lt;b&gt;Text&lt;/b&gt;</p>" || !bookmark.Starred || len(bookmark.Tags) != 1 {
+		t.Errorf("Unexpected bookmark: %+v", bookmark)
+	}
+}
+
+func TestReadeckSetBookmarkStarred(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/bookmarks/abc" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"is_marked":true}` {
+			t.Errorf("Unexpected request body: %s", body)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"url": server.URL, "api_key": "secret"}}
+	if err := ProviderByName("readeck").(BookmarkSyncer).SetBookmarkStarred(settings, "abc", true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestBookmarkSyncer(t *testing.T) {
+	provider := ProviderByName("wallabag")
+
+	if _, ok := bookmarkSyncer(provider, &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{}}); ok {
+		t.Error("The bookmarks must not be synced when the option is disabled")
+	}
+	if _, ok := bookmarkSyncer(provider, &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"sync_bookmarks": true}}); !ok {
+		t.Error("The bookmarks must be synced when the option is enabled")
+	}
+	if _, ok := bookmarkSyncer(ProviderByName("webhook"), &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"sync_bookmarks": true}}); ok {
+		t.Error("The webhook provider cannot sync bookmarks")
+	}
+}
+
+func TestBookmarkTextContent(t *testing.T) {
+	tests := map[string]string{
+		"":                            "",
+		"Text":                        "<p>Text</p>",
+		"A & B\r\nC\n\n\n\nD":         "<p>A &amp; B<br>C</p><p>D</p>",
+		"  <script>x</script>  \n\n ": "<p>&lt;script&gt;x&lt;/script&gt;</p>",
+	}
+	for text, expected := range tests {
+		if got := bookmarkTextContent(text); got != expected {
+			t.Errorf("Expected %q for %q, got %q", expected, text, got)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"context"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const (
+	// chatBotCommandsField is the setting enabling the commands of a chat bot.
+	chatBotCommandsField = "commands"
+
+	// ChatBotPausedField is the setting pausing the new entries sent by a chat bot, it is changed by the commands.
+	ChatBotPausedField = "paused"
+)
+
+// ChatBot is implemented by the providers receiving the commands of the users from their chat.
+type ChatBot interface {
+	// NewChatSession connects the bot to the chat of the user, the session is closed when the commands are stopped.
+	NewChatSession(settings *model.IntegrationSettings) (ChatSession, error)
+}
+
+// ChatSession reads the commands sent to a bot and replies to them.
+type ChatSession interface {
+	// ReceiveCommands waits for the next commands, the messages sent before the first call are skipped.
+	ReceiveCommands(ctx context.Context) ([]*ChatCommand, error)
+
+	// Reply answers a command, the HTML reply is used when the chat supports it.
+	Reply(command *ChatCommand, reply *ChatReply) error
+
+	// Close disconnects the bot.
+	Close()
+}
+
+// ChatCommand is a command sent to a bot, such as "/read 123".
+type ChatCommand struct {
+	Name      string
+	Arguments string
+
+	// ButtonID identifies the button of a pushed entry sending the command, the reply is a notification.
+	ButtonID string
+}
+
+// ChatReply is the answer of a bot in plain text and in HTML, the HTML is limited to the b, i, a and code
+// elements supported by all the chats and the lines are separated by newlines.
+type ChatReply struct {
+	Text string
+	HTML string
+}
+
+// ParseChatCommand returns the command of a message starting with a slash or an exclamation mark, nil otherwise.
+// The name of the bot added by Telegram in the groups is removed.
+func ParseChatCommand(message string) *ChatCommand {
+	message = strings.TrimSpace(message)
+	if !strings.HasPrefix(message, "/") && !strings.HasPrefix(message, "!") {
+		return nil
+	}
+
+	name, arguments, _ := strings.Cut(message[1:], " ")
+	name, _, _ = strings.Cut(name, "@")
+	if name == "" {
+		return nil
+	}
+
+	return &ChatCommand{Name: strings.ToLower(name), Arguments: strings.TrimSpace(arguments)}
+}
+
+// ChatBotEnabled returns the chat bot of the provider if the user enabled its commands.
+func ChatBotEnabled(settings *model.IntegrationSettings) (ChatBot, bool) {
+	provider := enabledProvider(settings)
+	bot, ok := provider.(ChatBot)
+	return bot, ok && settings.Values.Bool(chatBotCommandsField)
+}
+
+// chatBotPaused returns true if the new entries are not sent to the chat.
+func chatBotPaused(provider Provider, settings *model.IntegrationSettings) bool {
+	_, ok := provider.(ChatBot)
+	return ok && settings.Values.Bool(ChatBotPausedField)
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestParseChatCommand(t *testing.T) {
+	tests := map[string]*ChatCommand{
+		"/unread":                  {Name: "unread"},
+		"  /unread  Tech News ":    {Name: "unread", Arguments: "Tech News"},
+		"/read@MinifluxBot 123":    {Name: "read", Arguments: "123"},
+		"!Star #42":                {Name: "star", Arguments: "#42"},
+		"hello":                    nil,
+		"/":                        nil,
+		"/@MinifluxBot":            nil,
+		"https://example.org/feed": nil,
+	}
+	for message, expected := range tests {
+		command := ParseChatCommand(message)
+		switch {
+		case expected == nil && command != nil:
+			t.Errorf("Expected no command for %q, got %+v", message, command)
+		case expected != nil && (command == nil || *command != *expected):
+			t.Errorf("Expected %+v for %q, got %+v", expected, message, command)
+		}
+	}
+}
+
+func TestChatBotEnabled(t *testing.T) {
+	settings := &model.IntegrationSettings{Provider: "telegram_bot", Enabled: true, Values: model.IntegrationValues{}}
+	if _, ok := ChatBotEnabled(settings); ok {
+		t.Error("The commands must be disabled by default")
+	}
+
+	settings.Values["commands"] = true
+	if _, ok := ChatBotEnabled(settings); !ok {
+		t.Error("The commands must be enabled")
+	}
+
+	settings.Enabled = false
+	if _, ok := ChatBotEnabled(settings); ok {
+		t.Error("The commands of a disabled integration must be disabled")
+	}
+
+	if _, ok := ChatBotEnabled(&model.IntegrationSettings{Provider: "slack", Enabled: true, Values: model.IntegrationValues{"commands": true}}); ok {
+		t.Error("Slack has no chat bot")
+	}
+}
+
+func TestChatBotPaused(t *testing.T) {
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"paused": true}}
+	if !chatBotPaused(ProviderByName("matrix_bot"), settings) {
+		t.Error("The Matrix bot must be paused")
+	}
+	if chatBotPaused(ProviderByName("webhook"), settings) {
+		t.Error("Only the chat bots can be paused")
+	}
+}
+
+func TestMatrixBotSession(t *testing.T) {
+	var syncs, logouts int
+	var replies []map[string]string
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/.well-known/matrix/client":
+			json.NewEncoder(w).Encode(map[string]any{"m.homeserver": map[string]string{"base_url": server.URL}})
+		case r.URL.Path == "/_matrix/client/v3/login":
+			w.Write([]byte(`{"user_id":"@bot:example.org","access_token":"token"}`))
+		case r.URL.Path == "/_matrix/client/v3/sync":
+			syncs++
+			if r.Header.Get("Authorization") != "Bearer token" {
+				t.Errorf("Unexpected authorization header: %q", r.Header.Get("Authorization"))
+			}
+
+			switch since := r.URL.Query().Get("since"); since {
+			case "":
+				if r.URL.Query().Get("timeout") != "0" {
+					t.Errorf("The first sync must not wait, got timeout %q", r.URL.Query().Get("timeout"))
+				}
+				w.Write([]byte(`{"next_batch":"s1","rooms":{"join":{"!room:example.org":{"timeline":{"events":[
+					{"type":"m.room.message","sender":"@alice:example.org","content":{"msgtype":"m.text","body":"!read 1"}}
+				]}}}}}`))
+			case "s1":
+				w.Write([]byte(`{"next_batch":"s2","rooms":{"join":{"!room:example.org":{"timeline":{"events":[
+					{"type":"m.room.message","sender":"@bot:example.org","content":{"msgtype":"m.text","body":"/read 2"}},
+					{"type":"m.room.message","sender":"@alice:example.org","content":{"msgtype":"m.text","body":"Hello"}},
+					{"type":"m.room.message","sender":"@alice:example.org","content":{"msgtype":"m.notice","body":"/read 3"}},
+					{"type":"m.room.message","sender":"@mallory:example.org","content":{"msgtype":"m.text","body":"/read 5"}},
+					{"type":"m.room.message","sender":"@alice:example.org","content":{"msgtype":"m.text","body":"!read 4"}}
+				]}}}}}`))
+			default:
+				t.Errorf("Unexpected since token: %q", since)
+			}
+		case strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/rooms/!room:example.org/send/m.room.message/"):
+			var reply map[string]string
+			json.NewDecoder(r.Body).Decode(&reply)
+			replies = append(replies, reply)
+			w.Write([]byte(`{"event_id":"$event"}`))
+		case r.URL.Path == "/_matrix/client/v3/logout":
+			logouts++
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	settings := &model.IntegrationSettings{Provider: "matrix_bot", Enabled: true, Values: model.IntegrationValues{
+		"url":             server.URL,
+		"user":            "bot",
+		"password":        "secret",
+		"chat_id":         "!room:example.org",
+		"commands":        true,
+		"allowed_user_id": "@alice:example.org",
+	}}
+
+	bot, ok := ChatBotEnabled(settings)
+	if !ok {
+		t.Fatal("The Matrix bot must be enabled")
+	}
+
+	session, err := bot.NewChatSession(settings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if commands, err := session.ReceiveCommands(context.Background()); err != nil || len(commands) != 0 {
+		t.Fatalf("The messages of the first sync must be skipped, got %v: %v", commands, err)
+	}
+
+	commands, err := session.ReceiveCommands(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(commands) != 1 || commands[0].Name != "read" || commands[0].Arguments != "4" {
+		t.Fatalf("Expected only the command of the user, got %v", commands)
+	}
+
+	if err := session.Reply(commands[0], &ChatReply{Text: "First\nSecond", HTML: "<b>First</b>\nSecond"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(replies) != 1 || replies[0]["body"] != "First\nSecond" || replies[0]["formatted_body"] != "<b>First</b><br>Second" {
+		t.Errorf("Unexpected replies: %v", replies)
+	}
+
+	session.Close()
+	if syncs != 2 || logouts != 1 {
+		t.Errorf("Expected two syncs and one logout, got %d and %d", syncs, logouts)
+	}
+}
+
+func TestMatrixBotRequiresAllowedUser(t *testing.T) {
+	provider := ProviderByName("matrix_bot")
+	settings := &model.IntegrationSettings{Provider: "matrix_bot", Enabled: true, Values: model.IntegrationValues{"commands": true}}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("The commands must be refused without an allowed user")
+	}
+
+	settings.Values["allowed_user_id"] = "@alice:example.org"
+	if err := provider.Validate(settings); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if err := provider.Validate(&model.IntegrationSettings{Provider: "matrix_bot", Enabled: true, Values: model.IntegrationValues{}}); err != nil {
+		t.Errorf("The allowed user is only required by the commands: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
+)
+
+// digestEntriesLimit is the maximum number of entries of a digest, the most recent ones are kept.
+const digestEntriesLimit = 200
+
+// DigestSender is implemented by the providers sending a selection of entries periodically.
+type DigestSender interface {
+	// DigestScheduledAt returns the most recent scheduled date of the digest, now is in the timezone of the user.
+	DigestScheduledAt(settings *model.IntegrationSettings, now time.Time) time.Time
+
+	// FilterDigestEntries selects the entries of the digest, since is the date of the previous digest.
+	FilterDigestEntries(settings *model.IntegrationSettings, builder *storage.EntryQueryBuilder, since time.Time)
+
+	// MarksDigestAsRead returns true if the entries are marked as read once the digest is sent.
+	MarksDigestAsRead(settings *model.IntegrationSettings) bool
+
+	// SendDigest sends the entries to the user.
+	SendDigest(settings *model.IntegrationSettings, user *model.User, entries model.Entries) error
+}
+
+// SendDigests sends the digests scheduled since the previous ones.
+// The digest date is updated before sending, so the instances sharing the database do not send a digest twice.
+func SendDigests(store *storage.Storage) {
+	var providers []string
+	for _, provider := range Providers() {
+		if _, ok := provider.(DigestSender); ok {
+			providers = append(providers, provider.Name())
+		}
+	}
+
+	settingsList, err := store.EnabledIntegrationSettings(providers)
+	if err != nil {
+		slog.Error("Unable to fetch the digest integrations", slog.Any("error", err))
+		return
+	}
+
+	for _, settings := range settingsList {
+		scheduleDigest(store, settings)
+	}
+}
+
+func scheduleDigest(store *storage.Storage, settings *model.IntegrationSettings) {
+	provider := enabledProvider(settings)
+	sender, ok := provider.(DigestSender)
+	if !ok {
+		return
+	}
+
+	user, err := store.UserByID(settings.UserID)
+	if err != nil || user == nil {
+		slog.Error("Unable to fetch the user of the digest",
+			slog.Int64("user_id", settings.UserID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	now := time.Now()
+	previous := settings.LastDigestAt
+
+	// The first digest is sent at the next scheduled date.
+	if previous == nil {
+		if _, err := store.UpdateIntegrationDigestDate(settings, nil, now); err != nil {
+			slog.Error("Unable to update the digest date", slog.Int64("user_id", user.ID), slog.Any("error", err))
+		}
+		return
+	}
+
+	if !previous.Before(sender.DigestScheduledAt(settings, timezone.Convert(user.Timezone, now))) {
+		return
+	}
+
+	if claimed, err := store.UpdateIntegrationDigestDate(settings, previous, now); err != nil || !claimed {
+		if err != nil {
+			slog.Error("Unable to update the digest date", slog.Int64("user_id", user.ID), slog.Any("error", err))
+		}
+		return
+	}
+
+	builder := store.NewEntryQueryBuilder(user.ID)
+	sender.FilterDigestEntries(settings, builder, *previous)
+	builder.WithSorting("published_at", "DESC")
+	builder.WithLimit(digestEntriesLimit)
+	entries, err := builder.GetEntries()
+	if err != nil {
+		slog.Error("Unable to fetch the entries of the digest", slog.Int64("user_id", user.ID), slog.Any("error", err))
+		return
+	}
+
+	if len(entries) == 0 {
+		slog.Debug("No entry to send in the digest", logAttributes(provider, settings)...)
+		return
+	}
+
+	entryIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		entryIDs[i] = entry.ID
+	}
+
+	delivery := newDelivery(store, settings, model.DeliveryKindDigest, 0, entryIDs)
+	attemptDelivery(store, delivery, func() error {
+		return sendDigest(store, provider, sender, settings, user, entries)
+	})
+}
+
+func sendDigest(store *storage.Storage, provider Provider, sender DigestSender, settings *model.IntegrationSettings, user *model.User, entries model.Entries) error {
+	attrs := logAttributes(provider, settings, slog.Int("nb_entries", len(entries)))
+	slog.Debug("Sending digest to "+provider.ConfigSchema().Title, attrs...)
+
+	if err := sender.SendDigest(settings, user, entries); err != nil {
+		slog.Warn("Unable to send digest to "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+		return err
+	}
+
+	if sender.MarksDigestAsRead(settings) {
+		entryIDs := make([]int64, len(entries))
+		for i, entry := range entries {
+			entryIDs[i] = entry.ID
+		}
+
+		if err := store.SetEntriesStatus(user.ID, entryIDs, model.EntryStatusRead); err != nil {
+			slog.Error("Unable to mark the entries of the digest as read", append(attrs, slog.Any("error", err))...)
+		} else {
+			SendEntriesStatusEvent(store, user.ID, entryIDs, model.EntryStatusRead)
+		}
+	}
+	return nil
+}
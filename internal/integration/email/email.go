@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package email // import "miniflux.app/v2/internal/integration/email"
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+)
+
+const defaultClientTimeout = 30 * time.Second
+
+// Connection security of the SMTP server.
+const (
+	SecurityStartTLS = "starttls"
+	SecurityTLS      = "tls"
+	SecurityNone     = "none"
+)
+
+type Client struct {
+	host     string
+	port     int
+	security string
+	username string
+	password string
+}
+
+// Message is an email with an HTML and a plain text version of the same content.
+type Message struct {
+	From        string
+	To          string
+	Subject     string
+	HTMLBody    string
+	TextBody    string
+	Attachments []*Attachment
+}
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+func NewClient(host string, port int, security, username, password string) *Client {
+	return &Client{host: host, port: port, security: security, username: username, password: password}
+}
+
+// Send delivers the message to the SMTP server, the credentials are only sent over an encrypted connection.
+func (c *Client) Send(message *Message) error {
+	if c.host == "" {
+		return errors.New("email: missing SMTP server")
+	}
+
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return fmt.Errorf("email: invalid sender address: %v", err)
+	}
+
+	recipients, err := mail.ParseAddressList(message.To)
+	if err != nil {
+		return fmt.Errorf("email: invalid recipient address: %v", err)
+	}
+
+	data, err := buildMessage(from, recipients, message)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.username, c.password, c.host)); err != nil {
+			return fmt.Errorf("email: unable to authenticate: %v", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("email: sender rejected: %v", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient.Address); err != nil {
+			return fmt.Errorf("email: recipient %s rejected: %v", recipient.Address, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("email: unable to send message: %v", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return fmt.Errorf("email: unable to send message: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("email: message rejected: %v", err)
+	}
+
+	return client.Quit()
+}
+
+func (c *Client) dial() (*smtp.Client, error) {
+	address := net.JoinHostPort(c.host, strconv.Itoa(c.port))
+	dialer := &net.Dialer{Timeout: defaultClientTimeout}
+	tlsConfig := &tls.Config{ServerName: c.host}
+
+	var conn net.Conn
+	var err error
+	if c.security == SecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("email: unable to connect to %s: %v", address, err)
+	}
+	conn.SetDeadline(time.Now().Add(defaultClientTimeout))
+
+	client, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("email: unable to connect to %s: %v", address, err)
+	}
+
+	if c.security == SecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("email: the server %s does not support STARTTLS", address)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("email: unable to start TLS: %v", err)
+		}
+	}
+
+	return client, nil
+}
+
+func buildMessage(from *mail.Address, recipients []*mail.Address, message *Message) ([]byte, error) {
+	contentType, body, err := buildAlternativeBody(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(message.Attachments) > 0 {
+		contentType, body, err = buildMixedBody(contentType, body, message.Attachments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	to := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		to[i] = recipient.String()
+	}
+
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + strings.Join(to, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: <" + crypto.GenerateRandomStringHex(16) + "@" + domain + ">",
+		"MIME-Version: 1.0",
+		"Content-Type: " + contentType,
+	}
+
+	var data bytes.Buffer
+	data.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+	data.Write(body)
+	return data.Bytes(), nil
+}
+
+func buildAlternativeBody(message *Message) (string, []byte, error) {
+	var buffer bytes.Buffer
+	body := multipart.NewWriter(&buffer)
+
+	// The clients display the last supported part, the HTML version is preferred.
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", message.TextBody},
+		{"text/html; charset=utf-8", message.HTMLBody},
+	} {
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("email: unable to build message: %v", err)
+		}
+
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return "", nil, fmt.Errorf("email: unable to build message: %v", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return "", nil, fmt.Errorf("email: unable to build message: %v", err)
+		}
+	}
+
+	if err := body.Close(); err != nil {
+		return "", nil, fmt.Errorf("email: unable to build message: %v", err)
+	}
+	return "multipart/alternative; boundary=" + body.Boundary(), buffer.Bytes(), nil
+}
+
+func buildMixedBody(contentType string, content []byte, attachments []*Attachment) (string, []byte, error) {
+	var buffer bytes.Buffer
+	body := multipart.NewWriter(&buffer)
+
+	writer, err := body.CreatePart(textproto.MIMEHeader{"Content-Type": {contentType}})
+	if err != nil {
+		return "", nil, fmt.Errorf("email: unable to build message: %v", err)
+	}
+	if _, err := writer.Write(content); err != nil {
+		return "", nil, fmt.Errorf("email: unable to build message: %v", err)
+	}
+
+	for _, attachment := range attachments {
+		writer, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(attachment.ContentType, map[string]string{"name": attachment.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return "", nil, fmt.Errorf("email: unable to attach %s: %v", attachment.Filename, err)
+		}
+
+		// The encoded content is split in lines of 76 characters, as required by RFC 2045.
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		for len(encoded) > 76 {
+			io.WriteString(writer, encoded[:76]+"\r\n")
+			encoded = encoded[76:]
+		}
+		if _, err := io.WriteString(writer, encoded+"\r\n"); err != nil {
+			return "", nil, fmt.Errorf("email: unable to attach %s: %v", attachment.Filename, err)
+		}
+	}
+
+	if err := body.Close(); err != nil {
+		return "", nil, fmt.Errorf("email: unable to build message: %v", err)
+	}
+	return "multipart/mixed; boundary=" + body.Boundary(), buffer.Bytes(), nil
+}
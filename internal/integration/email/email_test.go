@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// smtpSink is a local SMTP server recording the messages it receives.
+type smtpSink struct {
+	host       string
+	port       int
+	extensions []string
+	auth       string
+	from       string
+	recipients []string
+	data       []byte
+	done       chan struct{}
+}
+
+func newSMTPSink(t *testing.T, extensions ...string) *smtpSink {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to start the SMTP sink: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	address := listener.Addr().(*net.TCPAddr)
+	sink := &smtpSink{host: "127.0.0.1", port: address.Port, extensions: extensions, done: make(chan struct{})}
+
+	go func() {
+		defer close(sink.done)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		sink.serve(textproto.NewConn(conn))
+	}()
+	return sink
+}
+
+func (s *smtpSink) serve(conn *textproto.Conn) {
+	conn.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command, argument, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(command) {
+		case "EHLO":
+			lines := append([]string{"localhost"}, s.extensions...)
+			for i, extension := range lines {
+				separator := "-"
+				if i == len(lines)-1 {
+					separator = " "
+				}
+				conn.PrintfLine("250%s%s", separator, extension)
+			}
+		case "AUTH":
+			s.auth = argument
+			conn.PrintfLine("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.from = argument
+			conn.PrintfLine("250 OK")
+		case "RCPT":
+			s.recipients = append(s.recipients, argument)
+			conn.PrintfLine("250 OK")
+		case "DATA":
+			conn.PrintfLine("354 Go ahead")
+			s.data, _ = conn.ReadDotBytes()
+			conn.PrintfLine("250 OK")
+		case "QUIT":
+			conn.PrintfLine("221 Bye")
+			return
+		default:
+			conn.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+func testMessage() *Message {
+	return &Message{
+		From:     "Miniflux <miniflux@example.org>",
+		To:       "alice@example.org, bob@example.org",
+		Subject:  "Résumé du jour",
+		HTMLBody: "<p>Hello</p>",
+		TextBody: "Hello",
+	}
+}
+
+func TestSend(t *testing.T) {
+	sink := newSMTPSink(t, "AUTH PLAIN")
+
+	client := NewClient(sink.host, sink.port, SecurityNone, "user", "secret")
+	if err := client.Send(testMessage()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	<-sink.done
+
+	if expected := "PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00user\x00secret")); sink.auth != expected {
+		t.Errorf("Expected authentication %q, got %q", expected, sink.auth)
+	}
+	if sink.from != "FROM:<miniflux@example.org>" {
+		t.Errorf("Unexpected sender %q", sink.from)
+	}
+	if len(sink.recipients) != 2 || sink.recipients[1] != "TO:<bob@example.org>" {
+		t.Errorf("Unexpected recipients %v", sink.recipients)
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(sink.data))
+	if err != nil {
+		t.Fatalf("Unable to parse the message: %v", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil || subject != "Résumé du jour" {
+		t.Errorf("Unexpected subject %q: %v", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Unexpected content type %q: %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Unable to read the message parts: %v", err)
+		}
+		content, _ := io.ReadAll(part)
+		parts = append(parts, part.Header.Get("Content-Type")+": "+string(content))
+	}
+
+	expected := []string{"text/plain; charset=utf-8: Hello", "text/html; charset=utf-8: <p>Hello</p>"}
+	if strings.Join(parts, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected parts %q", parts)
+	}
+}
+
+func TestSendWithoutStartTLSSupport(t *testing.T) {
+	sink := newSMTPSink(t, "AUTH PLAIN")
+
+	client := NewClient(sink.host, sink.port, SecurityStartTLS, "user", "secret")
+	err := client.Send(testMessage())
+	if err == nil || !strings.Contains(err.Error(), "does not support STARTTLS") {
+		t.Errorf("Expected a STARTTLS error, got %v", err)
+	}
+}
+
+func TestSendInvalidAddresses(t *testing.T) {
+	client := NewClient("127.0.0.1", 25, SecurityNone, "", "")
+
+	message := testMessage()
+	message.From = "not an address"
+	if err := client.Send(message); err == nil {
+		t.Error("Expected an error for an invalid sender")
+	}
+
+	message = testMessage()
+	message.To = ""
+	if err := client.Send(message); err == nil {
+		t.Error("Expected an error without recipient")
+	}
+}
+
+func TestSendMissingServer(t *testing.T) {
+	if err := NewClient("", 587, SecurityStartTLS, "", "").Send(testMessage()); err == nil {
+		t.Error("Expected an error without SMTP server")
+	}
+}
+
+func TestBuildMessageHeaders(t *testing.T) {
+	from, _ := mail.ParseAddress("miniflux@example.org")
+	to, _ := mail.ParseAddressList("alice@example.org")
+
+	data, err := buildMessage(from, to, testMessage())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unable to parse the message: %v", err)
+	}
+	if !strings.HasSuffix(message.Header.Get("Message-ID"), "@example.org>") {
+		t.Errorf("Unexpected message ID %q", message.Header.Get("Message-ID"))
+	}
+	if _, err := message.Header.Date(); err != nil {
+		t.Errorf("Invalid date: %v", err)
+	}
+	if message.Header.Get("To") != "<alice@example.org>" {
+		t.Errorf("Unexpected recipient %q", message.Header.Get("To"))
+	}
+}
+
+func TestBuildMessageWithAttachments(t *testing.T) {
+	from, _ := mail.ParseAddress("miniflux@example.org")
+	to, _ := mail.ParseAddressList("alice@example.org")
+
+	message := testMessage()
+	content := bytes.Repeat([]byte("epub"), 100)
+	message.Attachments = []*Attachment{{Filename: "Les Misérables.epub", ContentType: "application/epub+zip", Content: content}}
+
+	data, err := buildMessage(from, to, message)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unable to parse the message: %v", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Unexpected content type %q: %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	part, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("Unable to read the body: %v", err)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type")); mediaType != "multipart/alternative" {
+		t.Errorf("Unexpected body content type %q", mediaType)
+	}
+
+	part, err = reader.NextPart()
+	if err != nil {
+		t.Fatalf("Unable to read the attachment: %v", err)
+	}
+	if part.FileName() != "Les Misérables.epub" {
+		t.Errorf("Unexpected filename %q", part.FileName())
+	}
+
+	encoded, _ := io.ReadAll(part)
+	for line := range strings.Lines(string(encoded)) {
+		if len(line) > 78 {
+			t.Fatalf("Line too long: %q", line)
+		}
+	}
+
+	decoded, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(encoded)))
+	if err != nil || !bytes.Equal(decoded, content) {
+		t.Errorf("Unexpected attachment content: %v", err)
+	}
+
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("Expected only one attachment, got %v", err)
+	}
+}
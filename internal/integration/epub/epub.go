@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package epub // import "miniflux.app/v2/internal/integration/epub"
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ImageLoader returns the content and the media type of an image, the image is removed from the book on error.
+type ImageLoader func(imageURL string) (content []byte, mediaType string, err error)
+
+// Book is an EPUB 3 book, each chapter is an article. The table of contents is
+// also written in the EPUB 2 format for the older readers.
+type Book struct {
+	Identifier string
+	Title      string
+	Author     string
+	Language   string
+	Date       time.Time
+	Chapters   []*Chapter
+}
+
+// Chapter is an article of the book, the content is an HTML fragment.
+type Chapter struct {
+	Title   string
+	Author  string
+	URL     string
+	Date    time.Time
+	Content string
+}
+
+type image struct {
+	URL       string
+	Filename  string
+	MediaType string
+	Content   []byte
+}
+
+type archiveFile struct {
+	name    string
+	content []byte
+}
+
+type chapterFile struct {
+	Filename string
+	Title    string
+	Content  []byte
+}
+
+// imageExtensions are the image media types supported by the EPUB readers.
+var imageExtensions = map[string]string{
+	"image/gif":     "gif",
+	"image/jpeg":    "jpg",
+	"image/png":     "png",
+	"image/svg+xml": "svg",
+	"image/webp":    "webp",
+}
+
+// Write writes the book as an EPUB archive, the images of the chapters are embedded with the loader.
+func (b *Book) Write(w io.Writer, loader ImageLoader) error {
+	if len(b.Chapters) == 0 {
+		return fmt.Errorf("epub: the book %q has no chapter", b.Title)
+	}
+
+	book := &bookWriter{book: b, loader: loader, images: make(map[string]*image)}
+	for i, chapter := range b.Chapters {
+		content, err := book.renderChapter(chapter)
+		if err != nil {
+			return err
+		}
+		book.chapters = append(book.chapters, &chapterFile{
+			Filename: fmt.Sprintf("chapter-%d.xhtml", i+1),
+			Title:    chapter.Title,
+			Content:  content,
+		})
+	}
+
+	archive := zip.NewWriter(w)
+
+	// The mimetype must be the first file, without compression.
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return fmt.Errorf("epub: unable to write the archive: %v", err)
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return fmt.Errorf("epub: unable to write the archive: %v", err)
+	}
+
+	files := []archiveFile{
+		{"META-INF/container.xml", []byte(containerXML)},
+		{"OEBPS/style.css", []byte(styleCSS)},
+	}
+
+	for _, name := range []string{"content.opf", "nav.xhtml", "toc.ncx"} {
+		var buffer bytes.Buffer
+		if err := packageTemplates.ExecuteTemplate(&buffer, name, book.templateData()); err != nil {
+			return fmt.Errorf("epub: unable to render %s: %v", name, err)
+		}
+		files = append(files, archiveFile{"OEBPS/" + name, buffer.Bytes()})
+	}
+
+	for _, chapter := range book.chapters {
+		files = append(files, archiveFile{"OEBPS/" + chapter.Filename, chapter.Content})
+	}
+
+	for _, image := range book.imageList {
+		files = append(files, archiveFile{"OEBPS/" + image.Filename, image.Content})
+	}
+
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("epub: unable to write %s: %v", file.name, err)
+		}
+		if _, err := writer.Write(file.content); err != nil {
+			return fmt.Errorf("epub: unable to write %s: %v", file.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("epub: unable to write the archive: %v", err)
+	}
+	return nil
+}
+
+type bookWriter struct {
+	book      *Book
+	loader    ImageLoader
+	chapters  []*chapterFile
+	images    map[string]*image
+	imageList []*image
+}
+
+// embedImage returns the filename of the image in the book, or an empty string when the image cannot be loaded.
+// An image used several times is embedded once.
+func (b *bookWriter) embedImage(imageURL string) string {
+	if image, ok := b.images[imageURL]; ok {
+		if image == nil {
+			return ""
+		}
+		return image.Filename
+	}
+
+	b.images[imageURL] = nil
+	if b.loader == nil {
+		return ""
+	}
+
+	content, mediaType, err := b.loader(imageURL)
+	if err != nil || len(content) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ = strings.Cut(strings.ToLower(mediaType), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	if _, ok := imageExtensions[mediaType]; !ok {
+		mediaType, _, _ = strings.Cut(http.DetectContentType(content), ";")
+	}
+
+	extension, ok := imageExtensions[mediaType]
+	if !ok {
+		return ""
+	}
+
+	image := &image{
+		URL:       imageURL,
+		Filename:  fmt.Sprintf("images/image-%d.%s", len(b.imageList)+1, extension),
+		MediaType: mediaType,
+		Content:   content,
+	}
+	b.images[imageURL] = image
+	b.imageList = append(b.imageList, image)
+	return image.Filename
+}
+
+func (b *bookWriter) templateData() map[string]any {
+	language := b.book.Language
+	if language == "" {
+		language = "en"
+	}
+
+	return map[string]any{
+		"identifier": b.book.Identifier,
+		"title":      b.book.Title,
+		"author":     b.book.Author,
+		"language":   language,
+		"modified":   b.book.Date.UTC().Format("2006-01-02T15:04:05Z"),
+		"chapters":   b.chapters,
+		"images":     b.imageList,
+	}
+}
+
+// languageTag converts a locale such as en_US to the language tag en-US.
+func languageTag(language string) string {
+	return strings.ReplaceAll(language, "_", "-")
+}
+
+func escapeXML(value string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(value))
+	return buffer.String()
+}
+
+var packageTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"xml":      escapeXML,
+	"language": languageTag,
+	"inc":      func(i int) int { return i + 1 },
+}).Parse(`
+{{- define "content.opf" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid" xml:lang="{{ language .language | xml }}">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="uid">{{ xml .identifier }}</dc:identifier>
+    <dc:title>{{ xml .title }}</dc:title>
+    {{- if .author }}
+    <dc:creator>{{ xml .author }}</dc:creator>
+    {{- end }}
+    <dc:language>{{ language .language | xml }}</dc:language>
+    <meta property="dcterms:modified">{{ .modified }}</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="style" href="style.css" media-type="text/css"/>
+    {{- range $i, $chapter := .chapters }}
+    <item id="chapter-{{ inc $i }}" href="{{ $chapter.Filename }}" media-type="application/xhtml+xml"/>
+    {{- end }}
+    {{- range $i, $image := .images }}
+    <item id="image-{{ inc $i }}" href="{{ $image.Filename }}" media-type="{{ $image.MediaType }}"/>
+    {{- end }}
+  </manifest>
+  <spine toc="ncx">
+    <itemref idref="nav"/>
+    {{- range $i, $chapter := .chapters }}
+    <itemref idref="chapter-{{ inc $i }}"/>
+    {{- end }}
+  </spine>
+</package>
+{{ end -}}
+
+{{- define "nav.xhtml" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="{{ language .language | xml }}" lang="{{ language .language | xml }}">
+<head>
+  <meta charset="UTF-8"/>
+  <title>{{ xml .title }}</title>
+  <link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+  <nav epub:type="toc" id="toc">
+    <h1>{{ xml .title }}</h1>
+    <ol>
+      {{- range .chapters }}
+      <li><a href="{{ .Filename }}">{{ xml .Title }}</a></li>
+      {{- end }}
+    </ol>
+  </nav>
+</body>
+</html>
+{{ end -}}
+
+{{- define "toc.ncx" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head>
+    <meta name="dtb:uid" content="{{ xml .identifier }}"/>
+    <meta name="dtb:depth" content="1"/>
+    <meta name="dtb:totalPageCount" content="0"/>
+    <meta name="dtb:maxPageNumber" content="0"/>
+  </head>
+  <docTitle><text>{{ xml .title }}</text></docTitle>
+  <navMap>
+    {{- range $i, $chapter := .chapters }}
+    <navPoint id="navpoint-{{ inc $i }}" playOrder="{{ inc $i }}">
+      <navLabel><text>{{ xml $chapter.Title }}</text></navLabel>
+      <content src="{{ $chapter.Filename }}"/>
+    </navPoint>
+    {{- end }}
+  </navMap>
+</ncx>
+{{ end -}}
+`))
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const styleCSS = `body { font-family: serif; line-height: 1.5; }
+h1 { font-size: 1.4em; line-height: 1.2; }
+.entry-meta { color: #666; font-size: 0.9em; }
+img { max-width: 100%; height: auto; }
+pre { white-space: pre-wrap; }
+blockquote { margin-left: 1em; padding-left: 1em; border-left: 2px solid #ccc; }
+`
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package epub // import "miniflux.app/v2/internal/integration/epub"
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pngImage is the signature of a PNG file, enough for the content sniffing.
+var pngImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func readArchive(t *testing.T, data []byte) (names []string, files map[string]string) {
+	t.Helper()
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Invalid archive: %v", err)
+	}
+
+	files = make(map[string]string)
+	for _, file := range reader.File {
+		content, err := file.Open()
+		if err != nil {
+			t.Fatalf("Unable to open %s: %v", file.Name, err)
+		}
+		data, _ := io.ReadAll(content)
+		content.Close()
+
+		names = append(names, file.Name)
+		files[file.Name] = string(data)
+
+		if file.Name == "mimetype" && file.Method != zip.Store {
+			t.Error("The mimetype must not be compressed")
+		}
+	}
+	return names, files
+}
+
+func TestWriteBook(t *testing.T) {
+	book := &Book{
+		Identifier: "urn:miniflux:entry:1",
+		Title:      "Tom & Jerry",
+		Author:     "Miniflux",
+		Language:   "fr_FR",
+		Date:       time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC),
+		Chapters: []*Chapter{
+			{Title: "First <chapter>", Author: "Alice", URL: "https://example.org/articles/1", Content: `<p>Hello<br>World</p><img src="/a.png"><img src="/a.png">`},
+			{Title: "Second", URL: "https://example.org/articles/2", Content: `<p>Text</p>`},
+		},
+	}
+
+	var loaded []string
+	loader := func(imageURL string) ([]byte, string, error) {
+		loaded = append(loaded, imageURL)
+		return pngImage, "", nil
+	}
+
+	var buffer bytes.Buffer
+	if err := book.Write(&buffer, loader); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names, files := readArchive(t, buffer.Bytes())
+	if names[0] != "mimetype" || files["mimetype"] != "application/epub+zip" {
+		t.Errorf("The mimetype must be the first file, got %v", names)
+	}
+
+	for _, name := range []string{"META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/toc.ncx", "OEBPS/chapter-1.xhtml", "OEBPS/chapter-2.xhtml", "OEBPS/images/image-1.png"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Missing file %s in %v", name, names)
+		}
+	}
+
+	if len(loaded) != 1 || loaded[0] != "https://example.org/a.png" {
+		t.Errorf("The image must be loaded once with its absolute URL, got %v", loaded)
+	}
+
+	// All the documents must be well-formed XML.
+	for name, content := range files {
+		if !strings.HasSuffix(name, ".xhtml") && !strings.HasSuffix(name, ".opf") && !strings.HasSuffix(name, ".ncx") {
+			continue
+		}
+		decoder := xml.NewDecoder(strings.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("Invalid XML in %s: %v\n%s", name, err, content)
+			}
+		}
+	}
+
+	for _, expected := range []string{
+		"<dc:title>Tom &amp; Jerry</dc:title>",
+		"<dc:language>fr-FR</dc:language>",
+		`<meta property="dcterms:modified">2026-10-18T10:00:00Z</meta>`,
+		`<item id="image-1" href="images/image-1.png" media-type="image/png"/>`,
+		`<itemref idref="chapter-2"/>`,
+	} {
+		if !strings.Contains(files["OEBPS/content.opf"], expected) {
+			t.Errorf("Expected %q in the package document:\n%s", expected, files["OEBPS/content.opf"])
+		}
+	}
+
+	chapter := files["OEBPS/chapter-1.xhtml"]
+	for _, expected := range []string{
+		"<h1>First &lt;chapter&gt;</h1>",
+		`Alice · <a href="https://example.org/articles/1">example.org</a>`,
+		"<p>Hello<br/>World</p>",
+		`<img src="images/image-1.png" alt=""/><img src="images/image-1.png" alt=""/>`,
+	} {
+		if !strings.Contains(chapter, expected) {
+			t.Errorf("Expected %q in the chapter:\n%s", expected, chapter)
+		}
+	}
+
+	if !strings.Contains(files["OEBPS/nav.xhtml"], `<a href="chapter-1.xhtml">First &lt;chapter&gt;</a>`) {
+		t.Errorf("Unexpected table of contents:\n%s", files["OEBPS/nav.xhtml"])
+	}
+}
+
+func TestWriteBookWithoutChapter(t *testing.T) {
+	if err := (&Book{Title: "Empty"}).Write(io.Discard, nil); err == nil {
+		t.Error("Expected an error for a book without chapter")
+	}
+}
+
+func TestRenderChapterContent(t *testing.T) {
+	scenarios := []struct {
+		content  string
+		expected string
+	}{
+		{`<p onclick="alert(1)" class="x">Text</p>`, `<p>Text</p>`},
+		{`<script>alert(1)</script><p>Text</p>`, `<p>Text</p>`},
+		{`<iframe src="https://example.org/"></iframe>`, ``},
+		{`<font color="red">Red</font>`, `Red`},
+		{`<a href="/page">Link</a>`, `<a href="https://example.org/page">Link</a>`},
+		{`<a href="#note">Note</a>`, `<a>Note</a>`},
+		{`<a href="javascript:alert(1)">Link</a>`, `<a>Link</a>`},
+		{`<img src="https://example.org/broken.png" alt="Broken">`, ``},
+		{`<img src="https://example.org/text.txt">`, ``},
+		{`<img data-src="lazy.png" alt="Lazy">`, `<img src="images/image-1.png" alt="Lazy"/>`},
+		{`<td colspan="2" style="color: red">Cell</td>`, `Cell`},
+		{`<table><tr><td colspan="2" style="color: red">Cell</td></tr></table>`, `<table><tbody><tr><td colspan="2">Cell</td></tr></tbody></table>`},
+		{`<p>1 &lt; 2 &amp; 3</p>`, `<p>1 &lt; 2 &amp; 3</p>`},
+		{`<svg><circle r="1"></circle></svg><p>Text</p>`, `<p>Text</p>`},
+	}
+
+	loader := func(imageURL string) ([]byte, string, error) {
+		switch imageURL {
+		case "https://example.org/broken.png":
+			return nil, "", errors.New("not found")
+		case "https://example.org/text.txt":
+			return []byte("plain text"), "text/plain", nil
+		default:
+			return pngImage, "image/png", nil
+		}
+	}
+
+	for _, scenario := range scenarios {
+		book := &bookWriter{book: &Book{}, loader: loader, images: make(map[string]*image)}
+		content, err := book.renderChapter(&Chapter{Title: "Title", URL: "https://example.org/articles/", Content: scenario.content})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		_, body, _ := strings.Cut(string(content), "</h1>\n<p class=\"entry-meta\">")
+		_, body, _ = strings.Cut(body, "</p>\n")
+		body, _, _ = strings.Cut(body, "\n</article>")
+		if body != scenario.expected {
+			t.Errorf("Unexpected content for %q: got %q instead of %q", scenario.content, body, scenario.expected)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package epub // import "miniflux.app/v2/internal/integration/epub"
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedElements are kept with their allowed attributes, the other elements are replaced by their content.
+var allowedElements = map[string][]string{
+	"a":          {"href", "title"},
+	"abbr":       {"title"},
+	"b":          {},
+	"blockquote": {},
+	"br":         {},
+	"caption":    {},
+	"cite":       {},
+	"code":       {},
+	"dd":         {},
+	"del":        {},
+	"dfn":        {},
+	"div":        {},
+	"dl":         {},
+	"dt":         {},
+	"em":         {},
+	"figcaption": {},
+	"figure":     {},
+	"h1":         {},
+	"h2":         {},
+	"h3":         {},
+	"h4":         {},
+	"h5":         {},
+	"h6":         {},
+	"hr":         {},
+	"i":          {},
+	"img":        {"alt", "title"},
+	"ins":        {},
+	"kbd":        {},
+	"li":         {},
+	"mark":       {},
+	"ol":         {"start"},
+	"p":          {},
+	"pre":        {},
+	"q":          {},
+	"s":          {},
+	"samp":       {},
+	"small":      {},
+	"span":       {},
+	"strong":     {},
+	"sub":        {},
+	"sup":        {},
+	"table":      {},
+	"tbody":      {},
+	"td":         {"colspan", "rowspan"},
+	"tfoot":      {},
+	"th":         {"colspan", "rowspan"},
+	"thead":      {},
+	"time":       {},
+	"tr":         {},
+	"u":          {},
+	"ul":         {},
+	"var":        {},
+}
+
+// removedElements are removed with their content.
+var removedElements = []string{
+	"audio", "button", "canvas", "embed", "form", "iframe", "input", "link", "math", "meta", "noscript",
+	"object", "script", "select", "source", "style", "svg", "template", "textarea", "track", "video",
+}
+
+var voidElements = []string{"br", "hr", "img"}
+
+// renderChapter converts the chapter to an XHTML document, the relative links are resolved with the chapter URL.
+func (b *bookWriter) renderChapter(chapter *Chapter) ([]byte, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(chapter.Content), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("epub: unable to parse the chapter %q: %v", chapter.Title, err)
+	}
+
+	baseURL, _ := url.Parse(chapter.URL)
+	language := languageTag(b.book.Language)
+	if language == "" {
+		language = "en"
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buffer.WriteString("<!DOCTYPE html>\n")
+	fmt.Fprintf(&buffer, `<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="%[1]s" lang="%[1]s">`+"\n", escapeXML(language))
+	fmt.Fprintf(&buffer, `<head><meta charset="UTF-8"/><title>%s</title><link rel="stylesheet" type="text/css" href="style.css"/></head>`+"\n", escapeXML(chapter.Title))
+	buffer.WriteString("<body>\n<article>\n")
+	fmt.Fprintf(&buffer, "<h1>%s</h1>\n", escapeXML(chapter.Title))
+
+	var meta []string
+	if chapter.Author != "" {
+		meta = append(meta, escapeXML(chapter.Author))
+	}
+	if !chapter.Date.IsZero() {
+		meta = append(meta, chapter.Date.Format("2006-01-02"))
+	}
+	if href := linkURL(baseURL, chapter.URL); href != "" {
+		meta = append(meta, fmt.Sprintf(`<a href="%s">%s</a>`, escapeXML(href), escapeXML(baseURL.Hostname())))
+	}
+	if len(meta) > 0 {
+		fmt.Fprintf(&buffer, "<p class=\"entry-meta\">%s</p>\n", strings.Join(meta, " · "))
+	}
+
+	for _, node := range nodes {
+		b.writeNode(&buffer, node, baseURL)
+	}
+
+	buffer.WriteString("\n</article>\n</body>\n</html>\n")
+	return buffer.Bytes(), nil
+}
+
+func (b *bookWriter) writeNode(buffer *bytes.Buffer, node *html.Node, baseURL *url.URL) {
+	switch node.Type {
+	case html.TextNode:
+		buffer.WriteString(escapeXML(node.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if node.Namespace != "" || slices.Contains(removedElements, node.Data) {
+		return
+	}
+
+	allowedAttributes, allowed := allowedElements[node.Data]
+	if !allowed {
+		b.writeChildren(buffer, node, baseURL)
+		return
+	}
+
+	var attributes []html.Attribute
+	if node.Data == "img" {
+		filename := b.embedImage(imageURL(baseURL, node))
+		if filename == "" {
+			return
+		}
+		attributes = append(attributes, html.Attribute{Key: "src", Val: filename})
+		if !slices.ContainsFunc(node.Attr, func(attribute html.Attribute) bool { return attribute.Key == "alt" }) {
+			attributes = append(attributes, html.Attribute{Key: "alt", Val: ""})
+		}
+	}
+
+	for _, attribute := range node.Attr {
+		if attribute.Namespace != "" || !slices.Contains(allowedAttributes, attribute.Key) {
+			continue
+		}
+		// The internal links of the page point to nothing in the book.
+		if attribute.Key == "href" {
+			if attribute.Val = linkURL(baseURL, attribute.Val); attribute.Val == "" {
+				continue
+			}
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	buffer.WriteString("<" + node.Data)
+	for _, attribute := range attributes {
+		fmt.Fprintf(buffer, ` %s="%s"`, attribute.Key, escapeXML(attribute.Val))
+	}
+
+	if slices.Contains(voidElements, node.Data) {
+		buffer.WriteString("/>")
+		return
+	}
+
+	buffer.WriteString(">")
+	b.writeChildren(buffer, node, baseURL)
+	buffer.WriteString("</" + node.Data + ">")
+}
+
+func (b *bookWriter) writeChildren(buffer *bytes.Buffer, node *html.Node, baseURL *url.URL) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		b.writeNode(buffer, child, baseURL)
+	}
+}
+
+// imageURL returns the absolute URL of an image, the lazy loaded images are supported.
+func imageURL(baseURL *url.URL, node *html.Node) string {
+	var source string
+	for _, key := range []string{"src", "data-src", "data-original"} {
+		for _, attribute := range node.Attr {
+			if attribute.Key == key && attribute.Val != "" && !strings.HasPrefix(attribute.Val, "data:") {
+				source = attribute.Val
+				break
+			}
+		}
+		if source != "" {
+			break
+		}
+	}
+	return linkURL(baseURL, source)
+}
+
+// linkURL returns the absolute URL of a link, or an empty string when it cannot be followed from the book.
+func linkURL(baseURL *url.URL, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" || strings.HasPrefix(link, "#") {
+		return ""
+	}
+
+	parsedURL, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if baseURL != nil {
+		parsedURL = baseURL.ResolveReference(parsedURL)
+	}
+
+	switch parsedURL.Scheme {
+	case "http", "https", "mailto":
+		return parsedURL.String()
+	default:
+		return ""
+	}
+}
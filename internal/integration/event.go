@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"log/slog"
+	"slices"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// EventReceiver is implemented by the providers notified of the changes made by the users.
+type EventReceiver interface {
+	// ReceivesEvent returns true if the user subscribed the provider to the event type.
+	ReceivesEvent(settings *model.IntegrationSettings, eventType string) bool
+
+	// SendEvent sends an event about a feed, the entries are empty for the feed events.
+	// The storage is used by the providers keeping a state, such as the ActivityPub actor.
+	SendEvent(store *storage.Storage, settings *model.IntegrationSettings, eventType string, feed *model.Feed, entries model.Entries) error
+}
+
+type eventReceiver struct {
+	provider Provider
+	receiver EventReceiver
+	settings *model.IntegrationSettings
+}
+
+// SendEntriesStatusEvent notifies the providers when entries are marked as read or unread.
+func SendEntriesStatusEvent(store *storage.Storage, userID int64, entryIDs []int64, status string) {
+	var eventType string
+	switch status {
+	case model.EntryStatusRead:
+		eventType = model.EventEntryRead
+	case model.EntryStatusUnread:
+		eventType = model.EventEntryUnread
+	default:
+		return
+	}
+
+	sendEntriesEvent(store, userID, entryIDs, func(*model.Entry) string {
+		return eventType
+	}, eventType)
+}
+
+// SendEntriesStarredEvent notifies the providers when entries are starred or unstarred,
+// the event depends on the current starred flag of each entry. The imported bookmarks are updated too.
+func SendEntriesStarredEvent(store *storage.Storage, userID int64, entryIDs []int64) {
+	sendBookmarksStarred(store, userID, entryIDs)
+	sendEntriesEvent(store, userID, entryIDs, func(entry *model.Entry) string {
+		if entry.Starred {
+			return model.EventEntryStarred
+		}
+		return model.EventEntryUnstarred
+	}, model.EventEntryStarred, model.EventEntryUnstarred)
+}
+
+// SendEntriesSharedEvent notifies the providers when entries are shared or unshared,
+// the event depends on the current share code of each entry.
+func SendEntriesSharedEvent(store *storage.Storage, userID int64, entryIDs []int64) {
+	sendEntriesEvent(store, userID, entryIDs, func(entry *model.Entry) string {
+		if entry.ShareCode != "" {
+			return model.EventEntryShared
+		}
+		return model.EventEntryUnshared
+	}, model.EventEntryShared, model.EventEntryUnshared)
+}
+
+// SendEntriesTagsChangedEvent notifies the providers when the tags of existing entries are updated by a refresh.
+func SendEntriesTagsChangedEvent(store *storage.Storage, userID int64, entryIDs []int64) {
+	sendEntriesEvent(store, userID, entryIDs, func(*model.Entry) string {
+		return model.EventEntryTagsChanged
+	}, model.EventEntryTagsChanged)
+}
+
+// SendFeedEvent notifies the providers of a change of a feed.
+// The removal of a feed is not recorded in the outbox, the deliveries are removed with the feed.
+func SendFeedEvent(store *storage.Storage, eventType string, feed *model.Feed) {
+	receivers := eventReceivers(store, feed.UserID, eventType)
+	for _, receiver := range receivers {
+		send := func() error {
+			return sendEvent(store, receiver, eventType, feed, nil)
+		}
+
+		if eventType == model.EventFeedRemoved {
+			go send()
+			continue
+		}
+
+		delivery := newDelivery(store, receiver.settings, eventType, feed.ID, nil)
+		go attemptDelivery(store, delivery, send)
+	}
+}
+
+// sendEntriesEvent groups the entries by feed and event type, eventTypes are all the events returned by eventTypeOf.
+func sendEntriesEvent(store *storage.Storage, userID int64, entryIDs []int64, eventTypeOf func(*model.Entry) string, eventTypes ...string) {
+	if len(entryIDs) == 0 {
+		return
+	}
+
+	receivers := eventReceivers(store, userID, eventTypes...)
+	if len(receivers) == 0 {
+		return
+	}
+
+	builder := store.NewEntryQueryBuilder(userID)
+	builder.WithEntryIDs(entryIDs)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithSorting("id", "ASC")
+	entries, err := builder.GetEntries()
+	if err != nil {
+		slog.Error("Unable to fetch entries for the integrations",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	type eventKey struct {
+		eventType string
+		feedID    int64
+	}
+	var keys []eventKey
+	groups := make(map[eventKey]model.Entries)
+	for _, entry := range entries {
+		key := eventKey{eventTypeOf(entry), entry.FeedID}
+		if key.eventType == "" {
+			continue
+		}
+		if _, found := groups[key]; !found {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], entry)
+	}
+
+	feeds := make(map[int64]*model.Feed)
+	for _, key := range keys {
+		feed, found := feeds[key.feedID]
+		if !found {
+			if feed, err = store.FeedByID(userID, key.feedID); err != nil || feed == nil {
+				slog.Error("Unable to fetch feed for the integrations",
+					slog.Int64("user_id", userID),
+					slog.Int64("feed_id", key.feedID),
+					slog.Any("error", err),
+				)
+				continue
+			}
+			feeds[key.feedID] = feed
+		}
+
+		groupEntries := groups[key]
+		groupEntryIDs := make([]int64, len(groupEntries))
+		for i, entry := range groupEntries {
+			groupEntryIDs[i] = entry.ID
+		}
+
+		for _, receiver := range receivers {
+			if !receiver.receiver.ReceivesEvent(receiver.settings, key.eventType) {
+				continue
+			}
+
+			delivery := newDelivery(store, receiver.settings, key.eventType, feed.ID, groupEntryIDs)
+			go attemptDelivery(store, delivery, func() error {
+				return sendEvent(store, receiver, key.eventType, feed, groupEntries)
+			})
+		}
+	}
+}
+
+// eventReceivers returns the enabled providers subscribed to at least one of the event types.
+func eventReceivers(store *storage.Storage, userID int64, eventTypes ...string) []eventReceiver {
+	userIntegrations, err := store.IntegrationSettings(userID)
+	if err != nil {
+		slog.Error("Unable to fetch integrations",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+		return nil
+	}
+
+	var receivers []eventReceiver
+	for _, settings := range userIntegrations {
+		provider := enabledProvider(settings)
+		if provider == nil {
+			continue
+		}
+
+		receiver, ok := provider.(EventReceiver)
+		if !ok {
+			continue
+		}
+
+		if slices.ContainsFunc(eventTypes, func(eventType string) bool {
+			return receiver.ReceivesEvent(settings, eventType)
+		}) {
+			receivers = append(receivers, eventReceiver{provider, receiver, settings})
+		}
+	}
+	return receivers
+}
+
+func sendEvent(store *storage.Storage, receiver eventReceiver, eventType string, feed *model.Feed, entries model.Entries) error {
+	attrs := logAttributes(receiver.provider, receiver.settings,
+		slog.String("event_type", eventType),
+		slog.Int64("feed_id", feed.ID),
+		slog.Int("nb_entries", len(entries)),
+	)
+	slog.Debug("Sending event to "+receiver.provider.ConfigSchema().Title, attrs...)
+
+	if err := receiver.receiver.SendEvent(store, receiver.settings, eventType, feed, entries); err != nil {
+		slog.Warn("Unable to send event to "+receiver.provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+		return err
+	}
+	return nil
+}
+
+// receivesEvent returns false when the provider lets the user choose the events and the event is not selected.
+func receivesEvent(provider Provider, settings *model.IntegrationSettings, eventType string) bool {
+	if receiver, ok := provider.(EventReceiver); ok {
+		return receiver.ReceivesEvent(settings, eventType)
+	}
+	return true
+}
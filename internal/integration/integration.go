@@ -4,685 +4,145 @@
 package integration // import "miniflux.app/v2/internal/integration"
 
 import (
+	"fmt"
 	"log/slog"
 
-	"miniflux.app/v2/internal/integration/apprise"
-	"miniflux.app/v2/internal/integration/archiveorg"
-	"miniflux.app/v2/internal/integration/betula"
-	"miniflux.app/v2/internal/integration/cubox"
-	"miniflux.app/v2/internal/integration/discord"
-	"miniflux.app/v2/internal/integration/espial"
-	"miniflux.app/v2/internal/integration/instapaper"
-	"miniflux.app/v2/internal/integration/karakeep"
-	"miniflux.app/v2/internal/integration/linkace"
-	"miniflux.app/v2/internal/integration/linkding"
-	"miniflux.app/v2/internal/integration/linktaco"
-	"miniflux.app/v2/internal/integration/linkwarden"
-	"miniflux.app/v2/internal/integration/matrixbot"
-	"miniflux.app/v2/internal/integration/notion"
-	"miniflux.app/v2/internal/integration/ntfy"
-	"miniflux.app/v2/internal/integration/nunuxkeeper"
-	"miniflux.app/v2/internal/integration/omnivore"
-	"miniflux.app/v2/internal/integration/pinboard"
-	"miniflux.app/v2/internal/integration/pushover"
-	"miniflux.app/v2/internal/integration/raindrop"
-	"miniflux.app/v2/internal/integration/readeck"
-	"miniflux.app/v2/internal/integration/readwise"
-	"miniflux.app/v2/internal/integration/shaarli"
-	"miniflux.app/v2/internal/integration/shiori"
-	"miniflux.app/v2/internal/integration/slack"
-	"miniflux.app/v2/internal/integration/telegrambot"
-	"miniflux.app/v2/internal/integration/wallabag"
-	"miniflux.app/v2/internal/integration/webhook"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
 )
 
-// SendEntry sends the entry to third-party providers when the user click on "Save".
-func SendEntry(entry *model.Entry, userIntegrations *model.Integration) {
-	if userIntegrations.BetulaEnabled {
-		slog.Debug("Sending entry to Betula",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := betula.NewClient(userIntegrations.BetulaURL, userIntegrations.BetulaToken)
-		err := client.CreateBookmark(
-			entry.URL,
-			entry.Title,
-			entry.Tags,
-		)
-
-		if err != nil {
-			slog.Error("Unable to send entry to Betula",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.PinboardEnabled {
-		slog.Debug("Sending entry to Pinboard",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := pinboard.NewClient(userIntegrations.PinboardToken)
-		err := client.CreateBookmark(
-			entry.URL,
-			entry.Title,
-			userIntegrations.PinboardTags,
-			userIntegrations.PinboardMarkAsUnread,
-		)
-
-		if err != nil {
-			slog.Error("Unable to send entry to Pinboard",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.InstapaperEnabled {
-		slog.Debug("Sending entry to Instapaper",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := instapaper.NewClient(userIntegrations.InstapaperUsername, userIntegrations.InstapaperPassword)
-		if err := client.AddURL(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Instapaper",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.WallabagEnabled {
-		slog.Debug("Sending entry to Wallabag",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.String("user_tags", userIntegrations.WallabagTags),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := wallabag.NewClient(
-			userIntegrations.WallabagURL,
-			userIntegrations.WallabagClientID,
-			userIntegrations.WallabagClientSecret,
-			userIntegrations.WallabagUsername,
-			userIntegrations.WallabagPassword,
-			userIntegrations.WallabagTags,
-			userIntegrations.WallabagOnlyURL,
-		)
-
-		if err := client.CreateEntry(entry.URL, entry.Title, entry.Content); err != nil {
-			slog.Error("Unable to send entry to Wallabag",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.String("user_tags", userIntegrations.WallabagTags),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.NotionEnabled {
-		slog.Debug("Sending entry to Notion",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := notion.NewClient(
-			userIntegrations.NotionToken,
-			userIntegrations.NotionPageID,
-		)
-		if err := client.UpdateDocument(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Notion",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.NunuxKeeperEnabled {
-		slog.Debug("Sending entry to NunuxKeeper",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := nunuxkeeper.NewClient(
-			userIntegrations.NunuxKeeperURL,
-			userIntegrations.NunuxKeeperAPIKey,
-		)
-
-		if err := client.AddEntry(entry.URL, entry.Title, entry.Content); err != nil {
-			slog.Error("Unable to send entry to NunuxKeeper",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.EspialEnabled {
-		slog.Debug("Sending entry to Espial",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := espial.NewClient(
-			userIntegrations.EspialURL,
-			userIntegrations.EspialAPIKey,
-		)
-
-		if err := client.CreateLink(entry.URL, entry.Title, userIntegrations.EspialTags); err != nil {
-			slog.Error("Unable to send entry to Espial",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+// SendEntry records the delivery of an entry saved by the user to each provider and sends it in the background.
+func SendEntry(store *storage.Storage, entry *model.Entry, userIntegrations []*model.IntegrationSettings) {
+	for _, settings := range userIntegrations {
+		provider := enabledProvider(settings)
+		if provider == nil || !provider.ConfigSchema().SavesEntries || !receivesEvent(provider, settings, model.EventSaveEntry) {
+			continue
 		}
-	}
-
-	if userIntegrations.LinkAceEnabled {
-		slog.Debug("Sending entry to LinkAce",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
 
-		client := linkace.NewClient(
-			userIntegrations.LinkAceURL,
-			userIntegrations.LinkAceAPIKey,
-			userIntegrations.LinkAceTags,
-			userIntegrations.LinkAcePrivate,
-			userIntegrations.LinkAceCheckDisabled,
-		)
-		if err := client.AddURL(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to LinkAce",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
+		delivery := newDelivery(store, settings, model.DeliveryKindSaveEntry, entry.FeedID, []int64{entry.ID})
+		go attemptDelivery(store, delivery, func() error {
+			return saveEntry(store, provider, settings, entry)
+		})
 	}
+}
 
-	if userIntegrations.LinkdingEnabled {
-		slog.Debug("Sending entry to Linkding",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := linkding.NewClient(
-			userIntegrations.LinkdingURL,
-			userIntegrations.LinkdingAPIKey,
-			userIntegrations.LinkdingTags,
-			userIntegrations.LinkdingMarkAsUnread,
-		)
-		if err := client.CreateBookmark(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Linkding",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.LinktacoEnabled {
-		slog.Debug("Sending entry to LinkTaco",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := linktaco.NewClient(
-			userIntegrations.LinktacoAPIToken,
-			userIntegrations.LinktacoOrgSlug,
-			userIntegrations.LinktacoTags,
-			userIntegrations.LinktacoVisibility,
-		)
-		if err := client.CreateBookmark(entry.URL, entry.Title, entry.Content); err != nil {
-			slog.Error("Unable to send entry to LinkTaco",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.LinkwardenEnabled {
-		attrs := []any{
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		}
-
-		if userIntegrations.LinkwardenCollectionID != nil {
-			attrs = append(attrs, slog.Int64("collection_id", *userIntegrations.LinkwardenCollectionID))
-		}
-
-		slog.Debug("Sending entry to linkwarden", attrs...)
-
-		client := linkwarden.NewClient(
-			userIntegrations.LinkwardenURL,
-			userIntegrations.LinkwardenAPIKey,
-			userIntegrations.LinkwardenCollectionID,
-		)
-		if err := client.CreateBookmark(entry.URL, entry.Title); err != nil {
-			attrs = append(attrs, slog.Any("error", err))
-			slog.Error("Unable to send entry to Linkwarden", attrs...)
-		}
+// PushEntries records the delivery of the new entries of a feed to each provider and sends them in the background.
+// The notification providers only receive the entries matching the notification routes of the user.
+func PushEntries(store *storage.Storage, feed *model.Feed, entries model.Entries, userIntegrations []*model.IntegrationSettings) {
+	entryIDs := make([]int64, len(entries))
+	for i, entry := range entries {
+		entryIDs[i] = entry.ID
 	}
 
-	if userIntegrations.ReadeckEnabled {
-		slog.Debug("Sending entry to Readeck",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := readeck.NewClient(
-			userIntegrations.ReadeckURL,
-			userIntegrations.ReadeckAPIKey,
-			userIntegrations.ReadeckLabels,
-			userIntegrations.ReadeckOnlyURL,
+	routes, routesErr := store.NotificationRoutes(feed.UserID)
+	if routesErr != nil {
+		slog.Error("Unable to fetch the notification routes, no notification is sent",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", routesErr),
 		)
-		if err := client.CreateBookmark(entry.URL, entry.Title, entry.Content); err != nil {
-			slog.Error("Unable to send entry to Readeck",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
 	}
 
-	if userIntegrations.ReadwiseEnabled {
-		slog.Debug("Sending entry to Readwise",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := readwise.NewClient(
-			userIntegrations.ReadwiseAPIKey,
-		)
-
-		if err := client.CreateDocument(entry.URL); err != nil {
-			slog.Error("Unable to send entry to Readwise",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+	for _, settings := range userIntegrations {
+		provider := enabledProvider(settings)
+		if provider == nil || !provider.ConfigSchema().PushesEntries || !receivesEvent(provider, settings, model.EventNewEntries) || chatBotPaused(provider, settings) {
+			continue
 		}
-	}
 
-	if userIntegrations.CuboxEnabled {
-		slog.Debug("Sending entry to Cubox",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := cubox.NewClient(userIntegrations.CuboxAPILink)
-
-		if err := client.SaveLink(entry.URL); err != nil {
-			slog.Error("Unable to send entry to Cubox",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.ShioriEnabled {
-		slog.Debug("Sending entry to Shiori",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := shiori.NewClient(
-			userIntegrations.ShioriURL,
-			userIntegrations.ShioriUsername,
-			userIntegrations.ShioriPassword,
-		)
-
-		if err := client.CreateBookmark(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Shiori",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
-	}
-
-	if userIntegrations.ShaarliEnabled {
-		slog.Debug("Sending entry to Shaarli",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := shaarli.NewClient(
-			userIntegrations.ShaarliURL,
-			userIntegrations.ShaarliAPISecret,
-		)
-
-		if err := client.CreateLink(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Shaarli",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+		if notifier, ok := provider.(Notifier); ok {
+			if routesErr == nil {
+				notifyEntries(store, provider, notifier, settings, routes, feed, entries)
+			}
+			continue
 		}
-	}
-
-	if userIntegrations.ArchiveorgEnabled {
-		slog.Debug("Sending entry to archive.org",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
 
-		archiveorg.NewClient().SendURL(entry.URL, entry.Title)
+		delivery := newDelivery(store, settings, model.DeliveryKindPushEntries, feed.ID, entryIDs)
+		go attemptDelivery(store, delivery, func() error {
+			return pushEntries(store, provider, settings, feed, entries)
+		})
 	}
+}
 
-	if userIntegrations.WebhookEnabled {
-		var webhookURL string
-		if entry.Feed != nil && entry.Feed.WebhookURL != "" {
-			webhookURL = entry.Feed.WebhookURL
-		} else {
-			webhookURL = userIntegrations.WebhookURL
-		}
-
-		slog.Debug("Sending entry to Webhook",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-			slog.String("webhook_url", webhookURL),
-		)
-
-		webhookClient := webhook.NewClient(webhookURL, userIntegrations.WebhookSecret)
-		if err := webhookClient.SendSaveEntryWebhookEvent(entry); err != nil {
-			slog.Error("Unable to send entry to Webhook",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.String("webhook_url", webhookURL),
-				slog.Any("error", err),
-			)
-		}
+func saveEntry(store *storage.Storage, provider Provider, settings *model.IntegrationSettings, entry *model.Entry) error {
+	settings, err := authorizeSettings(store, provider, settings)
+	if err != nil {
+		return err
 	}
 
-	if userIntegrations.OmnivoreEnabled {
-		slog.Debug("Sending entry to Omnivore",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := omnivore.NewClient(userIntegrations.OmnivoreAPIKey, userIntegrations.OmnivoreURL)
-		if err := client.SaveUrl(entry.URL); err != nil {
-			slog.Error("Unable to send entry to Omnivore",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+	if sender, ok := provider.(BookSender); ok {
+		user, err := store.UserByID(settings.UserID)
+		if err != nil {
+			return err
 		}
-	}
-
-	if userIntegrations.KarakeepEnabled {
-		slog.Debug("Sending entry to Karakeep",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.String("user_tags", userIntegrations.KarakeepTags),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
-
-		client := karakeep.NewClient(
-			userIntegrations.KarakeepAPIKey,
-			userIntegrations.KarakeepURL,
-			userIntegrations.KarakeepTags,
-		)
-		if err := client.SaveURL(entry.URL); err != nil {
-			slog.Error("Unable to send entry to Karakeep",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.String("user_tags", userIntegrations.KarakeepTags),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
+		if user == nil {
+			return fmt.Errorf("integration: user #%d not found", settings.UserID)
 		}
+		return sendBook(store, provider, sender, settings, user, entry.Title, model.Entries{entry})
 	}
 
-	if userIntegrations.RaindropEnabled {
-		slog.Debug("Sending entry to Raindrop",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int64("entry_id", entry.ID),
-			slog.String("entry_url", entry.URL),
-		)
+	attrs := logAttributes(provider, settings,
+		slog.Int64("entry_id", entry.ID),
+		slog.String("entry_url", entry.URL),
+	)
+	slog.Debug("Sending entry to "+provider.ConfigSchema().Title, attrs...)
 
-		client := raindrop.NewClient(userIntegrations.RaindropToken, userIntegrations.RaindropCollectionID, userIntegrations.RaindropTags)
-		if err := client.CreateRaindrop(entry.URL, entry.Title); err != nil {
-			slog.Error("Unable to send entry to Raindrop",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int64("entry_id", entry.ID),
-				slog.String("entry_url", entry.URL),
-				slog.Any("error", err),
-			)
-		}
+	if err := provider.SaveEntry(settings, entry); err != nil {
+		slog.Error("Unable to send entry to "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+		return err
 	}
+	return nil
 }
 
-// PushEntries pushes a list of entries to activated third-party providers during feed refreshes.
-func PushEntries(feed *model.Feed, entries model.Entries, userIntegrations *model.Integration) {
-	if userIntegrations.MatrixBotEnabled {
-		slog.Debug("Sending new entries to Matrix",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-		)
-
-		err := matrixbot.PushEntries(
-			feed,
-			entries,
-			userIntegrations.MatrixBotURL,
-			userIntegrations.MatrixBotUser,
-			userIntegrations.MatrixBotPassword,
-			userIntegrations.MatrixBotChatID,
-		)
-		if err != nil {
-			slog.Error("Unable to send new entries to Matrix",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int("nb_entries", len(entries)),
-				slog.Int64("feed_id", feed.ID),
-				slog.Any("error", err),
-			)
-		}
+func pushEntries(store *storage.Storage, provider Provider, settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries) error {
+	settings, err := authorizeSettings(store, provider, settings)
+	if err != nil {
+		return err
 	}
-	if userIntegrations.WebhookEnabled {
-		var webhookURL string
-		if feed.WebhookURL != "" {
-			webhookURL = feed.WebhookURL
-		} else {
-			webhookURL = userIntegrations.WebhookURL
-		}
 
-		slog.Debug("Sending new entries to Webhook",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-			slog.String("webhook_url", webhookURL),
-		)
+	attrs := logAttributes(provider, settings,
+		slog.Int("nb_entries", len(entries)),
+		slog.Int64("feed_id", feed.ID),
+	)
+	slog.Debug("Sending new entries to "+provider.ConfigSchema().Title, attrs...)
 
-		webhookClient := webhook.NewClient(webhookURL, userIntegrations.WebhookSecret)
-		if err := webhookClient.SendNewEntriesWebhookEvent(feed, entries); err != nil {
-			slog.Debug("Unable to send new entries to Webhook",
-				slog.Int64("user_id", userIntegrations.UserID),
-				slog.Int("nb_entries", len(entries)),
-				slog.Int64("feed_id", feed.ID),
-				slog.String("webhook_url", webhookURL),
-				slog.Any("error", err),
-			)
-		}
+	if err := provider.PushEntries(settings, feed, entries); err != nil {
+		slog.Warn("Unable to send new entries to "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+		return err
 	}
+	return nil
+}
 
-	if userIntegrations.NtfyEnabled && feed.NtfyEnabled {
-		ntfyTopic := feed.NtfyTopic
-		if ntfyTopic == "" {
-			ntfyTopic = userIntegrations.NtfyTopic
-		}
-		slog.Debug("Sending new entries to Ntfy",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-			slog.String("topic", ntfyTopic),
-		)
-
-		client := ntfy.NewClient(
-			userIntegrations.NtfyURL,
-			ntfyTopic,
-			userIntegrations.NtfyAPIToken,
-			userIntegrations.NtfyUsername,
-			userIntegrations.NtfyPassword,
-			userIntegrations.NtfyIconURL,
-			userIntegrations.NtfyInternalLinks,
-			feed.NtfyPriority,
-		)
-
-		if err := client.SendMessages(feed, entries); err != nil {
-			slog.Warn("Unable to send new entries to Ntfy", slog.Any("error", err))
-		}
-	}
-
-	if userIntegrations.AppriseEnabled {
-		slog.Debug("Sending new entries to Apprise",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-		)
-
-		appriseServiceURLs := userIntegrations.AppriseServicesURL
-		if feed.AppriseServiceURLs != "" {
-			appriseServiceURLs = feed.AppriseServiceURLs
-		}
-
-		client := apprise.NewClient(
-			appriseServiceURLs,
-			userIntegrations.AppriseURL,
-		)
-
-		if err := client.SendNotification(feed, entries); err != nil {
-			slog.Warn("Unable to send new entries to Apprise", slog.Any("error", err))
-		}
-	}
-
-	if userIntegrations.DiscordEnabled {
-		slog.Debug("Sending new entries to Discord",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-		)
-
-		client := discord.NewClient(
-			userIntegrations.DiscordWebhookLink,
-		)
-
-		if err := client.SendDiscordMsg(feed, entries); err != nil {
-			slog.Warn("Unable to send new entries to Discord", slog.Any("error", err))
-		}
+func enabledProvider(settings *model.IntegrationSettings) Provider {
+	if !settings.Enabled {
+		return nil
 	}
 
-	if userIntegrations.SlackEnabled {
-		slog.Debug("Sending new entries to Slack",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-		)
-
-		client := slack.NewClient(
-			userIntegrations.SlackWebhookLink,
+	provider := ProviderByName(settings.Provider)
+	if provider == nil {
+		slog.Warn("Unknown integration provider",
+			slog.Int64("user_id", settings.UserID),
+			slog.String("provider", settings.Provider),
 		)
-
-		if err := client.SendSlackMsg(feed, entries); err != nil {
-			slog.Warn("Unable to send new entries to Slack", slog.Any("error", err))
-		}
 	}
+	return provider
+}
 
-	if userIntegrations.PushoverEnabled && feed.PushoverEnabled {
-		slog.Debug("Sending new entries to Pushover",
-			slog.Int64("user_id", userIntegrations.UserID),
-			slog.Int("nb_entries", len(entries)),
-			slog.Int64("feed_id", feed.ID),
-		)
-
-		client := pushover.New(
-			userIntegrations.PushoverUser,
-			userIntegrations.PushoverToken,
-			feed.PushoverPriority,
-			userIntegrations.PushoverDevice,
-			userIntegrations.PushoverPrefix,
-		)
-
-		if err := client.SendMessages(feed, entries); err != nil {
-			slog.Warn("Unable to send new entries to Pushover", slog.Any("error", err))
+func logAttributes(provider Provider, settings *model.IntegrationSettings, attrs ...any) []any {
+	attrs = append([]any{slog.Int64("user_id", settings.UserID)}, attrs...)
+	for _, field := range provider.ConfigSchema().Fields {
+		if !field.Log {
+			continue
 		}
-	}
-
-	// Integrations that only support sending individual entries
-	if userIntegrations.TelegramBotEnabled {
-		for _, entry := range entries {
-			if userIntegrations.TelegramBotEnabled {
-				slog.Debug("Sending a new entry to Telegram",
-					slog.Int64("user_id", userIntegrations.UserID),
-					slog.Int64("entry_id", entry.ID),
-					slog.String("entry_url", entry.URL),
-				)
-
-				if err := telegrambot.PushEntry(
-					feed,
-					entry,
-					userIntegrations.TelegramBotToken,
-					userIntegrations.TelegramBotChatID,
-					userIntegrations.TelegramBotTopicID,
-					userIntegrations.TelegramBotDisableWebPagePreview,
-					userIntegrations.TelegramBotDisableNotification,
-					userIntegrations.TelegramBotDisableButtons,
-				); err != nil {
-					slog.Error("Unable to send entry to Telegram",
-						slog.Int64("user_id", userIntegrations.UserID),
-						slog.Int64("entry_id", entry.ID),
-						slog.String("entry_url", entry.URL),
-						slog.Any("error", err),
-					)
-				}
+		switch field.Type {
+		case FieldTypeInt:
+			if number := settings.Values.Int64(field.Name); number != nil {
+				attrs = append(attrs, slog.Int64(field.Name, *number))
+			}
+		default:
+			if value := settings.Values.String(field.Name); value != "" {
+				attrs = append(attrs, slog.String(field.Name, value))
 			}
 		}
 	}
+	return attrs
 }
@@ -21,16 +21,14 @@ func TestSendEntryLogsLinkwardenCollectionID(t *testing.T) {
 	defer slog.SetDefault(prev)
 
 	entry := &model.Entry{ID: 52, URL: "https://example.org/test.html", Title: "Test"}
-	coll := int64(12345)
-	userIntegrations := &model.Integration{
-		UserID:                 1,
-		LinkwardenEnabled:      true,
-		LinkwardenCollectionID: &coll,
-		LinkwardenURL:          "",
-		LinkwardenAPIKey:       "",
-	}
+	userIntegrations := []*model.IntegrationSettings{{
+		UserID:   1,
+		Provider: "linkwarden",
+		Enabled:  true,
+		Values:   model.IntegrationValues{"collection_id": int64(12345)},
+	}}
 
-	SendEntry(entry, userIntegrations)
+	saveEntry(nil, ProviderByName("linkwarden"), userIntegrations[0], entry)
 
 	out := buf.String()
 	if !strings.Contains(out, `"collection_id":12345`) {
@@ -47,14 +45,14 @@ func TestSendEntryLogsLinkwardenWithoutCollectionID(t *testing.T) {
 	defer slog.SetDefault(prev)
 
 	entry := &model.Entry{ID: 52, URL: "https://example.org/test.html", Title: "Test"}
-	userIntegrations := &model.Integration{
-		UserID:            1,
-		LinkwardenEnabled: true,
-		LinkwardenURL:     "",
-		LinkwardenAPIKey:  "",
-	}
-
-	SendEntry(entry, userIntegrations)
+	userIntegrations := []*model.IntegrationSettings{{
+		UserID:   1,
+		Provider: "linkwarden",
+		Enabled:  true,
+		Values:   model.IntegrationValues{},
+	}}
+
+	saveEntry(nil, ProviderByName("linkwarden"), userIntegrations[0], entry)
 
 	out := buf.String()
 	if strings.Contains(out, "collection_id") {
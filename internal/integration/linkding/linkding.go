@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -77,6 +78,115 @@ func (c *Client) CreateBookmark(entryURL, entryTitle string) error {
 	return nil
 }
 
+// Bookmark is a bookmark returned by the API.
+type Bookmark struct {
+	ID           int64     `json:"id"`
+	URL          string    `json:"url"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	WebsiteTitle string    `json:"website_title"`
+	IsArchived   bool      `json:"is_archived"`
+	TagNames     []string  `json:"tag_names"`
+	DateAdded    time.Time `json:"date_added"`
+	DateModified time.Time `json:"date_modified"`
+}
+
+// Bookmarks returns at most limit bookmarks modified since the given date, all of them when the date is zero.
+// There is no limit when it is zero.
+// The archived bookmarks are listed separately.
+// https://linkding.link/api/#bookmarks
+func (c *Client) Bookmarks(archived bool, modifiedSince time.Time, limit int) ([]*Bookmark, error) {
+	if c.baseURL == "" || c.apiKey == "" {
+		return nil, errors.New("linkding: missing base URL or API key")
+	}
+
+	path := "/api/bookmarks/"
+	if archived {
+		path = "/api/bookmarks/archived/"
+	}
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, path)
+	if err != nil {
+		return nil, fmt.Errorf(`linkding: invalid API endpoint: %v`, err)
+	}
+
+	query := url.Values{}
+	query.Set("limit", "100")
+	if !modifiedSince.IsZero() {
+		query.Set("modified_since", modifiedSince.UTC().Format(time.RFC3339))
+	}
+	apiEndpoint += "?" + query.Encode()
+
+	var bookmarks []*Bookmark
+	for apiEndpoint != "" && (limit <= 0 || len(bookmarks) < limit) {
+		var page bookmarkList
+		if err := c.doRequest(http.MethodGet, apiEndpoint, &page); err != nil {
+			return nil, err
+		}
+		if len(page.Results) == 0 {
+			break
+		}
+		bookmarks = append(bookmarks, page.Results...)
+		apiEndpoint = page.Next
+	}
+
+	if limit > 0 && len(bookmarks) > limit {
+		bookmarks = bookmarks[:limit]
+	}
+	return bookmarks, nil
+}
+
+// SetArchived archives or unarchives a bookmark.
+func (c *Client) SetArchived(bookmarkID string, archived bool) error {
+	if c.baseURL == "" || c.apiKey == "" {
+		return errors.New("linkding: missing base URL or API key")
+	}
+
+	action := "unarchive"
+	if archived {
+		action = "archive"
+	}
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/api/bookmarks/"+url.PathEscape(bookmarkID)+"/"+action+"/")
+	if err != nil {
+		return fmt.Errorf(`linkding: invalid API endpoint: %v`, err)
+	}
+
+	return c.doRequest(http.MethodPost, apiEndpoint, nil)
+}
+
+func (c *Client) doRequest(method, apiEndpoint string, result any) error {
+	request, err := http.NewRequest(method, apiEndpoint, nil)
+	if err != nil {
+		return fmt.Errorf("linkding: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "Token "+c.apiKey)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("linkding: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("linkding: unexpected response: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+			return fmt.Errorf("linkding: unable to decode response: %v", err)
+		}
+	}
+	return nil
+}
+
+type bookmarkList struct {
+	Next    string      `json:"next"`
+	Results []*Bookmark `json:"results"`
+}
+
 type linkdingBookmark struct {
 	Url      string   `json:"url,omitempty"`
 	Title    string   `json:"title,omitempty"`
@@ -5,10 +5,12 @@ package matrixbot // import "miniflux.app/v2/internal/integration/matrixbot"
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"miniflux.app/v2/internal/crypto"
@@ -157,6 +159,96 @@ func (c *Client) SendFormattedTextMessage(homeServerURL, accessToken, roomID, te
 	return &eventResponse, nil
 }
 
+// Sync waits at most timeout for the messages of the room following the since token, empty for the first sync.
+// Specs https://spec.matrix.org/v1.8/client-server-api/#get_matrixclientv3sync
+func (c *Client) Sync(ctx context.Context, homeServerURL, accessToken, roomID, since string, timeout time.Duration) (*SyncResponse, error) {
+	endpointURL, err := url.JoinPath(homeServerURL, "/_matrix/client/v3/sync")
+	if err != nil {
+		return nil, fmt.Errorf("matrix: unable to join base URL and path: %w", err)
+	}
+
+	filter, err := json.Marshal(map[string]any{
+		"account_data": map[string]any{"types": []string{}},
+		"presence":     map[string]any{"types": []string{}},
+		"room": map[string]any{
+			"rooms":        []string{roomID},
+			"account_data": map[string]any{"types": []string{}},
+			"ephemeral":    map[string]any{"types": []string{}},
+			"state":        map[string]any{"types": []string{}},
+			"timeline":     map[string]any{"types": []string{"m.room.message"}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("matrix: unable to encode sync filter: %v", err)
+	}
+
+	query := url.Values{}
+	query.Set("filter", string(filter))
+	query.Set("timeout", strconv.FormatInt(timeout.Milliseconds(), 10))
+	if since != "" {
+		query.Set("since", since)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("matrix: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	httpClient := &http.Client{Timeout: timeout + defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("matrix: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("matrix: unexpected response from %s status code is %d", endpointURL, response.StatusCode)
+	}
+
+	var syncResponse SyncResponse
+	if err := json.NewDecoder(response.Body).Decode(&syncResponse); err != nil {
+		return nil, fmt.Errorf("matrix: unable to decode sync response: %w", err)
+	}
+
+	return &syncResponse, nil
+}
+
+// Logout invalidates the access token, the device created by the login is removed.
+// Specs https://spec.matrix.org/v1.8/client-server-api/#post_matrixclientv3logout
+func (c *Client) Logout(homeServerURL, accessToken string) error {
+	endpointURL, err := url.JoinPath(homeServerURL, "/_matrix/client/v3/logout")
+	if err != nil {
+		return fmt.Errorf("matrix: unable to join base URL and path: %w", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, endpointURL, bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return fmt.Errorf("matrix: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("matrix: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("matrix: unexpected response from %s status code is %d", endpointURL, response.StatusCode)
+	}
+
+	return nil
+}
+
 type HomeServerInformation struct {
 	BaseURL string `json:"base_url"`
 }
@@ -198,3 +290,26 @@ type TextMessageEventRequest struct {
 type RoomEventResponse struct {
 	EventID string `json:"event_id"`
 }
+
+type SyncResponse struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]JoinedRoom `json:"join"`
+	} `json:"rooms"`
+}
+
+type JoinedRoom struct {
+	Timeline struct {
+		Events []*RoomEvent `json:"events"`
+	} `json:"timeline"`
+}
+
+type RoomEvent struct {
+	Type    string `json:"type"`
+	EventID string `json:"event_id"`
+	Sender  string `json:"sender"`
+	Content struct {
+		MsgType string `json:"msgtype"`
+		Body    string `json:"body"`
+	} `json:"content"`
+}
@@ -10,8 +10,9 @@ import (
 	"miniflux.app/v2/internal/model"
 )
 
-// PushEntries pushes entries to matrix chat using integration settings provided
-func PushEntries(feed *model.Feed, entries model.Entries, matrixBaseURL, matrixUsername, matrixPassword, matrixRoomID string) error {
+// PushEntries pushes entries to matrix chat using integration settings provided,
+// the entry IDs are shown when the bot accepts commands.
+func PushEntries(feed *model.Feed, entries model.Entries, matrixBaseURL, matrixUsername, matrixPassword, matrixRoomID string, withEntryIDs bool) error {
 	client := NewClient(matrixBaseURL)
 	discovery, err := client.DiscoverEndpoints()
 	if err != nil {
@@ -27,8 +28,12 @@ func PushEntries(feed *model.Feed, entries model.Entries, matrixBaseURL, matrixU
 	formattedTextMessages := make([]string, 0, len(entries))
 
 	for _, entry := range entries {
-		textMessages = append(textMessages, fmt.Sprintf(`[%s] %s - %s`, feed.Title, entry.Title, entry.URL))
-		formattedTextMessages = append(formattedTextMessages, fmt.Sprintf(`<li><strong>%s</strong>: <a href=%q>%s</a></li>`, feed.Title, entry.URL, entry.Title))
+		var entryID string
+		if withEntryIDs {
+			entryID = fmt.Sprintf(" (#%d)", entry.ID)
+		}
+		textMessages = append(textMessages, fmt.Sprintf(`[%s] %s - %s%s`, feed.Title, entry.Title, entry.URL, entryID))
+		formattedTextMessages = append(formattedTextMessages, fmt.Sprintf(`<li><strong>%s</strong>: <a href=%q>%s</a>%s</li>`, feed.Title, entry.URL, entry.Title, entryID))
 	}
 
 	_, err = client.SendFormattedTextMessage(
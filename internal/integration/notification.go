@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/validator"
+)
+
+// Settings shared by the providers sending notifications.
+const (
+	quietHoursStartField = "quiet_hours_start"
+	quietHoursEndField   = "quiet_hours_end"
+	rateLimitField       = "rate_limit"
+)
+
+// rateLimitPeriod is the period of the rate limit of the notifications.
+const rateLimitPeriod = time.Hour
+
+// errNotificationRateLimited is the error of the deliveries above the rate limit, they can be sent again from the deliveries page.
+var errNotificationRateLimited = errors.New("the rate limit of the notifications is exceeded")
+
+// Notifier is implemented by the providers sending the new entries as notifications.
+// The notification routes of the user choose the entries sent to them, their target and their priority.
+type Notifier interface {
+	// Notify sends the new entries of a feed, the empty target of the notification is the one of the settings.
+	Notify(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error
+}
+
+// Notification is the target and the priority of the entries sent by a notification route.
+type Notification struct {
+	Target   string
+	Priority int
+}
+
+// NotifierProviders returns the providers sending notifications sorted by title.
+func NotifierProviders() []Provider {
+	var providers []Provider
+	for _, provider := range Providers() {
+		if _, ok := provider.(Notifier); ok {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// ValidateNotificationRoute checks a notification route before it is saved.
+func ValidateNotificationRoute(store *storage.Storage, route *model.NotificationRoute) *locale.LocalizedError {
+	if _, ok := ProviderByName(route.Provider).(Notifier); !ok {
+		return locale.NewLocalizedError("error.notification_route_invalid_provider")
+	}
+	return validator.ValidateNotificationRoute(store, route)
+}
+
+// routedEntries are the entries sent by a notification route, the route is nil when the provider receives all the entries.
+type routedEntries struct {
+	route   *model.NotificationRoute
+	entries model.Entries
+}
+
+func (r *routedEntries) notification() *Notification {
+	if r.route == nil {
+		return &Notification{}
+	}
+	return &Notification{Target: r.route.Target, Priority: r.route.Priority}
+}
+
+// notifyEntries records a delivery for each route of the provider matching the new entries.
+// The deliveries are postponed during the quiet hours and dead-lettered above the rate limit.
+func notifyEntries(store *storage.Storage, provider Provider, notifier Notifier, settings *model.IntegrationSettings, routes model.NotificationRoutes, feed *model.Feed, entries model.Entries) {
+	for _, routed := range routeEntries(provider, routes, feed, entries) {
+		entryIDs := make([]int64, len(routed.entries))
+		for i, entry := range routed.entries {
+			entryIDs[i] = entry.ID
+		}
+
+		delivery := &model.IntegrationDelivery{
+			UserID:        settings.UserID,
+			Provider:      settings.Provider,
+			Kind:          model.DeliveryKindPushEntries,
+			FeedID:        feed.ID,
+			EntryIDs:      entryIDs,
+			NextAttemptAt: time.Now().Add(deliveryClaimDuration),
+		}
+		if routed.route != nil {
+			delivery.RouteID = routed.route.ID
+		}
+
+		notification := routed.notification()
+		if quietHoursEnd, ok := notificationQuietHoursEnd(store, settings, notification.Priority); ok {
+			// The retry scheduler sends the delivery at the end of the quiet hours.
+			delivery.NextAttemptAt = quietHoursEnd
+			createDelivery(store, settings, delivery)
+			continue
+		}
+
+		createDelivery(store, settings, delivery)
+		if notificationRateLimited(store, settings) {
+			delivery.Status = model.DeliveryStatusDead
+			delivery.LastError = errNotificationRateLimited.Error()
+			slog.Debug("Notifications rate limited", logAttributes(provider, settings, slog.Int64("feed_id", feed.ID))...)
+			if delivery.ID > 0 {
+				if err := store.UpdateIntegrationDelivery(delivery); err != nil {
+					slog.Error("Unable to update integration delivery", slog.Int64("delivery_id", delivery.ID), slog.Any("error", err))
+				}
+			}
+			continue
+		}
+
+		go attemptDelivery(store, delivery, func() error {
+			return notify(store, provider, notifier, settings, feed, routed.entries, notification)
+		})
+	}
+}
+
+func notify(store *storage.Storage, provider Provider, notifier Notifier, settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error {
+	settings, err := authorizeSettings(store, provider, settings)
+	if err != nil {
+		return err
+	}
+
+	attrs := logAttributes(provider, settings,
+		slog.Int("nb_entries", len(entries)),
+		slog.Int64("feed_id", feed.ID),
+		slog.Int("priority", notification.Priority),
+	)
+	slog.Debug("Sending notifications to "+provider.ConfigSchema().Title, attrs...)
+
+	if err := notifier.Notify(settings, feed, entries, notification); err != nil {
+		slog.Warn("Unable to send notifications to "+provider.ConfigSchema().Title, append(attrs, slog.Any("error", err))...)
+		return err
+	}
+	return nil
+}
+
+// deliveryNotification returns the notification of the route of a delivery, the settings are used when the route was removed.
+func deliveryNotification(store *storage.Storage, delivery *model.IntegrationDelivery) (*Notification, error) {
+	if delivery.RouteID == 0 {
+		return &Notification{}, nil
+	}
+
+	route, err := store.NotificationRoute(delivery.UserID, delivery.RouteID)
+	if err != nil {
+		return nil, err
+	}
+	return (&routedEntries{route: route}).notification(), nil
+}
+
+// routeEntries groups the entries by the first route of the provider matching them, the routes are sorted from the most specific.
+// The provider receives all the entries when the user has no route for it, unless it requires a route.
+func routeEntries(provider Provider, routes model.NotificationRoutes, feed *model.Feed, entries model.Entries) []*routedEntries {
+	var providerRoutes model.NotificationRoutes
+	for _, route := range routes {
+		if route.Provider == provider.Name() {
+			providerRoutes = append(providerRoutes, route)
+		}
+	}
+
+	if len(providerRoutes) == 0 {
+		if provider.ConfigSchema().RequiresNotificationRoute {
+			return nil
+		}
+		return []*routedEntries{{entries: entries}}
+	}
+
+	var groups []*routedEntries
+	for _, entry := range entries {
+		for _, route := range providerRoutes {
+			if !routeMatches(route, feed, entry) {
+				continue
+			}
+
+			var group *routedEntries
+			for _, existing := range groups {
+				if existing.route == route {
+					group = existing
+					break
+				}
+			}
+			if group == nil {
+				group = &routedEntries{route: route}
+				groups = append(groups, group)
+			}
+			group.entries = append(group.entries, entry)
+			break
+		}
+	}
+	return groups
+}
+
+func routeMatches(route *model.NotificationRoute, feed *model.Feed, entry *model.Entry) bool {
+	if route.FeedID != 0 && route.FeedID != feed.ID {
+		return false
+	}
+	if route.CategoryID != 0 && (feed.Category == nil || feed.Category.ID != route.CategoryID) {
+		return false
+	}
+	return route.FilterRules == "" || filter.MatchesRules(route.FilterRules, feed, entry)
+}
+
+// notificationQuietHoursEnd returns the end of the current quiet hours of the provider, the notifications
+// of the highest priority are never postponed.
+func notificationQuietHoursEnd(store *storage.Storage, settings *model.IntegrationSettings, priority int) (time.Time, bool) {
+	start := settings.Values.Int64(quietHoursStartField)
+	end := settings.Values.Int64(quietHoursEndField)
+	if start == nil || end == nil || *start == *end || priority >= model.NotificationPriorityMax {
+		return time.Time{}, false
+	}
+
+	user, err := store.UserByID(settings.UserID)
+	if err != nil || user == nil {
+		slog.Error("Unable to fetch the user of the notifications",
+			slog.Int64("user_id", settings.UserID),
+			slog.String("provider", settings.Provider),
+			slog.Any("error", err),
+		)
+		return time.Time{}, false
+	}
+
+	return quietHoursEnd(timezone.Now(user.Timezone), int(*start), int(*end))
+}
+
+// quietHoursEnd returns the end of the quiet hours if now is between the start hour and the end hour, the quiet hours may span midnight.
+func quietHoursEnd(now time.Time, start, end int) (time.Time, bool) {
+	hour := now.Hour()
+	if start < end && (hour < start || hour >= end) {
+		return time.Time{}, false
+	}
+	if start > end && hour < start && hour >= end {
+		return time.Time{}, false
+	}
+
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), end, 0, 0, 0, now.Location())
+	if !endDate.After(now) {
+		endDate = endDate.AddDate(0, 0, 1)
+	}
+	return endDate, true
+}
+
+// notificationRateLimited returns true if the provider sent as many entries as its rate limit during the last period.
+func notificationRateLimited(store *storage.Storage, settings *model.IntegrationSettings) bool {
+	limit := settings.Values.Int64(rateLimitField)
+	if limit == nil || *limit <= 0 {
+		return false
+	}
+
+	count, err := store.CountNotifiedEntries(settings.UserID, settings.Provider, time.Now().Add(-rateLimitPeriod))
+	if err != nil {
+		slog.Error("Unable to count the notifications", slog.Int64("user_id", settings.UserID), slog.Any("error", err))
+		return false
+	}
+	return int64(count) >= *limit
+}
+
+func notificationConfigFields() []ConfigField {
+	return []ConfigField{
+		{Name: quietHoursStartField, Type: FieldTypeInt, Label: "form.integration.quiet_hours_start", Placeholder: "22", Hint: "form.integration.quiet_hours_help"},
+		{Name: quietHoursEndField, Type: FieldTypeInt, Label: "form.integration.quiet_hours_end", Placeholder: "7"},
+		{Name: rateLimitField, Type: FieldTypeInt, Label: "form.integration.rate_limit", Hint: "form.integration.rate_limit_help"},
+	}
+}
+
+func validateNotificationSettings(settings *model.IntegrationSettings) *locale.LocalizedError {
+	for _, name := range []string{quietHoursStartField, quietHoursEndField} {
+		if hour := settings.Values.Int64(name); hour != nil && (*hour < 0 || *hour > 23) {
+			return locale.NewLocalizedError("error.notification_invalid_quiet_hours")
+		}
+	}
+
+	if limit := settings.Values.Int64(rateLimitField); limit != nil && *limit < 0 {
+		return locale.NewLocalizedError("error.notification_invalid_rate_limit")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestRouteEntries(t *testing.T) {
+	feed := &model.Feed{ID: 1, Category: &model.Category{ID: 10}}
+	golang := &model.Entry{ID: 1, Title: "Golang release"}
+	rust := &model.Entry{ID: 2, Title: "Rust release"}
+	entries := model.Entries{golang, rust}
+
+	feedRoute := &model.NotificationRoute{ID: 1, Provider: "ntfy", FeedID: 1, FilterRules: "EntryTitle=(?i)golang"}
+	categoryRoute := &model.NotificationRoute{ID: 2, Provider: "ntfy", CategoryID: 10, Target: "news"}
+	otherCategoryRoute := &model.NotificationRoute{ID: 3, Provider: "ntfy", CategoryID: 20}
+	routes := model.NotificationRoutes{feedRoute, categoryRoute, otherCategoryRoute}
+
+	groups := routeEntries(ProviderByName("ntfy"), routes, feed, entries)
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].route != feedRoute || len(groups[0].entries) != 1 || groups[0].entries[0] != golang {
+		t.Errorf("Expected the Golang entry to be sent by the feed route, got %+v", groups[0])
+	}
+	if groups[1].route != categoryRoute || len(groups[1].entries) != 1 || groups[1].entries[0] != rust {
+		t.Errorf("Expected the Rust entry to be sent by the category route, got %+v", groups[1])
+	}
+	if notification := groups[1].notification(); notification.Target != "news" {
+		t.Errorf("Expected the target of the route, got %q", notification.Target)
+	}
+
+	if groups := routeEntries(ProviderByName("ntfy"), model.NotificationRoutes{otherCategoryRoute}, feed, entries); len(groups) != 0 {
+		t.Errorf("Expected no entries for the routes of another category, got %d groups", len(groups))
+	}
+}
+
+func TestRouteEntriesWithoutRoutes(t *testing.T) {
+	feed := &model.Feed{ID: 1, Category: &model.Category{ID: 10}}
+	entries := model.Entries{{ID: 1}, {ID: 2}}
+	routes := model.NotificationRoutes{{ID: 1, Provider: "ntfy"}}
+
+	groups := routeEntries(ProviderByName("slack"), routes, feed, entries)
+	if len(groups) != 1 || groups[0].route != nil || len(groups[0].entries) != 2 {
+		t.Errorf("Expected all the entries to be sent to Slack without routes, got %+v", groups)
+	}
+
+	if groups := routeEntries(ProviderByName("pushover"), routes, feed, entries); len(groups) != 0 {
+		t.Errorf("Expected Pushover to require a route, got %d groups", len(groups))
+	}
+}
+
+func TestQuietHoursEnd(t *testing.T) {
+	day := func(hour int) time.Time {
+		return time.Date(2024, 3, 10, hour, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		now        time.Time
+		start, end int
+		expected   time.Time
+	}{
+		{day(23), 22, 7, time.Date(2024, 3, 11, 7, 0, 0, 0, time.UTC)},
+		{day(3), 22, 7, time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC)},
+		{day(12), 22, 7, time.Time{}},
+		{day(7), 22, 7, time.Time{}},
+		{day(13), 12, 14, time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)},
+		{day(14), 12, 14, time.Time{}},
+		{day(11), 12, 14, time.Time{}},
+	}
+	for _, test := range tests {
+		end, ok := quietHoursEnd(test.now, test.start, test.end)
+		if ok != !test.expected.IsZero() || !end.Equal(test.expected) {
+			t.Errorf("Expected %v for %v between %d and %d, got %v", test.expected, test.now, test.start, test.end, end)
+		}
+	}
+}
+
+func TestValidateNotificationSettings(t *testing.T) {
+	tests := []struct {
+		values model.IntegrationValues
+		valid  bool
+	}{
+		{model.IntegrationValues{}, true},
+		{model.IntegrationValues{quietHoursStartField: int64(22), quietHoursEndField: int64(7), rateLimitField: int64(10)}, true},
+		{model.IntegrationValues{quietHoursStartField: int64(24)}, false},
+		{model.IntegrationValues{quietHoursEndField: int64(-1)}, false},
+		{model.IntegrationValues{rateLimitField: int64(-5)}, false},
+	}
+	for _, test := range tests {
+		err := validateNotificationSettings(&model.IntegrationSettings{Values: test.values})
+		if (err == nil) != test.valid {
+			t.Errorf("Unexpected validation result for %v: %v", test.values, err)
+		}
+	}
+}
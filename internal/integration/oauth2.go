@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+const (
+	// oauth2TokenField is the setting replaced by the access token once the user is connected.
+	oauth2TokenField = "token"
+
+	oauth2ClientTimeout = 10 * time.Second
+)
+
+// OAuth2Provider is implemented by the providers the users connect with the OAuth2 authorization code flow,
+// instead of copying an API token in the settings.
+type OAuth2Provider interface {
+	// OAuth2Config returns the client of the provider, nil when the server has no client for it.
+	OAuth2Config(redirectURL string) *oauth2.Config
+
+	// OAuth2AuthCodeOptions returns the parameters of the authorization URL specific to the provider.
+	OAuth2AuthCodeOptions() []oauth2.AuthCodeOption
+}
+
+// OAuth2Config returns the client of a provider, nil when the provider cannot be connected with OAuth2.
+func OAuth2Config(providerName, redirectURL string) *oauth2.Config {
+	provider, ok := ProviderByName(providerName).(OAuth2Provider)
+	if !ok {
+		return nil
+	}
+	return provider.OAuth2Config(redirectURL)
+}
+
+// OAuth2AuthCodeOptions returns the parameters of the authorization URL of a provider.
+func OAuth2AuthCodeOptions(providerName string) []oauth2.AuthCodeOption {
+	provider, ok := ProviderByName(providerName).(OAuth2Provider)
+	if !ok {
+		return nil
+	}
+	return provider.OAuth2AuthCodeOptions()
+}
+
+// ConnectOAuth2 exchanges the authorization code received on the callback and stores the token of the user.
+func ConnectOAuth2(ctx context.Context, store *storage.Storage, userID int64, providerName, redirectURL, code, codeVerifier string) error {
+	cfg := OAuth2Config(providerName, redirectURL)
+	if cfg == nil {
+		return fmt.Errorf("integration: the provider %q cannot be connected with OAuth2", providerName)
+	}
+
+	token, err := cfg.Exchange(oauth2Context(ctx), code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return fmt.Errorf("integration: unable to exchange the authorization code of %q: %w", providerName, err)
+	}
+
+	return store.SaveIntegrationToken(newIntegrationToken(userID, providerName, token))
+}
+
+// authorizeSettings returns the settings with the access token of the connected providers,
+// the token is refreshed and stored again when it has expired.
+func authorizeSettings(store *storage.Storage, provider Provider, settings *model.IntegrationSettings) (*model.IntegrationSettings, error) {
+	oauth2Provider, ok := provider.(OAuth2Provider)
+	if !ok || store == nil {
+		return settings, nil
+	}
+
+	storedToken, err := store.IntegrationToken(settings.UserID, provider.Name())
+	if err != nil {
+		return nil, err
+	}
+	if storedToken == nil {
+		return settings, nil
+	}
+
+	// The token saved with another encryption key is kept, it is neither used nor refreshed.
+	if storedToken.Unreadable {
+		return nil, fmt.Errorf("integration: the OAuth2 token of %q cannot be decrypted, connect the integration again", provider.Name())
+	}
+
+	token, err := refreshToken(context.Background(), oauth2Provider.OAuth2Config(""), storedToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.AccessToken != storedToken.AccessToken {
+		slog.Debug("Refreshed integration OAuth2 token",
+			slog.Int64("user_id", settings.UserID),
+			slog.String("provider", provider.Name()),
+		)
+		if err := store.SaveIntegrationToken(token); err != nil {
+			return nil, err
+		}
+	}
+
+	return withAccessToken(settings, token.AccessToken), nil
+}
+
+// refreshToken returns the stored token, or a new one requested with the refresh token when it has expired.
+// The token is used as is when the server has no client for the provider anymore.
+func refreshToken(ctx context.Context, cfg *oauth2.Config, storedToken *model.IntegrationToken) (*model.IntegrationToken, error) {
+	if cfg == nil {
+		return storedToken, nil
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  storedToken.AccessToken,
+		RefreshToken: storedToken.RefreshToken,
+		TokenType:    storedToken.TokenType,
+	}
+	if storedToken.ExpiresAt != nil {
+		token.Expiry = *storedToken.ExpiresAt
+	}
+
+	token, err := cfg.TokenSource(oauth2Context(ctx), token).Token()
+	if err != nil {
+		return nil, fmt.Errorf("integration: unable to refresh the OAuth2 token of %q: %w", storedToken.Provider, err)
+	}
+
+	return newIntegrationToken(storedToken.UserID, storedToken.Provider, token), nil
+}
+
+// withAccessToken returns a copy of the settings, the stored settings are shared by the deliveries of the user.
+func withAccessToken(settings *model.IntegrationSettings, accessToken string) *model.IntegrationSettings {
+	authorized := *settings
+	authorized.Values = maps.Clone(settings.Values)
+	if authorized.Values == nil {
+		authorized.Values = make(model.IntegrationValues)
+	}
+	authorized.Values[oauth2TokenField] = accessToken
+	return &authorized
+}
+
+func newIntegrationToken(userID int64, providerName string, token *oauth2.Token) *model.IntegrationToken {
+	integrationToken := &model.IntegrationToken{
+		UserID:       userID,
+		Provider:     providerName,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+	}
+	if !token.Expiry.IsZero() {
+		integrationToken.ExpiresAt = &token.Expiry
+	}
+	return integrationToken
+}
+
+func oauth2Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Timeout: oauth2ClientTimeout})
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestRefreshToken(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "refresh" {
+			t.Errorf("Unexpected refresh request: %v", r.PostForm)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"new","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	cfg := &oauth2.Config{ClientID: "client", Endpoint: oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams}}
+
+	future := time.Now().Add(time.Hour)
+	valid := &model.IntegrationToken{UserID: 1, Provider: "raindrop", AccessToken: "old", RefreshToken: "refresh", ExpiresAt: &future}
+	token, err := refreshToken(context.Background(), cfg, valid)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token.AccessToken != "old" || requests != 0 {
+		t.Errorf("A valid token must not be refreshed, got %q after %d requests", token.AccessToken, requests)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	expired := &model.IntegrationToken{UserID: 1, Provider: "raindrop", AccessToken: "old", RefreshToken: "refresh", ExpiresAt: &past}
+	token, err = refreshToken(context.Background(), cfg, expired)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token.AccessToken != "new" || requests != 1 {
+		t.Errorf("An expired token must be refreshed, got %q after %d requests", token.AccessToken, requests)
+	}
+	if token.RefreshToken != "refresh" {
+		t.Errorf("The refresh token must be kept when the provider does not return a new one, got %q", token.RefreshToken)
+	}
+	if token.ExpiresAt == nil || token.ExpiresAt.Before(time.Now()) || token.UserID != 1 || token.Provider != "raindrop" {
+		t.Errorf("Unexpected refreshed token: %+v", token)
+	}
+
+	expired.RefreshToken = ""
+	if _, err := refreshToken(context.Background(), cfg, expired); err == nil {
+		t.Error("Expected an error for an expired token without refresh token")
+	}
+
+	// The token is used as is when the server has no client anymore.
+	if token, err := refreshToken(context.Background(), nil, expired); err != nil || token != expired {
+		t.Errorf("Unexpected result without client: %v, %v", token, err)
+	}
+}
+
+func TestWithAccessToken(t *testing.T) {
+	settings := &model.IntegrationSettings{UserID: 1, Provider: "notion", Enabled: true, Values: model.IntegrationValues{"page_id": "abc"}}
+	authorized := withAccessToken(settings, "secret")
+
+	if authorized.Values.String("token") != "secret" || authorized.Values.String("page_id") != "abc" || !authorized.Enabled {
+		t.Errorf("Unexpected authorized settings: %+v", authorized)
+	}
+	if _, found := settings.Values["token"]; found {
+		t.Error("The stored settings must not be modified")
+	}
+
+	if authorized := withAccessToken(&model.IntegrationSettings{}, "secret"); authorized.Values.String("token") != "secret" {
+		t.Errorf("Unexpected authorized settings without values: %+v", authorized)
+	}
+}
+
+func TestAuthorizeSettingsWithoutOAuth2(t *testing.T) {
+	settings := &model.IntegrationSettings{UserID: 1, Provider: "linkwarden", Enabled: true}
+	authorized, err := authorizeSettings(nil, ProviderByName("linkwarden"), settings)
+	if err != nil || authorized != settings {
+		t.Errorf("The settings of the other providers must be returned as is, got %v, %v", authorized, err)
+	}
+}
+
+func TestOAuth2Config(t *testing.T) {
+	t.Setenv("RAINDROP_OAUTH2_CLIENT_ID", "client")
+	t.Setenv("RAINDROP_OAUTH2_CLIENT_SECRET", "secret")
+	parseConfig(t)
+
+	cfg := OAuth2Config("raindrop", "https://miniflux.example.org/integrations/raindrop/callback")
+	if cfg == nil || cfg.ClientID != "client" || cfg.ClientSecret != "secret" || cfg.RedirectURL != "https://miniflux.example.org/integrations/raindrop/callback" {
+		t.Errorf("Unexpected Raindrop client: %+v", cfg)
+	}
+
+	if cfg := OAuth2Config("notion", ""); cfg != nil {
+		t.Errorf("The providers without client must not be connectable, got %+v", cfg)
+	}
+	if cfg := OAuth2Config("linkwarden", ""); cfg != nil {
+		t.Errorf("The providers without OAuth2 must not be connectable, got %+v", cfg)
+	}
+}
@@ -0,0 +1,341 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+const (
+	// Deliveries in progress are skipped by the retry scheduler during this delay,
+	// the attempt sets the actual date of the next one.
+	deliveryClaimDuration = 15 * time.Minute
+
+	deliveryMinRetryDelay = time.Minute
+	deliveryMaxRetryDelay = 6 * time.Hour
+)
+
+var (
+	errDeliveryProviderDisabled = errors.New("the integration is disabled")
+	errDeliveryEntriesRemoved   = errors.New("the entries do not exist anymore")
+)
+
+// RetryDeliveries sends again a batch of pending deliveries due for a new attempt.
+func RetryDeliveries(store *storage.Storage, batchSize int) {
+	deliveries, err := store.ClaimIntegrationDeliveries(batchSize, deliveryClaimDuration)
+	if err != nil {
+		slog.Error("Unable to fetch integration deliveries", slog.Any("error", err))
+		return
+	}
+
+	if len(deliveries) > 0 {
+		slog.Debug("Retrying integration deliveries", slog.Int("nb_deliveries", len(deliveries)))
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			retryDelivery(store, delivery)
+		}()
+	}
+	wg.Wait()
+}
+
+// Redeliver resets the attempts of a delivery and sends it again in the background.
+func Redeliver(store *storage.Storage, delivery *model.IntegrationDelivery) error {
+	delivery.Status = model.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.LastError = ""
+	delivery.NextAttemptAt = time.Now().Add(deliveryClaimDuration)
+	if err := store.UpdateIntegrationDelivery(delivery); err != nil {
+		return err
+	}
+
+	go retryDelivery(store, delivery)
+	return nil
+}
+
+func newDelivery(store *storage.Storage, settings *model.IntegrationSettings, kind string, feedID int64, entryIDs []int64) *model.IntegrationDelivery {
+	return createDelivery(store, settings, &model.IntegrationDelivery{
+		UserID:        settings.UserID,
+		Provider:      settings.Provider,
+		Kind:          kind,
+		FeedID:        feedID,
+		EntryIDs:      entryIDs,
+		NextAttemptAt: time.Now().Add(deliveryClaimDuration),
+	})
+}
+
+func createDelivery(store *storage.Storage, settings *model.IntegrationSettings, delivery *model.IntegrationDelivery) *model.IntegrationDelivery {
+	// The first attempt is made anyway, it is just not retried.
+	if err := store.CreateIntegrationDelivery(delivery); err != nil {
+		slog.Error("Unable to record integration delivery",
+			slog.Int64("user_id", settings.UserID),
+			slog.String("provider", settings.Provider),
+			slog.Any("error", err),
+		)
+	}
+	return delivery
+}
+
+func retryDelivery(store *storage.Storage, delivery *model.IntegrationDelivery) {
+	send, err := loadDelivery(store, delivery)
+	switch {
+	case errors.Is(err, errDeliveryProviderDisabled), errors.Is(err, errDeliveryEntriesRemoved), errors.Is(err, errNotificationRateLimited):
+		delivery.Status = model.DeliveryStatusDead
+		delivery.LastError = err.Error()
+		if err := store.UpdateIntegrationDelivery(delivery); err != nil {
+			slog.Error("Unable to update integration delivery", slog.Int64("delivery_id", delivery.ID), slog.Any("error", err))
+		}
+	case err != nil:
+		// The delivery is retried when the claim expires.
+		slog.Error("Unable to load integration delivery",
+			slog.Int64("user_id", delivery.UserID),
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Any("error", err),
+		)
+	default:
+		attemptDelivery(store, delivery, send)
+	}
+}
+
+// loadDelivery fetches the current settings and entries of a delivery.
+func loadDelivery(store *storage.Storage, delivery *model.IntegrationDelivery) (func() error, error) {
+	userIntegrations, err := store.IntegrationSettings(delivery.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var provider Provider
+	var settings *model.IntegrationSettings
+	for _, providerSettings := range userIntegrations {
+		if providerSettings.Provider == delivery.Provider {
+			settings = providerSettings
+			provider = enabledProvider(providerSettings)
+			break
+		}
+	}
+	if provider == nil {
+		return nil, errDeliveryProviderDisabled
+	}
+
+	switch delivery.Kind {
+	case model.DeliveryKindSaveEntry:
+		if !provider.ConfigSchema().SavesEntries || !receivesEvent(provider, settings, model.EventSaveEntry) || len(delivery.EntryIDs) == 0 {
+			return nil, errDeliveryProviderDisabled
+		}
+
+		builder := store.NewEntryQueryBuilder(delivery.UserID)
+		builder.WithEntryID(delivery.EntryIDs[0])
+		entry, err := builder.GetEntry()
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			return nil, errDeliveryEntriesRemoved
+		}
+
+		return func() error {
+			return saveEntry(store, provider, settings, entry)
+		}, nil
+	case model.DeliveryKindPushEntries:
+		if !provider.ConfigSchema().PushesEntries || !receivesEvent(provider, settings, model.EventNewEntries) {
+			return nil, errDeliveryProviderDisabled
+		}
+
+		// The deliveries postponed by the quiet hours are rate limited when they are sent.
+		if _, ok := provider.(Notifier); ok && notificationRateLimited(store, settings) {
+			return nil, errNotificationRateLimited
+		}
+
+		feed, err := store.FeedByID(delivery.UserID, delivery.FeedID)
+		if err != nil {
+			return nil, err
+		}
+		if feed == nil {
+			return nil, errDeliveryEntriesRemoved
+		}
+
+		builder := store.NewEntryQueryBuilder(delivery.UserID)
+		builder.WithFeedID(delivery.FeedID)
+		builder.WithEntryIDs(delivery.EntryIDs)
+		builder.WithSorting("id", "ASC")
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, errDeliveryEntriesRemoved
+		}
+
+		if notifier, ok := provider.(Notifier); ok {
+			notification, err := deliveryNotification(store, delivery)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				return notify(store, provider, notifier, settings, feed, entries, notification)
+			}, nil
+		}
+
+		return func() error {
+			return pushEntries(store, provider, settings, feed, entries)
+		}, nil
+	case model.DeliveryKindDigest:
+		sender, ok := provider.(DigestSender)
+		if !ok {
+			return nil, errDeliveryProviderDisabled
+		}
+
+		user, err := store.UserByID(delivery.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, errDeliveryEntriesRemoved
+		}
+
+		builder := store.NewEntryQueryBuilder(delivery.UserID)
+		builder.WithEntryIDs(delivery.EntryIDs)
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.WithSorting("published_at", "DESC")
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, errDeliveryEntriesRemoved
+		}
+
+		return func() error {
+			return sendDigest(store, provider, sender, settings, user, entries)
+		}, nil
+	case model.DeliveryKindBook:
+		sender, ok := provider.(BookSender)
+		if !ok {
+			return nil, errDeliveryProviderDisabled
+		}
+
+		user, err := store.UserByID(delivery.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, errDeliveryEntriesRemoved
+		}
+
+		builder := store.NewEntryQueryBuilder(delivery.UserID)
+		builder.WithEntryIDs(delivery.EntryIDs)
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.WithSorting("published_at", "DESC")
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, errDeliveryEntriesRemoved
+		}
+
+		return func() error {
+			return sendStarredBook(store, provider, sender, settings, user, entries)
+		}, nil
+	case model.DeliveryKindBookmarkStarred:
+		syncer, ok := bookmarkSyncer(provider, settings)
+		if !ok {
+			return nil, errDeliveryProviderDisabled
+		}
+
+		return func() error {
+			return sendBookmarksStarredState(store, provider, syncer, settings, delivery.EntryIDs)
+		}, nil
+	default:
+		return loadEventDelivery(store, delivery, provider, settings)
+	}
+}
+
+// loadEventDelivery fetches the feed and the entries of an event, the event type is the kind of the delivery.
+func loadEventDelivery(store *storage.Storage, delivery *model.IntegrationDelivery, provider Provider, settings *model.IntegrationSettings) (func() error, error) {
+	receiver, ok := provider.(EventReceiver)
+	if !ok || !receiver.ReceivesEvent(settings, delivery.Kind) {
+		return nil, errDeliveryProviderDisabled
+	}
+
+	feed, err := store.FeedByID(delivery.UserID, delivery.FeedID)
+	if err != nil {
+		return nil, err
+	}
+	if feed == nil {
+		return nil, errDeliveryEntriesRemoved
+	}
+
+	var entries model.Entries
+	if len(delivery.EntryIDs) > 0 {
+		builder := store.NewEntryQueryBuilder(delivery.UserID)
+		builder.WithEntryIDs(delivery.EntryIDs)
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.WithSorting("id", "ASC")
+		if entries, err = builder.GetEntries(); err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, errDeliveryEntriesRemoved
+		}
+	}
+
+	return func() error {
+		return sendEvent(store, eventReceiver{provider, receiver, settings}, delivery.Kind, feed, entries)
+	}, nil
+}
+
+// attemptDelivery sends the delivery and schedules the next attempt when it fails.
+func attemptDelivery(store *storage.Storage, delivery *model.IntegrationDelivery, send func() error) {
+	err := send()
+
+	delivery.Attempts++
+	switch {
+	case err == nil:
+		delivery.Status = model.DeliveryStatusDelivered
+		delivery.LastError = ""
+	case delivery.Attempts >= config.Opts.IntegrationDeliveryMaxAttempts():
+		delivery.Status = model.DeliveryStatusDead
+		delivery.LastError = err.Error()
+		slog.Warn("Integration delivery failed too many times, giving up",
+			slog.Int64("user_id", delivery.UserID),
+			slog.Int64("delivery_id", delivery.ID),
+			slog.String("provider", delivery.Provider),
+			slog.Int("attempts", delivery.Attempts),
+		)
+	default:
+		delivery.Status = model.DeliveryStatusPending
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = time.Now().Add(deliveryRetryDelay(delivery.Attempts))
+	}
+
+	// The delivery could not be recorded.
+	if delivery.ID == 0 {
+		return
+	}
+
+	if err := store.UpdateIntegrationDelivery(delivery); err != nil {
+		slog.Error("Unable to update integration delivery", slog.Int64("delivery_id", delivery.ID), slog.Any("error", err))
+	}
+}
+
+// deliveryRetryDelay doubles the delay after each failed attempt.
+func deliveryRetryDelay(attempts int) time.Duration {
+	delay := deliveryMinRetryDelay
+	for i := 1; i < attempts && delay < deliveryMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, deliveryMaxRetryDelay)
+}
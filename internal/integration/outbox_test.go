@@ -0,0 +1,318 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+func TestDeliveryRetryDelay(t *testing.T) {
+	scenarios := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, 6 * time.Hour},
+		{1000, 6 * time.Hour},
+	}
+
+	for _, scenario := range scenarios {
+		if delay := deliveryRetryDelay(scenario.attempts); delay != scenario.expected {
+			t.Errorf(`Unexpected delay after %d attempts, got %v instead of %v`, scenario.attempts, delay, scenario.expected)
+		}
+	}
+}
+
+// outboxTestDatabase is the state of a minimal database driver: the integration settings query returns the configured rows,
+// the other queries return no rows, and the statements are recorded.
+type outboxTestDatabase struct {
+	mutex           sync.Mutex
+	settings        [][]driver.Value
+	notifiedEntries int64
+	statements      []outboxTestStatement
+}
+
+type outboxTestStatement struct {
+	query string
+	args  []driver.Value
+}
+
+type outboxTestConn struct {
+	database *outboxTestDatabase
+}
+
+type outboxTestRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+var outboxTestDatabases sync.Map
+
+func init() {
+	sql.Register("outboxtest", &outboxTestDriver{})
+}
+
+type outboxTestDriver struct{}
+
+func (*outboxTestDriver) Open(name string) (driver.Conn, error) {
+	database, found := outboxTestDatabases.Load(name)
+	if !found {
+		return nil, fmt.Errorf("unknown test database %q", name)
+	}
+	return &outboxTestConn{database: database.(*outboxTestDatabase)}, nil
+}
+
+func (c *outboxTestConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *outboxTestConn) Close() error { return nil }
+
+func (c *outboxTestConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *outboxTestConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if strings.Contains(query, "integration_providers") {
+		c.database.mutex.Lock()
+		defer c.database.mutex.Unlock()
+		return &outboxTestRows{
+			columns: []string{"user_id", "provider", "enabled", "settings", "last_digest_at", "last_sync_at"},
+			values:  slices.Clone(c.database.settings),
+		}, nil
+	}
+	if strings.Contains(query, "cardinality(entry_ids)") {
+		c.database.mutex.Lock()
+		defer c.database.mutex.Unlock()
+		return &outboxTestRows{
+			columns: []string{"count"},
+			values:  [][]driver.Value{{c.database.notifiedEntries}},
+		}, nil
+	}
+	return &outboxTestRows{}, nil
+}
+
+func (c *outboxTestConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.database.mutex.Lock()
+	defer c.database.mutex.Unlock()
+
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.database.statements = append(c.database.statements, outboxTestStatement{query: query, args: values})
+	return driver.RowsAffected(1), nil
+}
+
+func (r *outboxTestRows) Columns() []string { return r.columns }
+
+func (r *outboxTestRows) Close() error { return nil }
+
+func (r *outboxTestRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+// newOutboxTestStore returns a store where the user 1 enabled the given providers.
+func newOutboxTestStore(t *testing.T, providers ...string) (*storage.Storage, *outboxTestDatabase) {
+	t.Helper()
+
+	database := &outboxTestDatabase{}
+	for _, provider := range providers {
+		database.settings = append(database.settings, []driver.Value{int64(1), provider, true, []byte(`{}`), nil, nil})
+	}
+	outboxTestDatabases.Store(t.Name(), database)
+	t.Cleanup(func() { outboxTestDatabases.Delete(t.Name()) })
+
+	db, err := sql.Open("outboxtest", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return storage.NewStorage(db), database
+}
+
+// deliveryUpdates returns the statuses saved by UpdateIntegrationDelivery.
+func (d *outboxTestDatabase) deliveryUpdates() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var statuses []string
+	for _, statement := range d.statements {
+		if strings.Contains(statement.query, "integration_deliveries") {
+			statuses = append(statuses, statement.args[0].(string))
+		}
+	}
+	return statuses
+}
+
+func TestAttemptDeliveryDelivered(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	store, database := newOutboxTestStore(t)
+	delivery := &model.IntegrationDelivery{ID: 1, UserID: 1, Status: model.DeliveryStatusPending, LastError: "timeout", Attempts: 2}
+
+	attemptDelivery(store, delivery, func() error { return nil })
+
+	if delivery.Status != model.DeliveryStatusDelivered || delivery.Attempts != 3 || delivery.LastError != "" {
+		t.Fatalf(`Unexpected delivery: %+v`, delivery)
+	}
+
+	if updates := database.deliveryUpdates(); !slices.Equal(updates, []string{model.DeliveryStatusDelivered}) {
+		t.Errorf(`Unexpected delivery updates: %v`, updates)
+	}
+}
+
+func TestAttemptDeliveryRetriesUntilMaxAttempts(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("INTEGRATION_DELIVERY_MAX_ATTEMPTS", "2")
+	parseConfig(t)
+
+	store, database := newOutboxTestStore(t)
+	delivery := &model.IntegrationDelivery{ID: 1, UserID: 1, Status: model.DeliveryStatusPending}
+	send := func() error { return errors.New("connection refused") }
+
+	attemptDelivery(store, delivery, send)
+	if delivery.Status != model.DeliveryStatusPending || delivery.Attempts != 1 || delivery.LastError != "connection refused" {
+		t.Fatalf(`The failed delivery should stay pending: %+v`, delivery)
+	}
+
+	if delay := time.Until(delivery.NextAttemptAt); delay <= 0 || delay > deliveryMinRetryDelay {
+		t.Errorf(`Unexpected next attempt delay: %v`, delay)
+	}
+
+	attemptDelivery(store, delivery, send)
+	if delivery.Status != model.DeliveryStatusDead || delivery.Attempts != 2 {
+		t.Fatalf(`The delivery should be dead after the last attempt: %+v`, delivery)
+	}
+
+	if updates := database.deliveryUpdates(); !slices.Equal(updates, []string{model.DeliveryStatusPending, model.DeliveryStatusDead}) {
+		t.Errorf(`Unexpected delivery updates: %v`, updates)
+	}
+}
+
+func TestAttemptDeliveryNotRecorded(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	store, database := newOutboxTestStore(t)
+	delivery := &model.IntegrationDelivery{UserID: 1, Status: model.DeliveryStatusPending}
+
+	attemptDelivery(store, delivery, func() error { return nil })
+
+	if delivery.Status != model.DeliveryStatusDelivered {
+		t.Fatalf(`Unexpected delivery status: %q`, delivery.Status)
+	}
+
+	if updates := database.deliveryUpdates(); len(updates) != 0 {
+		t.Errorf(`A delivery that was not recorded should not be updated: %v`, updates)
+	}
+}
+
+func TestLoadDeliveryEntriesRemoved(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	store, _ := newOutboxTestStore(t, "archiveorg", "discord")
+
+	scenarios := []*model.IntegrationDelivery{
+		{ID: 1, UserID: 1, Provider: "archiveorg", Kind: model.DeliveryKindSaveEntry, EntryIDs: []int64{42}},
+		{ID: 2, UserID: 1, Provider: "discord", Kind: model.DeliveryKindPushEntries, FeedID: 7, EntryIDs: []int64{42}},
+	}
+
+	for _, delivery := range scenarios {
+		if _, err := loadDelivery(store, delivery); !errors.Is(err, errDeliveryEntriesRemoved) {
+			t.Errorf(`Expected errDeliveryEntriesRemoved for the %q delivery, got %v`, delivery.Kind, err)
+		}
+	}
+}
+
+func TestLoadDeliveryProviderDisabled(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	store, _ := newOutboxTestStore(t, "archiveorg")
+
+	scenarios := []*model.IntegrationDelivery{
+		{ID: 1, UserID: 1, Provider: "linkding", Kind: model.DeliveryKindSaveEntry, EntryIDs: []int64{42}},
+		{ID: 2, UserID: 1, Provider: "archiveorg", Kind: model.DeliveryKindPushEntries, FeedID: 7, EntryIDs: []int64{42}},
+	}
+
+	for _, delivery := range scenarios {
+		if _, err := loadDelivery(store, delivery); !errors.Is(err, errDeliveryProviderDisabled) {
+			t.Errorf(`Expected errDeliveryProviderDisabled for the %q delivery, got %v`, delivery.Provider, err)
+		}
+	}
+}
+
+func TestRetryDeliveryWithRemovedEntries(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	store, database := newOutboxTestStore(t, "archiveorg")
+	delivery := &model.IntegrationDelivery{ID: 1, UserID: 1, Provider: "archiveorg", Kind: model.DeliveryKindSaveEntry, EntryIDs: []int64{42}, Status: model.DeliveryStatusPending}
+
+	retryDelivery(store, delivery)
+
+	if delivery.Status != model.DeliveryStatusDead || delivery.LastError != errDeliveryEntriesRemoved.Error() {
+		t.Fatalf(`The delivery should be dead: %+v`, delivery)
+	}
+
+	// The delivery is not attempted.
+	if delivery.Attempts != 0 {
+		t.Errorf(`Unexpected number of attempts: %d`, delivery.Attempts)
+	}
+
+	if updates := database.deliveryUpdates(); !slices.Equal(updates, []string{model.DeliveryStatusDead}) {
+		t.Errorf(`Unexpected delivery updates: %v`, updates)
+	}
+}
+
+func TestRetryDeliveryAboveRateLimit(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	store, database := newOutboxTestStore(t, "ntfy")
+	database.settings[0][3] = []byte(`{"rate_limit": 10}`)
+	database.notifiedEntries = 10
+	delivery := &model.IntegrationDelivery{ID: 1, UserID: 1, Provider: "ntfy", Kind: model.DeliveryKindPushEntries, FeedID: 7, EntryIDs: []int64{42}, Status: model.DeliveryStatusPending}
+
+	retryDelivery(store, delivery)
+
+	if delivery.Status != model.DeliveryStatusDead || delivery.LastError != errNotificationRateLimited.Error() {
+		t.Fatalf(`The delivery should be dead: %+v`, delivery)
+	}
+
+	// The delivery is not attempted, so it is not counted by the rate limit.
+	if delivery.Attempts != 0 {
+		t.Errorf(`Unexpected number of attempts: %d`, delivery.Attempts)
+	}
+
+	if updates := database.deliveryUpdates(); !slices.Equal(updates, []string{model.DeliveryStatusDead}) {
+		t.Errorf(`Unexpected delivery updates: %v`, updates)
+	}
+}
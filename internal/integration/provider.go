@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// Provider is a third-party service receiving the entries of the users.
+type Provider interface {
+	// Name returns the identifier of the provider, it is stored with the user settings.
+	Name() string
+
+	// ConfigSchema describes the settings of the provider and what the provider supports.
+	ConfigSchema() *ConfigSchema
+
+	// SaveEntry sends an entry saved by the user.
+	SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error
+
+	// PushEntries sends the new entries of a feed after a refresh.
+	PushEntries(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries) error
+
+	// Validate checks the settings before they are saved, it may also normalize them.
+	Validate(settings *model.IntegrationSettings) *locale.LocalizedError
+}
+
+// FieldType is the type of a provider setting.
+type FieldType string
+
+const (
+	FieldTypeText   FieldType = "text"
+	FieldTypeURL    FieldType = "url"
+	FieldTypeSecret FieldType = "secret"
+	FieldTypeBool   FieldType = "bool"
+	FieldTypeInt    FieldType = "int"
+	FieldTypeSelect FieldType = "select"
+
+	// FieldTypeTextArea is a multi-line text, it is not trimmed.
+	FieldTypeTextArea FieldType = "textarea"
+)
+
+// ConfigSchema describes the settings form of a provider.
+type ConfigSchema struct {
+	Title         string
+	Fields        []ConfigField
+	SavesEntries  bool
+	PushesEntries bool
+
+	// RequiresNotificationRoute providers only receive the entries matching a notification route,
+	// the other notification providers receive all the new entries when the user has no route for them.
+	RequiresNotificationRoute bool
+}
+
+// ConfigField describes a provider setting, the label, hint and option labels are translation keys.
+type ConfigField struct {
+	Name        string
+	Type        FieldType
+	Label       string
+	Placeholder string
+	Hint        string
+	HelpURL     string
+	Options     []ConfigFieldOption
+
+	// ReadOnly fields are generated by the provider and never read from the form.
+	ReadOnly bool
+
+	// Log adds the value to the log records of the provider.
+	Log bool
+}
+
+// ConfigFieldOption is an option of a select field.
+type ConfigFieldOption struct {
+	Value string
+	Label string
+}
+
+// ParseValues converts the raw form values to typed settings, empty values are omitted.
+func (s *ConfigSchema) ParseValues(formValue func(name string) string) model.IntegrationValues {
+	values := make(model.IntegrationValues)
+	for _, field := range s.Fields {
+		if field.ReadOnly {
+			continue
+		}
+
+		raw := strings.TrimSpace(formValue(field.Name))
+		switch field.Type {
+		case FieldTypeBool:
+			if raw == "1" {
+				values[field.Name] = true
+			}
+		case FieldTypeInt:
+			if number, err := strconv.ParseInt(raw, 10, 64); err == nil {
+				values[field.Name] = number
+			}
+		case FieldTypeSecret, FieldTypeTextArea:
+			// Passwords may start or end with spaces.
+			if raw := formValue(field.Name); raw != "" {
+				values[field.Name] = raw
+			}
+		default:
+			if raw != "" {
+				values[field.Name] = raw
+			}
+		}
+	}
+	return values
+}
+
+// baseProvider implements the optional parts of the Provider interface.
+type baseProvider struct{}
+
+func (baseProvider) SaveEntry(*model.IntegrationSettings, *model.Entry) error {
+	return nil
+}
+
+func (baseProvider) PushEntries(*model.IntegrationSettings, *model.Feed, model.Entries) error {
+	return nil
+}
+
+func (baseProvider) Validate(*model.IntegrationSettings) *locale.LocalizedError {
+	return nil
+}
+
+var registry = make(map[string]Provider)
+
+func init() {
+	for _, provider := range []Provider{
+		&activityPubProvider{},
+		&appriseProvider{},
+		&archiveorgProvider{},
+		&betulaProvider{},
+		&cuboxProvider{},
+		&discordProvider{},
+		&emailDigestProvider{},
+		&ereaderProvider{},
+		&espialProvider{},
+		&instapaperProvider{},
+		&karakeepProvider{},
+		&linkaceProvider{},
+		&linkdingProvider{},
+		&linktacoProvider{},
+		&linkwardenProvider{},
+		&matrixBotProvider{},
+		&notionProvider{},
+		&ntfyProvider{},
+		&nunuxKeeperProvider{},
+		&omnivoreProvider{},
+		&pinboardProvider{},
+		&pushoverProvider{},
+		&raindropProvider{},
+		&readeckProvider{},
+		&readwiseProvider{},
+		&shaarliProvider{},
+		&shioriProvider{},
+		&slackProvider{},
+		&telegramBotProvider{},
+		&wallabagProvider{},
+		&webhookProvider{},
+	} {
+		Register(provider)
+	}
+}
+
+// Register adds a provider to the registry, the provider names must be unique.
+func Register(provider Provider) {
+	name := provider.Name()
+	if _, found := registry[name]; found {
+		panic(fmt.Sprintf("integration: provider %q is already registered", name))
+	}
+	registry[name] = provider
+}
+
+// ProviderByName returns a registered provider, nil if the name is unknown.
+func ProviderByName(name string) Provider {
+	return registry[name]
+}
+
+// Providers returns the registered providers sorted by title.
+func Providers() []Provider {
+	providers := make([]Provider, 0, len(registry))
+	for _, provider := range registry {
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return strings.ToLower(providers[i].ConfigSchema().Title) < strings.ToLower(providers[j].ConfigSchema().Title)
+	})
+	return providers
+}
+
+// HasSaveEntry returns true if one of the enabled providers receives the entries saved by the user.
+func HasSaveEntry(settings []*model.IntegrationSettings) bool {
+	for _, providerSettings := range settings {
+		if !providerSettings.Enabled {
+			continue
+		}
+		provider := ProviderByName(providerSettings.Provider)
+		if provider != nil && provider.ConfigSchema().SavesEntries && receivesEvent(provider, providerSettings, model.EventSaveEntry) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"strings"
+
+	"miniflux.app/v2/internal/activitypub"
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// activityPubProvider publishes the shared entries of the user with an ActivityPub actor.
+type activityPubProvider struct {
+	baseProvider
+}
+
+func (*activityPubProvider) Name() string {
+	return activitypub.ProviderName
+}
+
+func (*activityPubProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title: "ActivityPub",
+		Fields: []ConfigField{
+			{Name: activitypub.UsernameField, Type: FieldTypeText, Label: "form.integration.activitypub_username", Hint: "form.integration.activitypub_username_help", Log: true},
+			{Name: "handle", Type: FieldTypeText, Label: "form.integration.activitypub_handle", ReadOnly: true},
+			{Name: activitypub.ObjectTypeField, Type: FieldTypeSelect, Label: "form.integration.activitypub_object_type", Options: []ConfigFieldOption{
+				{Value: activitypub.ObjectTypeNote, Label: "form.integration.activitypub_object_type_note"},
+				{Value: activitypub.ObjectTypeArticle, Label: "form.integration.activitypub_object_type_article"},
+			}},
+			{Name: activitypub.PublishStarredField, Type: FieldTypeBool, Label: "form.integration.activitypub_publish_starred"},
+		},
+	}
+}
+
+func (*activityPubProvider) ReceivesEvent(settings *model.IntegrationSettings, eventType string) bool {
+	if !config.Opts.HasActivityPub() {
+		return false
+	}
+
+	switch eventType {
+	case model.EventEntryShared, model.EventEntryUnshared:
+		return true
+	case model.EventEntryStarred, model.EventEntryUnstarred:
+		return settings.Values.Bool(activitypub.PublishStarredField)
+	default:
+		return false
+	}
+}
+
+func (*activityPubProvider) SendEvent(store *storage.Storage, settings *model.IntegrationSettings, eventType string, _ *model.Feed, entries model.Entries) error {
+	switch eventType {
+	case model.EventEntryShared:
+		return activitypub.Publish(store, settings, entries, false)
+	case model.EventEntryStarred:
+		return activitypub.Publish(store, settings, entries, true)
+	case model.EventEntryUnshared:
+		return activitypub.Unpublish(store, settings, entries, false)
+	case model.EventEntryUnstarred:
+		return activitypub.Unpublish(store, settings, entries, true)
+	}
+	return nil
+}
+
+// Validate normalizes the username, the handle displayed to the user is generated from it.
+func (*activityPubProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	username := strings.ToLower(strings.TrimPrefix(settings.Values.String(activitypub.UsernameField), "@"))
+	if username != "" {
+		settings.Values[activitypub.UsernameField] = username
+	}
+
+	switch {
+	case !settings.Enabled:
+		delete(settings.Values, "handle")
+		return nil
+	case !config.Opts.HasActivityPub():
+		return locale.NewLocalizedError("error.activitypub_disabled")
+	case !activitypub.IsValidUsername(username):
+		return locale.NewLocalizedError("error.activitypub_invalid_username")
+	}
+
+	settings.Values["handle"] = activitypub.Handle(username)
+	return nil
+}
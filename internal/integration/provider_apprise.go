@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/apprise"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+type appriseProvider struct {
+	baseProvider
+}
+
+func (*appriseProvider) Name() string {
+	return "apprise"
+}
+
+func (*appriseProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:         "Apprise",
+		PushesEntries: true,
+		Fields: append([]ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.apprise_url", Placeholder: "http://apprise:8080"},
+			{Name: "services_url", Type: FieldTypeText, Label: "form.integration.apprise_services_url", Placeholder: "tgram://<token>/<chat_id>/,matrix://", HelpURL: "https://github.com/caronc/apprise/wiki"},
+		}, notificationConfigFields()...),
+	}
+}
+
+func (*appriseProvider) Notify(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error {
+	serviceURLs := notification.Target
+	if serviceURLs == "" {
+		serviceURLs = settings.Values.String("services_url")
+	}
+
+	client := apprise.NewClient(serviceURLs, settings.Values.String("url"))
+	return client.SendNotification(feed, entries)
+}
+
+func (*appriseProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	return validateNotificationSettings(settings)
+}
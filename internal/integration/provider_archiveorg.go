@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/archiveorg"
+	"miniflux.app/v2/internal/model"
+)
+
+type archiveorgProvider struct {
+	baseProvider
+}
+
+func (*archiveorgProvider) Name() string {
+	return "archiveorg"
+}
+
+func (*archiveorgProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Archive.org",
+		SavesEntries: true,
+	}
+}
+
+func (*archiveorgProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	archiveorg.NewClient().SendURL(entry.URL, entry.Title)
+	return nil
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/betula"
+	"miniflux.app/v2/internal/model"
+)
+
+type betulaProvider struct {
+	baseProvider
+}
+
+func (*betulaProvider) Name() string {
+	return "betula"
+}
+
+func (*betulaProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Betula",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.betula_url", Placeholder: "http://links.bouncepaw.com"},
+			{Name: "token", Type: FieldTypeText, Label: "form.integration.betula_token"},
+		},
+	}
+}
+
+func (*betulaProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := betula.NewClient(settings.Values.String("url"), settings.Values.String("token"))
+	return client.CreateBookmark(entry.URL, entry.Title, entry.Tags)
+}
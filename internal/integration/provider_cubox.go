@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/cubox"
+	"miniflux.app/v2/internal/model"
+)
+
+type cuboxProvider struct {
+	baseProvider
+}
+
+func (*cuboxProvider) Name() string {
+	return "cubox"
+}
+
+func (*cuboxProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Cubox",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "api_link", Type: FieldTypeURL, Label: "form.integration.cubox_api_link", Placeholder: "https://cubox.pro/c/api/save/xxx"},
+		},
+	}
+}
+
+func (*cuboxProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := cubox.NewClient(settings.Values.String("api_link"))
+	return client.SaveLink(entry.URL)
+}
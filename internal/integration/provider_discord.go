@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/discord"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+type discordProvider struct {
+	baseProvider
+}
+
+func (*discordProvider) Name() string {
+	return "discord"
+}
+
+func (*discordProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:         "Discord",
+		PushesEntries: true,
+		Fields: append([]ConfigField{
+			{Name: "webhook_link", Type: FieldTypeURL, Label: "form.integration.discord_webhook_link", Placeholder: "https://discord.com/api/webhooks/xxx/xxx"},
+		}, notificationConfigFields()...),
+	}
+}
+
+// Notify sends the entries to the webhook of the notification, the priority is not supported.
+func (*discordProvider) Notify(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error {
+	webhookLink := notification.Target
+	if webhookLink == "" {
+		webhookLink = settings.Values.String("webhook_link")
+	}
+
+	client := discord.NewClient(webhookLink)
+	return client.SendDiscordMsg(feed, entries)
+}
+
+func (*discordProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	return validateNotificationSettings(settings)
+}
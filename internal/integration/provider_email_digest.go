@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/integration/email"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/template"
+	"miniflux.app/v2/internal/timezone"
+)
+
+const emailDigestDefaultHour = 8
+
+type emailDigestProvider struct {
+	baseProvider
+}
+
+// emailDigestFeed groups the entries of a feed in the digest.
+type emailDigestFeed struct {
+	Feed    *model.Feed
+	Entries model.Entries
+}
+
+func (*emailDigestProvider) Name() string {
+	return "email_digest"
+}
+
+func (*emailDigestProvider) ConfigSchema() *ConfigSchema {
+	weekdays := make([]ConfigFieldOption, 0, 7)
+	for _, weekday := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		value := strconv.Itoa(int(weekday))
+		weekdays = append(weekdays, ConfigFieldOption{Value: value, Label: "time.weekday." + value})
+	}
+
+	fields := []ConfigField{
+		{Name: "to", Type: FieldTypeText, Label: "form.integration.email_digest_to", Placeholder: "me@example.org", Log: true},
+	}
+	fields = append(fields, smtpConfigFields()...)
+	fields = append(fields, []ConfigField{
+		{Name: "frequency", Type: FieldTypeSelect, Label: "form.integration.email_digest_frequency", Options: []ConfigFieldOption{
+			{Value: "daily", Label: "form.integration.email_digest_frequency_daily"},
+			{Value: "weekly", Label: "form.integration.email_digest_frequency_weekly"},
+		}},
+		{Name: "weekday", Type: FieldTypeSelect, Label: "form.integration.email_digest_weekday", Options: weekdays},
+		{Name: "hour", Type: FieldTypeInt, Label: "form.integration.email_digest_hour", Placeholder: strconv.Itoa(emailDigestDefaultHour), Hint: "form.integration.email_digest_hour_help"},
+		{Name: "entries", Type: FieldTypeSelect, Label: "form.integration.email_digest_entries", Options: []ConfigFieldOption{
+			{Value: "unread", Label: "form.integration.email_digest_entries_unread"},
+			{Value: "new", Label: "form.integration.email_digest_entries_new"},
+		}},
+		{Name: "categories", Type: FieldTypeTextArea, Label: "form.integration.email_digest_categories", Hint: "form.integration.email_digest_selection_help"},
+		{Name: "feeds", Type: FieldTypeTextArea, Label: "form.integration.email_digest_feeds"},
+		{Name: "tags", Type: FieldTypeTextArea, Label: "form.integration.email_digest_tags"},
+		{Name: "mark_as_read", Type: FieldTypeBool, Label: "form.integration.email_digest_mark_as_read"},
+	}...)
+
+	return &ConfigSchema{Title: "Email Digest", Fields: fields}
+}
+
+func (*emailDigestProvider) DigestScheduledAt(settings *model.IntegrationSettings, now time.Time) time.Time {
+	hour := emailDigestDefaultHour
+	if value := settings.Values.Int64("hour"); value != nil {
+		hour = int(*value)
+	}
+
+	scheduledAt := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if scheduledAt.After(now) {
+		scheduledAt = scheduledAt.AddDate(0, 0, -1)
+	}
+
+	if settings.Values.String("frequency") == "weekly" {
+		weekday, _ := strconv.Atoi(settings.Values.String("weekday"))
+		for int(scheduledAt.Weekday()) != weekday {
+			scheduledAt = scheduledAt.AddDate(0, 0, -1)
+		}
+	}
+	return scheduledAt
+}
+
+func (*emailDigestProvider) FilterDigestEntries(settings *model.IntegrationSettings, builder *storage.EntryQueryBuilder, since time.Time) {
+	if settings.Values.String("entries") == "new" {
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.AfterCreatedDate(since)
+	} else {
+		builder.WithStatus(model.EntryStatusUnread)
+	}
+
+	builder.WithCategoriesFeedsOrTags(
+		emailDigestLines(settings.Values.String("categories")),
+		emailDigestLines(settings.Values.String("feeds")),
+		emailDigestLines(settings.Values.String("tags")),
+	)
+}
+
+func (*emailDigestProvider) MarksDigestAsRead(settings *model.IntegrationSettings) bool {
+	return settings.Values.Bool("mark_as_read")
+}
+
+func (*emailDigestProvider) SendDigest(settings *model.IntegrationSettings, user *model.User, entries model.Entries) error {
+	var feeds []*emailDigestFeed
+	for _, entry := range entries {
+		index := slices.IndexFunc(feeds, func(feed *emailDigestFeed) bool {
+			return feed.Feed.ID == entry.FeedID
+		})
+		if index < 0 {
+			feeds = append(feeds, &emailDigestFeed{Feed: entry.Feed})
+			index = len(feeds) - 1
+		}
+		feeds[index].Entries = append(feeds[index].Entries, entry)
+	}
+	slices.SortStableFunc(feeds, func(a, b *emailDigestFeed) int {
+		return strings.Compare(strings.ToLower(a.Feed.Title), strings.ToLower(b.Feed.Title))
+	})
+
+	printer := locale.NewPrinter(user.Language)
+	subject := printer.Printf("email.digest.subject", timezone.Now(user.Timezone).Format("2006-01-02"))
+
+	htmlBody, textBody, err := template.RenderEmail("digest", map[string]any{
+		"language":   user.Language,
+		"timezone":   user.Timezone,
+		"title":      subject,
+		"feeds":      feeds,
+		"entryCount": len(entries),
+	})
+	if err != nil {
+		return err
+	}
+
+	return newSMTPClient(settings).Send(&email.Message{
+		From:     settings.Values.String("from"),
+		To:       settings.Values.String("to"),
+		Subject:  subject,
+		HTMLBody: htmlBody,
+		TextBody: textBody,
+	})
+}
+
+func (*emailDigestProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	if !settings.Enabled {
+		return nil
+	}
+
+	if settings.Values.String("to") == "" || settings.Values.String("from") == "" || settings.Values.String("smtp_host") == "" {
+		return locale.NewLocalizedError("error.email_digest_missing_required_fields")
+	}
+
+	if err := validateSMTPSettings(settings); err != nil {
+		return err
+	}
+
+	if hour := settings.Values.Int64("hour"); hour == nil {
+		settings.Values["hour"] = int64(emailDigestDefaultHour)
+	} else if *hour < 0 || *hour > 23 {
+		return locale.NewLocalizedError("error.email_digest_invalid_hour")
+	}
+
+	for name, value := range map[string]string{
+		"frequency": "daily",
+		"weekday":   strconv.Itoa(int(time.Monday)),
+		"entries":   "unread",
+	} {
+		if settings.Values.String(name) == "" {
+			settings.Values[name] = value
+		}
+	}
+
+	return nil
+}
+
+// emailDigestLines returns the non-empty lines of a text area.
+func emailDigestLines(text string) []string {
+	var lines []string
+	for line := range strings.Lines(text) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
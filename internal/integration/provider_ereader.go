@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/integration/email"
+	"miniflux.app/v2/internal/integration/webdav"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// Destinations of the e-reader books.
+const (
+	ereaderDestinationEmail  = "email"
+	ereaderDestinationWebDAV = "webdav"
+	ereaderDestinationFolder = "folder"
+)
+
+const ereaderContentType = "application/epub+zip"
+
+type ereaderProvider struct {
+	baseProvider
+}
+
+func (*ereaderProvider) Name() string {
+	return "ereader"
+}
+
+func (*ereaderProvider) ConfigSchema() *ConfigSchema {
+	fields := []ConfigField{
+		{Name: "destination", Type: FieldTypeSelect, Label: "form.integration.ereader_destination", Log: true, Options: []ConfigFieldOption{
+			{Value: ereaderDestinationEmail, Label: "form.integration.ereader_destination_email"},
+			{Value: ereaderDestinationWebDAV, Label: "form.integration.ereader_destination_webdav"},
+			{Value: ereaderDestinationFolder, Label: "form.integration.ereader_destination_folder"},
+		}},
+		{Name: "to", Type: FieldTypeText, Label: "form.integration.ereader_to", Placeholder: "me@kindle.com", Hint: "form.integration.ereader_to_help", Log: true},
+	}
+	fields = append(fields, smtpConfigFields()...)
+	fields = append(fields, []ConfigField{
+		{Name: "webdav_url", Type: FieldTypeURL, Label: "form.integration.ereader_webdav_url", Placeholder: "https://cloud.example.org/remote.php/dav/files/me/Books"},
+		{Name: "webdav_username", Type: FieldTypeText, Label: "form.integration.ereader_webdav_username"},
+		{Name: "webdav_password", Type: FieldTypeSecret, Label: "form.integration.ereader_webdav_password"},
+		{Name: "folder", Type: FieldTypeText, Label: "form.integration.ereader_folder", Placeholder: "kobo", Hint: "form.integration.ereader_folder_help", Log: true},
+	}...)
+
+	return &ConfigSchema{
+		Title:        "E-Reader",
+		SavesEntries: true,
+		Fields:       fields,
+	}
+}
+
+func (*ereaderProvider) SendBook(settings *model.IntegrationSettings, title, filename string, content []byte) error {
+	switch settings.Values.String("destination") {
+	case ereaderDestinationWebDAV:
+		client := webdav.NewClient(
+			settings.Values.String("webdav_url"),
+			settings.Values.String("webdav_username"),
+			settings.Values.String("webdav_password"),
+		)
+		return client.Upload(filename, ereaderContentType, content)
+	case ereaderDestinationFolder:
+		return writeEReaderFile(settings.UserID, settings.Values.String("folder"), filename, content)
+	default:
+		return newSMTPClient(settings).Send(&email.Message{
+			From:        settings.Values.String("from"),
+			To:          settings.Values.String("to"),
+			Subject:     title,
+			HTMLBody:    "<p>" + html.EscapeString(title) + "</p>",
+			TextBody:    title,
+			Attachments: []*email.Attachment{{Filename: filename, ContentType: ereaderContentType, Content: content}},
+		})
+	}
+}
+
+func (*ereaderProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	if !settings.Enabled {
+		return nil
+	}
+
+	switch settings.Values.String("destination") {
+	case ereaderDestinationWebDAV:
+		folderURL, err := url.Parse(settings.Values.String("webdav_url"))
+		if err != nil || (folderURL.Scheme != "http" && folderURL.Scheme != "https") || folderURL.Host == "" {
+			return locale.NewLocalizedError("error.ereader_invalid_webdav_url")
+		}
+	case ereaderDestinationFolder:
+		if config.Opts.EReaderDirectory() == "" {
+			return locale.NewLocalizedError("error.ereader_folder_disabled")
+		}
+		if folder := settings.Values.String("folder"); folder != "" && !filepath.IsLocal(folder) {
+			return locale.NewLocalizedError("error.ereader_invalid_folder")
+		}
+	default:
+		settings.Values["destination"] = ereaderDestinationEmail
+		if settings.Values.String("to") == "" || settings.Values.String("from") == "" || settings.Values.String("smtp_host") == "" {
+			return locale.NewLocalizedError("error.ereader_missing_email_fields")
+		}
+		return validateSMTPSettings(settings)
+	}
+	return nil
+}
+
+// writeEReaderFile writes the book in a sub-folder of the user directory inside the e-reader directory,
+// the file is renamed once complete so a synchronization never copies a partial book.
+func writeEReaderFile(userID int64, folder, filename string, content []byte) error {
+	directory := config.Opts.EReaderDirectory()
+	if directory == "" {
+		return errors.New("ereader: the local folder destination is disabled")
+	}
+	if folder != "" && !filepath.IsLocal(folder) {
+		return fmt.Errorf("ereader: invalid folder %q", folder)
+	}
+
+	// Each user has its own directory, the users never overwrite or read the books of the others.
+	directory = filepath.Join(directory, strconv.FormatInt(userID, 10), folder)
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return fmt.Errorf("ereader: unable to create the folder: %v", err)
+	}
+
+	file, err := os.CreateTemp(directory, ".miniflux-*.epub")
+	if err != nil {
+		return fmt.Errorf("ereader: unable to create the file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return fmt.Errorf("ereader: unable to write the file: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("ereader: unable to write the file: %v", err)
+	}
+
+	if err := os.Rename(file.Name(), filepath.Join(directory, filename)); err != nil {
+		return fmt.Errorf("ereader: unable to write the file: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/espial"
+	"miniflux.app/v2/internal/model"
+)
+
+type espialProvider struct {
+	baseProvider
+}
+
+func (*espialProvider) Name() string {
+	return "espial"
+}
+
+func (*espialProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Espial",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.espial_endpoint", Placeholder: "https://esp.ae8.org"},
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.espial_api_key"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.espial_tags"},
+		},
+	}
+}
+
+func (*espialProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := espial.NewClient(settings.Values.String("url"), settings.Values.String("api_key"))
+	return client.CreateLink(entry.URL, entry.Title, settings.Values.String("tags"))
+}
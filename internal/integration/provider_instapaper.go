@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/instapaper"
+	"miniflux.app/v2/internal/model"
+)
+
+type instapaperProvider struct {
+	baseProvider
+}
+
+func (*instapaperProvider) Name() string {
+	return "instapaper"
+}
+
+func (*instapaperProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Instapaper",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "username", Type: FieldTypeText, Label: "form.integration.instapaper_username"},
+			{Name: "password", Type: FieldTypeSecret, Label: "form.integration.instapaper_password"},
+		},
+	}
+}
+
+func (*instapaperProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := instapaper.NewClient(settings.Values.String("username"), settings.Values.String("password"))
+	return client.AddURL(entry.URL, entry.Title)
+}
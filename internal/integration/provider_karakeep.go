@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/karakeep"
+	"miniflux.app/v2/internal/model"
+)
+
+type karakeepProvider struct {
+	baseProvider
+}
+
+func (*karakeepProvider) Name() string {
+	return "karakeep"
+}
+
+func (*karakeepProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Karakeep",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.karakeep_api_key"},
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.karakeep_url", Placeholder: "https://try.karakeep.app/api/v1/bookmarks"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.karakeep_tags", Placeholder: "miniflux, new", Log: true},
+		},
+	}
+}
+
+func (*karakeepProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := karakeep.NewClient(settings.Values.String("api_key"), settings.Values.String("url"), settings.Values.String("tags"))
+	return client.SaveURL(entry.URL)
+}
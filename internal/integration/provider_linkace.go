@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/linkace"
+	"miniflux.app/v2/internal/model"
+)
+
+type linkaceProvider struct {
+	baseProvider
+}
+
+func (*linkaceProvider) Name() string {
+	return "linkace"
+}
+
+func (*linkaceProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "LinkAce",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.linkace_endpoint", Placeholder: "http://linkace-url:port"},
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.linkace_api_key"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.linkace_tags"},
+			{Name: "is_private", Type: FieldTypeBool, Label: "form.integration.linkace_is_private"},
+			{Name: "check_disabled", Type: FieldTypeBool, Label: "form.integration.linkace_check_disabled"},
+		},
+	}
+}
+
+func (*linkaceProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := linkace.NewClient(
+		settings.Values.String("url"),
+		settings.Values.String("api_key"),
+		settings.Values.String("tags"),
+		settings.Values.Bool("is_private"),
+		settings.Values.Bool("check_disabled"),
+	)
+	return client.AddURL(entry.URL, entry.Title)
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"cmp"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/integration/linkding"
+	"miniflux.app/v2/internal/model"
+)
+
+type linkdingProvider struct {
+	baseProvider
+}
+
+func (*linkdingProvider) Name() string {
+	return "linkding"
+}
+
+func (*linkdingProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Linkding",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.linkding_endpoint", Placeholder: "https://linkding.com"},
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.linkding_api_key"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.linkding_tags"},
+			{Name: "mark_as_unread", Type: FieldTypeBool, Label: "form.integration.linkding_bookmark"},
+			{Name: bookmarkSyncField, Type: FieldTypeBool, Label: "form.integration.linkding_sync_bookmarks", Hint: "form.integration.linkding_sync_bookmarks_help"},
+		},
+	}
+}
+
+func (*linkdingProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	return newLinkdingClient(settings).CreateBookmark(entry.URL, entry.Title)
+}
+
+// Linkding has no favorites: the bookmarks are starred in Miniflux until they are archived,
+// and unstarring an entry archives its bookmark.
+func (*linkdingProvider) FetchBookmarks(settings *model.IntegrationSettings, since time.Time, limit int) ([]*Bookmark, error) {
+	client := newLinkdingClient(settings)
+
+	var bookmarks []*Bookmark
+	for _, archived := range []bool{false, true} {
+		remaining := 0
+		if limit > 0 {
+			if remaining = limit - len(bookmarks); remaining <= 0 {
+				break
+			}
+		}
+
+		items, err := client.Bookmarks(archived, since, remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			bookmarks = append(bookmarks, &Bookmark{
+				ID:      strconv.FormatInt(item.ID, 10),
+				URL:     item.URL,
+				Title:   cmp.Or(item.Title, item.WebsiteTitle),
+				Content: bookmarkTextContent(item.Description),
+				Tags:    item.TagNames,
+				Starred: !item.IsArchived,
+				Date:    item.DateAdded,
+			})
+		}
+	}
+	return bookmarks, nil
+}
+
+func (*linkdingProvider) SetBookmarkStarred(settings *model.IntegrationSettings, bookmarkID string, starred bool) error {
+	return newLinkdingClient(settings).SetArchived(bookmarkID, !starred)
+}
+
+func newLinkdingClient(settings *model.IntegrationSettings) *linkding.Client {
+	return linkding.NewClient(
+		settings.Values.String("url"),
+		settings.Values.String("api_key"),
+		settings.Values.String("tags"),
+		settings.Values.Bool("mark_as_unread"),
+	)
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/linktaco"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+type linktacoProvider struct {
+	baseProvider
+}
+
+func (*linktacoProvider) Name() string {
+	return "linktaco"
+}
+
+func (*linktacoProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "LinkTaco",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "api_token", Type: FieldTypeSecret, Label: "form.integration.linktaco_api_token", Hint: "form.integration.linktaco_api_token_hint", HelpURL: "https://linktaco.com/oauth2/personal"},
+			{Name: "org_slug", Type: FieldTypeText, Label: "form.integration.linktaco_org_slug", Placeholder: "my-organization"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.linktaco_tags", Placeholder: "miniflux, bookmarks", Hint: "form.integration.linktaco_tags_hint"},
+			{Name: "visibility", Type: FieldTypeSelect, Label: "form.integration.linktaco_visibility", Hint: "form.integration.linktaco_visibility_hint", Options: []ConfigFieldOption{{Value: "PUBLIC", Label: "form.integration.linktaco_visibility_public"}, {Value: "PRIVATE", Label: "form.integration.linktaco_visibility_private"}}},
+		},
+	}
+}
+
+func (*linktacoProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := linktaco.NewClient(
+		settings.Values.String("api_token"),
+		settings.Values.String("org_slug"),
+		settings.Values.String("tags"),
+		settings.Values.String("visibility"),
+	)
+	return client.CreateBookmark(entry.URL, entry.Title, entry.Content)
+}
+
+func (*linktacoProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	if !settings.Enabled {
+		return nil
+	}
+
+	if settings.Values.String("api_token") == "" || settings.Values.String("org_slug") == "" {
+		return locale.NewLocalizedError("error.linktaco_missing_required_fields")
+	}
+
+	if settings.Values.String("visibility") == "" {
+		settings.Values["visibility"] = "PUBLIC"
+	}
+
+	return nil
+}
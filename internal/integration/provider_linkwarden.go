@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/linkwarden"
+	"miniflux.app/v2/internal/model"
+)
+
+type linkwardenProvider struct {
+	baseProvider
+}
+
+func (*linkwardenProvider) Name() string {
+	return "linkwarden"
+}
+
+func (*linkwardenProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Linkwarden",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.linkwarden_endpoint", Placeholder: "https://linkwarden.app"},
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.linkwarden_api_key"},
+			{Name: "collection_id", Type: FieldTypeInt, Label: "form.integration.linkwarden_collection_id", Log: true},
+		},
+	}
+}
+
+func (*linkwardenProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := linkwarden.NewClient(
+		settings.Values.String("url"),
+		settings.Values.String("api_key"),
+		settings.Values.Int64("collection_id"),
+	)
+	return client.CreateBookmark(entry.URL, entry.Title)
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/integration/matrixbot"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// matrixBotSyncTimeout is the duration of the sync requests reading the commands.
+const matrixBotSyncTimeout = 30 * time.Second
+
+type matrixBotProvider struct {
+	baseProvider
+}
+
+func (*matrixBotProvider) Name() string {
+	return "matrix_bot"
+}
+
+func (*matrixBotProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:         "Matrix Bot",
+		PushesEntries: true,
+		Fields: append([]ConfigField{
+			{Name: "user", Type: FieldTypeText, Label: "form.integration.matrix_bot_user"},
+			{Name: "password", Type: FieldTypeSecret, Label: "form.integration.matrix_bot_password"},
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.matrix_bot_url"},
+			{Name: "chat_id", Type: FieldTypeText, Label: "form.integration.matrix_bot_chat_id"},
+			{Name: chatBotCommandsField, Type: FieldTypeBool, Label: "form.integration.chat_bot_commands"},
+			{Name: "allowed_user_id", Type: FieldTypeText, Label: "form.integration.matrix_bot_allowed_user_id", Placeholder: "@user:matrix.org", Hint: "form.integration.matrix_bot_allowed_user_id_help"},
+			{Name: ChatBotPausedField, Type: FieldTypeBool, Label: "form.integration.chat_bot_paused"},
+		}, notificationConfigFields()...),
+	}
+}
+
+// Notify sends the entries to the room of the notification, the priority is not supported.
+func (*matrixBotProvider) Notify(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error {
+	roomID := notification.Target
+	if roomID == "" {
+		roomID = settings.Values.String("chat_id")
+	}
+
+	return matrixbot.PushEntries(
+		feed,
+		entries,
+		settings.Values.String("url"),
+		settings.Values.String("user"),
+		settings.Values.String("password"),
+		roomID,
+		settings.Values.Bool(chatBotCommandsField),
+	)
+}
+
+func (*matrixBotProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	// Everyone in the room could read and change the entries of the user.
+	if settings.Values.Bool(chatBotCommandsField) && settings.Values.String("allowed_user_id") == "" {
+		return locale.NewLocalizedError("error.matrix_bot_allowed_user_id_required")
+	}
+	return validateNotificationSettings(settings)
+}
+
+func (*matrixBotProvider) NewChatSession(settings *model.IntegrationSettings) (ChatSession, error) {
+	client := matrixbot.NewClient(settings.Values.String("url"))
+	discovery, err := client.DiscoverEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	homeServerURL := discovery.HomeServerInformation.BaseURL
+	loginResponse, err := client.Login(homeServerURL, settings.Values.String("user"), settings.Values.String("password"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &matrixBotSession{
+		client:        client,
+		homeServerURL: homeServerURL,
+		accessToken:   loginResponse.AccessToken,
+		userID:        loginResponse.UserID,
+		roomID:        settings.Values.String("chat_id"),
+		allowedUserID: settings.Values.String("allowed_user_id"),
+	}, nil
+}
+
+type matrixBotSession struct {
+	client        *matrixbot.Client
+	homeServerURL string
+	accessToken   string
+	userID        string
+	roomID        string
+	allowedUserID string
+	since         string
+}
+
+func (s *matrixBotSession) ReceiveCommands(ctx context.Context) ([]*ChatCommand, error) {
+	// The first sync returns the recent messages of the room, they are skipped.
+	started := s.since != ""
+	timeout := matrixBotSyncTimeout
+	if !started {
+		timeout = 0
+	}
+
+	response, err := s.client.Sync(ctx, s.homeServerURL, s.accessToken, s.roomID, s.since, timeout)
+	if err != nil {
+		return nil, err
+	}
+	s.since = response.NextBatch
+	if !started {
+		return nil, nil
+	}
+
+	var commands []*ChatCommand
+	for _, event := range response.Rooms.Join[s.roomID].Timeline.Events {
+		if event.Type != "m.room.message" || event.Content.MsgType != "m.text" {
+			continue
+		}
+		// Only the owner of the Miniflux account can send commands, the messages of the other members are ignored.
+		if event.Sender == s.userID || event.Sender != s.allowedUserID {
+			continue
+		}
+		if command := ParseChatCommand(event.Content.Body); command != nil {
+			commands = append(commands, command)
+		}
+	}
+	return commands, nil
+}
+
+func (s *matrixBotSession) Reply(_ *ChatCommand, reply *ChatReply) error {
+	_, err := s.client.SendFormattedTextMessage(s.homeServerURL, s.accessToken, s.roomID, reply.Text, strings.ReplaceAll(reply.HTML, "\n", "<br>"))
+	return err
+}
+
+func (s *matrixBotSession) Close() {
+	if err := s.client.Logout(s.homeServerURL, s.accessToken); err != nil {
+		slog.Debug("Unable to log out of Matrix", slog.String("user_id", s.userID), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"golang.org/x/oauth2"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/integration/notion"
+	"miniflux.app/v2/internal/model"
+)
+
+type notionProvider struct {
+	baseProvider
+}
+
+func (*notionProvider) Name() string {
+	return "notion"
+}
+
+func (*notionProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Notion",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "token", Type: FieldTypeSecret, Label: "form.integration.notion_token", Hint: "form.integration.oauth2_token_help"},
+			{Name: "page_id", Type: FieldTypeText, Label: "form.integration.notion_page_id"},
+		},
+	}
+}
+
+func (*notionProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := notion.NewClient(settings.Values.String("token"), settings.Values.String("page_id"))
+	return client.UpdateDocument(entry.URL, entry.Title)
+}
+
+// https://developers.notion.com/docs/authorization#public-integration-auth-flow-set-up
+func (*notionProvider) OAuth2Config(redirectURL string) *oauth2.Config {
+	if config.Opts.NotionOAuth2ClientID() == "" {
+		return nil
+	}
+	return &oauth2.Config{
+		ClientID:     config.Opts.NotionOAuth2ClientID(),
+		ClientSecret: config.Opts.NotionOAuth2ClientSecret(),
+		RedirectURL:  redirectURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://api.notion.com/v1/oauth/authorize",
+			TokenURL:  "https://api.notion.com/v1/oauth/token",
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+	}
+}
+
+// The pages shared with the integration are chosen by the user, not by a workspace admin.
+func (*notionProvider) OAuth2AuthCodeOptions() []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("owner", "user")}
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/ntfy"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// ntfyDefaultPriority is the priority of the default notifications on the scale of Ntfy, from 1 to 5.
+const ntfyDefaultPriority = 3
+
+type ntfyProvider struct {
+	baseProvider
+}
+
+func (*ntfyProvider) Name() string {
+	return "ntfy"
+}
+
+func (*ntfyProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:                     "Ntfy",
+		PushesEntries:             true,
+		RequiresNotificationRoute: true,
+		Fields: append([]ConfigField{
+			{Name: "topic", Type: FieldTypeText, Label: "form.integration.ntfy_topic", Log: true},
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.ntfy_url", Placeholder: "https://ntfy.sh"},
+			{Name: "api_token", Type: FieldTypeText, Label: "form.integration.ntfy_api_token"},
+			{Name: "username", Type: FieldTypeText, Label: "form.integration.ntfy_username"},
+			{Name: "password", Type: FieldTypeSecret, Label: "form.integration.ntfy_password"},
+			{Name: "icon_url", Type: FieldTypeURL, Label: "form.integration.ntfy_icon_url"},
+			{Name: "internal_links", Type: FieldTypeBool, Label: "form.integration.ntfy_internal_links"},
+		}, notificationConfigFields()...),
+	}
+}
+
+func (*ntfyProvider) Notify(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error {
+	topic := notification.Target
+	if topic == "" {
+		topic = settings.Values.String("topic")
+	}
+
+	client := ntfy.NewClient(
+		settings.Values.String("url"),
+		topic,
+		settings.Values.String("api_token"),
+		settings.Values.String("username"),
+		settings.Values.String("password"),
+		settings.Values.String("icon_url"),
+		settings.Values.Bool("internal_links"),
+		ntfyDefaultPriority+notification.Priority,
+	)
+	return client.SendMessages(feed, entries)
+}
+
+func (*ntfyProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	return validateNotificationSettings(settings)
+}
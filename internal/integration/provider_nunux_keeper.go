@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/nunuxkeeper"
+	"miniflux.app/v2/internal/model"
+)
+
+type nunuxKeeperProvider struct {
+	baseProvider
+}
+
+func (*nunuxKeeperProvider) Name() string {
+	return "nunux_keeper"
+}
+
+func (*nunuxKeeperProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Nunux Keeper",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.nunux_keeper_endpoint", Placeholder: "https://api.nunux.org/keeper"},
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.nunux_keeper_api_key"},
+		},
+	}
+}
+
+func (*nunuxKeeperProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := nunuxkeeper.NewClient(settings.Values.String("url"), settings.Values.String("api_key"))
+	return client.AddEntry(entry.URL, entry.Title, entry.Content)
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/omnivore"
+	"miniflux.app/v2/internal/model"
+)
+
+type omnivoreProvider struct {
+	baseProvider
+}
+
+func (*omnivoreProvider) Name() string {
+	return "omnivore"
+}
+
+func (*omnivoreProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Omnivore",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.omnivore_api_key"},
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.omnivore_url", Placeholder: "https://api-prod.omnivore.app/api/graphql"},
+		},
+	}
+}
+
+func (*omnivoreProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := omnivore.NewClient(settings.Values.String("api_key"), settings.Values.String("url"))
+	return client.SaveUrl(entry.URL)
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/pinboard"
+	"miniflux.app/v2/internal/model"
+)
+
+type pinboardProvider struct {
+	baseProvider
+}
+
+func (*pinboardProvider) Name() string {
+	return "pinboard"
+}
+
+func (*pinboardProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Pinboard",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "token", Type: FieldTypeSecret, Label: "form.integration.pinboard_token"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.pinboard_tags"},
+			{Name: "mark_as_unread", Type: FieldTypeBool, Label: "form.integration.pinboard_bookmark"},
+		},
+	}
+}
+
+func (*pinboardProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := pinboard.NewClient(settings.Values.String("token"))
+	return client.CreateBookmark(entry.URL, entry.Title, settings.Values.String("tags"), settings.Values.Bool("mark_as_unread"))
+}
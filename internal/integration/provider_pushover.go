@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/pushover"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+type pushoverProvider struct {
+	baseProvider
+}
+
+func (*pushoverProvider) Name() string {
+	return "pushover"
+}
+
+func (*pushoverProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:                     "Pushover",
+		PushesEntries:             true,
+		RequiresNotificationRoute: true,
+		Fields: append([]ConfigField{
+			{Name: "token", Type: FieldTypeText, Label: "form.integration.pushover_token"},
+			{Name: "user", Type: FieldTypeText, Label: "form.integration.pushover_user"},
+			{Name: "device", Type: FieldTypeText, Label: "form.integration.pushover_device"},
+			{Name: "prefix", Type: FieldTypeText, Label: "form.integration.pushover_prefix", Placeholder: "https://api.pushover.net"},
+		}, notificationConfigFields()...),
+	}
+}
+
+// Notify sends the entries to the device of the notification, the priorities of Pushover go from -2 to 2 as well.
+func (*pushoverProvider) Notify(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error {
+	device := notification.Target
+	if device == "" {
+		device = settings.Values.String("device")
+	}
+
+	client := pushover.New(
+		settings.Values.String("user"),
+		settings.Values.String("token"),
+		notification.Priority,
+		device,
+		settings.Values.String("prefix"),
+	)
+	return client.SendMessages(feed, entries)
+}
+
+func (*pushoverProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	return validateNotificationSettings(settings)
+}
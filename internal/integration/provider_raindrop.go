@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"golang.org/x/oauth2"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/integration/raindrop"
+	"miniflux.app/v2/internal/model"
+)
+
+type raindropProvider struct {
+	baseProvider
+}
+
+func (*raindropProvider) Name() string {
+	return "raindrop"
+}
+
+func (*raindropProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Raindrop",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "token", Type: FieldTypeText, Label: "form.integration.raindrop_token", Hint: "form.integration.oauth2_token_help"},
+			{Name: "collection_id", Type: FieldTypeText, Label: "form.integration.raindrop_collection_id"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.raindrop_tags"},
+		},
+	}
+}
+
+func (*raindropProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := raindrop.NewClient(settings.Values.String("token"), settings.Values.String("collection_id"), settings.Values.String("tags"))
+	return client.CreateRaindrop(entry.URL, entry.Title)
+}
+
+// https://developer.raindrop.io/v1/authentication/token
+func (*raindropProvider) OAuth2Config(redirectURL string) *oauth2.Config {
+	if config.Opts.RaindropOAuth2ClientID() == "" {
+		return nil
+	}
+	return &oauth2.Config{
+		ClientID:     config.Opts.RaindropOAuth2ClientID(),
+		ClientSecret: config.Opts.RaindropOAuth2ClientSecret(),
+		RedirectURL:  redirectURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://raindrop.io/oauth/authorize",
+			TokenURL:  "https://raindrop.io/oauth/access_token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+func (*raindropProvider) OAuth2AuthCodeOptions() []oauth2.AuthCodeOption {
+	return nil
+}
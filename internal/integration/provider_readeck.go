@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/integration/readeck"
+	"miniflux.app/v2/internal/model"
+)
+
+type readeckProvider struct {
+	baseProvider
+}
+
+func (*readeckProvider) Name() string {
+	return "readeck"
+}
+
+func (*readeckProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Readeck",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "only_url", Type: FieldTypeBool, Label: "form.integration.readeck_only_url"},
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.readeck_endpoint", Placeholder: "https://readeck.com"},
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.readeck_api_key"},
+			{Name: "labels", Type: FieldTypeText, Label: "form.integration.readeck_labels"},
+			{Name: bookmarkSyncField, Type: FieldTypeBool, Label: "form.integration.sync_bookmarks"},
+		},
+	}
+}
+
+func (*readeckProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	return newReadeckClient(settings).CreateBookmark(entry.URL, entry.Title, entry.Content)
+}
+
+// The favorite bookmarks of Readeck are starred.
+func (*readeckProvider) FetchBookmarks(settings *model.IntegrationSettings, since time.Time, limit int) ([]*Bookmark, error) {
+	items, err := newReadeckClient(settings).Bookmarks(since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks := make([]*Bookmark, 0, len(items))
+	for _, item := range items {
+		bookmarks = append(bookmarks, &Bookmark{
+			ID:      item.ID,
+			URL:     item.URL,
+			Title:   item.Title,
+			Content: bookmarkTextContent(item.Description),
+			Author:  strings.Join(item.Authors, ", "),
+			Tags:    item.Labels,
+			Starred: item.IsMarked,
+			Date:    item.Created,
+		})
+	}
+	return bookmarks, nil
+}
+
+func (*readeckProvider) SetBookmarkStarred(settings *model.IntegrationSettings, bookmarkID string, starred bool) error {
+	return newReadeckClient(settings).SetMarked(bookmarkID, starred)
+}
+
+func newReadeckClient(settings *model.IntegrationSettings) *readeck.Client {
+	return readeck.NewClient(
+		settings.Values.String("url"),
+		settings.Values.String("api_key"),
+		settings.Values.String("labels"),
+		settings.Values.Bool("only_url"),
+	)
+}
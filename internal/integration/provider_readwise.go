@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/readwise"
+	"miniflux.app/v2/internal/model"
+)
+
+type readwiseProvider struct {
+	baseProvider
+}
+
+func (*readwiseProvider) Name() string {
+	return "readwise"
+}
+
+func (*readwiseProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Readwise Reader",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "api_key", Type: FieldTypeText, Label: "form.integration.readwise_api_key", HelpURL: "https://readwise.io/access_token"},
+		},
+	}
+}
+
+func (*readwiseProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := readwise.NewClient(settings.Values.String("api_key"))
+	return client.CreateDocument(entry.URL)
+}
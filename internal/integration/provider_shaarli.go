@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/shaarli"
+	"miniflux.app/v2/internal/model"
+)
+
+type shaarliProvider struct {
+	baseProvider
+}
+
+func (*shaarliProvider) Name() string {
+	return "shaarli"
+}
+
+func (*shaarliProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Shaarli",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.shaarli_endpoint", Placeholder: "https://shaarli.example.org"},
+			{Name: "api_secret", Type: FieldTypeSecret, Label: "form.integration.shaarli_api_secret"},
+		},
+	}
+}
+
+func (*shaarliProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := shaarli.NewClient(settings.Values.String("url"), settings.Values.String("api_secret"))
+	return client.CreateLink(entry.URL, entry.Title)
+}
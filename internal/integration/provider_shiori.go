@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"miniflux.app/v2/internal/integration/shiori"
+	"miniflux.app/v2/internal/model"
+)
+
+type shioriProvider struct {
+	baseProvider
+}
+
+func (*shioriProvider) Name() string {
+	return "shiori"
+}
+
+func (*shioriProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Shiori",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.shiori_endpoint", Placeholder: "https://shiori.example.org"},
+			{Name: "username", Type: FieldTypeText, Label: "form.integration.shiori_username"},
+			{Name: "password", Type: FieldTypeSecret, Label: "form.integration.shiori_password"},
+		},
+	}
+}
+
+func (*shioriProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	client := shiori.NewClient(settings.Values.String("url"), settings.Values.String("username"), settings.Values.String("password"))
+	return client.CreateBookmark(entry.URL, entry.Title)
+}
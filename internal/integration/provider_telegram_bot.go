@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/integration/telegrambot"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+// telegramBotPollTimeout is the duration of the long polling requests reading the commands.
+const telegramBotPollTimeout = 30 * time.Second
+
+type telegramBotProvider struct {
+	baseProvider
+}
+
+func (*telegramBotProvider) Name() string {
+	return "telegram_bot"
+}
+
+func (*telegramBotProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:         "Telegram Bot",
+		PushesEntries: true,
+		Fields: append([]ConfigField{
+			{Name: "token", Type: FieldTypeText, Label: "form.integration.telegram_bot_token", Placeholder: "bot123456:Abcdefg"},
+			{Name: "chat_id", Type: FieldTypeText, Label: "form.integration.telegram_chat_id"},
+			{Name: "topic_id", Type: FieldTypeInt, Label: "form.integration.telegram_topic_id"},
+			{Name: "disable_web_page_preview", Type: FieldTypeBool, Label: "form.integration.telegram_bot_disable_web_page_preview"},
+			{Name: "disable_notification", Type: FieldTypeBool, Label: "form.integration.telegram_bot_disable_notification"},
+			{Name: "disable_buttons", Type: FieldTypeBool, Label: "form.integration.telegram_bot_disable_buttons"},
+			{Name: chatBotCommandsField, Type: FieldTypeBool, Label: "form.integration.chat_bot_commands"},
+			{Name: ChatBotPausedField, Type: FieldTypeBool, Label: "form.integration.chat_bot_paused"},
+		}, notificationConfigFields()...),
+	}
+}
+
+// Notify sends the entries to the chat of the notification, the topic is only used in the chat of the settings.
+// The notifications below the default priority are silent.
+func (*telegramBotProvider) Notify(settings *model.IntegrationSettings, feed *model.Feed, entries model.Entries, notification *Notification) error {
+	chatID := notification.Target
+	topicID := settings.Values.Int64("topic_id")
+	if chatID == "" {
+		chatID = settings.Values.String("chat_id")
+	} else {
+		topicID = nil
+	}
+	disableNotification := settings.Values.Bool("disable_notification") || notification.Priority < model.NotificationPriorityDefault
+
+	// Telegram only supports sending the entries one by one.
+	var errs []error
+	for _, entry := range entries {
+		if err := telegrambot.PushEntry(
+			feed,
+			entry,
+			settings.Values.String("token"),
+			chatID,
+			topicID,
+			settings.Values.Bool("disable_web_page_preview"),
+			disableNotification,
+			settings.Values.Bool("disable_buttons"),
+			settings.Values.Bool(chatBotCommandsField),
+		); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (*telegramBotProvider) Validate(settings *model.IntegrationSettings) *locale.LocalizedError {
+	return validateNotificationSettings(settings)
+}
+
+func (*telegramBotProvider) NewChatSession(settings *model.IntegrationSettings) (ChatSession, error) {
+	chatID := settings.Values.String("chat_id")
+	return &telegramBotSession{
+		client:  telegrambot.NewClient(settings.Values.String("token"), chatID),
+		chatID:  chatID,
+		topicID: settings.Values.Int64("topic_id"),
+	}, nil
+}
+
+type telegramBotSession struct {
+	client  *telegrambot.Client
+	chatID  string
+	topicID *int64
+	offset  int64
+	started bool
+}
+
+func (s *telegramBotSession) ReceiveCommands(ctx context.Context) ([]*ChatCommand, error) {
+	// The first request skips the pending updates, the negative offset only returns the last one.
+	offset, timeout := s.offset, telegramBotPollTimeout
+	if !s.started {
+		offset, timeout = -1, 0
+	}
+
+	updates, err := s.client.GetUpdates(ctx, offset, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	started := s.started
+	s.started = true
+
+	var commands []*ChatCommand
+	for _, update := range updates {
+		s.offset = update.UpdateID + 1
+		if !started {
+			continue
+		}
+
+		switch {
+		case update.CallbackQuery != nil:
+			if update.CallbackQuery.Message == nil || !s.isChat(update.CallbackQuery.Message.Chat) {
+				continue
+			}
+			if command := ParseChatCommand("/" + update.CallbackQuery.Data); command != nil {
+				command.ButtonID = update.CallbackQuery.ID
+				commands = append(commands, command)
+			}
+		case update.Message != nil:
+			if !s.isChat(update.Message.Chat) {
+				continue
+			}
+			if command := ParseChatCommand(update.Message.Text); command != nil {
+				commands = append(commands, command)
+			}
+		}
+	}
+	return commands, nil
+}
+
+func (s *telegramBotSession) Reply(command *ChatCommand, reply *ChatReply) error {
+	if command.ButtonID != "" {
+		return s.client.AnswerCallbackQuery(command.ButtonID, reply.Text)
+	}
+
+	message := &telegrambot.MessageRequest{
+		ChatID:                s.chatID,
+		Text:                  reply.HTML,
+		ParseMode:             telegrambot.HTMLFormatting,
+		DisableWebPagePreview: true,
+	}
+	if s.topicID != nil {
+		message.MessageThreadID = *s.topicID
+	}
+
+	_, err := s.client.SendMessage(message)
+	return err
+}
+
+func (*telegramBotSession) Close() {}
+
+// isChat returns true if the message comes from the chat of the settings, the commands of the other chats are ignored.
+func (s *telegramBotSession) isChat(chat telegrambot.Chat) bool {
+	return s.chatID == strconv.FormatInt(chat.ID, 10) || (chat.Username != "" && s.chatID == "@"+chat.Username)
+}
@@ -0,0 +1,412 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestRegisteredProviders(t *testing.T) {
+	providers := Providers()
+	if len(providers) == 0 {
+		t.Fatal("Expected registered providers")
+	}
+
+	for i, provider := range providers {
+		if ProviderByName(provider.Name()) != provider {
+			t.Errorf("Provider %q is not registered under its name", provider.Name())
+		}
+
+		schema := provider.ConfigSchema()
+		_, sendsDigests := provider.(DigestSender)
+		_, receivesEvents := provider.(EventReceiver)
+		if !schema.SavesEntries && !schema.PushesEntries && !sendsDigests && !receivesEvents {
+			t.Errorf("Provider %q neither saves, pushes nor sends entries", provider.Name())
+		}
+
+		if i > 0 && strings.ToLower(providers[i-1].ConfigSchema().Title) > strings.ToLower(schema.Title) {
+			t.Errorf("Providers are not sorted by title: %q before %q", providers[i-1].ConfigSchema().Title, schema.Title)
+		}
+	}
+
+	if ProviderByName("unknown") != nil {
+		t.Error("Expected no provider for an unknown name")
+	}
+}
+
+func TestRegisterDuplicateProvider(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic when registering a provider twice")
+		}
+	}()
+
+	Register(&pinboardProvider{})
+}
+
+func TestConfigSchemaParseValues(t *testing.T) {
+	schema := &ConfigSchema{
+		Fields: []ConfigField{
+			{Name: "url", Type: FieldTypeURL},
+			{Name: "password", Type: FieldTypeSecret},
+			{Name: "unread", Type: FieldTypeBool},
+			{Name: "disabled", Type: FieldTypeBool},
+			{Name: "collection_id", Type: FieldTypeInt},
+			{Name: "invalid_id", Type: FieldTypeInt},
+			{Name: "tags", Type: FieldTypeText},
+			{Name: "secret", Type: FieldTypeText, ReadOnly: true},
+		},
+	}
+
+	form := map[string]string{
+		"url":           " https://example.org ",
+		"password":      " secret ",
+		"unread":        "1",
+		"disabled":      "",
+		"collection_id": "42",
+		"invalid_id":    "abc",
+		"tags":          "",
+		"secret":        "forged",
+	}
+
+	values := schema.ParseValues(func(name string) string { return form[name] })
+
+	if values.String("url") != "https://example.org" {
+		t.Errorf("Unexpected URL: %q", values.String("url"))
+	}
+	if values.String("password") != " secret " {
+		t.Errorf("Passwords must not be trimmed, got %q", values.String("password"))
+	}
+	if !values.Bool("unread") {
+		t.Error("Expected a true boolean")
+	}
+	if id := values.Int64("collection_id"); id == nil || *id != 42 {
+		t.Errorf("Unexpected integer: %v", id)
+	}
+
+	for _, name := range []string{"disabled", "invalid_id", "tags", "secret"} {
+		if _, found := values[name]; found {
+			t.Errorf("Expected %q to be omitted", name)
+		}
+	}
+}
+
+func TestHasSaveEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings []*model.IntegrationSettings
+		expected bool
+	}{
+		{"no provider", nil, false},
+		{"disabled provider", []*model.IntegrationSettings{{Provider: "pinboard"}}, false},
+		{"push only provider", []*model.IntegrationSettings{{Provider: "discord", Enabled: true}}, false},
+		{"unknown provider", []*model.IntegrationSettings{{Provider: "unknown", Enabled: true}}, false},
+		{"save provider", []*model.IntegrationSettings{{Provider: "discord", Enabled: true}, {Provider: "pinboard", Enabled: true}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasSaveEntry(tt.settings); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWebhookProviderValidate(t *testing.T) {
+	provider := ProviderByName("webhook")
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"url": "https://example.org/hook"}}
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	secret := settings.Values.String("secret")
+	if len(secret) != 64 {
+		t.Fatalf("Expected a generated secret, got %q", secret)
+	}
+
+	provider.Validate(settings)
+	if settings.Values.String("secret") != secret {
+		t.Error("Expected the secret to be kept")
+	}
+
+	settings = &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"secret": secret}}
+	provider.Validate(settings)
+	if settings.Enabled || settings.Values.String("secret") != "" {
+		t.Error("Expected the webhook without URL to be disabled")
+	}
+}
+
+func TestWebhookProviderEvents(t *testing.T) {
+	provider := ProviderByName("webhook")
+	receiver, ok := provider.(EventReceiver)
+	if !ok {
+		t.Fatal("Expected the webhook provider to receive events")
+	}
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"url": "https://example.org/hook"}}
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, eventType := range webhookEvents {
+		expected := eventType == model.EventNewEntries || eventType == model.EventSaveEntry
+		if got := receiver.ReceivesEvent(settings, eventType); got != expected {
+			t.Errorf("Expected %v for the default event %q, got %v", expected, eventType, got)
+		}
+	}
+
+	settings.Values = model.IntegrationValues{"url": "https://example.org/hook", "event_feed_error": true}
+	provider.Validate(settings)
+	if !receiver.ReceivesEvent(settings, model.EventFeedError) || receiver.ReceivesEvent(settings, model.EventNewEntries) {
+		t.Error("Expected the selected events to be kept")
+	}
+	if receivesEvent(provider, settings, model.EventSaveEntry) || HasSaveEntry([]*model.IntegrationSettings{settings}) {
+		t.Error("Expected the webhook not to save entries when the event is not selected")
+	}
+	if !receivesEvent(ProviderByName("pinboard"), settings, model.EventSaveEntry) {
+		t.Error("Expected the providers without events to receive everything")
+	}
+
+	settings.Values["body_template"] = "{{ .Feed.Title"
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error for an invalid body template")
+	}
+}
+
+func TestEmailDigestScheduledAt(t *testing.T) {
+	provider := &emailDigestProvider{}
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("Timezone database not available")
+	}
+
+	// Sunday 18 October 2026, 10:30 in Paris.
+	now := time.Date(2026, time.October, 18, 10, 30, 0, 0, paris)
+
+	tests := []struct {
+		name     string
+		values   model.IntegrationValues
+		expected time.Time
+	}{
+		{"default hour", model.IntegrationValues{}, time.Date(2026, time.October, 18, 8, 0, 0, 0, paris)},
+		{"hour of today", model.IntegrationValues{"hour": int64(10)}, time.Date(2026, time.October, 18, 10, 0, 0, 0, paris)},
+		{"hour of yesterday", model.IntegrationValues{"hour": int64(11)}, time.Date(2026, time.October, 17, 11, 0, 0, 0, paris)},
+		{"weekly today", model.IntegrationValues{"frequency": "weekly", "weekday": "0", "hour": int64(9)}, time.Date(2026, time.October, 18, 9, 0, 0, 0, paris)},
+		{"weekly last week", model.IntegrationValues{"frequency": "weekly", "weekday": "0", "hour": int64(18)}, time.Date(2026, time.October, 11, 18, 0, 0, 0, paris)},
+		{"weekly monday", model.IntegrationValues{"frequency": "weekly", "weekday": "1", "hour": int64(7)}, time.Date(2026, time.October, 12, 7, 0, 0, 0, paris)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &model.IntegrationSettings{Values: tt.values}
+			if got := provider.DigestScheduledAt(settings, now); !got.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEmailDigestProviderValidate(t *testing.T) {
+	provider := ProviderByName("email_digest")
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"to": "me@example.org"}}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error without sender and SMTP server")
+	}
+
+	settings.Values = model.IntegrationValues{"to": "me@example.org", "from": "invalid", "smtp_host": "smtp.example.org"}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error for an invalid sender")
+	}
+
+	settings.Values = model.IntegrationValues{"to": "me@example.org", "from": "Miniflux <miniflux@example.org>", "smtp_host": "smtp.example.org", "hour": int64(24)}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error for an invalid hour")
+	}
+
+	settings.Values = model.IntegrationValues{"to": "me@example.org", "from": "Miniflux <miniflux@example.org>", "smtp_host": "smtp.example.org"}
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for name, expected := range map[string]string{"smtp_port": "587", "smtp_security": "starttls", "frequency": "daily", "weekday": "1", "hour": "8", "entries": "unread"} {
+		if got := settings.Values.Value(name); got != expected {
+			t.Errorf("Expected the default %s %q, got %q", name, expected, got)
+		}
+	}
+
+	if err := provider.Validate(&model.IntegrationSettings{Values: model.IntegrationValues{}}); err != nil {
+		t.Errorf("Expected no validation of the disabled integration: %v", err)
+	}
+}
+
+func TestEmailDigestLines(t *testing.T) {
+	lines := emailDigestLines("News\r\n\n  Tech  \nGo")
+	if strings.Join(lines, "|") != "News|Tech|Go" {
+		t.Errorf("Unexpected lines %q", lines)
+	}
+}
+
+func TestEReaderProviderValidate(t *testing.T) {
+	t.Setenv("EREADER_DIRECTORY", "")
+	parseConfig(t)
+	provider := ProviderByName("ereader")
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"to": "me@kindle.com"}}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error without sender and SMTP server")
+	}
+	if settings.Values.String("destination") != "email" {
+		t.Errorf("Expected the default destination, got %q", settings.Values.String("destination"))
+	}
+
+	settings.Values = model.IntegrationValues{"to": "me@kindle.com", "from": "miniflux@example.org", "smtp_host": "smtp.example.org"}
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	settings.Values = model.IntegrationValues{"destination": "webdav", "webdav_url": "ftp://example.org/books"}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error for an invalid WebDAV URL")
+	}
+
+	settings.Values = model.IntegrationValues{"destination": "webdav", "webdav_url": "https://example.org/books"}
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	settings.Values = model.IntegrationValues{"destination": "folder", "folder": "kobo"}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error when the local folder destination is disabled")
+	}
+
+	t.Setenv("EREADER_DIRECTORY", t.TempDir())
+	parseConfig(t)
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	settings.Values["folder"] = "../other"
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error for a folder outside of the e-reader directory")
+	}
+}
+
+func TestWriteEReaderFile(t *testing.T) {
+	directory := t.TempDir()
+	t.Setenv("EREADER_DIRECTORY", directory)
+	parseConfig(t)
+
+	if err := writeEReaderFile(42, "kobo/books", "Book.epub", []byte("content")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(directory, "42", "kobo", "books"))
+	if err != nil || len(files) != 1 || files[0].Name() != "Book.epub" {
+		t.Fatalf("Expected only the book in the folder, got %v: %v", files, err)
+	}
+
+	// The same folder of another user is a different directory.
+	if err := writeEReaderFile(43, "kobo/books", "Book.epub", []byte("other content")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if content, err := os.ReadFile(filepath.Join(directory, "42", "kobo", "books", "Book.epub")); err != nil || string(content) != "content" {
+		t.Fatalf("The book of another user should not be overwritten: %q, %v", content, err)
+	}
+
+	if err := writeEReaderFile(42, "../other", "Book.epub", []byte("content")); err == nil {
+		t.Error("Expected an error for a folder outside of the e-reader directory")
+	}
+
+	if err := writeEReaderFile(42, "..", "Book.epub", []byte("content")); err == nil {
+		t.Error("Expected an error for a folder outside of the user directory")
+	}
+}
+
+func TestBookFilename(t *testing.T) {
+	tests := map[string]string{
+		"Starred entries – 2026-10-18": "Starred entries – 2026-10-18.epub",
+		"What? A/B testing: <done>":    "What_ A_B testing_ _done_.epub",
+		"  ..  ":                       "miniflux.epub",
+		strings.Repeat("é", 120):       strings.Repeat("é", 100) + ".epub",
+	}
+	for title, expected := range tests {
+		if got := bookFilename(title); got != expected {
+			t.Errorf("Expected %q for %q, got %q", expected, title, got)
+		}
+	}
+}
+
+func parseConfig(t *testing.T) {
+	t.Helper()
+
+	var err error
+	if config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables(); err != nil {
+		t.Fatalf("Parsing failure: %v", err)
+	}
+}
+
+func TestLinktacoProviderValidate(t *testing.T) {
+	provider := ProviderByName("linktaco")
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"api_token": "token"}}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error without organization")
+	}
+
+	settings.Values["org_slug"] = "miniflux"
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.Values.String("visibility") != "PUBLIC" {
+		t.Errorf("Expected the default visibility, got %q", settings.Values.String("visibility"))
+	}
+}
+
+func TestActivityPubProvider(t *testing.T) {
+	t.Setenv("ACTIVITYPUB", "")
+	parseConfig(t)
+	provider := ProviderByName("activitypub")
+	receiver := provider.(EventReceiver)
+
+	settings := &model.IntegrationSettings{Enabled: true, Values: model.IntegrationValues{"username": "alice"}}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error when ActivityPub is disabled")
+	}
+	if receiver.ReceivesEvent(settings, model.EventEntryShared) {
+		t.Error("Expected no event when ActivityPub is disabled")
+	}
+
+	t.Setenv("ACTIVITYPUB", "1")
+	t.Setenv("BASE_URL", "https://reader.example.org")
+	parseConfig(t)
+
+	settings.Values = model.IntegrationValues{"username": "@Alice"}
+	if err := provider.Validate(settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.Values.String("username") != "alice" || settings.Values.String("handle") != "@alice@reader.example.org" {
+		t.Errorf("Unexpected settings %v", settings.Values)
+	}
+
+	settings.Values = model.IntegrationValues{"username": "alice.smith"}
+	if err := provider.Validate(settings); err == nil {
+		t.Error("Expected an error for an invalid username")
+	}
+
+	if !receiver.ReceivesEvent(settings, model.EventEntryUnshared) || receiver.ReceivesEvent(settings, model.EventEntryStarred) {
+		t.Error("Expected the starred entries to be published only when selected")
+	}
+	settings.Values["publish_starred"] = true
+	if !receiver.ReceivesEvent(settings, model.EventEntryUnstarred) || receiver.ReceivesEvent(settings, model.EventNewEntries) {
+		t.Error("Expected the starred entries to be published")
+	}
+}
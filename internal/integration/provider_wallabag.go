@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/integration/wallabag"
+	"miniflux.app/v2/internal/model"
+)
+
+type wallabagProvider struct {
+	baseProvider
+}
+
+func (*wallabagProvider) Name() string {
+	return "wallabag"
+}
+
+func (*wallabagProvider) ConfigSchema() *ConfigSchema {
+	return &ConfigSchema{
+		Title:        "Wallabag",
+		SavesEntries: true,
+		Fields: []ConfigField{
+			{Name: "only_url", Type: FieldTypeBool, Label: "form.integration.wallabag_only_url"},
+			{Name: "url", Type: FieldTypeURL, Label: "form.integration.wallabag_endpoint"},
+			{Name: "client_id", Type: FieldTypeText, Label: "form.integration.wallabag_client_id"},
+			{Name: "client_secret", Type: FieldTypeSecret, Label: "form.integration.wallabag_client_secret"},
+			{Name: "username", Type: FieldTypeText, Label: "form.integration.wallabag_username"},
+			{Name: "password", Type: FieldTypeSecret, Label: "form.integration.wallabag_password"},
+			{Name: "tags", Type: FieldTypeText, Label: "form.integration.wallabag_tags", Log: true},
+			{Name: bookmarkSyncField, Type: FieldTypeBool, Label: "form.integration.sync_bookmarks"},
+		},
+	}
+}
+
+func (*wallabagProvider) SaveEntry(settings *model.IntegrationSettings, entry *model.Entry) error {
+	return newWallabagClient(settings).CreateEntry(entry.URL, entry.Title, entry.Content)
+}
+
+func (*wallabagProvider) FetchBookmarks(settings *model.IntegrationSettings, since time.Time, limit int) ([]*Bookmark, error) {
+	items, err := newWallabagClient(settings).Entries(since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks := make([]*Bookmark, 0, len(items))
+	for _, item := range items {
+		bookmark := &Bookmark{
+			ID:      strconv.FormatInt(item.ID, 10),
+			URL:     item.URL,
+			Title:   item.Title,
+			Content: item.Content,
+			Author:  strings.Join(item.Authors, ", "),
+			Tags:    item.Tags,
+			Starred: item.IsStarred,
+			Date:    item.PublishedAt,
+		}
+		if bookmark.Date.IsZero() {
+			bookmark.Date = item.CreatedAt
+		}
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return bookmarks, nil
+}
+
+func (*wallabagProvider) SetBookmarkStarred(settings *model.IntegrationSettings, bookmarkID string, starred bool) error {
+	return newWallabagClient(settings).SetStarred(bookmarkID, starred)
+}
+
+func newWallabagClient(settings *model.IntegrationSettings) *wallabag.Client {
+	return wallabag.NewClient(
+		settings.Values.String("url"),
+		settings.Values.String("client_id"),
+		settings.Values.String("client_secret"),
+		settings.Values.String("username"),
+		settings.Values.String("password"),
+		settings.Values.String("tags"),
+		settings.Values.Bool("only_url"),
+	)
+}
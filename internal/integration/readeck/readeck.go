@@ -8,8 +8,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -134,6 +137,118 @@ func (c *Client) CreateBookmark(entryURL, entryTitle string, entryContent string
 	return nil
 }
 
+// Bookmark is a bookmark returned by the API.
+type Bookmark struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Authors     []string  `json:"authors"`
+	Labels      []string  `json:"labels"`
+	IsMarked    bool      `json:"is_marked"`
+	Created     time.Time `json:"created"`
+}
+
+const bookmarksPageSize = 100
+
+// Bookmarks returns at most limit bookmarks updated since the given date, all of them when the date is zero.
+// There is no limit when it is zero.
+func (c *Client) Bookmarks(updatedSince time.Time, limit int) ([]*Bookmark, error) {
+	if c.baseURL == "" || c.apiKey == "" {
+		return nil, errors.New("readeck: missing base URL or API key")
+	}
+
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/api/bookmarks")
+	if err != nil {
+		return nil, fmt.Errorf(`readeck: invalid API endpoint: %v`, err)
+	}
+
+	var bookmarks []*Bookmark
+	for offset := 0; limit <= 0 || len(bookmarks) < limit; offset += bookmarksPageSize {
+		query := url.Values{}
+		query.Set("sort", "-created")
+		query.Set("limit", strconv.Itoa(bookmarksPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		if !updatedSince.IsZero() {
+			query.Set("updated_since", updatedSince.UTC().Format(time.RFC3339))
+		}
+
+		var page []*Bookmark
+		if err := c.doRequest(http.MethodGet, apiEndpoint+"?"+query.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+
+		bookmarks = append(bookmarks, page...)
+		if len(page) < bookmarksPageSize {
+			break
+		}
+	}
+
+	if limit > 0 && len(bookmarks) > limit {
+		bookmarks = bookmarks[:limit]
+	}
+	return bookmarks, nil
+}
+
+// SetMarked adds or removes a bookmark from the favorites.
+func (c *Client) SetMarked(bookmarkID string, marked bool) error {
+	if c.baseURL == "" || c.apiKey == "" {
+		return errors.New("readeck: missing base URL or API key")
+	}
+
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/api/bookmarks/"+url.PathEscape(bookmarkID))
+	if err != nil {
+		return fmt.Errorf(`readeck: invalid API endpoint: %v`, err)
+	}
+
+	return c.doRequest(http.MethodPatch, apiEndpoint, &updateBookmarkRequest{IsMarked: marked}, nil)
+}
+
+func (c *Client) doRequest(method, apiEndpoint string, body, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("readeck: unable to encode request body: %v", err)
+		}
+		requestBody = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequest(method, apiEndpoint, requestBody)
+	if err != nil {
+		return fmt.Errorf("readeck: unable to create request: %v", err)
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("readeck: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("readeck: unexpected response: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+			return fmt.Errorf("readeck: unable to decode response: %v", err)
+		}
+	}
+	return nil
+}
+
+type updateBookmarkRequest struct {
+	IsMarked bool `json:"is_marked"`
+}
+
 type readeckBookmark struct {
 	Url    string   `json:"url"`
 	Title  string   `json:"title"`
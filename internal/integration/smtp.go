@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package integration // import "miniflux.app/v2/internal/integration"
+
+import (
+	"net/mail"
+	"strconv"
+
+	"miniflux.app/v2/internal/integration/email"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+const smtpDefaultPort = 587
+
+// smtpConfigFields returns the sender and the SMTP server settings of the providers sending emails.
+func smtpConfigFields() []ConfigField {
+	return []ConfigField{
+		{Name: "from", Type: FieldTypeText, Label: "form.integration.smtp_from", Placeholder: "Miniflux <miniflux@example.org>"},
+		{Name: "smtp_host", Type: FieldTypeText, Label: "form.integration.smtp_host", Placeholder: "smtp.example.org", Log: true},
+		{Name: "smtp_port", Type: FieldTypeInt, Label: "form.integration.smtp_port", Placeholder: strconv.Itoa(smtpDefaultPort)},
+		{Name: "smtp_security", Type: FieldTypeSelect, Label: "form.integration.smtp_security", Options: []ConfigFieldOption{
+			{Value: email.SecurityStartTLS, Label: "form.integration.smtp_security_starttls"},
+			{Value: email.SecurityTLS, Label: "form.integration.smtp_security_tls"},
+			{Value: email.SecurityNone, Label: "form.integration.smtp_security_none"},
+		}},
+		{Name: "smtp_username", Type: FieldTypeText, Label: "form.integration.smtp_username"},
+		{Name: "smtp_password", Type: FieldTypeSecret, Label: "form.integration.smtp_password"},
+	}
+}
+
+func newSMTPClient(settings *model.IntegrationSettings) *email.Client {
+	port := smtpDefaultPort
+	if value := settings.Values.Int64("smtp_port"); value != nil {
+		port = int(*value)
+	}
+
+	return email.NewClient(
+		settings.Values.String("smtp_host"),
+		port,
+		settings.Values.String("smtp_security"),
+		settings.Values.String("smtp_username"),
+		settings.Values.String("smtp_password"),
+	)
+}
+
+// validateSMTPSettings checks the sender and the recipients, the default port and security are set when missing.
+func validateSMTPSettings(settings *model.IntegrationSettings) *locale.LocalizedError {
+	if _, err := mail.ParseAddress(settings.Values.String("from")); err != nil {
+		return locale.NewLocalizedError("error.smtp_invalid_address", settings.Values.String("from"))
+	}
+	if _, err := mail.ParseAddressList(settings.Values.String("to")); err != nil {
+		return locale.NewLocalizedError("error.smtp_invalid_address", settings.Values.String("to"))
+	}
+
+	if port := settings.Values.Int64("smtp_port"); port == nil {
+		settings.Values["smtp_port"] = int64(smtpDefaultPort)
+	} else if *port < 1 || *port > 65535 {
+		return locale.NewLocalizedError("error.smtp_invalid_port")
+	}
+
+	if settings.Values.String("smtp_security") == "" {
+		settings.Values["smtp_security"] = email.SecurityStartTLS
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package telegrambot // import "miniflux.app/v2/internal/integration/telegrambot"
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -24,20 +25,22 @@ const (
 )
 
 type Client struct {
-	botToken string
-	chatID   string
+	botToken    string
+	chatID      string
+	apiEndpoint string
 }
 
 func NewClient(botToken, chatID string) *Client {
 	return &Client{
-		botToken: botToken,
-		chatID:   chatID,
+		botToken:    botToken,
+		chatID:      chatID,
+		apiEndpoint: telegramAPIEndpoint,
 	}
 }
 
 // Specs: https://core.telegram.org/bots/api#getme
 func (c *Client) GetMe() (*User, error) {
-	endpointURL, err := url.JoinPath(telegramAPIEndpoint, "/bot"+c.botToken, "/getMe")
+	endpointURL, err := url.JoinPath(c.apiEndpoint, "/bot"+c.botToken, "/getMe")
 	if err != nil {
 		return nil, fmt.Errorf("telegram: unable to join base URL and path: %w", err)
 	}
@@ -71,7 +74,7 @@ func (c *Client) GetMe() (*User, error) {
 
 // Specs: https://core.telegram.org/bots/api#sendmessage
 func (c *Client) SendMessage(message *MessageRequest) (*Message, error) {
-	endpointURL, err := url.JoinPath(telegramAPIEndpoint, "/bot"+c.botToken, "/sendMessage")
+	endpointURL, err := url.JoinPath(c.apiEndpoint, "/bot"+c.botToken, "/sendMessage")
 	if err != nil {
 		return nil, fmt.Errorf("telegram: unable to join base URL and path: %w", err)
 	}
@@ -109,6 +112,73 @@ func (c *Client) SendMessage(message *MessageRequest) (*Message, error) {
 	return &messageResponse.Result, nil
 }
 
+// GetUpdates waits at most timeout for the updates following the given offset, the previous updates are confirmed.
+// Specs: https://core.telegram.org/bots/api#getupdates
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]*Update, error) {
+	var updates []*Update
+	err := c.callMethod(ctx, "getUpdates", timeout+defaultClientTimeout, &UpdatesRequest{
+		Offset:         offset,
+		Timeout:        int(timeout.Seconds()),
+		AllowedUpdates: []string{"message", "callback_query"},
+	}, &updates)
+	return updates, err
+}
+
+// AnswerCallbackQuery shows a notification to the user who pressed a button.
+// Specs: https://core.telegram.org/bots/api#answercallbackquery
+func (c *Client) AnswerCallbackQuery(callbackQueryID, text string) error {
+	var answered bool
+	return c.callMethod(context.Background(), "answerCallbackQuery", defaultClientTimeout, &CallbackQueryAnswerRequest{
+		CallbackQueryID: callbackQueryID,
+		Text:            text,
+	}, &answered)
+}
+
+func (c *Client) callMethod(ctx context.Context, method string, timeout time.Duration, body, result any) error {
+	endpointURL, err := url.JoinPath(c.apiEndpoint, "/bot"+c.botToken, "/"+method)
+	if err != nil {
+		return fmt.Errorf("telegram: unable to join base URL and path: %w", err)
+	}
+
+	requestBody, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("telegram: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("telegram: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+
+	httpClient := &http.Client{Timeout: timeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("telegram: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	var methodResponse struct {
+		BaseResponse
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&methodResponse); err != nil {
+		return fmt.Errorf("telegram: unable to decode %s response: %w", method, err)
+	}
+
+	if !methodResponse.Ok {
+		return fmt.Errorf("telegram: unable to call %s: %s (error code is %d)", method, methodResponse.Description, methodResponse.ErrorCode)
+	}
+
+	if err := json.Unmarshal(methodResponse.Result, result); err != nil {
+		return fmt.Errorf("telegram: unable to decode %s result: %w", method, err)
+	}
+	return nil
+}
+
 type InlineKeyboard struct {
 	InlineKeyboard []InlineKeyboardRow `json:"inline_keyboard"`
 }
@@ -116,8 +186,9 @@ type InlineKeyboard struct {
 type InlineKeyboardRow []*InlineKeyboardButton
 
 type InlineKeyboardButton struct {
-	Text string `json:"text"`
-	URL  string `json:"url,omitempty"`
+	Text         string `json:"text"`
+	URL          string `json:"url,omitempty"`
+	CallbackData string `json:"callback_data,omitempty"`
 }
 
 type User struct {
@@ -134,17 +205,32 @@ type User struct {
 }
 
 type Chat struct {
-	ID    int64  `json:"id"`
-	Type  string `json:"type"`
-	Title string `json:"title"`
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Username string `json:"username"`
 }
 
 type Message struct {
-	MessageID       int64 `json:"message_id"`
-	From            User  `json:"from"`
-	Chat            Chat  `json:"chat"`
-	MessageThreadID int64 `json:"message_thread_id"`
-	Date            int64 `json:"date"`
+	MessageID       int64  `json:"message_id"`
+	From            User   `json:"from"`
+	Chat            Chat   `json:"chat"`
+	MessageThreadID int64  `json:"message_thread_id"`
+	Date            int64  `json:"date"`
+	Text            string `json:"text"`
+}
+
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	From    User     `json:"from"`
+	Message *Message `json:"message"`
+	Data    string   `json:"data"`
+}
+
+type Update struct {
+	UpdateID      int64          `json:"update_id"`
+	Message       *Message       `json:"message"`
+	CallbackQuery *CallbackQuery `json:"callback_query"`
 }
 
 type BaseResponse struct {
@@ -168,6 +254,17 @@ type MessageRequest struct {
 	ReplyMarkup           *InlineKeyboard `json:"reply_markup,omitempty"`
 }
 
+type UpdatesRequest struct {
+	Offset         int64    `json:"offset,omitempty"`
+	Timeout        int      `json:"timeout"`
+	AllowedUpdates []string `json:"allowed_updates"`
+}
+
+type CallbackQueryAnswerRequest struct {
+	CallbackQueryID string `json:"callback_query_id"`
+	Text            string `json:"text,omitempty"`
+}
+
 type MessageResponse struct {
 	BaseResponse
 	Result Message `json:"result"`
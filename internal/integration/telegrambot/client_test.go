@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package telegrambot // import "miniflux.app/v2/internal/integration/telegrambot"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bottoken/getUpdates" {
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+		}
+
+		var request UpdatesRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Invalid request body: %v", err)
+		}
+		if request.Offset != 42 || request.Timeout != 30 {
+			t.Errorf("Unexpected request: %+v", request)
+		}
+
+		w.Write([]byte(`{"ok":true,"result":[
+			{"update_id":42,"message":{"message_id":1,"chat":{"id":123},"text":"/unread"}},
+			{"update_id":43,"callback_query":{"id":"query","message":{"message_id":2,"chat":{"id":123}},"data":"read 7"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "123")
+	client.apiEndpoint = server.URL
+
+	updates, err := client.GetUpdates(context.Background(), 42, 30*time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(updates) != 2 {
+		t.Fatalf("Expected two updates, got %d", len(updates))
+	}
+	if updates[0].Message == nil || updates[0].Message.Text != "/unread" || updates[0].Message.Chat.ID != 123 {
+		t.Errorf("Unexpected message: %+v", updates[0].Message)
+	}
+	if updates[1].CallbackQuery == nil || updates[1].CallbackQuery.ID != "query" || updates[1].CallbackQuery.Data != "read 7" {
+		t.Errorf("Unexpected callback query: %+v", updates[1].CallbackQuery)
+	}
+}
+
+func TestGetUpdatesWithError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"ok":false,"error_code":409,"description":"Conflict: terminated by other getUpdates request"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "123")
+	client.apiEndpoint = server.URL
+
+	if _, err := client.GetUpdates(context.Background(), 0, 0); err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestAnswerCallbackQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request CallbackQueryAnswerRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Invalid request body: %v", err)
+		}
+		if r.URL.Path != "/bottoken/answerCallbackQuery" || request.CallbackQueryID != "query" || request.Text != "Done" {
+			t.Errorf("Unexpected request: %s %+v", r.URL.Path, request)
+		}
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("token", "123")
+	client.apiEndpoint = server.URL
+
+	if err := client.AnswerCallbackQuery("query", "Done"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
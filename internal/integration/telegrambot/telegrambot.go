@@ -13,7 +13,8 @@ import (
 	"miniflux.app/v2/internal/urllib"
 )
 
-func PushEntry(feed *model.Feed, entry *model.Entry, botToken, chatID string, topicID *int64, disableWebPagePreview, disableNotification bool, disableButtons bool) error {
+// PushEntry sends an entry to the chat, the action buttons send the commands of the entry back to the bot.
+func PushEntry(feed *model.Feed, entry *model.Entry, botToken, chatID string, topicID *int64, disableWebPagePreview, disableNotification bool, disableButtons bool, actionButtons bool) error {
 	formattedText := fmt.Sprintf(
 		`<b>%s</b> - <a href=%q>%s</a>`,
 		feed.Title,
@@ -59,6 +60,19 @@ func PushEntry(feed *model.Feed, entry *model.Entry, botToken, chatID string, to
 		message.ReplyMarkup.InlineKeyboard = append(message.ReplyMarkup.InlineKeyboard, markupRow)
 	}
 
+	if actionButtons {
+		if message.ReplyMarkup == nil {
+			message.ReplyMarkup = &InlineKeyboard{}
+		}
+
+		entryID := strconv.FormatInt(entry.ID, 10)
+		message.ReplyMarkup.InlineKeyboard = append(message.ReplyMarkup.InlineKeyboard, InlineKeyboardRow{
+			{Text: "Mark as read", CallbackData: "read " + entryID},
+			{Text: "Star", CallbackData: "star " + entryID},
+			{Text: "Save", CallbackData: "save " + entryID},
+		})
+	}
+
 	client := NewClient(botToken, chatID)
 	_, err := client.SendMessage(message)
 	return err
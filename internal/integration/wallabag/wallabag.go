@@ -8,8 +8,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -90,6 +92,131 @@ func (c *Client) createEntry(accessToken, entryURL, entryTitle, entryContent, ta
 	return nil
 }
 
+// Entry is an entry returned by the API.
+type Entry struct {
+	ID          int64
+	URL         string
+	Title       string
+	Content     string
+	Authors     []string
+	Tags        []string
+	IsStarred   bool
+	CreatedAt   time.Time
+	PublishedAt time.Time
+}
+
+// Entries returns at most limit entries updated since the given date, all of them when the date is zero.
+// There is no limit when it is zero.
+// https://app.wallabag.it/api/doc/#get--api-entries.{_format}
+func (c *Client) Entries(since time.Time, limit int) ([]*Entry, error) {
+	if c.baseURL == "" || c.clientID == "" || c.clientSecret == "" || c.username == "" || c.password == "" {
+		return nil, errors.New("wallabag: missing base URL, client ID, client secret, username or password")
+	}
+
+	accessToken, err := c.getAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/api/entries.json")
+	if err != nil {
+		return nil, fmt.Errorf("wallabag: unable to generate entries endpoint: %v", err)
+	}
+
+	var entries []*Entry
+	for page := 1; limit <= 0 || len(entries) < limit; page++ {
+		query := url.Values{}
+		query.Set("sort", "updated")
+		query.Set("order", "desc")
+		query.Set("perPage", "100")
+		query.Set("page", strconv.Itoa(page))
+		if !since.IsZero() {
+			query.Set("since", strconv.FormatInt(since.Unix(), 10))
+		}
+
+		var response entriesResponse
+		if err := c.doRequest(accessToken, http.MethodGet, apiEndpoint+"?"+query.Encode(), nil, &response); err != nil {
+			return nil, err
+		}
+
+		for _, item := range response.Embedded.Items {
+			entries = append(entries, item.entry())
+		}
+		if len(response.Embedded.Items) == 0 || page >= response.Pages {
+			break
+		}
+	}
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// SetStarred stars or unstars an entry.
+func (c *Client) SetStarred(entryID string, starred bool) error {
+	if c.baseURL == "" || c.clientID == "" || c.clientSecret == "" || c.username == "" || c.password == "" {
+		return errors.New("wallabag: missing base URL, client ID, client secret, username or password")
+	}
+
+	accessToken, err := c.getAccessToken()
+	if err != nil {
+		return err
+	}
+
+	apiEndpoint, err := urllib.JoinBaseURLAndPath(c.baseURL, "/api/entries/"+url.PathEscape(entryID)+".json")
+	if err != nil {
+		return fmt.Errorf("wallabag: unable to generate entry endpoint: %v", err)
+	}
+
+	request := &updateEntryRequest{}
+	if starred {
+		request.Starred = 1
+	}
+	return c.doRequest(accessToken, http.MethodPatch, apiEndpoint, request, nil)
+}
+
+func (c *Client) doRequest(accessToken, method, apiEndpoint string, body, result any) error {
+	var requestBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("wallabag: unable to encode request body: %v", err)
+		}
+		requestBody = bytes.NewReader(data)
+	}
+
+	request, err := http.NewRequest(method, apiEndpoint, requestBody)
+	if err != nil {
+		return fmt.Errorf("wallabag: unable to create request: %v", err)
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("wallabag: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("wallabag: unexpected response: url=%s status=%d", apiEndpoint, response.StatusCode)
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+			return fmt.Errorf("wallabag: unable to decode response: %v", err)
+		}
+	}
+	return nil
+}
+
 func (c *Client) getAccessToken() (string, error) {
 	values := url.Values{}
 	values.Add("grant_type", "password")
@@ -145,3 +272,70 @@ type createEntryRequest struct {
 	Content string `json:"content,omitempty"`
 	Tags    string `json:"tags,omitempty"`
 }
+
+type updateEntryRequest struct {
+	Starred int `json:"starred"`
+}
+
+type entriesResponse struct {
+	Pages    int `json:"pages"`
+	Embedded struct {
+		Items []*entryItem `json:"items"`
+	} `json:"_embedded"`
+}
+
+type entryItem struct {
+	ID          int64    `json:"id"`
+	URL         string   `json:"url"`
+	Title       string   `json:"title"`
+	Content     string   `json:"content"`
+	PublishedBy []string `json:"published_by"`
+	IsStarred   flag     `json:"is_starred"`
+	CreatedAt   string   `json:"created_at"`
+	PublishedAt string   `json:"published_at"`
+	Tags        []struct {
+		Label string `json:"label"`
+	} `json:"tags"`
+}
+
+func (e *entryItem) entry() *Entry {
+	entry := &Entry{
+		ID:          e.ID,
+		URL:         e.URL,
+		Title:       e.Title,
+		Content:     e.Content,
+		Authors:     e.PublishedBy,
+		IsStarred:   bool(e.IsStarred),
+		CreatedAt:   parseDate(e.CreatedAt),
+		PublishedAt: parseDate(e.PublishedAt),
+	}
+	for _, tag := range e.Tags {
+		entry.Tags = append(entry.Tags, tag.Label)
+	}
+	return entry
+}
+
+// flag is a boolean encoded as 0 or 1 by the API.
+type flag bool
+
+func (f *flag) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "1", "true":
+		*f = true
+	case "0", "false", "null":
+		*f = false
+	default:
+		return fmt.Errorf("wallabag: invalid flag %s", data)
+	}
+	return nil
+}
+
+// parseDate parses the ISO 8601 dates of the API, the offset has no colon.
+func parseDate(value string) time.Time {
+	for _, layout := range []string{"2006-01-02T15:04:05-0700", time.RFC3339} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date
+		}
+	}
+	return time.Time{}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateEntry(t *testing.T) {
@@ -319,3 +320,75 @@ func TestNewClient(t *testing.T) {
 		})
 	}
 }
+
+func TestEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/oauth/v2/token") {
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "test-token"})
+			return
+		}
+		if since := r.URL.Query().Get("since"); since != "1760781600" {
+			t.Errorf("Unexpected since parameter: %q", since)
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Write([]byte(`{"pages":2,"_embedded":{"items":[{"id":1,"url":"https://example.org/1","title":"First","published_by":["Alice"],"is_starred":1,"created_at":"2025-10-18T10:00:00+0200","tags":[{"label":"news"}]}]}}`))
+		case "2":
+			w.Write([]byte(`{"pages":2,"_embedded":{"items":[{"id":2,"url":"https://example.org/2","title":"Second","is_starred":false,"created_at":"2025-10-18T10:00:00+0200","published_at":"2025-10-17T10:00:00+00:00"}]}}`))
+		default:
+			t.Errorf("Unexpected page: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "clientId", "clientSecret", "username", "password", "", false)
+	entries, err := client.Entries(time.Unix(1760781600, 0), 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %d", len(entries))
+	}
+
+	first := entries[0]
+	if first.ID != 1 || first.URL != "https://example.org/1" || !first.IsStarred || len(first.Authors) != 1 || len(first.Tags) != 1 || first.Tags[0] != "news" {
+		t.Errorf("Unexpected entry: %+v", first)
+	}
+	if !first.CreatedAt.Equal(time.Date(2025, time.October, 18, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected creation date: %v", first.CreatedAt)
+	}
+
+	second := entries[1]
+	if second.IsStarred || !second.PublishedAt.Equal(time.Date(2025, time.October, 17, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected entry: %+v", second)
+	}
+
+	if entries, err := client.Entries(time.Unix(1760781600, 0), 1); err != nil || len(entries) != 1 {
+		t.Errorf("Expected one entry with a limit, got %d: %v", len(entries), err)
+	}
+}
+
+func TestSetStarred(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/oauth/v2/token") {
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "test-token"})
+			return
+		}
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/entries/42.json" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"starred":1}` {
+			t.Errorf("Unexpected request body: %s", body)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "clientId", "clientSecret", "username", "password", "", false)
+	if err := client.SetStarred("42", true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
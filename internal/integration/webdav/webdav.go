@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package webdav // import "miniflux.app/v2/internal/integration/webdav"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/version"
+)
+
+const defaultClientTimeout = 30 * time.Second
+
+type Client struct {
+	folderURL string
+	username  string
+	password  string
+}
+
+func NewClient(folderURL, username, password string) *Client {
+	return &Client{folderURL: folderURL, username: username, password: password}
+}
+
+// Upload creates or replaces the file in the folder, the folder must exist.
+func (c *Client) Upload(filename, contentType string, content []byte) error {
+	if c.folderURL == "" {
+		return errors.New("webdav: missing folder URL")
+	}
+
+	fileURL, err := url.JoinPath(strings.TrimSuffix(c.folderURL, "/")+"/", url.PathEscape(filename))
+	if err != nil {
+		return fmt.Errorf("webdav: invalid folder URL: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, fileURL, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("webdav: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	if c.username != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("webdav: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("webdav: unable to upload file: url=%s status=%d", fileURL, response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package webdav // import "miniflux.app/v2/internal/integration/webdav"
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpload(t *testing.T) {
+	var method, path, contentType, username, password, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.EscapedPath()
+		contentType = r.Header.Get("Content-Type")
+		username, password, _ = r.BasicAuth()
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/remote.php/dav/files/alice/Books", "alice", "secret")
+	if err := client.Upload("Tom & Jerry?.epub", "application/epub+zip", []byte("content")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if method != http.MethodPut {
+		t.Errorf("Unexpected method %q", method)
+	}
+	if path != "/remote.php/dav/files/alice/Books/Tom%20&%20Jerry%3F.epub" {
+		t.Errorf("Unexpected path %q", path)
+	}
+	if contentType != "application/epub+zip" || body != "content" {
+		t.Errorf("Unexpected content %q: %q", contentType, body)
+	}
+	if username != "alice" || password != "secret" {
+		t.Errorf("Unexpected credentials %q:%q", username, password)
+	}
+}
+
+func TestUploadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL+"/missing/", "", "").Upload("book.epub", "application/epub+zip", nil)
+	if err == nil || !strings.Contains(err.Error(), "status=409") {
+		t.Errorf("Expected an upload error, got %v", err)
+	}
+
+	if err := NewClient("", "", "").Upload("book.epub", "application/epub+zip", nil); err == nil {
+		t.Error("Expected an error without folder URL")
+	}
+}
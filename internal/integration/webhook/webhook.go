@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"text/template"
 	"time"
 
 	"miniflux.app/v2/internal/crypto"
@@ -19,96 +20,87 @@ import (
 const (
 	defaultClientTimeout = 10 * time.Second
 
-	NewEntriesEventType = "new_entries"
-	SaveEntryEventType  = "save_entry"
+	NewEntriesEventType = model.EventNewEntries
+	SaveEntryEventType  = model.EventSaveEntry
 )
 
 type Client struct {
 	webhookURL    string
 	webhookSecret string
+	bodyTemplate  *template.Template
 }
 
 func NewClient(webhookURL, webhookSecret string) *Client {
-	return &Client{webhookURL, webhookSecret}
+	return &Client{webhookURL: webhookURL, webhookSecret: webhookSecret}
+}
+
+// WithBodyTemplate replaces the JSON encoded event by the output of the template, the event is the template data.
+func (c *Client) WithBodyTemplate(bodyTemplate string) error {
+	if bodyTemplate == "" {
+		c.bodyTemplate = nil
+		return nil
+	}
+
+	tpl, err := ParseBodyTemplate(bodyTemplate)
+	if err != nil {
+		return err
+	}
+	c.bodyTemplate = tpl
+	return nil
+}
+
+// ParseBodyTemplate parses a request body template, the "json" function encodes a value in JSON.
+func ParseBodyTemplate(bodyTemplate string) (*template.Template, error) {
+	tpl, err := template.New("body").Option("missingkey=error").Funcs(template.FuncMap{
+		"json": func(value any) (string, error) {
+			encoded, err := json.Marshal(value)
+			return string(encoded), err
+		},
+	}).Parse(bodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: invalid body template: %v", err)
+	}
+	return tpl, nil
 }
 
 func (c *Client) SendSaveEntryWebhookEvent(entry *model.Entry) error {
+	webhookEntry := newWebhookEntry(entry)
+	if entry.Feed != nil {
+		webhookEntry.Feed = newWebhookFeed(entry.Feed)
+	}
+
 	return c.makeRequest(SaveEntryEventType, &WebhookSaveEntryEvent{
 		EventType: SaveEntryEventType,
-		Entry: &WebhookEntry{
-			ID:          entry.ID,
-			UserID:      entry.UserID,
-			FeedID:      entry.FeedID,
-			Status:      entry.Status,
-			Hash:        entry.Hash,
-			Title:       entry.Title,
-			URL:         entry.URL,
-			CommentsURL: entry.CommentsURL,
-			Date:        entry.Date,
-			CreatedAt:   entry.CreatedAt,
-			ChangedAt:   entry.ChangedAt,
-			Content:     entry.Content,
-			Author:      entry.Author,
-			ShareCode:   entry.ShareCode,
-			Starred:     entry.Starred,
-			ReadingTime: entry.ReadingTime,
-			Enclosures:  entry.Enclosures,
-			Tags:        entry.Tags,
-			Feed: &WebhookFeed{
-				ID:         entry.Feed.ID,
-				UserID:     entry.Feed.UserID,
-				CategoryID: entry.Feed.Category.ID,
-				Category:   &WebhookCategory{ID: entry.Feed.Category.ID, Title: entry.Feed.Category.Title},
-				FeedURL:    entry.Feed.FeedURL,
-				SiteURL:    entry.Feed.SiteURL,
-				Title:      entry.Feed.Title,
-				CheckedAt:  entry.Feed.CheckedAt,
-			},
-		},
+		Entry:     webhookEntry,
 	})
 }
 
 func (c *Client) SendNewEntriesWebhookEvent(feed *model.Feed, entries model.Entries) error {
+	return c.SendEntriesWebhookEvent(NewEntriesEventType, feed, entries)
+}
+
+// SendEntriesWebhookEvent sends an event about some entries of a feed.
+func (c *Client) SendEntriesWebhookEvent(eventType string, feed *model.Feed, entries model.Entries) error {
 	if len(entries) == 0 {
 		return nil
 	}
 
 	webhookEntries := make([]*WebhookEntry, 0, len(entries))
 	for _, entry := range entries {
-		webhookEntries = append(webhookEntries, &WebhookEntry{
-			ID:          entry.ID,
-			UserID:      entry.UserID,
-			FeedID:      entry.FeedID,
-			Status:      entry.Status,
-			Hash:        entry.Hash,
-			Title:       entry.Title,
-			URL:         entry.URL,
-			CommentsURL: entry.CommentsURL,
-			Date:        entry.Date,
-			CreatedAt:   entry.CreatedAt,
-			ChangedAt:   entry.ChangedAt,
-			Content:     entry.Content,
-			Author:      entry.Author,
-			ShareCode:   entry.ShareCode,
-			Starred:     entry.Starred,
-			ReadingTime: entry.ReadingTime,
-			Enclosures:  entry.Enclosures,
-			Tags:        entry.Tags,
-		})
+		webhookEntries = append(webhookEntries, newWebhookEntry(entry))
 	}
-	return c.makeRequest(NewEntriesEventType, &WebhookNewEntriesEvent{
-		EventType: NewEntriesEventType,
-		Feed: &WebhookFeed{
-			ID:         feed.ID,
-			UserID:     feed.UserID,
-			CategoryID: feed.Category.ID,
-			Category:   &WebhookCategory{ID: feed.Category.ID, Title: feed.Category.Title},
-			FeedURL:    feed.FeedURL,
-			SiteURL:    feed.SiteURL,
-			Title:      feed.Title,
-			CheckedAt:  feed.CheckedAt,
-		},
-		Entries: webhookEntries,
+	return c.makeRequest(eventType, &WebhookEntriesEvent{
+		EventType: eventType,
+		Feed:      newWebhookFeed(feed),
+		Entries:   webhookEntries,
+	})
+}
+
+// SendFeedWebhookEvent sends an event about a feed.
+func (c *Client) SendFeedWebhookEvent(eventType string, feed *model.Feed) error {
+	return c.makeRequest(eventType, &WebhookFeedEvent{
+		EventType: eventType,
+		Feed:      newWebhookFeed(feed),
 	})
 }
 
@@ -117,9 +109,18 @@ func (c *Client) makeRequest(eventType string, payload any) error {
 		return errors.New(`webhook: missing webhook URL`)
 	}
 
-	requestBody, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("webhook: unable to encode request body: %v", err)
+	var requestBody []byte
+	if c.bodyTemplate != nil {
+		var buffer bytes.Buffer
+		if err := c.bodyTemplate.Execute(&buffer, payload); err != nil {
+			return fmt.Errorf("webhook: unable to render request body: %v", err)
+		}
+		requestBody = buffer.Bytes()
+	} else {
+		var err error
+		if requestBody, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("webhook: unable to encode request body: %v", err)
+		}
 	}
 
 	request, err := http.NewRequest(http.MethodPost, c.webhookURL, bytes.NewReader(requestBody))
@@ -146,15 +147,58 @@ func (c *Client) makeRequest(eventType string, payload any) error {
 	return nil
 }
 
+func newWebhookFeed(feed *model.Feed) *WebhookFeed {
+	webhookFeed := &WebhookFeed{
+		ID:                  feed.ID,
+		UserID:              feed.UserID,
+		FeedURL:             feed.FeedURL,
+		SiteURL:             feed.SiteURL,
+		Title:               feed.Title,
+		CheckedAt:           feed.CheckedAt,
+		ParsingErrorCount:   feed.ParsingErrorCount,
+		ParsingErrorMessage: feed.ParsingErrorMsg,
+	}
+	if feed.Category != nil {
+		webhookFeed.CategoryID = feed.Category.ID
+		webhookFeed.Category = &WebhookCategory{ID: feed.Category.ID, Title: feed.Category.Title}
+	}
+	return webhookFeed
+}
+
+func newWebhookEntry(entry *model.Entry) *WebhookEntry {
+	return &WebhookEntry{
+		ID:          entry.ID,
+		UserID:      entry.UserID,
+		FeedID:      entry.FeedID,
+		Status:      entry.Status,
+		Hash:        entry.Hash,
+		Title:       entry.Title,
+		URL:         entry.URL,
+		CommentsURL: entry.CommentsURL,
+		Date:        entry.Date,
+		CreatedAt:   entry.CreatedAt,
+		ChangedAt:   entry.ChangedAt,
+		Content:     entry.Content,
+		Author:      entry.Author,
+		ShareCode:   entry.ShareCode,
+		Starred:     entry.Starred,
+		ReadingTime: entry.ReadingTime,
+		Enclosures:  entry.Enclosures,
+		Tags:        entry.Tags,
+	}
+}
+
 type WebhookFeed struct {
-	ID         int64            `json:"id"`
-	UserID     int64            `json:"user_id"`
-	CategoryID int64            `json:"category_id"`
-	Category   *WebhookCategory `json:"category,omitempty"`
-	FeedURL    string           `json:"feed_url"`
-	SiteURL    string           `json:"site_url"`
-	Title      string           `json:"title"`
-	CheckedAt  time.Time        `json:"checked_at"`
+	ID                  int64            `json:"id"`
+	UserID              int64            `json:"user_id"`
+	CategoryID          int64            `json:"category_id"`
+	Category            *WebhookCategory `json:"category,omitempty"`
+	FeedURL             string           `json:"feed_url"`
+	SiteURL             string           `json:"site_url"`
+	Title               string           `json:"title"`
+	CheckedAt           time.Time        `json:"checked_at"`
+	ParsingErrorCount   int              `json:"parsing_error_count,omitempty"`
+	ParsingErrorMessage string           `json:"parsing_error_message,omitempty"`
 }
 
 type WebhookCategory struct {
@@ -184,7 +228,8 @@ type WebhookEntry struct {
 	Feed        *WebhookFeed        `json:"feed,omitempty"`
 }
 
-type WebhookNewEntriesEvent struct {
+// WebhookEntriesEvent is sent for the new entries and the changes made to existing entries.
+type WebhookEntriesEvent struct {
 	EventType string          `json:"event_type"`
 	Feed      *WebhookFeed    `json:"feed"`
 	Entries   []*WebhookEntry `json:"entries"`
@@ -194,3 +239,9 @@ type WebhookSaveEntryEvent struct {
 	EventType string        `json:"event_type"`
 	Entry     *WebhookEntry `json:"entry"`
 }
+
+// WebhookFeedEvent is sent when a feed is created, removed, starts failing or recovers.
+type WebhookFeedEvent struct {
+	EventType string       `json:"event_type"`
+	Feed      *WebhookFeed `json:"feed"`
+}
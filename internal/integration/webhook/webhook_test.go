@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+)
+
+type webhookRequest struct {
+	eventType string
+	signature string
+	body      []byte
+}
+
+func newTestServer(t *testing.T, statusCode int) (*httptest.Server, *webhookRequest) {
+	t.Helper()
+
+	received := &webhookRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Unable to read request body: %v", err)
+		}
+		received.eventType = r.Header.Get("X-Miniflux-Event-Type")
+		received.signature = r.Header.Get("X-Miniflux-Signature")
+		received.body = body
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func testFeed() *model.Feed {
+	return &model.Feed{
+		ID:       1,
+		UserID:   2,
+		Title:    "Example",
+		FeedURL:  "https://example.org/feed.xml",
+		Category: &model.Category{ID: 3, Title: "News"},
+	}
+}
+
+func TestSendEntriesWebhookEvent(t *testing.T) {
+	server, received := newTestServer(t, http.StatusOK)
+
+	client := NewClient(server.URL, "secret")
+	entries := model.Entries{{ID: 10, FeedID: 1, Title: "First", Status: model.EntryStatusRead}}
+	if err := client.SendEntriesWebhookEvent(model.EventEntryRead, testFeed(), entries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if received.eventType != model.EventEntryRead {
+		t.Errorf("Expected event type %q, got %q", model.EventEntryRead, received.eventType)
+	}
+	if expected := crypto.GenerateSHA256Hmac("secret", received.body); received.signature != expected {
+		t.Errorf("Expected signature %q, got %q", expected, received.signature)
+	}
+
+	var event WebhookEntriesEvent
+	if err := json.Unmarshal(received.body, &event); err != nil {
+		t.Fatalf("Unable to decode request body: %v", err)
+	}
+	if event.EventType != model.EventEntryRead || event.Feed.Category.Title != "News" || len(event.Entries) != 1 || event.Entries[0].Title != "First" {
+		t.Errorf("Unexpected event: %s", received.body)
+	}
+}
+
+func TestSendEntriesWebhookEventWithoutEntries(t *testing.T) {
+	client := NewClient("http://127.0.0.1:0", "secret")
+	if err := client.SendEntriesWebhookEvent(model.EventEntryRead, testFeed(), nil); err != nil {
+		t.Errorf("Expected no request without entries, got %v", err)
+	}
+}
+
+func TestSendFeedWebhookEvent(t *testing.T) {
+	server, received := newTestServer(t, http.StatusOK)
+
+	feed := testFeed()
+	feed.ParsingErrorCount = 1
+	feed.ParsingErrorMsg = "timeout"
+	feed.Category = nil
+
+	client := NewClient(server.URL, "secret")
+	if err := client.SendFeedWebhookEvent(model.EventFeedError, feed); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var event WebhookFeedEvent
+	if err := json.Unmarshal(received.body, &event); err != nil {
+		t.Fatalf("Unable to decode request body: %v", err)
+	}
+	if event.EventType != model.EventFeedError || event.Feed.ParsingErrorMessage != "timeout" || event.Feed.Category != nil {
+		t.Errorf("Unexpected event: %s", received.body)
+	}
+}
+
+func TestSendWebhookEventWithBodyTemplate(t *testing.T) {
+	server, received := newTestServer(t, http.StatusOK)
+
+	client := NewClient(server.URL, "secret")
+	if err := client.WithBodyTemplate(`{"text": {{ json (printf "%s: %s" .EventType .Feed.Title) }}}`); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendFeedWebhookEvent(model.EventFeedCreated, testFeed()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := `{"text": "feed_created: Example"}`; string(received.body) != expected {
+		t.Errorf("Expected body %s, got %s", expected, received.body)
+	}
+	if expected := crypto.GenerateSHA256Hmac("secret", received.body); received.signature != expected {
+		t.Errorf("Expected the rendered body to be signed")
+	}
+}
+
+func TestSendWebhookEventWithFailingBodyTemplate(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK)
+
+	client := NewClient(server.URL, "secret")
+	if err := client.WithBodyTemplate(`{{ .Entry.Title }}`); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := client.SendFeedWebhookEvent(model.EventFeedCreated, testFeed())
+	if err == nil || !strings.Contains(err.Error(), "unable to render request body") {
+		t.Errorf("Expected a rendering error, got %v", err)
+	}
+}
+
+func TestParseBodyTemplate(t *testing.T) {
+	if _, err := ParseBodyTemplate(`{{ .Feed.Title `); err == nil {
+		t.Error("Expected an error for an invalid template")
+	}
+	if _, err := ParseBodyTemplate(`{{ unknown .Feed }}`); err == nil {
+		t.Error("Expected an error for an unknown function")
+	}
+}
+
+func TestSendWebhookEventErrorStatus(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusInternalServerError)
+
+	client := NewClient(server.URL, "secret")
+	if err := client.SendFeedWebhookEvent(model.EventFeedRemoved, testFeed()); err == nil {
+		t.Error("Expected an error for a failing webhook")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"time"
 
+	"miniflux.app/v2/internal/proxyrotator"
 	"miniflux.app/v2/internal/storage"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -44,6 +45,23 @@ var (
 		[]string{"status"},
 	)
 
+	WorkerQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "worker_queue_depth",
+			Help:      "Number of feed refresh jobs waiting in the queue by priority",
+		},
+		[]string{"priority"},
+	)
+
+	WorkerJobsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "worker_jobs_in_flight",
+			Help:      "Number of feed refresh jobs being processed by the background workers",
+		},
+	)
+
 	usersGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -133,6 +151,33 @@ var (
 			Help:      "The total number of connections closed due to SetConnMaxLifetime",
 		},
 	)
+
+	proxyHealthyGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "proxy_healthy",
+			Help:      "Whether the rotated proxy is in rotation (1) or backed off (0)",
+		},
+		[]string{"proxy"},
+	)
+
+	proxyRequestsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "proxy_requests",
+			Help:      "Number of requests made through the rotated proxy by status",
+		},
+		[]string{"proxy", "status"},
+	)
+
+	proxyLatencyGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "proxy_latency_seconds",
+			Help:      "Average latency of the requests made through the rotated proxy",
+		},
+		[]string{"proxy"},
+	)
 )
 
 // collector represents a metric collector.
@@ -146,6 +191,8 @@ func NewCollector(store *storage.Storage, refreshInterval time.Duration) *collec
 	prometheus.MustRegister(BackgroundFeedRefreshDuration)
 	prometheus.MustRegister(ScraperRequestDuration)
 	prometheus.MustRegister(ArchiveEntriesDuration)
+	prometheus.MustRegister(WorkerQueueDepth)
+	prometheus.MustRegister(WorkerJobsInFlight)
 	prometheus.MustRegister(usersGauge)
 	prometheus.MustRegister(feedsGauge)
 	prometheus.MustRegister(brokenFeedsGauge)
@@ -157,6 +204,9 @@ func NewCollector(store *storage.Storage, refreshInterval time.Duration) *collec
 	prometheus.MustRegister(dbConnectionsMaxIdleClosedGauge)
 	prometheus.MustRegister(dbConnectionsMaxIdleTimeClosedGauge)
 	prometheus.MustRegister(dbConnectionsMaxLifetimeClosedGauge)
+	prometheus.MustRegister(proxyHealthyGauge)
+	prometheus.MustRegister(proxyRequestsGauge)
+	prometheus.MustRegister(proxyLatencyGauge)
 
 	return &collector{store, refreshInterval}
 }
@@ -187,5 +237,24 @@ func (c *collector) GatherStorageMetrics() {
 		dbConnectionsMaxIdleClosedGauge.Set(float64(dbStats.MaxIdleClosed))
 		dbConnectionsMaxIdleTimeClosedGauge.Set(float64(dbStats.MaxIdleTimeClosed))
 		dbConnectionsMaxLifetimeClosedGauge.Set(float64(dbStats.MaxLifetimeClosed))
+
+		gatherProxyMetrics()
+	}
+}
+
+func gatherProxyMetrics() {
+	if proxyrotator.ProxyRotatorInstance == nil {
+		return
+	}
+
+	for _, proxy := range proxyrotator.ProxyRotatorInstance.Stats() {
+		healthy := 0.0
+		if proxy.Healthy {
+			healthy = 1
+		}
+		proxyHealthyGauge.WithLabelValues(proxy.URL).Set(healthy)
+		proxyRequestsGauge.WithLabelValues(proxy.URL, "success").Set(float64(proxy.Successes))
+		proxyRequestsGauge.WithLabelValues(proxy.URL, "failure").Set(float64(proxy.Failures))
+		proxyLatencyGauge.WithLabelValues(proxy.URL).Set(proxy.Latency.Seconds())
 	}
 }
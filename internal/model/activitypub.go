@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// ActivityPubKey is the key pair signing the requests of the ActivityPub actor of a user, the keys are PEM encoded.
+type ActivityPubKey struct {
+	UserID     int64
+	PublicKey  string
+	PrivateKey string
+	CreatedAt  time.Time
+}
+
+// ActivityPubFollower is a remote actor following the ActivityPub actor of a user.
+type ActivityPubFollower struct {
+	ID       int64
+	UserID   int64
+	ActorURL string
+	InboxURL string
+
+	// SharedInboxURL receives a single copy of the activities for all the followers of the same server.
+	SharedInboxURL string
+
+	CreatedAt time.Time
+}
+
+// ActivityPubObject is an entry published by the ActivityPub actor of a user,
+// its identifier is the URL of the shared entry.
+type ActivityPubObject struct {
+	UserID     int64
+	EntryID    int64
+	ShareCode  string
+	ObjectType string
+
+	// Starred is true when the entry is published because it was starred, it is retracted when unstarred.
+	Starred bool
+
+	PublishedAt time.Time
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// ClientCertificate represents the TLS client certificate and the private certificate authorities
+// used to fetch the feeds of a user. The private key is never returned by the API.
+type ClientCertificate struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Name        string    `json:"name"`
+	Certificate string    `json:"certificate"`
+	PrivateKey  string    `json:"-"`
+	CABundle    string    `json:"ca_bundle"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ClientCertificates represents a list of client certificates.
+type ClientCertificates []*ClientCertificate
+
+// ClientCertificateCreationRequest represents the request to upload a client certificate.
+type ClientCertificateCreationRequest struct {
+	Name        string `json:"name"`
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"private_key"`
+	CABundle    string `json:"ca_bundle"`
+}
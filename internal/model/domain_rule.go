@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/urllib"
+)
+
+// DomainRule represents scraper and rewrite rules registered for a website domain.
+// Global rules are managed by administrators and have no owner (UserID is 0).
+type DomainRule struct {
+	ID           int64     `json:"id"`
+	UserID       int64     `json:"user_id"`
+	Domain       string    `json:"domain"`
+	ScraperRules string    `json:"scraper_rules"`
+	RewriteRules string    `json:"rewrite_rules"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// IsGlobal returns true if the rule applies to all users.
+func (d *DomainRule) IsGlobal() bool {
+	return d.UserID == 0
+}
+
+// IsEditableBy returns true if the given user is allowed to modify the rule.
+func (d *DomainRule) IsEditableBy(user *User) bool {
+	if d.IsGlobal() {
+		return user.IsAdmin
+	}
+	return d.UserID == user.ID
+}
+
+// NewDomainRule returns a rule owned by the given user, or a global rule if requested.
+func NewDomainRule(userID int64, request *DomainRuleCreationRequest) *DomainRule {
+	rule := &DomainRule{
+		UserID:       userID,
+		Domain:       NormalizeRuleDomain(request.Domain),
+		ScraperRules: request.ScraperRules,
+		RewriteRules: request.RewriteRules,
+	}
+	if request.Global {
+		rule.UserID = 0
+	}
+	return rule
+}
+
+// DomainRules represents a list of domain rules.
+type DomainRules []*DomainRule
+
+// Export returns the rules in the format used to import them.
+// The global rules are only exported for the administrators, the other users cannot import them.
+func (d DomainRules) Export(includeGlobal bool) []DomainRuleCreationRequest {
+	export := make([]DomainRuleCreationRequest, 0, len(d))
+	for _, rule := range d {
+		if rule.IsGlobal() && !includeGlobal {
+			continue
+		}
+		export = append(export, DomainRuleCreationRequest{
+			Domain:       rule.Domain,
+			ScraperRules: rule.ScraperRules,
+			RewriteRules: rule.RewriteRules,
+			Global:       rule.IsGlobal(),
+		})
+	}
+	return export
+}
+
+// DomainRuleCreationRequest represents the request to create a domain rule.
+// This is also the format used to import and export the registry as JSON.
+type DomainRuleCreationRequest struct {
+	Domain       string `json:"domain"`
+	ScraperRules string `json:"scraper_rules"`
+	RewriteRules string `json:"rewrite_rules"`
+	Global       bool   `json:"global"`
+}
+
+// DomainRuleModificationRequest represents the request to update a domain rule.
+type DomainRuleModificationRequest struct {
+	Domain       *string `json:"domain"`
+	ScraperRules *string `json:"scraper_rules"`
+	RewriteRules *string `json:"rewrite_rules"`
+}
+
+// Patch updates a domain rule with modified values.
+func (d *DomainRuleModificationRequest) Patch(rule *DomainRule) {
+	if d.Domain != nil && *d.Domain != "" {
+		rule.Domain = NormalizeRuleDomain(*d.Domain)
+	}
+
+	if d.ScraperRules != nil {
+		rule.ScraperRules = *d.ScraperRules
+	}
+
+	if d.RewriteRules != nil {
+		rule.RewriteRules = *d.RewriteRules
+	}
+}
+
+// NormalizeRuleDomain returns the domain in the form used for lookups:
+// lowercase, without scheme, path or "www." prefix.
+func NormalizeRuleDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if strings.Contains(domain, "://") {
+		domain = urllib.Domain(domain)
+	}
+	domain = strings.TrimSuffix(domain, ".")
+	return strings.TrimPrefix(domain, "www.")
+}
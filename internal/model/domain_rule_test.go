@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "testing"
+
+func TestNormalizeRuleDomain(t *testing.T) {
+	scenarios := map[string]string{
+		"example.org":                     "example.org",
+		" Example.ORG ":                   "example.org",
+		"www.example.org":                 "example.org",
+		"example.org.":                    "example.org",
+		"https://www.example.org/a/b?c=d": "example.org",
+	}
+
+	for input, expected := range scenarios {
+		if actual := NormalizeRuleDomain(input); actual != expected {
+			t.Errorf(`Unexpected domain for %q, got %q instead of %q`, input, actual, expected)
+		}
+	}
+}
+
+func TestDomainRuleIsEditableBy(t *testing.T) {
+	admin := &User{ID: 1, IsAdmin: true}
+	user := &User{ID: 2}
+
+	globalRule := &DomainRule{UserID: 0}
+	if !globalRule.IsEditableBy(admin) {
+		t.Error(`Administrators should be able to edit global rules`)
+	}
+	if globalRule.IsEditableBy(user) {
+		t.Error(`Regular users should not be able to edit global rules`)
+	}
+
+	personalRule := &DomainRule{UserID: 2}
+	if !personalRule.IsEditableBy(user) {
+		t.Error(`Users should be able to edit their own rules`)
+	}
+	if personalRule.IsEditableBy(admin) {
+		t.Error(`Users should not be able to edit rules owned by someone else`)
+	}
+}
+
+func TestNewGlobalDomainRule(t *testing.T) {
+	rule := NewDomainRule(42, &DomainRuleCreationRequest{Domain: "www.Example.org", ScraperRules: "article", Global: true})
+	if !rule.IsGlobal() {
+		t.Error(`The rule should be global`)
+	}
+	if rule.Domain != "example.org" {
+		t.Errorf(`Unexpected domain: %q`, rule.Domain)
+	}
+}
+
+func TestExportDomainRules(t *testing.T) {
+	rules := DomainRules{
+		{UserID: 0, Domain: "example.org", ScraperRules: "article"},
+		{UserID: 2, Domain: "example.com", RewriteRules: "add_youtube_video"},
+	}
+
+	if export := rules.Export(true); len(export) != 2 || !export[0].Global || export[1].Global {
+		t.Errorf(`Expected the global rules to be exported for the administrators, got %+v`, export)
+	}
+
+	export := rules.Export(false)
+	if len(export) != 1 || export[0].Domain != "example.com" || export[0].Global {
+		t.Errorf(`Expected only the personal rules to be exported, got %+v`, export)
+	}
+}
@@ -13,13 +13,18 @@ import (
 
 // List of supported schedulers.
 const (
-	SchedulerRoundRobin     = "round_robin"
-	SchedulerEntryFrequency = "entry_frequency"
+	SchedulerRoundRobin        = "round_robin"
+	SchedulerEntryFrequency    = "entry_frequency"
+	SchedulerPublishingPattern = "publishing_pattern"
 	// Default settings for the feed query builder
 	DefaultFeedSorting          = "parsing_error_count"
 	DefaultFeedSortingDirection = "desc"
 )
 
+// SavedFeedURL is the URL of the pseudo-feed receiving the bookmarks imported from the integrations,
+// the feed is disabled and never refreshed.
+const SavedFeedURL = "miniflux:saved"
+
 // Feed represents a feed in the application.
 type Feed struct {
 	ID                          int64     `json:"id"`
@@ -51,14 +56,7 @@ type Feed struct {
 	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
 	FetchViaProxy               bool      `json:"fetch_via_proxy"`
 	DisableHTTP2                bool      `json:"disable_http2"`
-	PushoverEnabled             bool      `json:"pushover_enabled"`
-	NtfyEnabled                 bool      `json:"ntfy_enabled"`
 	Crawler                     bool      `json:"crawler"`
-	AppriseServiceURLs          string    `json:"apprise_service_urls"`
-	WebhookURL                  string    `json:"webhook_url"`
-	NtfyPriority                int       `json:"ntfy_priority"`
-	NtfyTopic                   string    `json:"ntfy_topic"`
-	PushoverPriority            int       `json:"pushover_priority"`
 	ProxyURL                    string    `json:"proxy_url"`
 
 	// Non-persisted attributes
@@ -67,15 +65,72 @@ type Feed struct {
 	Entries  Entries   `json:"entries,omitempty"`
 
 	// Internal attributes (not exposed in the API and not persisted in the database)
-	TTL                    time.Duration `json:"-"`
-	IconURL                string        `json:"-"`
-	UnreadCount            int           `json:"-"`
-	ReadCount              int           `json:"-"`
-	NumberOfVisibleEntries int           `json:"-"`
+	TTL                    time.Duration      `json:"-"`
+	IconURL                string             `json:"-"`
+	UnreadCount            int                `json:"-"`
+	ReadCount              int                `json:"-"`
+	NumberOfVisibleEntries int                `json:"-"`
+	HubURL                 string             `json:"-"`
+	ArchiveURL             string             `json:"-"`
+	PublishingProfile      *PublishingProfile `json:"-"`
+	ClientCertificate      ClientCertificate  `json:"-"`
 
 	NSFW       bool   `json:"nsfw"`
 	View       string `json:"view"`
 	CacheMedia bool   `json:"cache_media"`
+
+	// Extractor forces a content extractor, empty means automatic.
+	Extractor string `json:"extractor"`
+
+	// DetectedExtractor is the extractor that gave the best result during the last automatic extraction.
+	DetectedExtractor string `json:"detected_extractor"`
+
+	// CustomHeaders are sent with the requests to the feed host, one "Name: value" per line.
+	// Lines starting with "?" add query string parameters. They are encrypted in the database when an encryption key is configured.
+	CustomHeaders string `json:"custom_headers"`
+
+	// CustomHeadersUnreadable is set when the saved custom headers cannot be decrypted with the current key.
+	// They are left empty and the saved value is kept until new custom headers are set or they are cleared explicitly.
+	CustomHeadersUnreadable bool `json:"-"`
+
+	// ClientCertificateID references the client certificate and CA bundle used to connect to the feed host, 0 if none.
+	ClientCertificateID int64 `json:"client_certificate_id"`
+
+	// Deprecated: the notification settings of the feed are replaced by the notification routes.
+	// These read-only values are derived from the routes of the feed, they are ignored when a feed is saved.
+	PushoverEnabled    bool   `json:"pushover_enabled"`
+	PushoverPriority   int    `json:"pushover_priority"`
+	NtfyEnabled        bool   `json:"ntfy_enabled"`
+	NtfyPriority       int    `json:"ntfy_priority"`
+	NtfyTopic          string `json:"ntfy_topic"`
+	AppriseServiceURLs string `json:"apprise_service_urls"`
+	WebhookURL         string `json:"webhook_url"`
+}
+
+// WithNotificationRoutes sets the deprecated notification settings from the first route of each provider for the feed.
+func (f *Feed) WithNotificationRoutes(routes NotificationRoutes) {
+	seen := make(map[string]bool)
+	for _, route := range routes {
+		if route.FeedID != f.ID || seen[route.Provider] {
+			continue
+		}
+		seen[route.Provider] = true
+
+		switch route.Provider {
+		case "pushover":
+			f.PushoverEnabled = true
+			f.PushoverPriority = route.Priority
+		case "ntfy":
+			// The priority of Ntfy goes from 1 to 5.
+			f.NtfyEnabled = true
+			f.NtfyPriority = route.Priority + 3
+			f.NtfyTopic = route.Target
+		case "apprise":
+			f.AppriseServiceURLs = route.Target
+		case "webhook":
+			f.WebhookURL = route.Target
+		}
+	}
 }
 
 type FeedCounters struct {
@@ -94,6 +149,11 @@ func (f *Feed) String() string {
 	)
 }
 
+// IsSavedFeed returns true for the pseudo-feed of the imported bookmarks.
+func (f *Feed) IsSavedFeed() bool {
+	return f.FeedURL == SavedFeedURL
+}
+
 // WithCategoryID initializes the category attribute of the feed.
 func (f *Feed) WithCategoryID(categoryID int64) {
 	f.Category = &Category{ID: categoryID}
@@ -125,7 +185,18 @@ func (f *Feed) ScheduleNextCheck(weeklyCount int, refreshDelay time.Duration) ti
 	// Default to the global config Polling Frequency.
 	interval := config.Opts.SchedulerRoundRobinMinInterval()
 
-	if config.Opts.PollingScheduler() == SchedulerEntryFrequency {
+	switch config.Opts.PollingScheduler() {
+	case SchedulerPublishingPattern:
+		if f.PublishingProfile != nil && f.PublishingProfile.Total() >= publishingProfileMinEntries {
+			expectedEntries := 1 / float64(config.Opts.SchedulerEntryFrequencyFactor())
+			interval = f.PublishingProfile.NextCheckInterval(time.Now(), expectedEntries, config.Opts.SchedulerEntryFrequencyMaxInterval())
+			interval = max(interval, config.Opts.SchedulerEntryFrequencyMinInterval())
+			break
+		}
+
+		// Not enough entries to learn from yet, use the average frequency.
+		fallthrough
+	case SchedulerEntryFrequency:
 		if weeklyCount <= 0 {
 			interval = config.Opts.SchedulerEntryFrequencyMaxInterval()
 		} else {
@@ -142,7 +213,7 @@ func (f *Feed) ScheduleNextCheck(weeklyCount int, refreshDelay time.Duration) ti
 	switch config.Opts.PollingScheduler() {
 	case SchedulerRoundRobin:
 		interval = min(interval, config.Opts.SchedulerRoundRobinMaxInterval())
-	case SchedulerEntryFrequency:
+	case SchedulerEntryFrequency, SchedulerPublishingPattern:
 		interval = min(interval, config.Opts.SchedulerEntryFrequencyMaxInterval())
 	}
 
@@ -174,6 +245,8 @@ type FeedCreationRequest struct {
 	KeepFilterEntryRules        string `json:"keep_filter_entry_rules"`
 	UrlRewriteRules             string `json:"urlrewrite_rules"`
 	ProxyURL                    string `json:"proxy_url"`
+	CustomHeaders               string `json:"custom_headers"`
+	ClientCertificateID         int64  `json:"client_certificate_id"`
 
 	NSFW bool `json:"nsfw"`
 }
@@ -216,6 +289,10 @@ type FeedModificationRequest struct {
 	NSFW       *bool   `json:"nsfw"`
 	View       *string `json:"view"`
 	CacheMedia *bool   `json:"cache_media"`
+	Extractor  *string `json:"extractor"`
+
+	CustomHeaders       *string `json:"custom_headers"`
+	ClientCertificateID *int64  `json:"client_certificate_id"`
 }
 
 // Patch updates a feed with modified values.
@@ -327,6 +404,20 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.ProxyURL != nil {
 		feed.ProxyURL = *f.ProxyURL
 	}
+
+	if f.Extractor != nil {
+		feed.Extractor = *f.Extractor
+	}
+
+	if f.CustomHeaders != nil {
+		// An explicit value, even empty, replaces the custom headers that could not be decrypted.
+		feed.CustomHeaders = *f.CustomHeaders
+		feed.CustomHeadersUnreadable = false
+	}
+
+	if f.ClientCertificateID != nil {
+		feed.ClientCertificateID = *f.ClientCertificateID
+	}
 }
 
 // Feeds is a list of feed
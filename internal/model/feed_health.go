@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"net/http"
+	"time"
+)
+
+// FeedHealth tracks the permanent redirects and the long failures of a feed across refreshes.
+type FeedHealth struct {
+	FeedID          int64
+	RedirectURL     string
+	RedirectCount   int
+	PreviousFeedURL string
+	MovedAt         *time.Time
+	DeadSince       *time.Time
+	DeadNotifiedAt  *time.Time
+	AlternativeURLs []string
+}
+
+// RecordPermanentRedirect counts the consecutive refreshes permanently redirected to the same URL.
+// It returns true when the threshold is reached and the feed URL should be updated.
+func (f *FeedHealth) RecordPermanentRedirect(redirectURL string, threshold int) bool {
+	switch {
+	case redirectURL == "":
+		f.RedirectURL = ""
+		f.RedirectCount = 0
+	case redirectURL == f.RedirectURL:
+		f.RedirectCount++
+	default:
+		f.RedirectURL = redirectURL
+		f.RedirectCount = 1
+	}
+
+	return threshold > 0 && f.RedirectCount >= threshold
+}
+
+// RecordMove remembers the URL the feed was moved from.
+func (f *FeedHealth) RecordMove(previousFeedURL string, now time.Time) {
+	f.PreviousFeedURL = previousFeedURL
+	f.MovedAt = &now
+	f.RedirectURL = ""
+	f.RedirectCount = 0
+}
+
+// RecordFailure starts the dead period when the feed is not found anymore.
+// Other errors, like timeouts, neither start nor end the period.
+func (f *FeedHealth) RecordFailure(statusCode int, now time.Time) {
+	if f.DeadSince == nil && (statusCode == http.StatusNotFound || statusCode == http.StatusGone) {
+		f.DeadSince = &now
+	}
+}
+
+// RecordSuccess ends the dead period.
+func (f *FeedHealth) RecordSuccess() {
+	f.DeadSince = nil
+	f.DeadNotifiedAt = nil
+	f.AlternativeURLs = nil
+}
+
+// ShouldNotifyDead returns true when the feed has been dead for the given delay and the user was not notified yet.
+func (f *FeedHealth) ShouldNotifyDead(now time.Time, delay time.Duration) bool {
+	return delay > 0 && f.DeadSince != nil && f.DeadNotifiedAt == nil && now.Sub(*f.DeadSince) >= delay
+}
+
+// IsDead returns true when the user was notified that the feed is dead.
+func (f *FeedHealth) IsDead() bool {
+	return f.DeadNotifiedAt != nil
+}
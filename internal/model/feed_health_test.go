@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFeedHealthPermanentRedirect(t *testing.T) {
+	health := &FeedHealth{}
+
+	if health.RecordPermanentRedirect("https://example.org/a", 2) {
+		t.Fatal(`The feed URL should not be updated after the first redirect`)
+	}
+
+	// A different target restarts the count.
+	if health.RecordPermanentRedirect("https://example.org/b", 2) {
+		t.Fatal(`The feed URL should not be updated when the target changes`)
+	}
+
+	if !health.RecordPermanentRedirect("https://example.org/b", 2) {
+		t.Fatal(`The feed URL should be updated after two consecutive redirects`)
+	}
+
+	// A refresh without redirect resets the count.
+	health.RecordPermanentRedirect("", 2)
+	if health.RedirectCount != 0 || health.RedirectURL != "" {
+		t.Errorf(`Unexpected redirect state: %q (%d)`, health.RedirectURL, health.RedirectCount)
+	}
+
+	if health.RecordPermanentRedirect("https://example.org/b", 0) || health.RecordPermanentRedirect("https://example.org/b", 0) {
+		t.Error(`The feed URL should never be updated when the threshold is zero`)
+	}
+
+	now := time.Now()
+	health.RecordMove("https://example.org/feed", now)
+	if health.PreviousFeedURL != "https://example.org/feed" || health.MovedAt == nil || health.RedirectCount != 0 {
+		t.Errorf(`Unexpected state after move: %+v`, health)
+	}
+}
+
+func TestFeedHealthDeadFeed(t *testing.T) {
+	health := &FeedHealth{}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	delay := 60 * 24 * time.Hour
+
+	health.RecordFailure(http.StatusInternalServerError, start)
+	if health.DeadSince != nil {
+		t.Fatal(`A server error should not start the dead period`)
+	}
+
+	health.RecordFailure(http.StatusNotFound, start)
+	health.RecordFailure(http.StatusGone, start.Add(24*time.Hour))
+	if health.DeadSince == nil || !health.DeadSince.Equal(start) {
+		t.Fatalf(`Unexpected dead period start: %v`, health.DeadSince)
+	}
+
+	if health.ShouldNotifyDead(start.Add(delay-time.Hour), delay) {
+		t.Error(`The user should not be notified before the delay`)
+	}
+
+	if !health.ShouldNotifyDead(start.Add(delay), delay) {
+		t.Error(`The user should be notified after the delay`)
+	}
+
+	if health.ShouldNotifyDead(start.Add(delay), 0) {
+		t.Error(`The user should not be notified when the notification is disabled`)
+	}
+
+	notifiedAt := start.Add(delay)
+	health.DeadNotifiedAt = &notifiedAt
+	if !health.IsDead() || health.ShouldNotifyDead(start.Add(2*delay), delay) {
+		t.Error(`The user should be notified only once`)
+	}
+
+	health.RecordSuccess()
+	if health.IsDead() || health.DeadSince != nil {
+		t.Errorf(`The dead period should end after a successful refresh: %+v`, health)
+	}
+}
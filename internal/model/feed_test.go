@@ -376,3 +376,45 @@ func TestFeedScheduleNextCheckEntryFrequencyLargeNewTTL(t *testing.T) {
 		t.Error(`The next_check_at should be after timeBefore + entry frequency min interval`)
 	}
 }
+
+func TestFeedModificationRequestClearsUnreadableCustomHeaders(t *testing.T) {
+	feed := &Feed{CustomHeadersUnreadable: true}
+
+	(&FeedModificationRequest{}).Patch(feed)
+	if !feed.CustomHeadersUnreadable {
+		t.Error("The unreadable custom headers should be kept when they are not submitted")
+	}
+
+	emptyHeaders := ""
+	(&FeedModificationRequest{CustomHeaders: &emptyHeaders}).Patch(feed)
+	if feed.CustomHeadersUnreadable {
+		t.Error("The unreadable custom headers should be cleared by an explicit empty value")
+	}
+}
+
+func TestFeedWithNotificationRoutes(t *testing.T) {
+	feed := &Feed{ID: 1}
+	feed.WithNotificationRoutes(NotificationRoutes{
+		{Provider: "ntfy", FeedID: 2, Target: "other", Priority: NotificationPriorityMax},
+		{Provider: "ntfy", FeedID: 1, Target: "news", Priority: NotificationPriorityHigh},
+		{Provider: "ntfy", FeedID: 1, Target: "ignored", Priority: NotificationPriorityMin},
+		{Provider: "pushover", FeedID: 1, Priority: NotificationPriorityLow},
+		{Provider: "webhook", FeedID: 1, Target: "https://example.org/hook"},
+	})
+
+	if !feed.NtfyEnabled || feed.NtfyTopic != "news" || feed.NtfyPriority != 4 {
+		t.Errorf("Unexpected Ntfy settings: %v %q %d", feed.NtfyEnabled, feed.NtfyTopic, feed.NtfyPriority)
+	}
+
+	if !feed.PushoverEnabled || feed.PushoverPriority != NotificationPriorityLow {
+		t.Errorf("Unexpected Pushover settings: %v %d", feed.PushoverEnabled, feed.PushoverPriority)
+	}
+
+	if feed.WebhookURL != "https://example.org/hook" {
+		t.Errorf("Unexpected webhook URL: %q", feed.WebhookURL)
+	}
+
+	if feed.AppriseServiceURLs != "" {
+		t.Errorf("Unexpected Apprise service URLs: %q", feed.AppriseServiceURLs)
+	}
+}
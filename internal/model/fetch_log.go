@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// FeedFetchLog records what happened during a feed refresh.
+type FeedFetchLog struct {
+	ID             int64     `json:"id"`
+	FeedID         int64     `json:"feed_id"`
+	FetchedAt      time.Time `json:"fetched_at"`
+	StatusCode     int       `json:"status_code"`
+	EffectiveURL   string    `json:"effective_url"`
+	Redirects      []string  `json:"redirects"`
+	NotModified    bool      `json:"not_modified"`
+	ETag           string    `json:"etag"`
+	LastModified   string    `json:"last_modified"`
+	ContentLength  int64     `json:"content_length"`
+	DurationMs     int64     `json:"duration_ms"`
+	ProxyURL       string    `json:"proxy_url"`
+	NewEntries     int       `json:"new_entries"`
+	UpdatedEntries int       `json:"updated_entries"`
+	ErrorMessage   string    `json:"error_message"`
+}
+
+// FeedFetchLogs represents a list of fetch logs, the most recent first.
+type FeedFetchLogs []*FeedFetchLog
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// HostLimit represents the rate limiting state shared by all requests to a host.
+type HostLimit struct {
+	Host         string
+	BlockedUntil time.Time
+	Backoff      time.Duration
+	UpdatedAt    time.Time
+}
@@ -3,127 +3,97 @@
 
 package model // import "miniflux.app/v2/internal/model"
 
-// Integration represents user integration settings.
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Integration represents the user settings of the APIs and of the services used by the subscription finder.
+// The settings of the third-party providers receiving the entries are stored in IntegrationSettings.
 type Integration struct {
-	UserID                           int64
-	BetulaEnabled                    bool
-	BetulaURL                        string
-	BetulaToken                      string
-	PinboardEnabled                  bool
-	PinboardToken                    string
-	PinboardTags                     string
-	PinboardMarkAsUnread             bool
-	InstapaperEnabled                bool
-	InstapaperUsername               string
-	InstapaperPassword               string
-	FeverEnabled                     bool
-	FeverUsername                    string
-	FeverToken                       string
-	GoogleReaderEnabled              bool
-	GoogleReaderUsername             string
-	GoogleReaderPassword             string
-	WallabagEnabled                  bool
-	WallabagOnlyURL                  bool
-	WallabagURL                      string
-	WallabagClientID                 string
-	WallabagClientSecret             string
-	WallabagUsername                 string
-	WallabagPassword                 string
-	WallabagTags                     string
-	NunuxKeeperEnabled               bool
-	NunuxKeeperURL                   string
-	NunuxKeeperAPIKey                string
-	NotionEnabled                    bool
-	NotionToken                      string
-	NotionPageID                     string
-	EspialEnabled                    bool
-	EspialURL                        string
-	EspialAPIKey                     string
-	EspialTags                       string
-	ReadwiseEnabled                  bool
-	ReadwiseAPIKey                   string
-	TelegramBotEnabled               bool
-	TelegramBotToken                 string
-	TelegramBotChatID                string
-	TelegramBotTopicID               *int64
-	TelegramBotDisableWebPagePreview bool
-	TelegramBotDisableNotification   bool
-	TelegramBotDisableButtons        bool
-	LinkAceEnabled                   bool
-	LinkAceURL                       string
-	LinkAceAPIKey                    string
-	LinkAceTags                      string
-	LinkAcePrivate                   bool
-	LinkAceCheckDisabled             bool
-	LinkdingEnabled                  bool
-	LinkdingURL                      string
-	LinkdingAPIKey                   string
-	LinkdingTags                     string
-	LinkdingMarkAsUnread             bool
-	LinktacoEnabled                  bool
-	LinktacoAPIToken                 string
-	LinktacoOrgSlug                  string
-	LinktacoTags                     string
-	LinktacoVisibility               string
-	LinkwardenEnabled                bool
-	LinkwardenURL                    string
-	LinkwardenAPIKey                 string
-	LinkwardenCollectionID           *int64
-	MatrixBotEnabled                 bool
-	MatrixBotUser                    string
-	MatrixBotPassword                string
-	MatrixBotURL                     string
-	MatrixBotChatID                  string
-	AppriseEnabled                   bool
-	AppriseURL                       string
-	AppriseServicesURL               string
-	ReadeckEnabled                   bool
-	ReadeckURL                       string
-	ReadeckAPIKey                    string
-	ReadeckLabels                    string
-	ReadeckOnlyURL                   bool
-	ShioriEnabled                    bool
-	ShioriURL                        string
-	ShioriUsername                   string
-	ShioriPassword                   string
-	ShaarliEnabled                   bool
-	ShaarliURL                       string
-	ShaarliAPISecret                 string
-	WebhookEnabled                   bool
-	WebhookURL                       string
-	WebhookSecret                    string
-	RSSBridgeEnabled                 bool
-	RSSBridgeURL                     string
-	RSSBridgeToken                   string
-	OmnivoreEnabled                  bool
-	OmnivoreAPIKey                   string
-	OmnivoreURL                      string
-	KarakeepEnabled                  bool
-	KarakeepAPIKey                   string
-	KarakeepURL                      string
-	KarakeepTags                     string
-	RaindropEnabled                  bool
-	RaindropToken                    string
-	RaindropCollectionID             string
-	RaindropTags                     string
-	NtfyEnabled                      bool
-	NtfyTopic                        string
-	NtfyURL                          string
-	NtfyAPIToken                     string
-	NtfyUsername                     string
-	NtfyPassword                     string
-	NtfyIconURL                      string
-	NtfyInternalLinks                bool
-	CuboxEnabled                     bool
-	CuboxAPILink                     string
-	DiscordEnabled                   bool
-	DiscordWebhookLink               string
-	SlackEnabled                     bool
-	SlackWebhookLink                 string
-	PushoverEnabled                  bool
-	PushoverUser                     string
-	PushoverToken                    string
-	PushoverDevice                   string
-	PushoverPrefix                   string
-	ArchiveorgEnabled                bool
+	UserID               int64
+	FeverEnabled         bool
+	FeverUsername        string
+	FeverToken           string
+	GoogleReaderEnabled  bool
+	GoogleReaderUsername string
+	GoogleReaderPassword string
+	RSSBridgeEnabled     bool
+	RSSBridgeURL         string
+	RSSBridgeToken       string
+}
+
+// IntegrationSettings represents the settings of a user for an integration provider.
+type IntegrationSettings struct {
+	UserID   int64
+	Provider string
+	Enabled  bool
+	Values   IntegrationValues
+
+	// LastDigestAt is the date of the last digest sent by the provider, nil before the first one.
+	LastDigestAt *time.Time
+
+	// LastSyncAt is the date of the last import of the bookmarks of the provider, nil before the first one.
+	LastSyncAt *time.Time
+}
+
+// IntegrationValues holds the typed values of the provider settings, as stored in JSON.
+type IntegrationValues map[string]any
+
+// String returns a text value, or an empty string if the value is not set.
+func (v IntegrationValues) String(name string) string {
+	switch value := v[name].(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		return v.Value(name)
+	}
+}
+
+// Bool returns a boolean value, false if the value is not set.
+func (v IntegrationValues) Bool(name string) bool {
+	value, _ := v[name].(bool)
+	return value
+}
+
+// Int64 returns an integer value, nil if the value is not set.
+func (v IntegrationValues) Int64(name string) *int64 {
+	var number int64
+	switch value := v[name].(type) {
+	case int64:
+		number = value
+	case int:
+		number = int64(value)
+	case float64:
+		number = int64(value)
+	case json.Number:
+		parsed, err := value.Int64()
+		if err != nil {
+			return nil
+		}
+		number = parsed
+	default:
+		return nil
+	}
+	return &number
+}
+
+// Value returns the value formatted for a form field.
+func (v IntegrationValues) Value(name string) string {
+	switch value := v[name].(type) {
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case nil:
+		return ""
+	default:
+		if number := v.Int64(name); number != nil {
+			return strconv.FormatInt(*number, 10)
+		}
+		return ""
+	}
 }
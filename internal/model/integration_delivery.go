@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// Kinds of integration deliveries.
+const (
+	DeliveryKindSaveEntry   = "save_entry"
+	DeliveryKindPushEntries = "push_entries"
+	DeliveryKindDigest      = "digest"
+	DeliveryKindBook        = "book"
+
+	// DeliveryKindBookmarkStarred sends the starred state of the entries to the bookmarks they were imported from.
+	DeliveryKindBookmarkStarred = "bookmark_starred"
+)
+
+// Statuses of integration deliveries.
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+// IntegrationDelivery represents an entry or a list of entries sent to a third-party provider.
+type IntegrationDelivery struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	Provider      string    `json:"provider"`
+	Kind          string    `json:"kind"`
+	FeedID        int64     `json:"feed_id,omitempty"`
+	EntryIDs      []int64   `json:"entry_ids"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	// RouteID is the notification route of the pushed entries, 0 when the provider receives all the entries.
+	RouteID int64 `json:"route_id,omitempty"`
+
+	// Title is the title of the saved entry or of the feed for pushed entries, it is empty for digests, books and bookmarks.
+	Title string `json:"title"`
+}
+
+// IntegrationDeliveries represents a list of deliveries.
+type IntegrationDeliveries []*IntegrationDelivery
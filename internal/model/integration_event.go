@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// Events sent to the integrations receiving the changes made by the users.
+const (
+	EventNewEntries       = "new_entries"
+	EventSaveEntry        = "save_entry"
+	EventEntryRead        = "entry_read"
+	EventEntryUnread      = "entry_unread"
+	EventEntryStarred     = "entry_starred"
+	EventEntryUnstarred   = "entry_unstarred"
+	EventEntryTagsChanged = "entry_tags_changed"
+	EventEntryShared      = "entry_shared"
+	EventEntryUnshared    = "entry_unshared"
+	EventFeedCreated      = "feed_created"
+	EventFeedRemoved      = "feed_removed"
+	EventFeedError        = "feed_error"
+	EventFeedRecovered    = "feed_recovered"
+)
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// IntegrationToken represents the OAuth2 token of a user for an integration provider.
+type IntegrationToken struct {
+	UserID       int64
+	Provider     string
+	AccessToken  string
+	RefreshToken string
+	TokenType    string
+
+	// ExpiresAt is nil when the access token does not expire.
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+
+	// Unreadable is set when the saved token cannot be decrypted with the current key.
+	// The token values are left empty and the saved token is kept until the user connects the integration again.
+	Unreadable bool
+}
@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// Lease represents a scheduler role held by one of the instances sharing the database.
+type Lease struct {
+	Name       string
+	Holder     string
+	ExpiresAt  time.Time
+	AcquiredAt time.Time
+}
@@ -22,6 +22,11 @@ type Media struct {
 	Cached     bool      `json:"cached"`
 	ErrorCount int       `json:"error_count"`
 	CreatedAt  time.Time `json:"created_at"`
+
+	// Feed settings used to download the media, not persisted.
+	FeedURL           string            `json:"-"`
+	CustomHeaders     string            `json:"-"`
+	ClientCertificate ClientCertificate `json:"-"`
 }
 
 // DataURL returns the data URL of the media cache.
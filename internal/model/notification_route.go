@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// Priorities of the notifications, each provider converts them to its own scale.
+const (
+	NotificationPriorityMin     = -2
+	NotificationPriorityLow     = -1
+	NotificationPriorityDefault = 0
+	NotificationPriorityHigh    = 1
+
+	// NotificationPriorityMax notifications are sent during the quiet hours.
+	NotificationPriorityMax = 2
+)
+
+// NotificationRoute sends the new entries of a category or of a feed matching the filter rules
+// to a notification provider of the user, with a target and a priority.
+type NotificationRoute struct {
+	ID       int64  `json:"id"`
+	UserID   int64  `json:"user_id"`
+	Provider string `json:"provider"`
+
+	// CategoryID and FeedID restrict the route to a category or to a feed, 0 matches all of them.
+	CategoryID int64 `json:"category_id"`
+	FeedID     int64 `json:"feed_id"`
+
+	// FilterRules are the rules of the entries sent by the route, in the syntax of the entry filters.
+	// The route matches all the entries when they are empty.
+	FilterRules string `json:"filter_rules"`
+
+	// Target replaces the topic, the channel or the URL of the provider settings when it is not empty.
+	Target string `json:"target"`
+
+	Priority  int       `json:"priority"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Non-persisted attributes
+	CategoryTitle string `json:"-"`
+	FeedTitle     string `json:"-"`
+}
+
+// NotificationRoutes represents a list of notification routes.
+type NotificationRoutes []*NotificationRoute
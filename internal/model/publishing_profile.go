@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// PublishingProfileWeeks is the number of weeks of entries used to learn the publishing pattern of a feed.
+const PublishingProfileWeeks = 12
+
+// Below this number of entries, the profile is not reliable enough to schedule the refreshes.
+const publishingProfileMinEntries = 10
+
+// PublishingProfile counts the entries published by a feed for each day of the week and hour of the day.
+type PublishingProfile struct {
+	// Counts is indexed by time.Weekday and by hour, in the profile location.
+	Counts   [7][24]int
+	Weeks    float64
+	Location *time.Location
+}
+
+// NewPublishingProfile creates an empty profile covering the given number of weeks.
+func NewPublishingProfile(weeks float64, location *time.Location) *PublishingProfile {
+	return &PublishingProfile{
+		Weeks:    min(max(weeks, 1), PublishingProfileWeeks),
+		Location: location,
+	}
+}
+
+// Total returns the number of entries in the profile.
+func (p *PublishingProfile) Total() int {
+	total := 0
+	for _, hours := range p.Counts {
+		for _, count := range hours {
+			total += count
+		}
+	}
+	return total
+}
+
+// WeeklyAverage returns the average number of entries published each week.
+func (p *PublishingProfile) WeeklyAverage() float64 {
+	return float64(p.Total()) / p.Weeks
+}
+
+// DailyCounts returns the number of entries for each day of the week.
+func (p *PublishingProfile) DailyCounts() [7]int {
+	var days [7]int
+	for day, hours := range p.Counts {
+		for _, count := range hours {
+			days[day] += count
+		}
+	}
+	return days
+}
+
+// HourlyCounts returns the number of entries for each hour of the day.
+func (p *PublishingProfile) HourlyCounts() [24]int {
+	var hours [24]int
+	for _, dayHours := range p.Counts {
+		for hour, count := range dayHours {
+			hours[hour] += count
+		}
+	}
+	return hours
+}
+
+// NextCheckInterval returns the delay until the given number of entries is expected to be published.
+// Quiet periods of the profile do not count, the delay is at most maxInterval.
+func (p *PublishingProfile) NextCheckInterval(now time.Time, expectedEntries float64, maxInterval time.Duration) time.Duration {
+	location := p.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	current := now.In(location)
+	expected := 0.0
+	elapsed := time.Duration(0)
+
+	for elapsed < maxInterval {
+		slotEnd := time.Date(current.Year(), current.Month(), current.Day(), current.Hour()+1, 0, 0, 0, location)
+		slotDuration := slotEnd.Sub(current)
+		entriesPerHour := float64(p.Counts[current.Weekday()][current.Hour()]) / p.Weeks
+
+		slotExpected := entriesPerHour * slotDuration.Hours()
+		if expected+slotExpected >= expectedEntries {
+			elapsed += time.Duration((expectedEntries - expected) / entriesPerHour * float64(time.Hour))
+			return min(elapsed, maxInterval)
+		}
+
+		expected += slotExpected
+		elapsed += slotDuration
+		current = slotEnd
+	}
+
+	return maxInterval
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+)
+
+// A feed publishing two entries every weekday at 9:00 UTC, over 4 weeks.
+func newWeekdayMorningProfile() *PublishingProfile {
+	profile := NewPublishingProfile(4, time.UTC)
+	for day := time.Monday; day <= time.Friday; day++ {
+		profile.Counts[day][9] = 8
+	}
+	return profile
+}
+
+func TestPublishingProfileAggregates(t *testing.T) {
+	profile := newWeekdayMorningProfile()
+
+	if total := profile.Total(); total != 40 {
+		t.Errorf(`Unexpected total: got %d instead of 40`, total)
+	}
+
+	if average := profile.WeeklyAverage(); average != 10 {
+		t.Errorf(`Unexpected weekly average: got %v instead of 10`, average)
+	}
+
+	if days := profile.DailyCounts(); days[time.Sunday] != 0 || days[time.Monday] != 8 {
+		t.Errorf(`Unexpected daily counts: %v`, days)
+	}
+
+	if hours := profile.HourlyCounts(); hours[9] != 40 || hours[10] != 0 {
+		t.Errorf(`Unexpected hourly counts: %v`, hours)
+	}
+}
+
+func TestPublishingProfileWeeksAreBounded(t *testing.T) {
+	if weeks := NewPublishingProfile(0.2, nil).Weeks; weeks != 1 {
+		t.Errorf(`Expected at least one week, got %v`, weeks)
+	}
+
+	if weeks := NewPublishingProfile(52, nil).Weeks; weeks != PublishingProfileWeeks {
+		t.Errorf(`Expected at most %d weeks, got %v`, PublishingProfileWeeks, weeks)
+	}
+}
+
+func TestPublishingProfileNextCheckIntervalDuringActiveHour(t *testing.T) {
+	profile := newWeekdayMorningProfile()
+
+	// Monday 9:00, two entries are expected during this hour.
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	if interval := profile.NextCheckInterval(now, 1, 24*time.Hour); interval != 30*time.Minute {
+		t.Errorf(`Unexpected interval: got %v instead of 30m`, interval)
+	}
+}
+
+func TestPublishingProfileNextCheckIntervalSkipsQuietHours(t *testing.T) {
+	profile := newWeekdayMorningProfile()
+
+	// Monday 12:00, the next entries are expected on Tuesday morning.
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if interval := profile.NextCheckInterval(now, 1, 48*time.Hour); interval != 21*time.Hour+30*time.Minute {
+		t.Errorf(`Unexpected interval: got %v instead of 21h30m`, interval)
+	}
+
+	// Friday 12:00, nothing is expected before Monday.
+	now = time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC)
+	if interval := profile.NextCheckInterval(now, 1, 24*time.Hour); interval != 24*time.Hour {
+		t.Errorf(`Unexpected interval: got %v instead of the maximum interval`, interval)
+	}
+}
+
+func TestPublishingProfileNextCheckIntervalWithTimezone(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	profile := NewPublishingProfile(1, location)
+	profile.Counts[time.Monday][11] = 1
+
+	// Monday 9:00 UTC is 11:00 in the profile location.
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	if interval := profile.NextCheckInterval(now, 1, 24*time.Hour); interval != time.Hour {
+		t.Errorf(`Unexpected interval: got %v instead of 1h`, interval)
+	}
+}
+
+func TestFeedScheduleNextCheckPublishingPattern(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SCHEDULER", "publishing_pattern")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL", "10080")
+	os.Setenv("SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL", "1")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	// Entries are published every hour, a new one is expected within the hour.
+	profile := NewPublishingProfile(1, time.UTC)
+	for day := range profile.Counts {
+		for hour := range profile.Counts[day] {
+			profile.Counts[day][hour] = 1
+		}
+	}
+
+	feed := &Feed{PublishingProfile: profile}
+	if interval := feed.ScheduleNextCheck(0, noRefreshDelay); interval > time.Hour || interval < time.Minute {
+		t.Errorf(`Unexpected interval: %v`, interval)
+	}
+
+	// Without enough entries, the average frequency is used.
+	feed = &Feed{PublishingProfile: NewPublishingProfile(1, time.UTC)}
+	if interval := feed.ScheduleNextCheck(7, noRefreshDelay); interval != 24*time.Hour {
+		t.Errorf(`Unexpected interval: got %v instead of 24h`, interval)
+	}
+}
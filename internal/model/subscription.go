@@ -14,4 +14,6 @@ type SubscriptionDiscoveryRequest struct {
 	FetchViaProxy               bool   `json:"fetch_via_proxy"`
 	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
 	DisableHTTP2                bool   `json:"disable_http2"`
+	CustomHeaders               string `json:"custom_headers"`
+	ClientCertificateID         int64  `json:"client_certificate_id"`
 }
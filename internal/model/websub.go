@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// WebSub subscription states.
+const (
+	// WebSubStatePending means a subscription request must be sent to the hub.
+	WebSubStatePending = "pending"
+
+	// WebSubStateRequested means the hub has not yet verified the subscription intent.
+	WebSubStateRequested = "requested"
+
+	// WebSubStateVerified means the hub confirmed the subscription and pushes updates.
+	WebSubStateVerified = "verified"
+
+	// WebSubStateDenied means the hub refused the subscription.
+	WebSubStateDenied = "denied"
+)
+
+// WebSubSubscription represents a push subscription to a feed hub.
+type WebSubSubscription struct {
+	FeedID         int64
+	UserID         int64
+	HubURL         string
+	TopicURL       string
+	Secret         string
+	State          string
+	LeaseExpiresAt time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsActive returns true if the hub is expected to push updates for this subscription.
+func (w *WebSubSubscription) IsActive() bool {
+	return w.State == WebSubStateVerified && w.LeaseExpiresAt.After(time.Now())
+}
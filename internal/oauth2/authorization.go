@@ -30,7 +30,9 @@ func (u *Authorization) CodeVerifier() string {
 	return u.codeVerifier
 }
 
-func GenerateAuthorization(config *oauth2.Config) *Authorization {
+// GenerateAuthorization returns the authorization URL of the provider with a random state and a PKCE code challenge,
+// the options add parameters specific to the provider.
+func GenerateAuthorization(config *oauth2.Config, opts ...oauth2.AuthCodeOption) *Authorization {
 	codeVerifier := crypto.GenerateRandomStringHex(32)
 	sum := sha256.Sum256([]byte(codeVerifier))
 
@@ -38,8 +40,10 @@ func GenerateAuthorization(config *oauth2.Config) *Authorization {
 
 	authUrl := config.AuthCodeURL(
 		state,
-		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
-		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(sum[:])),
+		append([]oauth2.AuthCodeOption{
+			oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+			oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(sum[:])),
+		}, opts...)...,
 	)
 
 	return &Authorization{
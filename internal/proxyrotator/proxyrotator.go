@@ -6,50 +6,263 @@ package proxyrotator // import "miniflux.app/v2/internal/proxyrotator"
 import (
 	"net/url"
 	"sync"
+	"time"
+)
+
+const (
+	// Number of consecutive failures before a proxy is taken out of rotation.
+	failureThreshold = 3
+
+	initialBackoff = 30 * time.Second
+	maxBackoff     = 30 * time.Minute
+
+	// Weight of the last request in the average latency.
+	latencySmoothing = 0.2
+
+	// Sticky hosts without requests during this delay are forgotten.
+	idleHostTimeout = time.Hour
 )
 
 var ProxyRotatorInstance *ProxyRotator
 
 // ProxyRotator manages a list of proxies and rotates through them.
+// Failing proxies are taken out of rotation with an exponential backoff,
+// then a single request probes them back in.
 type ProxyRotator struct {
-	proxies      []*url.URL
+	proxies      []*proxyState
 	currentIndex int
+	stickyHosts  bool
+	hostProxies  map[string]*hostProxy
+	lastEviction time.Time
 	mutex        sync.Mutex
 }
 
+// hostProxy is the proxy used for a sticky host.
+type hostProxy struct {
+	proxy    *proxyState
+	lastUsed time.Time
+}
+
+type proxyState struct {
+	url                 *url.URL
+	successes           int64
+	failures            int64
+	consecutiveFailures int
+	latency             time.Duration
+	backoff             time.Duration
+	retryAt             time.Time
+}
+
+func (p *proxyState) isAvailable(now time.Time) bool {
+	return !p.retryAt.After(now)
+}
+
+func (p *proxyState) isHealthy() bool {
+	return p.consecutiveFailures < failureThreshold
+}
+
+// ProxyStats represents the health of a proxy.
+type ProxyStats struct {
+	URL                 string
+	Healthy             bool
+	Successes           int64
+	Failures            int64
+	ConsecutiveFailures int
+	Latency             time.Duration
+	RetryAt             time.Time
+}
+
 // NewProxyRotator creates a new ProxyRotator with the given proxy URLs.
 func NewProxyRotator(proxyURLs []string) (*ProxyRotator, error) {
-	parsedProxies := make([]*url.URL, 0, len(proxyURLs))
+	parsedProxies := make([]*proxyState, 0, len(proxyURLs))
 
 	for _, p := range proxyURLs {
 		proxyURL, err := url.Parse(p)
 		if err != nil {
 			return nil, err
 		}
-		parsedProxies = append(parsedProxies, proxyURL)
+		parsedProxies = append(parsedProxies, &proxyState{url: proxyURL})
 	}
 
 	return &ProxyRotator{
 		proxies:      parsedProxies,
 		currentIndex: 0,
+		hostProxies:  make(map[string]*hostProxy),
 		mutex:        sync.Mutex{},
 	}, nil
 }
 
-// GetNextProxy returns the next proxy in the rotation.
+// WithStickyHosts keeps using the same proxy for a given host as long as it is healthy.
+func (pr *ProxyRotator) WithStickyHosts(enabled bool) *ProxyRotator {
+	pr.mutex.Lock()
+	pr.stickyHosts = enabled
+	pr.mutex.Unlock()
+	return pr
+}
+
+// GetNextProxy returns the next available proxy in the rotation.
 func (pr *ProxyRotator) GetNextProxy() *url.URL {
 	if len(pr.proxies) == 0 {
 		return nil
 	}
 
 	pr.mutex.Lock()
-	proxy := pr.proxies[pr.currentIndex]
-	pr.currentIndex = (pr.currentIndex + 1) % len(pr.proxies)
-	pr.mutex.Unlock()
+	defer pr.mutex.Unlock()
 
+	return pr.nextProxy(time.Now()).url
+}
+
+// GetProxyForHost returns the proxy to use for the given host.
+// Without sticky hosts, this is the same as GetNextProxy.
+func (pr *ProxyRotator) GetProxyForHost(host string) *url.URL {
+	if len(pr.proxies) == 0 {
+		return nil
+	}
+
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	return pr.proxyForHost(host, time.Now()).url
+}
+
+// proxyForHost must be called with the mutex locked.
+func (pr *ProxyRotator) proxyForHost(host string, now time.Time) *proxyState {
+	if !pr.stickyHosts || host == "" {
+		return pr.nextProxy(now)
+	}
+
+	pr.evictIdleHosts(now)
+
+	if sticky, found := pr.hostProxies[host]; found && sticky.proxy.isHealthy() && sticky.proxy.isAvailable(now) {
+		sticky.lastUsed = now
+		return sticky.proxy
+	}
+
+	proxy := pr.nextProxy(now)
+	pr.hostProxies[host] = &hostProxy{proxy: proxy, lastUsed: now}
 	return proxy
 }
 
+// evictIdleHosts must be called with the mutex locked, it runs at most once per idle delay.
+func (pr *ProxyRotator) evictIdleHosts(now time.Time) {
+	if now.Sub(pr.lastEviction) < idleHostTimeout {
+		return
+	}
+	pr.lastEviction = now
+
+	for host, sticky := range pr.hostProxies {
+		if now.Sub(sticky.lastUsed) >= idleHostTimeout {
+			delete(pr.hostProxies, host)
+		}
+	}
+}
+
+// nextProxy must be called with the mutex locked.
+func (pr *ProxyRotator) nextProxy(now time.Time) *proxyState {
+	for range len(pr.proxies) {
+		proxy := pr.proxies[pr.currentIndex]
+		pr.currentIndex = (pr.currentIndex + 1) % len(pr.proxies)
+
+		if proxy.isAvailable(now) {
+			if !proxy.isHealthy() {
+				// This request probes the proxy: keep the others out until its result is reported.
+				proxy.retryAt = now.Add(proxy.backoff)
+			}
+			return proxy
+		}
+	}
+
+	// All proxies are out of rotation, use the one coming back first.
+	nextProxy := pr.proxies[0]
+	for _, proxy := range pr.proxies[1:] {
+		if proxy.retryAt.Before(nextProxy.retryAt) {
+			nextProxy = proxy
+		}
+	}
+	return nextProxy
+}
+
+// ReportSuccess records a request that went through the proxy.
+func (pr *ProxyRotator) ReportSuccess(proxyURL *url.URL, latency time.Duration) {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	proxy := pr.findProxy(proxyURL)
+	if proxy == nil {
+		return
+	}
+
+	proxy.successes++
+	proxy.consecutiveFailures = 0
+	proxy.backoff = 0
+	proxy.retryAt = time.Time{}
+
+	if proxy.latency == 0 {
+		proxy.latency = latency
+	} else {
+		proxy.latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(proxy.latency))
+	}
+}
+
+// ReportFailure records a request that failed because of the proxy.
+func (pr *ProxyRotator) ReportFailure(proxyURL *url.URL) {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	proxy := pr.findProxy(proxyURL)
+	if proxy == nil {
+		return
+	}
+
+	proxy.failures++
+	proxy.consecutiveFailures++
+
+	if !proxy.isHealthy() {
+		if proxy.backoff == 0 {
+			proxy.backoff = initialBackoff
+		} else {
+			proxy.backoff = min(proxy.backoff*2, maxBackoff)
+		}
+		proxy.retryAt = time.Now().Add(proxy.backoff)
+	}
+}
+
+// findProxy must be called with the mutex locked.
+func (pr *ProxyRotator) findProxy(proxyURL *url.URL) *proxyState {
+	if proxyURL == nil {
+		return nil
+	}
+
+	for _, proxy := range pr.proxies {
+		if proxy.url == proxyURL || proxy.url.String() == proxyURL.String() {
+			return proxy
+		}
+	}
+
+	return nil
+}
+
+// Stats returns the health of each proxy, the URLs are redacted.
+func (pr *ProxyRotator) Stats() []ProxyStats {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	stats := make([]ProxyStats, 0, len(pr.proxies))
+	for _, proxy := range pr.proxies {
+		stats = append(stats, ProxyStats{
+			URL:                 proxy.url.Redacted(),
+			Healthy:             proxy.isHealthy(),
+			Successes:           proxy.successes,
+			Failures:            proxy.failures,
+			ConsecutiveFailures: proxy.consecutiveFailures,
+			Latency:             proxy.latency,
+			RetryAt:             proxy.retryAt,
+		})
+	}
+
+	return stats
+}
+
 // HasProxies checks if there are any proxies available in the rotator.
 func (pr *ProxyRotator) HasProxies() bool {
 	return len(pr.proxies) > 0
@@ -5,6 +5,7 @@ package proxyrotator // import "miniflux.app/v2/internal/proxyrotator"
 
 import (
 	"testing"
+	"time"
 )
 
 func TestProxyRotator(t *testing.T) {
@@ -69,3 +70,152 @@ func TestProxyRotatorInvalidURL(t *testing.T) {
 		t.Fatalf("Expected rotator to be nil when initialization fails, but got: %v", rotator)
 	}
 }
+
+func TestProxyRotatorSkipsFailingProxy(t *testing.T) {
+	rotator, err := NewProxyRotator([]string{"http://proxy1.example.com", "http://proxy2.example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create ProxyRotator: %v", err)
+	}
+
+	deadProxy := rotator.GetNextProxy()
+	for range failureThreshold {
+		rotator.ReportFailure(deadProxy)
+	}
+
+	for range 4 {
+		if proxy := rotator.GetNextProxy(); proxy.String() == deadProxy.String() {
+			t.Fatalf("Expected the failing proxy to be out of rotation")
+		}
+	}
+
+	stats := rotator.Stats()
+	if stats[0].Healthy || stats[0].Failures != failureThreshold || stats[0].RetryAt.IsZero() {
+		t.Fatalf("Unexpected stats for the failing proxy: %+v", stats[0])
+	}
+}
+
+func TestProxyRotatorProbesProxyAfterBackoff(t *testing.T) {
+	rotator, err := NewProxyRotator([]string{"http://proxy1.example.com", "http://proxy2.example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create ProxyRotator: %v", err)
+	}
+
+	deadProxy := rotator.GetNextProxy()
+	for range failureThreshold {
+		rotator.ReportFailure(deadProxy)
+	}
+
+	if rotator.proxies[0].backoff != initialBackoff {
+		t.Fatalf("Expected the initial backoff, got %v", rotator.proxies[0].backoff)
+	}
+
+	// Pretend the backoff is over.
+	rotator.proxies[0].retryAt = time.Now().Add(-time.Second)
+
+	var probed int
+	for range 4 {
+		if proxy := rotator.GetNextProxy(); proxy.String() == deadProxy.String() {
+			probed++
+		}
+	}
+
+	if probed != 1 {
+		t.Fatalf("Expected a single probe request, got %d", probed)
+	}
+
+	// A failed probe doubles the backoff.
+	rotator.ReportFailure(deadProxy)
+	if rotator.proxies[0].backoff != 2*initialBackoff {
+		t.Fatalf("Expected the backoff to double, got %v", rotator.proxies[0].backoff)
+	}
+
+	// A successful probe brings the proxy back.
+	rotator.ReportSuccess(deadProxy, 100*time.Millisecond)
+	stats := rotator.Stats()
+	if !stats[0].Healthy || !stats[0].RetryAt.IsZero() || stats[0].Latency != 100*time.Millisecond {
+		t.Fatalf("Unexpected stats for the recovered proxy: %+v", stats[0])
+	}
+}
+
+func TestProxyRotatorWhenAllProxiesAreFailing(t *testing.T) {
+	rotator, err := NewProxyRotator([]string{"http://proxy1.example.com", "http://proxy2.example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create ProxyRotator: %v", err)
+	}
+
+	for range 2 {
+		proxy := rotator.GetNextProxy()
+		for range failureThreshold {
+			rotator.ReportFailure(proxy)
+		}
+	}
+
+	rotator.proxies[1].retryAt = rotator.proxies[0].retryAt.Add(-time.Minute)
+
+	if proxy := rotator.GetNextProxy(); proxy.String() != "http://proxy2.example.com" {
+		t.Fatalf("Expected the proxy coming back first, got %v", proxy)
+	}
+}
+
+func TestProxyRotatorStickyHosts(t *testing.T) {
+	rotator, err := NewProxyRotator([]string{"http://proxy1.example.com", "http://proxy2.example.com", "http://proxy3.example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create ProxyRotator: %v", err)
+	}
+	rotator.WithStickyHosts(true)
+
+	first := rotator.GetProxyForHost("example.org")
+	rotator.GetProxyForHost("example.net")
+
+	for range 3 {
+		if proxy := rotator.GetProxyForHost("example.org"); proxy.String() != first.String() {
+			t.Fatalf("Expected the same proxy for the host, got %v instead of %v", proxy, first)
+		}
+	}
+
+	for range failureThreshold {
+		rotator.ReportFailure(first)
+	}
+
+	if proxy := rotator.GetProxyForHost("example.org"); proxy.String() == first.String() {
+		t.Fatalf("Expected another proxy once the sticky one is failing")
+	}
+}
+
+func TestProxyRotatorWithoutStickyHosts(t *testing.T) {
+	rotator, err := NewProxyRotator([]string{"http://proxy1.example.com", "http://proxy2.example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create ProxyRotator: %v", err)
+	}
+
+	if rotator.GetProxyForHost("example.org").String() == rotator.GetProxyForHost("example.org").String() {
+		t.Fatalf("Expected round-robin rotation without sticky hosts")
+	}
+}
+
+func TestProxyRotatorEvictsIdleStickyHosts(t *testing.T) {
+	rotator, err := NewProxyRotator([]string{"http://proxy1.example.com", "http://proxy2.example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create ProxyRotator: %v", err)
+	}
+	rotator.WithStickyHosts(true)
+
+	now := time.Now()
+	rotator.proxyForHost("idle.example.org", now)
+	rotator.proxyForHost("busy.example.org", now)
+
+	rotator.proxyForHost("busy.example.org", now.Add(90*time.Minute))
+	rotator.proxyForHost("example.org", now.Add(2*time.Hour))
+
+	if _, found := rotator.hostProxies["idle.example.org"]; found {
+		t.Error("Expected the idle host to be evicted")
+	}
+
+	if _, found := rotator.hostProxies["busy.example.org"]; !found {
+		t.Error("Expected the recently used host to be kept")
+	}
+
+	if len(rotator.hostProxies) != 2 {
+		t.Errorf("Unexpected number of sticky hosts: %d", len(rotator.hostProxies))
+	}
+}
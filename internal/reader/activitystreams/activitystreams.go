@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitystreams // import "miniflux.app/v2/internal/reader/activitystreams"
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ActivityStreams 2.0 specs:
+// https://www.w3.org/TR/activitystreams-core/
+// https://www.w3.org/TR/activitystreams-vocabulary/
+
+// Namespace is the JSON-LD context of ActivityStreams documents.
+const Namespace = "https://www.w3.org/ns/activitystreams"
+
+// Document contains the properties shared by every ActivityStreams document.
+type Document struct {
+	Context any    `json:"@context"`
+	ID      string `json:"id"`
+	Type    any    `json:"type"`
+}
+
+// IsActivityStreams returns true if the document uses the ActivityStreams context.
+func (d *Document) IsActivityStreams() bool {
+	return contextContains(d.Context, Namespace) || contextContains(d.Context, "http://www.w3.org/ns/activitystreams")
+}
+
+// IsCollection returns true if the document is a collection or a page of a collection.
+func (d *Document) IsCollection() bool {
+	return typeIs(d.Type, "OrderedCollection", "OrderedCollectionPage", "Collection", "CollectionPage")
+}
+
+// Actor represents a person, a group or a service publishing activities.
+type Actor struct {
+	Document
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferredUsername"`
+	Summary           string `json:"summary"`
+	Outbox            string `json:"outbox"`
+	URL               any    `json:"url"`
+	Icon              any    `json:"icon"`
+}
+
+// Collection represents an ordered or unordered collection, or one of its pages.
+type Collection struct {
+	Document
+	Name         string            `json:"name"`
+	Summary      string            `json:"summary"`
+	PartOf       string            `json:"partOf"`
+	First        json.RawMessage   `json:"first"`
+	OrderedItems []json.RawMessage `json:"orderedItems"`
+	Items        []json.RawMessage `json:"items"`
+}
+
+// Object represents an activity or the object of an activity (Note, Article, Video, etc.).
+type Object struct {
+	ID           string          `json:"id"`
+	Type         any             `json:"type"`
+	Name         string          `json:"name"`
+	Summary      string          `json:"summary"`
+	Content      string          `json:"content"`
+	URL          any             `json:"url"`
+	Published    string          `json:"published"`
+	Updated      string          `json:"updated"`
+	AttributedTo any             `json:"attributedTo"`
+	Actor        any             `json:"actor"`
+	Object       json.RawMessage `json:"object"`
+	Attachment   any             `json:"attachment"`
+	Tag          any             `json:"tag"`
+	Sensitive    bool            `json:"sensitive"`
+}
+
+func contextContains(context any, namespace string) bool {
+	switch value := context.(type) {
+	case string:
+		return value == namespace
+	case []any:
+		for _, item := range value {
+			if contextContains(item, namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeIs(value any, types ...string) bool {
+	switch t := value.(type) {
+	case string:
+		for _, expected := range types {
+			if t == expected {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if typeIs(item, types...) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// linkHref returns the URL of a property that can be a string, a Link object or an array of them.
+// HTML links are preferred when several are available.
+func linkHref(value any) string {
+	switch link := value.(type) {
+	case string:
+		return strings.TrimSpace(link)
+	case map[string]any:
+		if href, ok := link["href"].(string); ok {
+			return strings.TrimSpace(href)
+		}
+		if href, ok := link["url"]; ok {
+			return linkHref(href)
+		}
+		if id, ok := link["id"].(string); ok {
+			return strings.TrimSpace(id)
+		}
+	case []any:
+		var first string
+		for _, item := range link {
+			href := linkHref(item)
+			if href == "" {
+				continue
+			}
+			if m, ok := item.(map[string]any); ok && m["mediaType"] == "text/html" {
+				return href
+			}
+			if first == "" {
+				first = href
+			}
+		}
+		return first
+	}
+	return ""
+}
+
+// objects returns the items of a property that can be a single object or an array.
+func objects(value any) []map[string]any {
+	switch v := value.(type) {
+	case map[string]any:
+		return []map[string]any{v}
+	case []any:
+		items := make([]map[string]any, 0, len(v))
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				items = append(items, m)
+			}
+		}
+		return items
+	}
+	return nil
+}
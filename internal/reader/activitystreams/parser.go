@@ -0,0 +1,300 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package activitystreams // import "miniflux.app/v2/internal/reader/activitystreams"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// ErrItemsNotEmbedded is returned when the items of a collection are only available on another page.
+var ErrItemsNotEmbedded = errors.New("activitystreams: the collection items are not embedded, use the URL of its first page")
+
+// Parse returns a normalized feed struct from an ActivityStreams collection, such as an outbox.
+func Parse(baseURL string, data io.Reader) (*model.Feed, error) {
+	collection := new(Collection)
+	if err := json.NewDecoder(data).Decode(collection); err != nil {
+		return nil, fmt.Errorf("activitystreams: unable to parse collection: %w", err)
+	}
+
+	items := collectionItems(collection)
+	if items == nil && len(collection.First) > 0 {
+		return nil, ErrItemsNotEmbedded
+	}
+
+	feed := &model.Feed{
+		FeedURL: baseURL,
+		Title:   strings.TrimSpace(collection.Name),
+	}
+
+	for _, raw := range items {
+		object, author := activityObject(raw)
+		if object == nil {
+			continue
+		}
+
+		entry := buildEntry(baseURL, object)
+		if entry.Author == "" {
+			entry.Author = actorName(author)
+		}
+
+		if feed.SiteURL == "" {
+			feed.SiteURL = actorURL(author)
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	if feed.SiteURL == "" {
+		feed.SiteURL = strings.TrimSuffix(strings.TrimSuffix(firstNonEmpty(collection.PartOf, collection.ID, baseURL), "/outbox"), "/")
+	}
+
+	if feed.Title == "" {
+		feed.Title = handleFromURL(feed.SiteURL)
+	}
+
+	return feed, nil
+}
+
+// ParseActor returns the actor described by the document.
+func ParseActor(data io.Reader) (*Actor, error) {
+	actor := new(Actor)
+	if err := json.NewDecoder(data).Decode(actor); err != nil {
+		return nil, fmt.Errorf("activitystreams: unable to parse actor: %w", err)
+	}
+
+	if !actor.IsActivityStreams() || actor.Outbox == "" {
+		return nil, errors.New("activitystreams: the document is not an actor")
+	}
+
+	return actor, nil
+}
+
+// FirstPageURL returns the URL of the first page of a collection when its items are not embedded.
+func FirstPageURL(data io.Reader) string {
+	collection := new(Collection)
+	if err := json.NewDecoder(data).Decode(collection); err != nil {
+		return ""
+	}
+
+	if collectionItems(collection) != nil || len(collection.First) == 0 {
+		return ""
+	}
+
+	var first any
+	if err := json.Unmarshal(collection.First, &first); err != nil {
+		return ""
+	}
+
+	return linkHref(first)
+}
+
+// collectionItems returns the items of the collection, or of its embedded first page.
+func collectionItems(collection *Collection) []json.RawMessage {
+	if collection.OrderedItems != nil {
+		return collection.OrderedItems
+	}
+
+	if collection.Items != nil {
+		return collection.Items
+	}
+
+	if len(collection.First) > 0 && collection.First[0] == '{' {
+		page := new(Collection)
+		if err := json.Unmarshal(collection.First, page); err == nil {
+			return collectionItems(page)
+		}
+	}
+
+	return nil
+}
+
+// activityObject returns the object published by an activity, along with its actor.
+// Items that are objects themselves are returned as is.
+func activityObject(raw json.RawMessage) (*Object, any) {
+	activity := new(Object)
+	if err := json.Unmarshal(raw, activity); err != nil {
+		return nil, nil
+	}
+
+	switch {
+	case typeIs(activity.Type, "Create", "Announce", "Update"):
+		// Boosts of remote objects only reference them by URL.
+		if len(activity.Object) == 0 || activity.Object[0] != '{' {
+			return nil, nil
+		}
+
+		object := new(Object)
+		if err := json.Unmarshal(activity.Object, object); err != nil {
+			return nil, nil
+		}
+
+		if typeIs(activity.Type, "Announce") {
+			return object, object.AttributedTo
+		}
+		return object, activity.Actor
+	case typeIs(activity.Type, "Note", "Article", "Page", "Question", "Video", "Audio", "Image", "Event"):
+		return activity, activity.AttributedTo
+	}
+
+	return nil, nil
+}
+
+func buildEntry(baseURL string, object *Object) *model.Entry {
+	entry := model.NewEntry()
+
+	entry.URL = firstNonEmpty(linkHref(object.URL), object.ID)
+	if absoluteURL, err := urllib.AbsoluteURL(baseURL, entry.URL); err == nil {
+		entry.URL = absoluteURL
+	}
+
+	content := strings.TrimSpace(object.Content)
+	summary := strings.TrimSpace(object.Summary)
+
+	// On microblogs, the summary of a note is a content warning.
+	if summary != "" && content != "" && !typeIs(object.Type, "Article", "Page") {
+		content = "<p><strong>" + summary + "</strong></p>" + content
+	}
+	entry.Content = firstNonEmpty(content, summary)
+
+	entry.Title = strings.TrimSpace(object.Name)
+	if entry.Title == "" {
+		for _, value := range []string{summary, object.Content} {
+			if value != "" {
+				entry.Title = sanitizer.TruncateHTML(value, 100)
+				break
+			}
+		}
+	}
+	if entry.Title == "" {
+		entry.Title = entry.URL
+	}
+
+	for _, value := range []string{object.Published, object.Updated} {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if parsedDate, err := date.Parse(value); err != nil {
+			slog.Debug("Unable to parse date from ActivityStreams object",
+				slog.String("date", value),
+				slog.String("url", entry.URL),
+				slog.Any("error", err),
+			)
+		} else {
+			entry.Date = parsedDate
+			break
+		}
+	}
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+
+	entry.Author = actorName(object.AttributedTo)
+
+	for _, attachment := range objects(object.Attachment) {
+		attachmentURL := linkHref(attachment["url"])
+		if attachmentURL == "" {
+			continue
+		}
+		mediaType, _ := attachment["mediaType"].(string)
+		entry.Enclosures = append(entry.Enclosures, &model.Enclosure{URL: attachmentURL, MimeType: mediaType})
+	}
+
+	for _, tag := range objects(object.Tag) {
+		if !typeIs(tag["type"], "Hashtag") {
+			continue
+		}
+		if name, ok := tag["name"].(string); ok {
+			if name = strings.TrimPrefix(strings.TrimSpace(name), "#"); name != "" {
+				entry.Tags = append(entry.Tags, name)
+			}
+		}
+	}
+	slices.Sort(entry.Tags)
+	entry.Tags = slices.Compact(entry.Tags)
+
+	entry.Hash = crypto.SHA256(firstNonEmpty(object.ID, entry.URL, object.Content))
+
+	return entry
+}
+
+// actorName returns the name of an actor embedded in a document or a handle derived from its URL.
+func actorName(actor any) string {
+	switch value := actor.(type) {
+	case string:
+		return handleFromURL(value)
+	case map[string]any:
+		if name, ok := value["name"].(string); ok && strings.TrimSpace(name) != "" {
+			return strings.TrimSpace(name)
+		}
+		if username, ok := value["preferredUsername"].(string); ok && username != "" {
+			return username
+		}
+		if id, ok := value["id"].(string); ok {
+			return handleFromURL(id)
+		}
+	case []any:
+		for _, item := range value {
+			if name := actorName(item); name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func actorURL(actor any) string {
+	switch value := actor.(type) {
+	case string:
+		return value
+	case map[string]any:
+		return firstNonEmpty(linkHref(value["url"]), linkHref(value["id"]))
+	case []any:
+		for _, item := range value {
+			if u := actorURL(item); u != "" {
+				return u
+			}
+		}
+	}
+	return ""
+}
+
+// handleFromURL turns an actor URL like https://example.org/users/alice into alice@example.org.
+func handleFromURL(actorURL string) string {
+	parsedURL, err := url.Parse(actorURL)
+	if err != nil || parsedURL.Host == "" {
+		return actorURL
+	}
+
+	username := strings.TrimPrefix(path.Base(strings.TrimSuffix(parsedURL.Path, "/")), "@")
+	if username == "" || username == "." || username == "/" {
+		return parsedURL.Host
+	}
+
+	return username + "@" + parsedURL.Host
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -39,6 +39,23 @@ func (a *atom10Adapter) BuildFeed(baseURL string) *model.Feed {
 		feed.FeedURL = baseURL
 	}
 
+	// Populate the WebSub hub URL.
+	if hubURL := a.atomFeed.Links.firstLinkWithRelation("hub"); hubURL != "" {
+		if absoluteHubURL, err := urllib.AbsoluteURL(baseURL, hubURL); err == nil {
+			feed.HubURL = absoluteHubURL
+		}
+	}
+
+	// Populate the URL of the page with older entries (RFC 5005 paged or archived feeds).
+	for _, relation := range []string{"next", "prev-archive"} {
+		if archiveURL := a.atomFeed.Links.firstLinkWithRelation(relation); archiveURL != "" {
+			if absoluteArchiveURL, err := urllib.AbsoluteURL(baseURL, archiveURL); err == nil {
+				feed.ArchiveURL = absoluteArchiveURL
+				break
+			}
+		}
+	}
+
 	// Populate the site URL.
 	siteURL := a.atomFeed.Links.originalLink()
 	if siteURL != "" {
@@ -240,6 +240,51 @@ func TestParseFeedURL(t *testing.T) {
 	}
 }
 
+func TestParseFeedHubURL(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom">
+	  <title>Example Feed</title>
+	  <link rel="alternate" type="text/html" href="https://example.org/"/>
+	  <link rel="self" type="application/atom+xml" href="https://example.org/feed"/>
+	  <link rel="hub" href="/hub"/>
+	  <updated>2003-12-13T18:30:02Z</updated>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://example.org/hub" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
+func TestParseFeedArchiveURL(t *testing.T) {
+	scenarios := map[string]string{
+		"next":         `<link rel="next" href="/feed?page=2"/>`,
+		"prev-archive": `<link rel="prev-archive" href="https://example.org/feed?page=2"/>`,
+	}
+
+	for relation, link := range scenarios {
+		data := `<?xml version="1.0" encoding="utf-8"?>
+		<feed xmlns="http://www.w3.org/2005/Atom">
+		  <title>Example Feed</title>
+		  <link rel="self" type="application/atom+xml" href="https://example.org/feed"/>
+		  ` + link + `
+		</feed>`
+
+		feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if feed.ArchiveURL != "https://example.org/feed?page=2" {
+			t.Errorf("Incorrect archive URL for %q link, got: %s", relation, feed.ArchiveURL)
+		}
+	}
+}
+
 func TestParseFeedWithRelativeFeedURL(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 	<feed xmlns="http://www.w3.org/2005/Atom">
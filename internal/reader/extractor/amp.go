@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor // import "miniflux.app/v2/internal/reader/extractor"
+
+import (
+	"bytes"
+	"strings"
+
+	"miniflux.app/v2/internal/reader/readability"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ampExtractor follows the link to the AMP version of the page, which is usually
+// free of the clutter found on the canonical page, and runs readability on it.
+type ampExtractor struct{}
+
+func (a *ampExtractor) fetchesPages() {}
+
+func (a *ampExtractor) Name() string {
+	return "amp"
+}
+
+func (a *ampExtractor) Extract(page *Page) (*Result, error) {
+	if page.Fetch == nil {
+		return nil, ErrNotApplicable
+	}
+
+	document, err := page.parsedDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	ampURL, _ := document.FindMatcher(goquery.Single(`link[rel="amphtml"]`)).Attr("href")
+	ampURL = strings.TrimSpace(ampURL)
+	if ampURL == "" {
+		return nil, ErrNotApplicable
+	}
+
+	ampURL, err = urllib.AbsoluteURL(page.URL, ampURL)
+	if err != nil || ampURL == page.URL {
+		return nil, ErrNotApplicable
+	}
+
+	body, effectiveURL, err := page.fetch(ampURL)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := readability.ExtractArticle(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := article.BaseURL
+	if baseURL == "" {
+		baseURL = effectiveURL
+	}
+
+	confidence := contentConfidence(article.Content)
+	if article.Score <= 0 {
+		confidence /= 2
+	}
+
+	return &Result{
+		BaseURL:    baseURL,
+		Content:    article.Content,
+		Confidence: confidence,
+	}, nil
+}
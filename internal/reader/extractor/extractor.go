@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor // import "miniflux.app/v2/internal/reader/extractor"
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MinConfidence is the confidence below which the remembered extractor is not trusted anymore.
+const MinConfidence = 0.5
+
+// ErrNotApplicable is returned when an extractor cannot handle a page, for example
+// when there is no JSON-LD article or no AMP version.
+var ErrNotApplicable = errors.New("extractor: not applicable to this page")
+
+// FetchFunc downloads a page and returns its HTML body and the URL after redirects.
+type FetchFunc func(pageURL string) (body []byte, effectiveURL string, err error)
+
+// Page represents a downloaded web page.
+type Page struct {
+	URL  string
+	Body []byte
+
+	// Fetch is used by extractors that need to download other pages.
+	// It can be nil, in which case those extractors are not applicable.
+	Fetch FetchFunc
+
+	// The parsed body and the other pages are shared by the extractors.
+	document *goquery.Document
+	fetched  map[string]*fetchedPage
+}
+
+type fetchedPage struct {
+	body         []byte
+	effectiveURL string
+	err          error
+}
+
+// parsedDocument returns the parsed body of the page, it is parsed once for all the extractors.
+func (p *Page) parsedDocument() (*goquery.Document, error) {
+	if p.document == nil {
+		document, err := goquery.NewDocumentFromReader(bytes.NewReader(p.Body))
+		if err != nil {
+			return nil, err
+		}
+		p.document = document
+	}
+	return p.document, nil
+}
+
+// fetch downloads another page, each page is downloaded once for all the extractors.
+func (p *Page) fetch(pageURL string) ([]byte, string, error) {
+	if p.fetched == nil {
+		p.fetched = make(map[string]*fetchedPage)
+	}
+
+	fetched, found := p.fetched[pageURL]
+	if !found {
+		fetched = &fetchedPage{}
+		fetched.body, fetched.effectiveURL, fetched.err = p.Fetch(pageURL)
+		p.fetched[pageURL] = fetched
+	}
+	return fetched.body, fetched.effectiveURL, fetched.err
+}
+
+// Result represents the content extracted from a page.
+type Result struct {
+	Extractor  string
+	BaseURL    string
+	Content    string
+	Confidence float64
+}
+
+// Extractor extracts the main content of a web page.
+type Extractor interface {
+	// Name returns the identifier stored in the feed settings.
+	Name() string
+
+	// Extract returns the article content with a confidence score between 0 and 1.
+	Extract(page *Page) (*Result, error)
+}
+
+// remoteExtractor is implemented by the extractors downloading other pages,
+// they only run when the extractors using the page alone are not confident enough.
+type remoteExtractor interface {
+	fetchesPages()
+}
+
+var extractors = []Extractor{
+	&readabilityExtractor{},
+	&jsonLDExtractor{},
+	&ampExtractor{},
+	&multiPageExtractor{},
+}
+
+// Names returns the list of available extractors.
+func Names() []string {
+	names := make([]string, 0, len(extractors))
+	for _, e := range extractors {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+// IsValid returns true if the given name is empty (automatic) or a known extractor.
+func IsValid(name string) bool {
+	return name == "" || find(name) != nil
+}
+
+func find(name string) Extractor {
+	for _, e := range extractors {
+		if e.Name() == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// Extract returns the content of the page.
+//
+// When name is set, only this extractor is used. Otherwise, the remembered extractor is tried
+// first and kept if it is confident enough, else the extractors using the page alone run,
+// then the extractors downloading other pages if needed, and the best result wins.
+func Extract(page *Page, name, remembered string) (*Result, error) {
+	if name != "" {
+		e := find(name)
+		if e == nil {
+			return nil, errors.New("extractor: unknown extractor " + name)
+		}
+		return run(e, page)
+	}
+
+	var best *Result
+	var lastErr error
+	try := func(e Extractor) {
+		result, err := run(e, page)
+		if err != nil {
+			if !errors.Is(err, ErrNotApplicable) {
+				lastErr = err
+			}
+			return
+		}
+
+		slog.Debug("Extractor result",
+			slog.String("url", page.URL),
+			slog.String("extractor", result.Extractor),
+			slog.Float64("confidence", result.Confidence),
+		)
+
+		if best == nil || result.Confidence > best.Confidence {
+			best = result
+		}
+	}
+
+	if e := find(remembered); e != nil {
+		try(e)
+	}
+
+	for _, remote := range []bool{false, true} {
+		if best != nil && best.Confidence >= MinConfidence {
+			return best, nil
+		}
+
+		for _, e := range extractors {
+			if _, fetchesPages := e.(remoteExtractor); fetchesPages == remote && e.Name() != remembered {
+				try(e)
+			}
+		}
+	}
+
+	if best == nil {
+		if lastErr == nil {
+			lastErr = ErrNotApplicable
+		}
+		return nil, lastErr
+	}
+
+	return best, nil
+}
+
+func run(e Extractor, page *Page) (*Result, error) {
+	result, err := e.Extract(page)
+	if err != nil {
+		return nil, err
+	}
+	result.Extractor = e.Name()
+	return result, nil
+}
+
+// contentConfidence scores extracted HTML between 0 and 1.
+// Long content made of several paragraphs with few links gets a higher score.
+func contentConfidence(content string) float64 {
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return 0
+	}
+
+	text := strings.Join(strings.Fields(document.Text()), " ")
+	textLength := len(text)
+	if textLength == 0 {
+		return 0
+	}
+
+	linkLength := len(strings.Join(strings.Fields(document.Find("a").Text()), " "))
+	linkDensity := float64(linkLength) / float64(textLength)
+
+	paragraphs := document.Find("p").Length()
+
+	lengthScore := min(float64(textLength)/2000, 1)
+	paragraphScore := min(float64(paragraphs)/5, 1)
+
+	return (0.7*lengthScore + 0.3*paragraphScore) * (1 - linkDensity)
+}
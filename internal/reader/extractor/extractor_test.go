@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor // import "miniflux.app/v2/internal/reader/extractor"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const paragraph = `<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua, ut enim ad minim veniam.</p>`
+
+func articlePage(head, body string) []byte {
+	return []byte(`<html><head>` + head + `</head><body><div class="article-content">` + body + `</div></body></html>`)
+}
+
+func fakeFetch(pages map[string][]byte) FetchFunc {
+	return func(pageURL string) ([]byte, string, error) {
+		if body, ok := pages[pageURL]; ok {
+			return body, pageURL, nil
+		}
+		return nil, "", errors.New("not found")
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, name := range []string{"", "readability", "jsonld", "amp", "multipage"} {
+		if !IsValid(name) {
+			t.Errorf(`%q should be a valid extractor`, name)
+		}
+	}
+
+	if IsValid("unknown") {
+		t.Error(`"unknown" should not be a valid extractor`)
+	}
+}
+
+func TestJSONLDExtractor(t *testing.T) {
+	body := articlePage(
+		`<script type="application/ld+json">{"@context":"https://schema.org","@graph":[{"@type":"WebSite"},{"@type":["NewsArticle"],"articleBody":"First paragraph.\nSecond <paragraph>."}]}</script>`,
+		`<p>Teaser</p>`,
+	)
+
+	result, err := Extract(&Page{URL: "https://example.org/a", Body: body}, "jsonld", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<p>First paragraph.</p><p>Second &lt;paragraph&gt;.</p>`
+	if result.Content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, result.Content, expected)
+	}
+
+	if result.Extractor != "jsonld" {
+		t.Errorf(`Unexpected extractor: %q`, result.Extractor)
+	}
+}
+
+func TestJSONLDExtractorWithoutArticle(t *testing.T) {
+	body := articlePage(`<script type="application/ld+json">{"@type":"Organization"}</script>`, paragraph)
+
+	_, err := Extract(&Page{URL: "https://example.org/a", Body: body}, "jsonld", "")
+	if !errors.Is(err, ErrNotApplicable) {
+		t.Errorf(`Expected ErrNotApplicable, got %v`, err)
+	}
+}
+
+func TestAMPExtractor(t *testing.T) {
+	page := &Page{
+		URL:  "https://example.org/article",
+		Body: articlePage(`<link rel="amphtml" href="/article/amp">`, `<p>Teaser</p>`),
+		Fetch: fakeFetch(map[string][]byte{
+			"https://example.org/article/amp": articlePage("", paragraph+paragraph),
+		}),
+	}
+
+	result, err := Extract(page, "amp", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(result.Content, "Lorem ipsum") {
+		t.Errorf(`Unexpected content: %s`, result.Content)
+	}
+
+	if result.BaseURL != "https://example.org/article/amp" {
+		t.Errorf(`Unexpected base URL: %q`, result.BaseURL)
+	}
+}
+
+func TestAMPExtractorWithoutFetcher(t *testing.T) {
+	page := &Page{URL: "https://example.org/article", Body: articlePage(`<link rel="amphtml" href="/article/amp">`, paragraph)}
+
+	if _, err := Extract(page, "amp", ""); !errors.Is(err, ErrNotApplicable) {
+		t.Errorf(`Expected ErrNotApplicable, got %v`, err)
+	}
+}
+
+func TestMultiPageExtractor(t *testing.T) {
+	page := &Page{
+		URL:  "https://example.org/article",
+		Body: articlePage(`<link rel="next" href="/article/2">`, `<p>Page one, with enough text to be scored as a paragraph.</p>`),
+		Fetch: fakeFetch(map[string][]byte{
+			"https://example.org/article/2": articlePage(`<link rel="next" href="/article/3">`, `<p>Page two, with enough text to be scored as a paragraph.</p>`),
+			"https://example.org/article/3": articlePage(`<link rel="next" href="/article/2">`, `<p>Page three, with enough text to be scored as a paragraph.</p>`),
+		}),
+	}
+
+	result, err := Extract(page, "multipage", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{"Page one", "Page two", "Page three"} {
+		if strings.Count(result.Content, text) != 1 {
+			t.Errorf(`The content should contain %q once: %s`, text, result.Content)
+		}
+	}
+}
+
+func TestIsNextPageOfArticle(t *testing.T) {
+	scenarios := []struct {
+		current   string
+		candidate string
+		expected  bool
+	}{
+		{"https://example.org/article", "https://example.org/article/2", true},
+		{"https://example.org/article", "https://example.org/article?page=2", true},
+		{"https://example.org/article/page/2", "https://example.org/article/page/3", true},
+		{"https://example.org/article-1.html", "https://example.org/article-1.html?p=2", true},
+		{"https://example.org/p/123", "https://example.org/p/124", false},
+		{"https://example.org/2024/05/first-post", "https://example.org/2024/05/second-post", false},
+		{"https://example.org/article", "https://example.com/article/2", false},
+		{"https://example.org/", "https://example.org/page/2", false},
+	}
+
+	for _, scenario := range scenarios {
+		if actual := isNextPageOfArticle(scenario.current, scenario.candidate); actual != scenario.expected {
+			t.Errorf(`Unexpected result for %q -> %q, got %v instead of %v`, scenario.current, scenario.candidate, actual, scenario.expected)
+		}
+	}
+}
+
+func TestExtractPicksBestResult(t *testing.T) {
+	articleBody := strings.Repeat("A sentence of the full article provided by the publisher. ", 10)
+	body := articlePage(
+		`<script type="application/ld+json">{"@type":"Article","articleBody":"`+strings.Repeat(articleBody+`\n`, 5)+`"}</script>`,
+		`<p>Only a short teaser is visible on this page, subscribe to read more.</p>`,
+	)
+
+	result, err := Extract(&Page{URL: "https://example.org/a", Body: body}, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Extractor != "jsonld" {
+		t.Errorf(`The JSON-LD extractor should win, got %q`, result.Extractor)
+	}
+}
+
+func TestExtractKeepsRememberedExtractorWhenConfident(t *testing.T) {
+	body := articlePage("", strings.Repeat(paragraph, 20))
+
+	result, err := Extract(&Page{URL: "https://example.org/a", Body: body}, "", "readability")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Extractor != "readability" {
+		t.Errorf(`The remembered extractor should be used, got %q`, result.Extractor)
+	}
+
+	if result.Confidence < MinConfidence {
+		t.Errorf(`Unexpected confidence: %f`, result.Confidence)
+	}
+}
+
+func TestExtractWithUnknownExtractor(t *testing.T) {
+	if _, err := Extract(&Page{URL: "https://example.org/a", Body: articlePage("", paragraph)}, "unknown", ""); err == nil {
+		t.Error(`An unknown extractor should return an error`)
+	}
+}
+
+func countingFetch(pages map[string][]byte, counts map[string]int) FetchFunc {
+	fetch := fakeFetch(pages)
+	return func(pageURL string) ([]byte, string, error) {
+		counts[pageURL]++
+		return fetch(pageURL)
+	}
+}
+
+func TestExtractSkipsRemoteExtractorsWhenConfident(t *testing.T) {
+	counts := make(map[string]int)
+	page := &Page{
+		URL:   "https://example.org/article",
+		Body:  articlePage(`<link rel="amphtml" href="/article/amp">`, strings.Repeat(paragraph, 20)),
+		Fetch: countingFetch(map[string][]byte{"https://example.org/article/amp": articlePage("", paragraph)}, counts),
+	}
+
+	result, err := Extract(page, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Extractor != "readability" {
+		t.Errorf(`The readability extractor should be used, got %q`, result.Extractor)
+	}
+	if len(counts) != 0 {
+		t.Errorf(`No page should be downloaded, got %v`, counts)
+	}
+}
+
+func TestExtractRunsRememberedExtractorOnce(t *testing.T) {
+	counts := make(map[string]int)
+	page := &Page{
+		URL:   "https://example.org/article",
+		Body:  articlePage(`<link rel="amphtml" href="/article/amp">`, `<p>Teaser</p>`),
+		Fetch: countingFetch(map[string][]byte{"https://example.org/article/amp": articlePage("", paragraph)}, counts),
+	}
+
+	result, err := Extract(page, "", "amp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Confidence >= MinConfidence {
+		t.Errorf(`Unexpected confidence: %f`, result.Confidence)
+	}
+	if counts["https://example.org/article/amp"] != 1 {
+		t.Errorf(`The AMP page should be downloaded once, got %v`, counts)
+	}
+}
+
+func TestPageFetchesEachPageOnce(t *testing.T) {
+	counts := make(map[string]int)
+	page := &Page{URL: "https://example.org/article", Fetch: countingFetch(map[string][]byte{}, counts)}
+
+	for range 2 {
+		if _, _, err := page.fetch("https://example.org/missing"); err == nil {
+			t.Error(`Expected an error for a missing page`)
+		}
+	}
+	if counts["https://example.org/missing"] != 1 {
+		t.Errorf(`The page should be downloaded once, got %v`, counts)
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor // import "miniflux.app/v2/internal/reader/extractor"
+
+import (
+	"encoding/json"
+	"html"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var jsonLDArticleTypes = []string{"Article", "NewsArticle", "BlogPosting", "Report", "TechArticle", "ScholarlyArticle", "ReportageNewsArticle", "AnalysisNewsArticle"}
+
+// jsonLDExtractor uses the articleBody property of schema.org metadata embedded in the page.
+type jsonLDExtractor struct{}
+
+func (j *jsonLDExtractor) Name() string {
+	return "jsonld"
+}
+
+func (j *jsonLDExtractor) Extract(page *Page) (*Result, error) {
+	document, err := page.parsedDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	var articleBody string
+	document.Find(`script[type="application/ld+json"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+		articleBody = findArticleBody(data)
+		return articleBody == ""
+	})
+
+	if articleBody == "" {
+		return nil, ErrNotApplicable
+	}
+
+	content := articleBodyToHTML(articleBody)
+	return &Result{
+		Content:    content,
+		Confidence: contentConfidence(content),
+	}, nil
+}
+
+func findArticleBody(data any) string {
+	switch value := data.(type) {
+	case []any:
+		for _, item := range value {
+			if body := findArticleBody(item); body != "" {
+				return body
+			}
+		}
+	case map[string]any:
+		if graph, ok := value["@graph"]; ok {
+			if body := findArticleBody(graph); body != "" {
+				return body
+			}
+		}
+		if isArticleType(value["@type"]) {
+			if body, ok := value["articleBody"].(string); ok {
+				return strings.TrimSpace(body)
+			}
+		}
+	}
+	return ""
+}
+
+func isArticleType(value any) bool {
+	switch t := value.(type) {
+	case string:
+		for _, articleType := range jsonLDArticleTypes {
+			if t == articleType {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range t {
+			if isArticleType(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// articleBodyToHTML converts a plain text article body to paragraphs.
+// Some publishers put HTML in this property, it's returned as is.
+func articleBodyToHTML(body string) string {
+	if strings.Contains(body, "</p>") || strings.Contains(body, "<br") {
+		return body
+	}
+
+	var output strings.Builder
+	for _, paragraph := range strings.Split(body, "\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph != "" {
+			output.WriteString("<p>" + html.EscapeString(paragraph) + "</p>")
+		}
+	}
+	return output.String()
+}
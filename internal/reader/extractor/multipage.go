@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor // import "miniflux.app/v2/internal/reader/extractor"
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"miniflux.app/v2/internal/reader/readability"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const maxPages = 5
+
+// multiPageExtractor follows "next page" links of articles split across several pages
+// and stitches the content of each page together.
+type multiPageExtractor struct{}
+
+func (m *multiPageExtractor) fetchesPages() {}
+
+func (m *multiPageExtractor) Name() string {
+	return "multipage"
+}
+
+func (m *multiPageExtractor) Extract(page *Page) (*Result, error) {
+	if page.Fetch == nil {
+		return nil, ErrNotApplicable
+	}
+
+	document, err := page.parsedDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	nextURL := findNextPageURL(page.URL, page.URL, document)
+	if nextURL == "" {
+		return nil, ErrNotApplicable
+	}
+
+	first, err := readability.ExtractArticle(bytes.NewReader(page.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	content.WriteString(first.Content)
+
+	visited := map[string]bool{page.URL: true}
+	for pages := 1; nextURL != "" && !visited[nextURL] && pages < maxPages; pages++ {
+		visited[nextURL] = true
+
+		body, effectiveURL, err := page.fetch(nextURL)
+		if err != nil {
+			break
+		}
+
+		article, err := readability.ExtractArticle(bytes.NewReader(body))
+		if err != nil || article.Score <= 0 {
+			break
+		}
+
+		content.WriteString(article.Content)
+		nextDocument, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			break
+		}
+		nextURL = findNextPageURL(page.URL, effectiveURL, nextDocument)
+	}
+
+	if len(visited) == 1 {
+		return nil, ErrNotApplicable
+	}
+
+	return &Result{
+		BaseURL:    first.BaseURL,
+		Content:    content.String(),
+		Confidence: contentConfidence(content.String()),
+	}, nil
+}
+
+// findNextPageURL returns the link to the page following pageURL, if it belongs to the article starting at articleURL.
+func findNextPageURL(articleURL, pageURL string, document *goquery.Document) string {
+	var nextURL string
+	document.Find(`link[rel="next"],a[rel~="next"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		href, _ := s.Attr("href")
+		absoluteURL, err := urllib.AbsoluteURL(pageURL, strings.TrimSpace(href))
+		if err == nil && absoluteURL != pageURL && isNextPageOfArticle(articleURL, absoluteURL) {
+			nextURL = absoluteURL
+			return false
+		}
+		return true
+	})
+
+	return nextURL
+}
+
+// isNextPageOfArticle returns true if the candidate URL looks like another page of the same article
+// (e.g. "/article/2", "/article?page=2" or "/article/page/2") rather than the next post of a blog.
+func isNextPageOfArticle(pageURL, candidateURL string) bool {
+	current, err := url.Parse(pageURL)
+	if err != nil {
+		return false
+	}
+
+	candidate, err := url.Parse(candidateURL)
+	if err != nil || candidate.Host != current.Host || candidateURL == pageURL {
+		return false
+	}
+
+	currentPath := stripPageNumber(current.Path)
+	candidatePath := stripPageNumber(candidate.Path)
+	if currentPath == "" || !strings.HasPrefix(candidatePath, currentPath) {
+		return false
+	}
+
+	// Sequential numeric identifiers ("/p/123" and "/p/124") are different posts.
+	return candidate.Path == current.Path ||
+		strings.HasPrefix(candidate.Path, strings.TrimRight(current.Path, "/")+"/") ||
+		strings.Contains(strings.ToLower(candidate.Path), "page")
+}
+
+func stripPageNumber(path string) string {
+	path = strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return -1
+		}
+		return r
+	}, path)
+	return strings.TrimRight(path, "/-_")
+}
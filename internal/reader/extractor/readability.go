@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package extractor // import "miniflux.app/v2/internal/reader/extractor"
+
+import (
+	"bytes"
+
+	"miniflux.app/v2/internal/reader/readability"
+)
+
+// readabilityExtractor is the default heuristic based on paragraph scoring.
+type readabilityExtractor struct{}
+
+func (r *readabilityExtractor) Name() string {
+	return "readability"
+}
+
+func (r *readabilityExtractor) Extract(page *Page) (*Result, error) {
+	article, err := readability.ExtractArticle(bytes.NewReader(page.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	// A top candidate without any scored paragraph means readability fell back to the whole body.
+	confidence := contentConfidence(article.Content)
+	if article.Score <= 0 {
+		confidence /= 2
+	}
+
+	return &Result{
+		BaseURL:    article.BaseURL,
+		Content:    article.Content,
+		Confidence: confidence,
+	}, nil
+}
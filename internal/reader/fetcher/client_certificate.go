@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseClientCertificate parses a PEM encoded client certificate with its private key and a PEM encoded CA bundle.
+// The certificate and the key must be given together, and at least one of the certificate or the CA bundle is required.
+// The returned pool contains the system roots and the certificate authorities of the bundle, it is nil without a bundle.
+func ParseClientCertificate(certificatePEM, privateKeyPEM, caBundlePEM string) ([]tls.Certificate, *x509.CertPool, error) {
+	certificatePEM = strings.TrimSpace(certificatePEM)
+	privateKeyPEM = strings.TrimSpace(privateKeyPEM)
+	caBundlePEM = strings.TrimSpace(caBundlePEM)
+
+	if certificatePEM == "" && privateKeyPEM == "" && caBundlePEM == "" {
+		return nil, nil, errors.New("fetcher: a client certificate or a CA bundle is required")
+	}
+
+	if (certificatePEM == "") != (privateKeyPEM == "") {
+		return nil, nil, errors.New("fetcher: the client certificate and its private key must be given together")
+	}
+
+	var certificates []tls.Certificate
+	if certificatePEM != "" {
+		certificate, err := tls.X509KeyPair([]byte(certificatePEM), []byte(privateKeyPEM))
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetcher: invalid client certificate: %w", err)
+		}
+		certificates = []tls.Certificate{certificate}
+	}
+
+	var rootCAs *x509.CertPool
+	if caBundlePEM != "" {
+		// The bundle extends the system roots, the feed may redirect to public hosts.
+		var err error
+		if rootCAs, err = x509.SystemCertPool(); err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM([]byte(caBundlePEM)) {
+			return nil, nil, errors.New("fetcher: the CA bundle does not contain any certificate")
+		}
+	}
+
+	return certificates, rootCAs, nil
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func generateClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "miniflux"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privateKeyBytes})
+	return string(certificatePEM), string(privateKeyPEM)
+}
+
+func TestParseClientCertificate(t *testing.T) {
+	certificatePEM, privateKeyPEM := generateClientCertificate(t)
+
+	certificates, rootCAs, err := ParseClientCertificate(certificatePEM, privateKeyPEM, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(certificates) != 1 {
+		t.Errorf("Expected one certificate, got %d", len(certificates))
+	}
+	if rootCAs != nil {
+		t.Error("Expected no CA pool without a CA bundle")
+	}
+
+	certificates, rootCAs, err = ParseClientCertificate("", "", certificatePEM)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(certificates) != 0 {
+		t.Errorf("Expected no certificate, got %d", len(certificates))
+	}
+	if rootCAs == nil {
+		t.Error("Expected a CA pool")
+	}
+}
+
+func TestParseInvalidClientCertificate(t *testing.T) {
+	certificatePEM, privateKeyPEM := generateClientCertificate(t)
+	_, otherPrivateKeyPEM := generateClientCertificate(t)
+
+	tests := []struct {
+		name           string
+		certificatePEM string
+		privateKeyPEM  string
+		caBundlePEM    string
+	}{
+		{"empty", "", "", ""},
+		{"certificate without key", certificatePEM, "", ""},
+		{"key without certificate", "", privateKeyPEM, ""},
+		{"mismatched key", certificatePEM, otherPrivateKeyPEM, ""},
+		{"invalid CA bundle", "", "", "not a certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseClientCertificate(tt.certificatePEM, tt.privateKeyPEM, tt.caBundlePEM); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestRequestBuilder_WithClientCertificate(t *testing.T) {
+	certificatePEM, privateKeyPEM := generateClientCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) != 1 || r.TLS.PeerCertificates[0].Subject.CommonName != "miniflux" {
+			t.Error("Expected the client certificate to be presented")
+		}
+		w.Write([]byte("<rss></rss>"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	caBundlePEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	builder := NewRequestBuilder()
+	builder.WithClientCertificate(certificatePEM, privateKeyPEM, caBundlePEM, server.URL+"/feed.xml")
+	responseHandler := NewResponseHandler(builder.ExecuteRequest(server.URL))
+	defer responseHandler.Close()
+
+	if responseHandler.LocalizedError() != nil {
+		t.Fatalf("Expected no error, got %v", responseHandler.LocalizedError().Error())
+	}
+
+	// The private CA is not trusted for other hosts.
+	builder = NewRequestBuilder()
+	builder.WithClientCertificate(certificatePEM, privateKeyPEM, caBundlePEM, "https://example.org/feed.xml")
+	otherResponseHandler := NewResponseHandler(builder.ExecuteRequest(server.URL))
+	defer otherResponseHandler.Close()
+
+	if otherResponseHandler.LocalizedError() == nil {
+		t.Error("Expected a TLS error for another host")
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// ParseCustomHeaders parses the custom headers of a feed, one "Name: value" per line.
+// Lines starting with "?" add query string parameters, like "?token=secret".
+// Empty lines and lines starting with "#" are ignored.
+func ParseCustomHeaders(customHeaders string) (http.Header, url.Values, error) {
+	headers := make(http.Header)
+	query := make(url.Values)
+
+	for i, line := range strings.Split(customHeaders, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if parameters, found := strings.CutPrefix(line, "?"); found {
+			values, err := url.ParseQuery(parameters)
+			if err != nil || len(values) == 0 {
+				return nil, nil, fmt.Errorf("fetcher: invalid query string parameter on line %d", i+1)
+			}
+			for name, parameterValues := range values {
+				for _, value := range parameterValues {
+					query.Add(name, value)
+				}
+			}
+			continue
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if !found || !httpguts.ValidHeaderFieldName(name) || !httpguts.ValidHeaderFieldValue(value) {
+			return nil, nil, fmt.Errorf("fetcher: invalid header on line %d", i+1)
+		}
+		headers.Add(name, value)
+	}
+
+	return headers, query, nil
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
+
+import "testing"
+
+func TestParseCustomHeaders(t *testing.T) {
+	headers, query, err := ParseCustomHeaders("Authorization: Bearer abc:def\r\n\n# API key\nX-Api-Key:  123 \n?token=secret&sig=a%2Bb")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if value := headers.Get("Authorization"); value != "Bearer abc:def" {
+		t.Errorf(`Unexpected Authorization header: %q`, value)
+	}
+
+	if value := headers.Get("X-Api-Key"); value != "123" {
+		t.Errorf(`Unexpected X-Api-Key header: %q`, value)
+	}
+
+	if len(headers) != 2 {
+		t.Errorf(`Unexpected number of headers: %d`, len(headers))
+	}
+
+	if query.Get("token") != "secret" || query.Get("sig") != "a+b" {
+		t.Errorf(`Unexpected query string parameters: %v`, query)
+	}
+}
+
+func TestParseInvalidCustomHeaders(t *testing.T) {
+	for _, customHeaders := range []string{
+		"Authorization Bearer abc",
+		"Invalid Name: value",
+		": value",
+		"?",
+		"?token=%zz",
+	} {
+		if _, _, err := ParseCustomHeaders(customHeaders); err == nil {
+			t.Errorf(`Parsing %q should fail`, customHeaders)
+		}
+	}
+}
@@ -4,17 +4,26 @@
 package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"miniflux.app/v2/internal/hostlimiter"
 	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/urllib"
 )
 
 const (
@@ -22,6 +31,10 @@ const (
 	defaultAcceptHeader      = "application/xml, application/atom+xml, application/rss+xml, application/rdf+xml, application/feed+json, text/html, */*;q=0.9"
 )
 
+// customQueryContextKey holds the query string added to the request URL from the custom headers,
+// it is removed from the URLs reported by the response handler.
+type customQueryContextKey struct{}
+
 type RequestBuilder struct {
 	headers            http.Header
 	clientProxyURL     *url.URL
@@ -31,8 +44,16 @@ type RequestBuilder struct {
 	ignoreTLSErrors    bool
 	disableHTTP2       bool
 	disableCompression bool
+	interactive        bool
 	proxyRotator       *proxyrotator.ProxyRotator
 	feedProxyURL       string
+	usedProxyURL       string
+	customHeaders      http.Header
+	customQuery        url.Values
+	customHeadersHost  string
+	clientCertificates []tls.Certificate
+	rootCAs            *x509.CertPool
+	tlsHost            string
 }
 
 func NewRequestBuilder() *RequestBuilder {
@@ -47,6 +68,17 @@ func (r *RequestBuilder) WithHeader(key, value string) *RequestBuilder {
 	return r
 }
 
+// WithoutHeader removes a header set by WithHeader.
+func (r *RequestBuilder) WithoutHeader(key string) *RequestBuilder {
+	r.headers.Del(key)
+	return r
+}
+
+// Header returns the value of a header set by WithHeader.
+func (r *RequestBuilder) Header(key string) string {
+	return r.headers.Get(key)
+}
+
 func (r *RequestBuilder) WithETag(etag string) *RequestBuilder {
 	if etag != "" {
 		r.headers.Set("If-None-Match", etag)
@@ -84,6 +116,54 @@ func (r *RequestBuilder) WithUsernameAndPassword(username, password string) *Req
 	return r
 }
 
+// WithCustomHeaders adds the custom headers and query string parameters of a feed to the requests sent to the host of the scope URL.
+// They are never sent to other hosts, like the ones of the entries or of the media. Invalid custom headers are ignored.
+func (r *RequestBuilder) WithCustomHeaders(customHeaders, scopeURL string) *RequestBuilder {
+	if customHeaders == "" {
+		return r
+	}
+
+	headers, query, err := ParseCustomHeaders(customHeaders)
+	if err != nil {
+		slog.Warn("Ignoring invalid custom headers", slog.String("scope_url", scopeURL), slog.Any("error", err))
+		return r
+	}
+
+	parsedScopeURL, err := url.Parse(scopeURL)
+	if err != nil || parsedScopeURL.Hostname() == "" {
+		return r
+	}
+
+	r.customHeaders = headers
+	r.customQuery = query
+	r.customHeadersHost = parsedScopeURL.Hostname()
+	return r
+}
+
+// WithClientCertificate presents the client certificate and trusts the CA bundle when connecting to the host of the scope URL.
+// Like the custom headers, they are not used for other hosts. Invalid certificates are ignored.
+func (r *RequestBuilder) WithClientCertificate(certificatePEM, privateKeyPEM, caBundlePEM, scopeURL string) *RequestBuilder {
+	if certificatePEM == "" && caBundlePEM == "" {
+		return r
+	}
+
+	certificates, rootCAs, err := ParseClientCertificate(certificatePEM, privateKeyPEM, caBundlePEM)
+	if err != nil {
+		slog.Warn("Ignoring invalid client certificate", slog.String("scope_url", scopeURL), slog.Any("error", err))
+		return r
+	}
+
+	parsedScopeURL, err := url.Parse(scopeURL)
+	if err != nil || parsedScopeURL.Hostname() == "" {
+		return r
+	}
+
+	r.clientCertificates = certificates
+	r.rootCAs = rootCAs
+	r.tlsHost = parsedScopeURL.Hostname()
+	return r
+}
+
 func (r *RequestBuilder) WithProxyRotator(proxyRotator *proxyrotator.ProxyRotator) *RequestBuilder {
 	r.proxyRotator = proxyRotator
 	return r
@@ -129,7 +209,39 @@ func (r *RequestBuilder) WithoutCompression() *RequestBuilder {
 	return r
 }
 
+// Interactive marks the requests made while a user is waiting for the page, they fail instead of waiting for the host limits.
+func (r *RequestBuilder) Interactive() *RequestBuilder {
+	r.interactive = true
+	return r
+}
+
+// UsedProxyURL returns the redacted URL of the proxy used by the last request, if any.
+func (r *RequestBuilder) UsedProxyURL() string {
+	return r.usedProxyURL
+}
+
 func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, error) {
+	return r.executeRequest(http.MethodGet, requestURL, "", nil)
+}
+
+// ExecutePostRequest sends a POST request with a body of the given content type, such as a form or a JSON document.
+func (r *RequestBuilder) ExecutePostRequest(requestURL, contentType string, body []byte) (*http.Response, error) {
+	return r.executeRequest(http.MethodPost, requestURL, contentType, body)
+}
+
+func (r *RequestBuilder) executeRequest(method, requestURL, contentType string, body []byte) (*http.Response, error) {
+	// Scraper, icon and media requests share the limits of the feed requests to the same host.
+	requestHost := urllib.Domain(requestURL)
+	if hostlimiter.HostLimiterInstance != nil {
+		waitForHost := hostlimiter.HostLimiterInstance.Wait
+		if r.interactive {
+			waitForHost = hostlimiter.HostLimiterInstance.TryAcquire
+		}
+		if err := waitForHost(requestHost); err != nil {
+			return nil, err
+		}
+	}
+
 	transport := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		// Setting `DialContext` disables HTTP/2, this option forces the transport to try HTTP/2 regardless.
@@ -155,6 +267,16 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 		}
 	}
 
+	useClientCertificate := false
+	if parsedRequestURL, err := url.Parse(requestURL); err == nil && r.tlsHost != "" && strings.EqualFold(parsedRequestURL.Hostname(), r.tlsHost) {
+		useClientCertificate = true
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.Certificates = r.clientCertificates
+		transport.TLSClientConfig.RootCAs = r.rootCAs
+	}
+
 	if r.disableHTTP2 {
 		transport.ForceAttemptHTTP2 = false
 
@@ -163,7 +285,7 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
 	}
 
-	var clientProxyURL *url.URL
+	var clientProxyURL, rotatedProxyURL *url.URL
 
 	switch {
 	case r.feedProxyURL != "":
@@ -175,14 +297,23 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 	case r.useClientProxy && r.clientProxyURL != nil:
 		clientProxyURL = r.clientProxyURL
 	case r.proxyRotator != nil && r.proxyRotator.HasProxies():
-		clientProxyURL = r.proxyRotator.GetNextProxy()
+		clientProxyURL = r.proxyRotator.GetProxyForHost(requestHost)
+		rotatedProxyURL = clientProxyURL
 	}
 
 	var clientProxyURLRedacted string
+	var proxyConnectFailed atomic.Bool
 	if clientProxyURL != nil {
 		transport.Proxy = http.ProxyURL(clientProxyURL)
+		transport.OnProxyConnectResponse = func(ctx context.Context, proxyURL *url.URL, connectReq *http.Request, connectRes *http.Response) error {
+			if connectRes.StatusCode != http.StatusOK {
+				proxyConnectFailed.Store(true)
+			}
+			return nil
+		}
 		clientProxyURLRedacted = clientProxyURL.Redacted()
 	}
+	r.usedProxyURL = clientProxyURLRedacted
 
 	client := &http.Client{
 		Timeout: r.clientTimeout,
@@ -196,12 +327,33 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 
 	client.Transport = transport
 
-	req, err := http.NewRequest("GET", requestURL, nil)
+	var requestBody io.Reader
+	if body != nil {
+		requestBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, requestURL, requestBody)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header = r.headers
+	// The custom headers depend on the request host, the builder may be used for several hosts.
+	req.Header = r.headers.Clone()
+	if r.customHeadersHost != "" && strings.EqualFold(req.URL.Hostname(), r.customHeadersHost) {
+		for name, values := range r.customHeaders {
+			req.Header[name] = values
+		}
+
+		if len(r.customQuery) > 0 {
+			customQuery := r.customQuery.Encode()
+			if req.URL.RawQuery != "" {
+				customQuery = "&" + customQuery
+			}
+			req.URL.RawQuery += customQuery
+			req = req.WithContext(context.WithValue(req.Context(), customQueryContextKey{}, customQuery))
+		}
+	}
+
 	if r.disableCompression {
 		req.Header.Set("Accept-Encoding", "identity")
 	} else {
@@ -215,6 +367,9 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 	}
 
 	req.Header.Set("Connection", "close")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	slog.Debug("Making outgoing request", slog.Group("request",
 		slog.String("method", req.Method),
@@ -224,8 +379,42 @@ func (r *RequestBuilder) ExecuteRequest(requestURL string) (*http.Response, erro
 		slog.Bool("use_app_client_proxy", r.useClientProxy),
 		slog.String("client_proxy_url", clientProxyURLRedacted),
 		slog.Bool("ignore_tls_errors", r.ignoreTLSErrors),
+		slog.Bool("use_client_certificate", useClientCertificate),
 		slog.Bool("disable_http2", r.disableHTTP2),
 	))
 
-	return client.Do(req)
+	startTime := time.Now()
+	response, err := client.Do(req)
+
+	// Report the outcome to the rotator so failing proxies are taken out of rotation.
+	if rotatedProxyURL != nil {
+		switch {
+		case isProxyFailure(err, response, proxyConnectFailed.Load()):
+			r.proxyRotator.ReportFailure(rotatedProxyURL)
+		case err == nil:
+			r.proxyRotator.ReportSuccess(rotatedProxyURL, time.Since(startTime))
+		}
+	}
+
+	if hostlimiter.HostLimiterInstance != nil && err == nil {
+		hostlimiter.HostLimiterInstance.ReportResponse(requestHost, response.StatusCode, parseRetryAfterHeader(response.Header.Get("Retry-After")))
+	}
+
+	return response, err
+}
+
+// isProxyFailure returns true when the request failed because of the proxy rather than the remote server:
+// the proxy is unreachable, refused the CONNECT request, or requires authentication.
+func isProxyFailure(err error, response *http.Response, proxyConnectFailed bool) bool {
+	if proxyConnectFailed {
+		return true
+	}
+
+	if err != nil {
+		// The HTTP proxies report their dial errors as "proxyconnect", and the SOCKS proxies as "socks connect".
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && (opErr.Op == "proxyconnect" || strings.HasPrefix(opErr.Op, "socks"))
+	}
+
+	return response.StatusCode == http.StatusProxyAuthRequired
 }
@@ -4,11 +4,15 @@
 package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
+	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
+
+	"miniflux.app/v2/internal/proxyrotator"
 )
 
 func TestNewRequestBuilder(t *testing.T) {
@@ -230,6 +234,27 @@ func TestRequestBuilder_CustomAcceptHeaderNotOverridden(t *testing.T) {
 	defer resp.Body.Close()
 }
 
+func TestRequestBuilder_WithoutHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != defaultAcceptHeader {
+			t.Errorf("Expected the removed Accept header to be replaced by '%s', got '%s'", defaultAcceptHeader, r.Header.Get("Accept"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	builder := NewRequestBuilder().WithHeader("Accept", "application/json").WithoutHeader("Accept")
+	if builder.Header("Accept") != "" {
+		t.Errorf("Expected no Accept header, got '%s'", builder.Header("Accept"))
+	}
+
+	resp, err := builder.ExecuteRequest(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+}
+
 func TestRequestBuilder_WithTimeout(t *testing.T) {
 	builder := NewRequestBuilder()
 	builder = builder.WithTimeout(30 * time.Second)
@@ -361,6 +386,83 @@ func TestRequestBuilder_WithCustomFeedProxyURL(t *testing.T) {
 	}
 }
 
+func TestRequestBuilder_ProxyRotatorReportsConnectFailure(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	rotator, err := proxyrotator.NewProxyRotator([]string{proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewRequestBuilder().WithProxyRotator(rotator).ExecuteRequest("https://example.org/feed.xml"); err == nil {
+		t.Fatal("Expected the CONNECT request to fail")
+	}
+
+	if stats := rotator.Stats(); stats[0].Failures != 1 {
+		t.Errorf("Expected the proxy failure to be reported, got %d failures", stats[0].Failures)
+	}
+}
+
+func TestRequestBuilder_ProxyRotatorIgnoresRemoteErrors(t *testing.T) {
+	// The proxy forwards plain HTTP requests, the remote server errors are not proxy failures.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer proxy.Close()
+
+	rotator, err := proxyrotator.NewProxyRotator([]string{proxy.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := NewRequestBuilder().WithProxyRotator(rotator).ExecuteRequest("http://example.org/feed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if stats := rotator.Stats(); stats[0].Failures != 0 || stats[0].Successes != 1 {
+		t.Errorf("Expected the request to be reported as a success, got %d failures and %d successes", stats[0].Failures, stats[0].Successes)
+	}
+}
+
+func TestIsProxyFailure(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		err                error
+		statusCode         int
+		proxyConnectFailed bool
+		expected           bool
+	}{
+		{"proxy dial error", &net.OpError{Op: "proxyconnect", Err: errors.New("connection refused")}, 0, false, true},
+		{"socks dial error", &net.OpError{Op: "socks connect", Err: errors.New("connection refused")}, 0, false, true},
+		{"remote dial error", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, 0, false, false},
+		{"remote timeout", errors.New("context deadline exceeded"), 0, false, false},
+		{"connect refused", errors.New("Bad Gateway"), 0, true, true},
+		{"proxy authentication", nil, http.StatusProxyAuthRequired, false, true},
+		{"remote error", nil, http.StatusBadGateway, false, false},
+		{"success", nil, http.StatusOK, false, false},
+	}
+
+	for _, scenario := range scenarios {
+		var response *http.Response
+		if scenario.err == nil {
+			response = &http.Response{StatusCode: scenario.statusCode}
+		}
+
+		if actual := isProxyFailure(scenario.err, response, scenario.proxyConnectFailed); actual != scenario.expected {
+			t.Errorf("%s: expected %v, got %v", scenario.name, scenario.expected, actual)
+		}
+	}
+}
+
 func TestRequestBuilder_ChainedMethods(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check multiple headers
@@ -420,3 +522,70 @@ func TestRequestBuilder_TimeoutConfiguration(t *testing.T) {
 		t.Errorf("Expected timeout around 1s, took %v", duration)
 	}
 }
+
+func TestRequestBuilder_WithCustomHeaders(t *testing.T) {
+	tests := []struct {
+		name          string
+		scopeURL      string
+		expectedToken string
+		expectedQuery string
+	}{
+		{"same host", "http://127.0.0.1/feed.xml", "Bearer secret", "page=1&token=abc"},
+		{"other host", "https://example.org/feed.xml", "", "page=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != tt.expectedToken {
+					t.Errorf("Expected Authorization to be '%s', got '%s'", tt.expectedToken, r.Header.Get("Authorization"))
+				}
+				if r.URL.RawQuery != tt.expectedQuery {
+					t.Errorf("Expected query to be '%s', got '%s'", tt.expectedQuery, r.URL.RawQuery)
+				}
+				w.Write([]byte("<rss></rss>"))
+			}))
+			defer server.Close()
+
+			builder := NewRequestBuilder()
+			builder.WithCustomHeaders("Authorization: Bearer secret\n?token=abc", tt.scopeURL)
+			responseHandler := NewResponseHandler(builder.ExecuteRequest(server.URL + "?page=1"))
+			defer responseHandler.Close()
+
+			if responseHandler.LocalizedError() != nil {
+				t.Fatalf("Expected no error, got %v", responseHandler.LocalizedError().Error())
+			}
+
+			// The token must not leak into the feed URL.
+			if responseHandler.EffectiveURL() != server.URL+"?page=1" {
+				t.Errorf("Unexpected effective URL: %s", responseHandler.EffectiveURL())
+			}
+		})
+	}
+}
+
+func TestRequestBuilder_ExecutePostRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected a POST request, got %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Errorf("Unexpected Content-Type: '%s'", r.Header.Get("Content-Type"))
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("key") != "value" {
+			t.Errorf("Unexpected form: %v, %v", r.PostForm, err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	resp, err := NewRequestBuilder().ExecutePostRequest(server.URL, "application/x-www-form-urlencoded", []byte("key=value"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Unexpected status code: %d", resp.StatusCode)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"miniflux.app/v2/internal/hostlimiter"
 	"miniflux.app/v2/internal/locale"
 )
 
@@ -30,7 +31,73 @@ func NewResponseHandler(httpResponse *http.Response, clientErr error) *ResponseH
 }
 
 func (r *ResponseHandler) EffectiveURL() string {
-	return r.httpResponse.Request.URL.String()
+	return requestURL(r.httpResponse.Request)
+}
+
+// requestURL returns the URL of the request without the query string parameters added from the custom headers.
+func requestURL(request *http.Request) string {
+	customQuery, found := request.Context().Value(customQueryContextKey{}).(string)
+	if !found || !strings.HasSuffix(request.URL.RawQuery, customQuery) {
+		return request.URL.String()
+	}
+
+	cleanURL := *request.URL
+	cleanURL.RawQuery = strings.TrimSuffix(cleanURL.RawQuery, customQuery)
+	return cleanURL.String()
+}
+
+// StatusCode returns the status code of the final response, or zero when the request failed.
+func (r *ResponseHandler) StatusCode() int {
+	if r.httpResponse == nil {
+		return 0
+	}
+	return r.httpResponse.StatusCode
+}
+
+// Redirects returns the URLs followed before reaching the effective URL, in order.
+func (r *ResponseHandler) Redirects() []string {
+	if r.httpResponse == nil {
+		return nil
+	}
+
+	var redirects []string
+	for request := r.httpResponse.Request; request != nil && request.Response != nil; request = request.Response.Request {
+		redirects = append([]string{requestURL(request.Response.Request)}, redirects...)
+	}
+	return redirects
+}
+
+// PermanentRedirectURL returns the URL reached by following only the permanent redirects (301 or 308 status codes)
+// at the start of the redirect chain, or an empty string when the original URL was not permanently moved.
+func (r *ResponseHandler) PermanentRedirectURL() string {
+	if r.httpResponse == nil {
+		return ""
+	}
+
+	// Each request holds the redirect response that caused it, walk the chain from the original request.
+	var requests []*http.Request
+	for request := r.httpResponse.Request; request != nil; {
+		requests = append([]*http.Request{request}, requests...)
+		if request.Response == nil {
+			break
+		}
+		request = request.Response.Request
+	}
+
+	// A response built without its request, or without redirects, has no permanent redirect.
+	if len(requests) < 2 {
+		return ""
+	}
+
+	permanentRedirectURL := ""
+	for _, request := range requests[1:] {
+		statusCode := request.Response.StatusCode
+		if statusCode != http.StatusMovedPermanently && statusCode != http.StatusPermanentRedirect {
+			break
+		}
+		permanentRedirectURL = requestURL(request)
+	}
+	return permanentRedirectURL
 }
 
 func (r *ResponseHandler) ContentType() string {
@@ -82,7 +149,19 @@ func (r *ResponseHandler) CacheControlMaxAge() time.Duration {
 }
 
 func (r *ResponseHandler) ParseRetryDelay() time.Duration {
-	retryAfterHeaderValue := r.httpResponse.Header.Get("Retry-After")
+	return parseRetryAfterHeader(r.httpResponse.Header.Get("Retry-After"))
+}
+
+// HostRetryAt returns the date until which the request was not sent to avoid overloading the host, or the zero time.
+func (r *ResponseHandler) HostRetryAt() time.Time {
+	var throttledErr *hostlimiter.ThrottledError
+	if errors.As(r.clientErr, &throttledErr) {
+		return throttledErr.RetryAt
+	}
+	return time.Time{}
+}
+
+func parseRetryAfterHeader(retryAfterHeaderValue string) time.Duration {
 	if retryAfterHeaderValue != "" {
 		// First, try to parse as an integer (number of seconds)
 		if seconds, err := strconv.Atoi(retryAfterHeaderValue); err == nil {
@@ -176,7 +255,10 @@ func (r *ResponseHandler) ReadBody(maxBodySize int64) ([]byte, *locale.Localized
 
 func (r *ResponseHandler) LocalizedError() *locale.LocalizedErrorWrapper {
 	if r.clientErr != nil {
+		var throttledErr *hostlimiter.ThrottledError
 		switch {
+		case errors.As(r.clientErr, &throttledErr):
+			return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: %w", r.clientErr), "error.host_throttled", throttledErr.Host, throttledErr.RetryAt.Format(time.RFC1123))
 		case isSSLError(r.clientErr):
 			return locale.NewLocalizedErrorWrapper(fmt.Errorf("fetcher: %w", r.clientErr), "error.tls_error", r.clientErr)
 		case isNetworkError(r.clientErr):
@@ -5,6 +5,7 @@ package fetcher // import "miniflux.app/v2/internal/reader/fetcher"
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -174,3 +175,73 @@ func TestCacheControlMaxAgeInMinutes(t *testing.T) {
 		})
 	}
 }
+
+func TestRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+		case "/moved":
+			http.Redirect(w, r, "/feed.xml", http.StatusFound)
+		default:
+			w.Write([]byte("<rss></rss>"))
+		}
+	}))
+	defer server.Close()
+
+	responseHandler := NewResponseHandler(NewRequestBuilder().ExecuteRequest(server.URL + "/old"))
+	defer responseHandler.Close()
+
+	if responseHandler.StatusCode() != http.StatusOK {
+		t.Fatalf(`Unexpected status code: %d`, responseHandler.StatusCode())
+	}
+
+	redirects := responseHandler.Redirects()
+	if len(redirects) != 2 || redirects[0] != server.URL+"/old" || redirects[1] != server.URL+"/moved" {
+		t.Errorf(`Unexpected redirects: %v`, redirects)
+	}
+
+	if responseHandler.EffectiveURL() != server.URL+"/feed.xml" {
+		t.Errorf(`Unexpected effective URL: %q`, responseHandler.EffectiveURL())
+	}
+}
+
+func TestPermanentRedirectURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/new":
+			http.Redirect(w, r, "/newer", http.StatusPermanentRedirect)
+		case "/newer":
+			http.Redirect(w, r, "/feed.xml", http.StatusFound)
+		case "/temporary":
+			http.Redirect(w, r, "/old", http.StatusTemporaryRedirect)
+		default:
+			w.Write([]byte("<rss></rss>"))
+		}
+	}))
+	defer server.Close()
+
+	scenarios := map[string]string{
+		"/old":       server.URL + "/newer",
+		"/new":       server.URL + "/newer",
+		"/temporary": "",
+		"/feed.xml":  "",
+	}
+
+	for path, expected := range scenarios {
+		responseHandler := NewResponseHandler(NewRequestBuilder().ExecuteRequest(server.URL + path))
+		if permanentRedirectURL := responseHandler.PermanentRedirectURL(); permanentRedirectURL != expected {
+			t.Errorf(`Unexpected permanent redirect URL for %s: got %q instead of %q`, path, permanentRedirectURL, expected)
+		}
+		responseHandler.Close()
+	}
+}
+
+func TestPermanentRedirectURLWithoutRequest(t *testing.T) {
+	responseHandler := NewResponseHandler(&http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil)
+	if permanentRedirectURL := responseHandler.PermanentRedirectURL(); permanentRedirectURL != "" {
+		t.Errorf(`Expected no permanent redirect for a response without request, got %q`, permanentRedirectURL)
+	}
+}
@@ -101,6 +101,11 @@ func IsBlockedEntry(blockRules filterRules, allowRules filterRules, feed *model.
 	return false
 }
 
+// MatchesRules returns true if the entry matches one of the filter rules, each rule is on a separate line.
+func MatchesRules(rules string, feed *model.Feed, entry *model.Entry) bool {
+	return matchesEntryFilterRules(ParseRules(rules, ""), feed, entry)
+}
+
 func isBlockedGlobally(entry *model.Entry) bool {
 	if config.Opts == nil {
 		return false
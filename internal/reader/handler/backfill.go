@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/parser"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/storage"
+)
+
+// Maximum number of archive pages fetched by a single backfill.
+const backfillMaxPages = 10
+
+// BackfillFeed loads older entries by following the archive links of the feed.
+// It resumes from the archive page where the previous backfill stopped, and returns the number of entries added.
+func BackfillFeed(store *storage.Storage, userID, feedID int64) (int, *locale.LocalizedErrorWrapper) {
+	feed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return 0, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if feed == nil {
+		return 0, locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
+	}
+
+	if config.Opts.BackfillMaxEntries() == 0 {
+		return 0, nil
+	}
+
+	archiveURL, storeErr := store.FeedBackfillURL(userID, feedID)
+	if storeErr != nil {
+		return 0, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	// The first archive link is not stored, the current feed document gives it.
+	if archiveURL == "" {
+		currentFeed, localizedError := fetchFeedPage(feed, feed.FeedURL, false)
+		if localizedError != nil {
+			return 0, localizedError
+		}
+		archiveURL = currentFeed.ArchiveURL
+	}
+
+	return backfillFeed(store, feed, archiveURL)
+}
+
+// startBackfill loads the older entries of a new subscription in the background.
+func startBackfill(store *storage.Storage, userID, feedID int64, archiveURL string) {
+	if archiveURL == "" || config.Opts.BackfillMaxEntries() == 0 {
+		return
+	}
+
+	go func() {
+		feed, err := store.FeedByID(userID, feedID)
+		if err != nil || feed == nil {
+			slog.Error("Unable to load the feed to backfill", slog.Int64("feed_id", feedID), slog.Any("error", err))
+			return
+		}
+
+		if _, localizedError := backfillFeed(store, feed, archiveURL); localizedError != nil {
+			slog.Warn("Unable to backfill feed",
+				slog.Int64("user_id", userID),
+				slog.Int64("feed_id", feedID),
+				slog.Any("error", localizedError.Error()),
+			)
+		}
+	}()
+}
+
+func backfillFeed(store *storage.Storage, feed *model.Feed, archiveURL string) (int, *locale.LocalizedErrorWrapper) {
+	fetchPage := func(pageURL string) (*model.Feed, *locale.LocalizedErrorWrapper) {
+		slog.Debug("Loading feed archive page",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("archive_url", pageURL),
+		)
+		return fetchFeedPage(feed, pageURL, true)
+	}
+
+	appendEntries := func(entries model.Entries, limit int) (int, *locale.LocalizedErrorWrapper) {
+		var olderEntries model.Entries
+		for _, entry := range entries {
+			entry.UserID = feed.UserID
+			entry.FeedID = feed.ID
+			if !store.EntryExists(entry) && len(olderEntries) < limit {
+				olderEntries = append(olderEntries, entry)
+			}
+		}
+
+		// Filters and the maximum age are applied by the processor, like during a refresh.
+		feed.Entries = olderEntries
+		processor.ProcessFeedEntries(store, feed, feed.UserID, false)
+
+		newEntries, _, _, storeErr := store.AppendFeedEntries(feed.UserID, feed.ID, feed.Entries, false)
+		if storeErr != nil {
+			return 0, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+		return len(newEntries), nil
+	}
+
+	visitedURLs := map[string]bool{feed.FeedURL: true}
+	addedEntries, nextURL, localizedError := backfillArchive(archiveURL, visitedURLs, config.Opts.BackfillMaxEntries(), fetchPage, appendEntries)
+
+	if storeErr := store.UpdateFeedBackfillURL(feed.UserID, feed.ID, nextURL); storeErr != nil && localizedError == nil {
+		localizedError = locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	slog.Debug("Feed backfill completed",
+		slog.Int64("user_id", feed.UserID),
+		slog.Int64("feed_id", feed.ID),
+		slog.Int("added_entries", addedEntries),
+		slog.String("next_archive_url", nextURL),
+	)
+
+	return addedEntries, localizedError
+}
+
+// backfillArchive follows the archive pages from archiveURL until maxEntries entries are added or backfillMaxPages
+// pages are loaded. It returns the number of entries added and the archive page where the next backfill resumes,
+// which is empty once the end of the archive is reached. A page reaching the limit is loaded again by the next backfill
+// since some of its entries may have been skipped.
+func backfillArchive(
+	archiveURL string,
+	visitedURLs map[string]bool,
+	maxEntries int,
+	fetchPage func(pageURL string) (*model.Feed, *locale.LocalizedErrorWrapper),
+	appendEntries func(entries model.Entries, limit int) (int, *locale.LocalizedErrorWrapper),
+) (int, string, *locale.LocalizedErrorWrapper) {
+	addedEntries := 0
+
+	for page := 0; page < backfillMaxPages; page++ {
+		if archiveURL == "" || visitedURLs[archiveURL] {
+			return addedEntries, "", nil
+		}
+		visitedURLs[archiveURL] = true
+
+		archiveFeed, localizedError := fetchPage(archiveURL)
+		if localizedError != nil {
+			return addedEntries, archiveURL, localizedError
+		}
+
+		if archiveFeed == nil || len(archiveFeed.Entries) == 0 || isOlderThanMaxAge(archiveFeed.Entries) {
+			return addedEntries, "", nil
+		}
+
+		pageEntries, localizedError := appendEntries(archiveFeed.Entries, maxEntries-addedEntries)
+		if localizedError != nil {
+			return addedEntries, archiveURL, localizedError
+		}
+
+		addedEntries += pageEntries
+		if addedEntries >= maxEntries {
+			return addedEntries, archiveURL, nil
+		}
+
+		archiveURL = archiveFeed.ArchiveURL
+	}
+
+	if visitedURLs[archiveURL] {
+		return addedEntries, "", nil
+	}
+	return addedEntries, archiveURL, nil
+}
+
+// fetchFeedPage downloads and parses a page of the feed. For the archive pages, a missing page is the end of
+// the archive and nil is returned: the WordPress archives always link to the page following the last one.
+func fetchFeedPage(feed *model.Feed, pageURL string, archivePage bool) (*model.Feed, *locale.LocalizedErrorWrapper) {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUsernameAndPassword(feed.Username, feed.Password)
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithCustomHeaders(feed.CustomHeaders, feed.FeedURL)
+	requestBuilder.WithClientCertificate(feed.ClientCertificate.Certificate, feed.ClientCertificate.PrivateKey, feed.ClientCertificate.CABundle, feed.FeedURL)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(pageURL))
+	defer responseHandler.Close()
+
+	if archivePage && isEndOfArchive(responseHandler.StatusCode()) {
+		return nil, nil
+	}
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		slog.Warn("Unable to fetch feed page", slog.String("page_url", pageURL), slog.Any("error", localizedError.Error()))
+		return nil, localizedError
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		slog.Warn("Unable to fetch feed page", slog.String("page_url", pageURL), slog.Any("error", localizedError.Error()))
+		return nil, localizedError
+	}
+
+	pageFeed, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+	if parseErr != nil {
+		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
+	}
+
+	return pageFeed, nil
+}
+
+func isEndOfArchive(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode == http.StatusGone
+}
+
+// isOlderThanMaxAge returns true when all entries are too old to be kept,
+// the following archive pages are even older.
+func isOlderThanMaxAge(entries model.Entries) bool {
+	if config.Opts.FilterEntryMaxAgeDays() <= 0 {
+		return false
+	}
+
+	maxAge := time.Duration(config.Opts.FilterEntryMaxAgeDays()) * 24 * time.Hour
+	for _, entry := range entries {
+		if time.Since(entry.Date) <= maxAge {
+			return false
+		}
+	}
+
+	return true
+}
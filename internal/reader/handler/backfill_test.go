@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+)
+
+func parseConfig(t *testing.T) {
+	t.Helper()
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+// fakeArchive serves numbered archive pages of entriesPerPage entries, each page linking to the next one.
+type fakeArchive struct {
+	pages          int
+	entriesPerPage int
+	entryDate      time.Time
+	fetchedURLs    []string
+}
+
+func (a *fakeArchive) pageURL(page int) string {
+	return fmt.Sprintf("https://example.org/feed/?paged=%d", page)
+}
+
+func (a *fakeArchive) fetchPage(pageURL string) (*model.Feed, *locale.LocalizedErrorWrapper) {
+	a.fetchedURLs = append(a.fetchedURLs, pageURL)
+
+	var page int
+	if _, err := fmt.Sscanf(pageURL, "https://example.org/feed/?paged=%d", &page); err != nil {
+		return nil, locale.NewLocalizedErrorWrapper(err, "error.http_resource_not_found")
+	}
+
+	// Missing pages are the end of the archive.
+	if page > a.pages {
+		return nil, nil
+	}
+
+	feed := &model.Feed{ArchiveURL: a.pageURL(page + 1)}
+	for i := range a.entriesPerPage {
+		feed.Entries = append(feed.Entries, &model.Entry{
+			URL:  fmt.Sprintf("https://example.org/%d/%d", page, i),
+			Date: a.entryDate,
+		})
+	}
+	return feed, nil
+}
+
+func appendAll(entries model.Entries, limit int) (int, *locale.LocalizedErrorWrapper) {
+	return min(len(entries), limit), nil
+}
+
+func TestBackfillArchiveStopsAtMaxEntries(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	archive := &fakeArchive{pages: 5, entriesPerPage: 10, entryDate: time.Now()}
+	addedEntries, nextURL, err := backfillArchive(archive.pageURL(1), map[string]bool{}, 25, archive.fetchPage, appendAll)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if addedEntries != 25 {
+		t.Errorf(`Unexpected number of entries added: got %d instead of 25`, addedEntries)
+	}
+
+	if len(archive.fetchedURLs) != 3 {
+		t.Errorf(`Unexpected number of pages fetched: got %d instead of 3`, len(archive.fetchedURLs))
+	}
+
+	// The third page was only partially loaded, the next backfill resumes there.
+	if nextURL != archive.pageURL(3) {
+		t.Errorf(`Unexpected next archive URL: got %q instead of %q`, nextURL, archive.pageURL(3))
+	}
+}
+
+func TestBackfillArchiveStopsAtMaxPages(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	archive := &fakeArchive{pages: backfillMaxPages + 5, entriesPerPage: 1, entryDate: time.Now()}
+	addedEntries, nextURL, err := backfillArchive(archive.pageURL(1), map[string]bool{}, 1000, archive.fetchPage, appendAll)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if addedEntries != backfillMaxPages {
+		t.Errorf(`Unexpected number of entries added: got %d instead of %d`, addedEntries, backfillMaxPages)
+	}
+
+	if nextURL != archive.pageURL(backfillMaxPages+1) {
+		t.Errorf(`Unexpected next archive URL: got %q instead of %q`, nextURL, archive.pageURL(backfillMaxPages+1))
+	}
+}
+
+func TestBackfillArchiveEndOfArchive(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	archive := &fakeArchive{pages: 3, entriesPerPage: 2, entryDate: time.Now()}
+	addedEntries, nextURL, err := backfillArchive(archive.pageURL(1), map[string]bool{}, 1000, archive.fetchPage, appendAll)
+	if err != nil {
+		t.Fatalf(`A missing page after the first one must not be an error: %v`, err)
+	}
+
+	if addedEntries != 6 {
+		t.Errorf(`Unexpected number of entries added: got %d instead of 6`, addedEntries)
+	}
+
+	if nextURL != "" {
+		t.Errorf(`The next archive URL should be empty at the end of the archive, got %q`, nextURL)
+	}
+}
+
+func TestBackfillArchiveSkipsVisitedURLs(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	feedURL := "https://example.org/feed/"
+	fetchedURLs := 0
+	fetchPage := func(pageURL string) (*model.Feed, *locale.LocalizedErrorWrapper) {
+		fetchedURLs++
+		// The archive page links back to the feed itself.
+		return &model.Feed{
+			ArchiveURL: feedURL,
+			Entries:    model.Entries{{URL: "https://example.org/1", Date: time.Now()}},
+		}, nil
+	}
+
+	visitedURLs := map[string]bool{feedURL: true}
+	addedEntries, nextURL, err := backfillArchive("https://example.org/feed/?paged=2", visitedURLs, 1000, fetchPage, appendAll)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if fetchedURLs != 1 || addedEntries != 1 {
+		t.Errorf(`Unexpected backfill: %d pages fetched and %d entries added`, fetchedURLs, addedEntries)
+	}
+
+	if nextURL != "" {
+		t.Errorf(`The next archive URL should be empty when the archive loops, got %q`, nextURL)
+	}
+}
+
+func TestBackfillArchiveStopsAtMaxAge(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("FILTER_ENTRY_MAX_AGE_DAYS", "30")
+	parseConfig(t)
+
+	archive := &fakeArchive{pages: 5, entriesPerPage: 3, entryDate: time.Now().AddDate(0, 0, -60)}
+	addedEntries, nextURL, err := backfillArchive(archive.pageURL(1), map[string]bool{}, 1000, archive.fetchPage, appendAll)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if addedEntries != 0 || len(archive.fetchedURLs) != 1 {
+		t.Errorf(`Unexpected backfill: %d pages fetched and %d entries added`, len(archive.fetchedURLs), addedEntries)
+	}
+
+	if nextURL != "" {
+		t.Errorf(`The next archive URL should be empty once the entries are too old, got %q`, nextURL)
+	}
+}
+
+func TestBackfillArchiveResumesAfterError(t *testing.T) {
+	os.Clearenv()
+	parseConfig(t)
+
+	archive := &fakeArchive{pages: 5, entriesPerPage: 2, entryDate: time.Now()}
+	failingURL := archive.pageURL(3)
+	fetchPage := func(pageURL string) (*model.Feed, *locale.LocalizedErrorWrapper) {
+		if pageURL == failingURL {
+			err := errors.New("connection reset")
+			return nil, locale.NewLocalizedErrorWrapper(err, "error.http_client_error", err)
+		}
+		return archive.fetchPage(pageURL)
+	}
+
+	addedEntries, nextURL, err := backfillArchive(archive.pageURL(1), map[string]bool{}, 1000, fetchPage, appendAll)
+	if err == nil {
+		t.Fatal(`The error should be returned`)
+	}
+
+	if addedEntries != 4 {
+		t.Errorf(`Unexpected number of entries added: got %d instead of 4`, addedEntries)
+	}
+
+	if nextURL != failingURL {
+		t.Errorf(`Unexpected next archive URL: got %q instead of %q`, nextURL, failingURL)
+	}
+}
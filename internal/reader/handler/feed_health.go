@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/subscription"
+	"miniflux.app/v2/internal/storage"
+)
+
+// recordFeedSuccess ends the dead period of the feed and moves it to its new URL
+// once it has been permanently redirected enough times in a row.
+// The feed URL is only updated in memory, the caller saves the feed.
+func recordFeedSuccess(store *storage.Storage, feed *model.Feed, permanentRedirectURL string) {
+	health, err := store.FeedHealth(feed.UserID, feed.ID)
+	if err != nil {
+		slog.Error("Unable to load feed health", slog.Int64("feed_id", feed.ID), slog.Any("error", err))
+		return
+	}
+
+	if health == nil {
+		if permanentRedirectURL == "" {
+			return
+		}
+		health = &model.FeedHealth{FeedID: feed.ID}
+	}
+
+	health.RecordSuccess()
+
+	if health.RecordPermanentRedirect(permanentRedirectURL, config.Opts.PermanentRedirectThreshold()) {
+		if store.AnotherFeedURLExists(feed.UserID, feed.ID, permanentRedirectURL) {
+			slog.Warn("Feed moved permanently to the URL of another feed",
+				slog.Int64("user_id", feed.UserID),
+				slog.Int64("feed_id", feed.ID),
+				slog.String("feed_url", feed.FeedURL),
+				slog.String("new_feed_url", permanentRedirectURL),
+			)
+		} else {
+			slog.Info("Feed moved permanently",
+				slog.Int64("user_id", feed.UserID),
+				slog.Int64("feed_id", feed.ID),
+				slog.String("feed_url", feed.FeedURL),
+				slog.String("new_feed_url", permanentRedirectURL),
+			)
+			health.RecordMove(feed.FeedURL, time.Now())
+			feed.FeedURL = permanentRedirectURL
+		}
+	}
+
+	saveFeedHealth(store, health)
+}
+
+// recordFeedFailure tracks how long the feed has not been found.
+// Once dead for long enough, or gone, the user is notified with the other feeds found on the website.
+func recordFeedFailure(store *storage.Storage, feed *model.Feed, statusCode int) {
+	health, err := store.FeedHealth(feed.UserID, feed.ID)
+	if err != nil {
+		slog.Error("Unable to load feed health", slog.Int64("feed_id", feed.ID), slog.Any("error", err))
+		return
+	}
+
+	if health == nil {
+		if statusCode != http.StatusNotFound && statusCode != http.StatusGone {
+			return
+		}
+		health = &model.FeedHealth{FeedID: feed.ID}
+	}
+
+	now := time.Now()
+	health.RecordFailure(statusCode, now)
+
+	notificationDelay := time.Duration(config.Opts.DeadFeedNotificationDays()) * 24 * time.Hour
+	if (statusCode == http.StatusGone && !health.IsDead()) || health.ShouldNotifyDead(now, notificationDelay) {
+		health.AlternativeURLs = findAlternativeFeeds(feed)
+		health.DeadNotifiedAt = &now
+
+		slog.Warn("Feed is dead",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("feed_url", feed.FeedURL),
+			slog.Int("status_code", statusCode),
+			slog.Time("dead_since", *health.DeadSince),
+			slog.Any("alternative_urls", health.AlternativeURLs),
+		)
+	}
+
+	saveFeedHealth(store, health)
+}
+
+// sendFeedHealthEvent notifies the integrations when the feed starts failing or recovers.
+func sendFeedHealthEvent(store *storage.Storage, feed *model.Feed, previousErrorCount int) {
+	switch {
+	case previousErrorCount == 0 && feed.ParsingErrorCount > 0:
+		integration.SendFeedEvent(store, model.EventFeedError, feed)
+	case previousErrorCount > 0 && feed.ParsingErrorCount == 0:
+		integration.SendFeedEvent(store, model.EventFeedRecovered, feed)
+	}
+}
+
+// findAlternativeFeeds returns the feeds currently advertised by the website of the feed.
+func findAlternativeFeeds(feed *model.Feed) []string {
+	if feed.SiteURL == "" {
+		return nil
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUsernameAndPassword(feed.Username, feed.Password)
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithCustomHeaders(feed.CustomHeaders, feed.FeedURL)
+	requestBuilder.WithClientCertificate(feed.ClientCertificate.Certificate, feed.ClientCertificate.PrivateKey, feed.ClientCertificate.CABundle, feed.FeedURL)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	subscriptions, localizedError := subscription.NewSubscriptionFinder(requestBuilder).FindSubscriptions(feed.SiteURL, "", "")
+	if localizedError != nil {
+		slog.Debug("Unable to find alternative feeds",
+			slog.Int64("feed_id", feed.ID),
+			slog.String("site_url", feed.SiteURL),
+			slog.Any("error", localizedError.Error()),
+		)
+		return nil
+	}
+
+	var alternativeURLs []string
+	for _, subscription := range subscriptions {
+		if subscription.URL != feed.FeedURL {
+			alternativeURLs = append(alternativeURLs, subscription.URL)
+		}
+	}
+	return alternativeURLs
+}
+
+func saveFeedHealth(store *storage.Storage, health *model.FeedHealth) {
+	if err := store.SaveFeedHealth(health); err != nil {
+		slog.Error("Unable to save feed health", slog.Int64("feed_id", health.FeedID), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"log/slog"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/storage"
+)
+
+func newFetchLog(feed *model.Feed, requestBuilder *fetcher.RequestBuilder, responseHandler *fetcher.ResponseHandler, startTime time.Time) *model.FeedFetchLog {
+	fetchLog := &model.FeedFetchLog{
+		FeedID:     feed.ID,
+		FetchedAt:  startTime,
+		StatusCode: responseHandler.StatusCode(),
+		DurationMs: time.Since(startTime).Milliseconds(),
+		ProxyURL:   requestBuilder.UsedProxyURL(),
+	}
+
+	// The request failed before getting a response.
+	if fetchLog.StatusCode == 0 {
+		return fetchLog
+	}
+
+	fetchLog.EffectiveURL = responseHandler.EffectiveURL()
+	fetchLog.Redirects = responseHandler.Redirects()
+	fetchLog.ETag = responseHandler.ETag()
+	fetchLog.LastModified = responseHandler.LastModified()
+	return fetchLog
+}
+
+func saveFetchLog(store *storage.Storage, fetchLog *model.FeedFetchLog, localizedError *locale.LocalizedErrorWrapper) {
+	if config.Opts.FetchLogSize() == 0 {
+		return
+	}
+
+	if localizedError != nil {
+		fetchLog.ErrorMessage = localizedError.Error().Error()
+	}
+
+	if err := store.CreateFeedFetchLog(fetchLog, config.Opts.FetchLogSize()); err != nil {
+		slog.Error("Unable to save feed fetch log",
+			slog.Int64("feed_id", fetchLog.FeedID),
+			slog.Any("error", err),
+		)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"miniflux.app/v2/internal/config"
@@ -22,9 +23,10 @@ import (
 )
 
 var (
-	ErrCategoryNotFound = errors.New("fetcher: category not found")
-	ErrFeedNotFound     = errors.New("fetcher: feed not found")
-	ErrDuplicatedFeed   = errors.New("fetcher: duplicated feed")
+	ErrCategoryNotFound          = errors.New("fetcher: category not found")
+	ErrFeedNotFound              = errors.New("fetcher: feed not found")
+	ErrDuplicatedFeed            = errors.New("fetcher: duplicated feed")
+	ErrClientCertificateNotFound = errors.New("fetcher: client certificate not found")
 )
 
 func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, feedCreationRequest *model.FeedCreationRequestFromSubscriptionDiscovery) (*model.Feed, *locale.LocalizedErrorWrapper) {
@@ -38,6 +40,11 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		return nil, locale.NewLocalizedErrorWrapper(ErrCategoryNotFound, "error.category_not_found")
 	}
 
+	clientCertificate, localizedError := feedClientCertificate(store, userID, feedCreationRequest.ClientCertificateID)
+	if localizedError != nil {
+		return nil, localizedError
+	}
+
 	if store.FeedURLExists(userID, feedCreationRequest.FeedURL) {
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
@@ -47,9 +54,13 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
 
+	topicURL := subscription.FeedURL
 	subscription.UserID = userID
 	subscription.UserAgent = feedCreationRequest.UserAgent
 	subscription.Cookie = feedCreationRequest.Cookie
+	subscription.CustomHeaders = feedCreationRequest.CustomHeaders
+	subscription.ClientCertificateID = feedCreationRequest.ClientCertificateID
+	subscription.ClientCertificate = clientCertificate
 	subscription.Username = feedCreationRequest.Username
 	subscription.Password = feedCreationRequest.Password
 	subscription.Crawler = feedCreationRequest.Crawler
@@ -85,10 +96,16 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		slog.String("feed_url", subscription.FeedURL),
 	)
 
+	updateWebSubHub(store, subscription.ID, subscription.HubURL, topicURL)
+	startBackfill(store, userID, subscription.ID, subscription.ArchiveURL)
+	integration.SendFeedEvent(store, model.EventFeedCreated, subscription)
+
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithUsernameAndPassword(feedCreationRequest.Username, feedCreationRequest.Password)
 	requestBuilder.WithUserAgent(feedCreationRequest.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feedCreationRequest.Cookie)
+	requestBuilder.WithCustomHeaders(feedCreationRequest.CustomHeaders, feedCreationRequest.FeedURL)
+	requestBuilder.WithClientCertificate(clientCertificate.Certificate, clientCertificate.PrivateKey, clientCertificate.CABundle, feedCreationRequest.FeedURL)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(feedCreationRequest.ProxyURL)
@@ -114,10 +131,17 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(ErrCategoryNotFound, "error.category_not_found")
 	}
 
+	clientCertificate, localizedError := feedClientCertificate(store, userID, feedCreationRequest.ClientCertificateID)
+	if localizedError != nil {
+		return nil, localizedError
+	}
+
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithUsernameAndPassword(feedCreationRequest.Username, feedCreationRequest.Password)
 	requestBuilder.WithUserAgent(feedCreationRequest.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feedCreationRequest.Cookie)
+	requestBuilder.WithCustomHeaders(feedCreationRequest.CustomHeaders, feedCreationRequest.FeedURL)
+	requestBuilder.WithClientCertificate(clientCertificate.Certificate, clientCertificate.PrivateKey, clientCertificate.CABundle, feedCreationRequest.FeedURL)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(feedCreationRequest.ProxyURL)
@@ -149,9 +173,13 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
 
+	topicURL := subscription.FeedURL
 	subscription.UserID = userID
 	subscription.UserAgent = feedCreationRequest.UserAgent
 	subscription.Cookie = feedCreationRequest.Cookie
+	subscription.CustomHeaders = feedCreationRequest.CustomHeaders
+	subscription.ClientCertificateID = feedCreationRequest.ClientCertificateID
+	subscription.ClientCertificate = clientCertificate
 	subscription.Username = feedCreationRequest.Username
 	subscription.Password = feedCreationRequest.Password
 	subscription.Crawler = feedCreationRequest.Crawler
@@ -187,13 +215,35 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		slog.String("feed_url", subscription.FeedURL),
 	)
 
+	updateWebSubHub(store, subscription.ID, subscription.HubURL, topicURL)
+	startBackfill(store, userID, subscription.ID, subscription.ArchiveURL)
+	integration.SendFeedEvent(store, model.EventFeedCreated, subscription)
+
 	icon.NewIconChecker(store, subscription).UpdateOrCreateFeedIcon()
 
 	return subscription, nil
 }
 
+// feedClientCertificate returns the client certificate selected for a new feed, or an empty certificate if none.
+func feedClientCertificate(store *storage.Storage, userID, certificateID int64) (model.ClientCertificate, *locale.LocalizedErrorWrapper) {
+	if certificateID == 0 {
+		return model.ClientCertificate{}, nil
+	}
+
+	certificate, err := store.ClientCertificate(userID, certificateID)
+	if err != nil {
+		return model.ClientCertificate{}, locale.NewLocalizedErrorWrapper(err, "error.database_error", err)
+	}
+
+	if certificate == nil {
+		return model.ClientCertificate{}, locale.NewLocalizedErrorWrapper(ErrClientCertificateNotFound, "error.client_certificate_not_found")
+	}
+
+	return *certificate, nil
+}
+
 // RefreshFeed refreshes a feed.
-func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool) *locale.LocalizedErrorWrapper {
+func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool) (refreshErr *locale.LocalizedErrorWrapper) {
 	slog.Debug("Begin feed refresh process",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", feedID),
@@ -209,9 +259,14 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
 	}
 
+	// The entries of the saved feed are imported from the integrations.
+	if originalFeed.IsSavedFeed() {
+		return nil
+	}
+
 	weeklyEntryCount := 0
 	var refreshDelay time.Duration
-	if config.Opts.PollingScheduler() == model.SchedulerEntryFrequency {
+	if config.Opts.PollingScheduler() == model.SchedulerEntryFrequency || config.Opts.PollingScheduler() == model.SchedulerPublishingPattern {
 		var weeklyCountErr error
 		weeklyEntryCount, weeklyCountErr = store.WeeklyFeedEntryCount(userID, feedID)
 		if weeklyCountErr != nil {
@@ -219,6 +274,14 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		}
 	}
 
+	if config.Opts.PollingScheduler() == model.SchedulerPublishingPattern {
+		var profileErr error
+		originalFeed.PublishingProfile, profileErr = feedPublishingProfile(store, userID, feedID)
+		if profileErr != nil {
+			return locale.NewLocalizedErrorWrapper(profileErr, "error.database_error", profileErr)
+		}
+	}
+
 	originalFeed.CheckedNow()
 	originalFeed.ScheduleNextCheck(weeklyEntryCount, refreshDelay)
 
@@ -226,6 +289,8 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 	requestBuilder.WithUsernameAndPassword(originalFeed.Username, originalFeed.Password)
 	requestBuilder.WithUserAgent(originalFeed.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(originalFeed.Cookie)
+	requestBuilder.WithCustomHeaders(originalFeed.CustomHeaders, originalFeed.FeedURL)
+	requestBuilder.WithClientCertificate(originalFeed.ClientCertificate.Certificate, originalFeed.ClientCertificate.PrivateKey, originalFeed.ClientCertificate.CABundle, originalFeed.FeedURL)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(originalFeed.ProxyURL)
@@ -240,9 +305,20 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		requestBuilder.WithLastModified(originalFeed.LastModifiedHeader)
 	}
 
+	fetchStartTime := time.Now()
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(originalFeed.FeedURL))
 	defer responseHandler.Close()
 
+	fetchLog := newFetchLog(originalFeed, requestBuilder, responseHandler, fetchStartTime)
+	defer func() {
+		saveFetchLog(store, fetchLog, refreshErr)
+	}()
+
+	previousErrorCount := originalFeed.ParsingErrorCount
+	defer func() {
+		sendFeedHealthEvent(store, originalFeed, previousErrorCount)
+	}()
+
 	if responseHandler.IsRateLimited() {
 		retryDelay := responseHandler.ParseRetryDelay()
 		calculatedNextCheckInterval := originalFeed.ScheduleNextCheck(weeklyEntryCount, retryDelay)
@@ -256,6 +332,21 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		)
 	}
 
+	// The host asked to slow down: wait for it without counting an error against the feed.
+	if hostRetryAt := responseHandler.HostRetryAt(); !hostRetryAt.IsZero() {
+		slog.Debug("Feed host is throttled",
+			slog.Int64("user_id", userID),
+			slog.Int64("feed_id", feedID),
+			slog.String("feed_url", originalFeed.FeedURL),
+			slog.Time("new_next_check_at", hostRetryAt),
+		)
+		originalFeed.NextCheckAt = hostRetryAt
+		if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
+			return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+		}
+		return responseHandler.LocalizedError()
+	}
+
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
 		slog.Warn("Unable to fetch feed",
 			slog.Int64("user_id", userID),
@@ -268,7 +359,20 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 		}
 		originalFeed.WithTranslatedErrorMessage(localizedError.Translate(user.Language))
-		store.UpdateFeedError(originalFeed)
+		recordFeedFailure(store, originalFeed, responseHandler.StatusCode())
+
+		// The resource is intentionally removed and will not come back.
+		if responseHandler.StatusCode() == http.StatusGone {
+			slog.Info("Disabling gone feed",
+				slog.Int64("user_id", userID),
+				slog.Int64("feed_id", feedID),
+				slog.String("feed_url", originalFeed.FeedURL),
+			)
+			originalFeed.Disabled = true
+			store.UpdateFeed(originalFeed)
+		} else {
+			store.UpdateFeedError(originalFeed)
+		}
 		return localizedError
 	}
 
@@ -297,6 +401,9 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			return localizedError
 		}
 
+		fetchLog.ContentLength = int64(len(responseBody))
+		fetchLog.DurationMs = time.Since(fetchStartTime).Milliseconds()
+
 		updatedFeed, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
 		if parseErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
@@ -338,10 +445,11 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 
 		originalFeed.Entries = updatedFeed.Entries
 		processor.ProcessFeedEntries(store, originalFeed, userID, forceRefresh)
+		updateWebSubHub(store, originalFeed.ID, updatedFeed.HubURL, updatedFeed.FeedURL)
 
 		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries). Unless it is forced to refresh
 		updateExistingEntries := forceRefresh || !originalFeed.Crawler
-		newEntries, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries)
+		newEntries, updatedEntries, retaggedEntryIDs, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries)
 		if storeErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 			user, storeErr := store.UserByID(userID)
@@ -353,16 +461,20 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			return localizedError
 		}
 
-		userIntegrations, intErr := store.Integration(userID)
+		fetchLog.NewEntries = len(newEntries)
+		fetchLog.UpdatedEntries = updatedEntries
+
+		userIntegrations, intErr := store.IntegrationSettings(userID)
 		if intErr != nil {
 			slog.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
 				slog.Int64("user_id", userID),
 				slog.Int64("feed_id", feedID),
 				slog.Any("error", intErr),
 			)
-		} else if userIntegrations != nil && len(newEntries) > 0 {
-			go integration.PushEntries(originalFeed, newEntries, userIntegrations)
+		} else if len(newEntries) > 0 {
+			integration.PushEntries(store, originalFeed, newEntries, userIntegrations)
 		}
+		integration.SendEntriesTagsChangedEvent(store, userID, retaggedEntryIDs)
 
 		originalFeed.EtagHeader = responseHandler.ETag()
 		originalFeed.LastModifiedHeader = responseHandler.LastModified()
@@ -379,6 +491,7 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			slog.Int64("user_id", userID),
 			slog.Int64("feed_id", feedID),
 		)
+		fetchLog.NotModified = true
 
 		// Last-Modified may be updated even if ETag is not. In this case, per
 		// RFC9111 sections 3.2 and 4.3.4, the stored response must be updated.
@@ -388,6 +501,14 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 	}
 
 	originalFeed.ResetErrorCounter()
+	recordFeedSuccess(store, originalFeed, responseHandler.PermanentRedirectURL())
+
+	// The hub pushes new entries, polling is only a fallback.
+	if config.Opts.HasWebSub() && store.HasActiveWebSubSubscription(originalFeed.ID) {
+		if webSubNextCheckAt := time.Now().Add(config.Opts.WebSubPollingFrequency()); webSubNextCheckAt.After(originalFeed.NextCheckAt) {
+			originalFeed.NextCheckAt = webSubNextCheckAt
+		}
+	}
 
 	if storeErr := store.UpdateFeed(originalFeed); storeErr != nil {
 		localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
@@ -402,3 +523,70 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 
 	return nil
 }
+
+// RefreshFeedFromContent stores the entries of a feed document pushed by a WebSub hub.
+func RefreshFeedFromContent(store *storage.Storage, userID, feedID int64, content []byte) *locale.LocalizedErrorWrapper {
+	slog.Debug("Begin pushed feed refresh process",
+		slog.Int64("user_id", userID),
+		slog.Int64("feed_id", feedID),
+	)
+
+	originalFeed, storeErr := store.FeedByID(userID, feedID)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if originalFeed == nil {
+		return locale.NewLocalizedErrorWrapper(ErrFeedNotFound, "error.feed_not_found")
+	}
+
+	pushedFeed, parseErr := parser.ParseFeed(originalFeed.FeedURL, bytes.NewReader(content))
+	if parseErr != nil {
+		return locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
+	}
+
+	originalFeed.Entries = pushedFeed.Entries
+	processor.ProcessFeedEntries(store, originalFeed, userID, false)
+
+	// Same rule as polling: the crawler only fetches entries that do not exist yet.
+	// Hubs usually push only the new entries, so the removed ones missing from the payload are kept.
+	newEntries, updatedEntries, retaggedEntryIDs, storeErr := store.AppendFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, !originalFeed.Crawler)
+	if storeErr != nil {
+		return locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	userIntegrations, intErr := store.IntegrationSettings(userID)
+	if intErr != nil {
+		slog.Error("Fetching integrations failed; no integrations will run for this pushed update",
+			slog.Int64("user_id", userID),
+			slog.Int64("feed_id", feedID),
+			slog.Any("error", intErr),
+		)
+	} else if len(newEntries) > 0 {
+		integration.PushEntries(store, originalFeed, newEntries, userIntegrations)
+	}
+	integration.SendEntriesTagsChangedEvent(store, userID, retaggedEntryIDs)
+
+	slog.Debug("Pushed feed entries stored",
+		slog.Int64("user_id", userID),
+		slog.Int64("feed_id", feedID),
+		slog.Int("new_entries", len(newEntries)),
+		slog.Int("updated_entries", updatedEntries),
+	)
+
+	return nil
+}
+
+func updateWebSubHub(store *storage.Storage, feedID int64, hubURL, topicURL string) {
+	if !config.Opts.HasWebSub() {
+		return
+	}
+
+	if err := store.UpdateFeedWebSubHub(feedID, hubURL, topicURL); err != nil {
+		slog.Error("Unable to update the WebSub hub of the feed",
+			slog.Int64("feed_id", feedID),
+			slog.String("hub_url", hubURL),
+			slog.Any("error", err),
+		)
+	}
+}
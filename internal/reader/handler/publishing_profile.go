@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// The publishing profile covers several weeks of entries, a few hours old profile is still accurate.
+const publishingProfileTTL = 6 * time.Hour
+
+var publishingProfiles = newPublishingProfileCache(publishingProfileTTL)
+
+type publishingProfileCache struct {
+	mutex    sync.Mutex
+	ttl      time.Duration
+	profiles map[int64]cachedPublishingProfile
+}
+
+type cachedPublishingProfile struct {
+	profile   *model.PublishingProfile
+	expiresAt time.Time
+}
+
+func newPublishingProfileCache(ttl time.Duration) *publishingProfileCache {
+	return &publishingProfileCache{ttl: ttl, profiles: make(map[int64]cachedPublishingProfile)}
+}
+
+// get returns the cached profile of the feed, or computes it when missing or expired.
+func (c *publishingProfileCache) get(feedID int64, now time.Time, compute func() (*model.PublishingProfile, error)) (*model.PublishingProfile, error) {
+	c.mutex.Lock()
+	cached, found := c.profiles[feedID]
+	c.mutex.Unlock()
+
+	if found && now.Before(cached.expiresAt) {
+		return cached.profile, nil
+	}
+
+	// The workers refresh different feeds, the profile is computed without holding the lock.
+	profile, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Expired profiles are removed while computing a new one, the cache does not keep the deleted feeds.
+	for cachedFeedID, cached := range c.profiles {
+		if !now.Before(cached.expiresAt) {
+			delete(c.profiles, cachedFeedID)
+		}
+	}
+
+	c.profiles[feedID] = cachedPublishingProfile{profile: profile, expiresAt: now.Add(c.ttl)}
+	return profile, nil
+}
+
+// feedPublishingProfile returns the publishing profile of the feed in the timezone of the user.
+func feedPublishingProfile(store *storage.Storage, userID, feedID int64) (*model.PublishingProfile, error) {
+	return publishingProfiles.get(feedID, time.Now(), func() (*model.PublishingProfile, error) {
+		user, err := store.UserByID(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		timezone := "UTC"
+		if user != nil {
+			timezone = user.Timezone
+		}
+		return store.FeedPublishingProfile(userID, feedID, timezone)
+	})
+}
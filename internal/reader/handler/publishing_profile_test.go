@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package handler // import "miniflux.app/v2/internal/reader/handler"
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestPublishingProfileCache(t *testing.T) {
+	cache := newPublishingProfileCache(time.Hour)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	computations := 0
+	compute := func() (*model.PublishingProfile, error) {
+		computations++
+		return model.NewPublishingProfile(1, time.UTC), nil
+	}
+
+	first, err := cache.get(1, now, compute)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	second, _ := cache.get(1, now.Add(30*time.Minute), compute)
+	if second != first || computations != 1 {
+		t.Fatalf(`The profile should be cached, computed %d times`, computations)
+	}
+
+	cache.get(2, now.Add(30*time.Minute), compute)
+	if computations != 2 {
+		t.Fatalf(`Each feed has its own profile, computed %d times`, computations)
+	}
+
+	third, _ := cache.get(1, now.Add(time.Hour), compute)
+	if third == first || computations != 3 {
+		t.Fatalf(`The expired profile should be computed again, computed %d times`, computations)
+	}
+}
+
+func TestPublishingProfileCacheDoesNotKeepErrors(t *testing.T) {
+	cache := newPublishingProfileCache(time.Hour)
+	now := time.Now()
+
+	if _, err := cache.get(1, now, func() (*model.PublishingProfile, error) {
+		return nil, errors.New("database error")
+	}); err == nil {
+		t.Fatal(`The error should be returned`)
+	}
+
+	profile, err := cache.get(1, now, func() (*model.PublishingProfile, error) {
+		return model.NewPublishingProfile(1, time.UTC), nil
+	})
+	if err != nil || profile == nil {
+		t.Fatalf(`The profile should be computed again after an error: %v`, err)
+	}
+}
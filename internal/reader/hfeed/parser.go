@@ -0,0 +1,292 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package hfeed // import "miniflux.app/v2/internal/reader/hfeed"
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/encoding"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Microformats specs:
+// https://microformats.org/wiki/h-feed
+// https://microformats.org/wiki/h-entry
+
+// Parse returns a normalized feed struct from a HTML page with h-entry microformats.
+func Parse(baseURL string, data io.Reader) (*model.Feed, error) {
+	htmlDocumentReader, err := encoding.NewCharsetReader(data, "text/html")
+	if err != nil {
+		return nil, fmt.Errorf("hfeed: unable to read document: %w", err)
+	}
+
+	document, err := goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return nil, fmt.Errorf("hfeed: unable to parse document: %w", err)
+	}
+
+	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
+		if absoluteURL, err := urllib.AbsoluteURL(baseURL, strings.TrimSpace(hrefValue)); err == nil {
+			baseURL = absoluteURL
+		}
+	}
+
+	// The h-feed wrapper is optional, a page with top-level h-entry elements is an implied feed.
+	root := document.Selection
+	if hfeed := document.Find(".h-feed").First(); hfeed.Length() > 0 {
+		root = hfeed
+	}
+
+	entries := topLevelEntries(root)
+	if entries.Length() == 0 {
+		return nil, fmt.Errorf("hfeed: no h-entry found in the document")
+	}
+
+	feed := &model.Feed{
+		FeedURL: baseURL,
+		SiteURL: baseURL,
+	}
+
+	var feedAuthor string
+	if root != document.Selection {
+		feed.Title = propertyText(root, "p-name")
+		feed.Description = propertyText(root, "p-summary")
+		feedAuthor = propertyAuthor(root)
+	}
+
+	if feed.Title == "" {
+		feed.Title = strings.TrimSpace(document.FindMatcher(goquery.Single("head title")).Text())
+	}
+
+	if feed.Title == "" {
+		feed.Title = feed.SiteURL
+	}
+
+	if iconURL, exists := document.FindMatcher(goquery.Single(`link[rel~="icon"]`)).Attr("href"); exists {
+		if absoluteIconURL, err := urllib.AbsoluteURL(baseURL, strings.TrimSpace(iconURL)); err == nil {
+			feed.IconURL = absoluteIconURL
+		}
+	}
+
+	entries.Each(func(i int, s *goquery.Selection) {
+		feed.Entries = append(feed.Entries, buildEntry(baseURL, feedAuthor, s))
+	})
+
+	return feed, nil
+}
+
+// IsHFeed returns true if the document contains at least one h-entry.
+func IsHFeed(data io.Reader) bool {
+	document, err := goquery.NewDocumentFromReader(data)
+	if err != nil {
+		return false
+	}
+	return document.Find(".h-entry").Length() > 0
+}
+
+func buildEntry(baseURL, feedAuthor string, s *goquery.Selection) *model.Entry {
+	entry := model.NewEntry()
+
+	for _, value := range propertyURLs(s, "u-url") {
+		if absoluteURL, err := urllib.AbsoluteURL(baseURL, value); err == nil {
+			entry.URL = absoluteURL
+			break
+		}
+	}
+	if entry.URL == "" {
+		entry.URL = baseURL
+	}
+
+	var contentHTML string
+	if content := property(s, "e-content").First(); content.Length() > 0 {
+		contentHTML, _ = content.Html()
+		contentHTML = strings.TrimSpace(contentHTML)
+	}
+
+	summary := propertyText(s, "p-summary")
+
+	for _, value := range []string{contentHTML, summary} {
+		if value != "" {
+			entry.Content = value
+			break
+		}
+	}
+
+	// Notes usually have no name, or a name implied from the whole content.
+	entry.Title = propertyText(s, "p-name")
+	if entry.Title == "" || entry.Title == strings.Join(strings.Fields(sanitizer.StripTags(contentHTML)), " ") {
+		for _, value := range []string{summary, contentHTML} {
+			if value != "" {
+				entry.Title = sanitizer.TruncateHTML(value, 100)
+				break
+			}
+		}
+	}
+	if entry.Title == "" {
+		entry.Title = entry.URL
+	}
+
+	for _, value := range []string{propertyDate(s, "dt-published"), propertyDate(s, "dt-updated")} {
+		if value == "" {
+			continue
+		}
+		if parsedDate, err := date.Parse(value); err != nil {
+			slog.Debug("Unable to parse date from h-entry",
+				slog.String("date", value),
+				slog.String("url", entry.URL),
+				slog.Any("error", err),
+			)
+		} else {
+			entry.Date = parsedDate
+			break
+		}
+	}
+	if entry.Date.IsZero() {
+		entry.Date = time.Now()
+	}
+
+	entry.Author = propertyAuthor(s)
+	if entry.Author == "" {
+		entry.Author = feedAuthor
+	}
+
+	property(s, "p-category").Each(func(i int, category *goquery.Selection) {
+		if tag := strings.TrimSpace(category.Text()); tag != "" {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	})
+	slices.Sort(entry.Tags)
+	entry.Tags = slices.Compact(entry.Tags)
+
+	for _, value := range propertyURLs(s, "u-photo") {
+		if absoluteURL, err := urllib.AbsoluteURL(baseURL, value); err == nil {
+			entry.Enclosures = append(entry.Enclosures, &model.Enclosure{URL: absoluteURL, MimeType: "image/*"})
+		}
+	}
+
+	for _, value := range []string{propertyText(s, "u-uid"), entry.URL, contentHTML} {
+		if value != "" && value != baseURL {
+			entry.Hash = crypto.SHA256(value)
+			break
+		}
+	}
+	if entry.Hash == "" {
+		entry.Hash = crypto.SHA256(entry.Title + entry.Date.String())
+	}
+
+	return entry
+}
+
+var microformatRoots = []string{"h-entry", "h-feed", "h-card", "h-cite", "h-event", "h-review", "h-product", "h-adr", "h-geo"}
+
+// topLevelEntries returns the h-entry elements that are not nested in another h-entry (replies, quotes).
+func topLevelEntries(root *goquery.Selection) *goquery.Selection {
+	return root.Find(".h-entry").FilterFunction(func(i int, s *goquery.Selection) bool {
+		return s.ParentsFiltered(".h-entry").Length() == 0
+	})
+}
+
+func isMicroformatRoot(s *goquery.Selection) bool {
+	for _, class := range strings.Fields(s.AttrOr("class", "")) {
+		if slices.Contains(microformatRoots, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// rootDepth returns the number of microformat items containing the element.
+// Utility classes like "h-full" are not microformats, hence the explicit list of roots.
+func rootDepth(s *goquery.Selection) int {
+	depth := 0
+	s.Parents().Each(func(i int, parent *goquery.Selection) {
+		if isMicroformatRoot(parent) {
+			depth++
+		}
+	})
+	return depth
+}
+
+// property returns the elements of the given property that belong to the item, not to nested items.
+func property(item *goquery.Selection, name string) *goquery.Selection {
+	depth := rootDepth(item) + 1
+	return item.Find("." + name).FilterFunction(func(i int, s *goquery.Selection) bool {
+		return rootDepth(s) == depth
+	})
+}
+
+func propertyText(item *goquery.Selection, name string) string {
+	s := property(item, name).First()
+	if s.Length() == 0 {
+		return ""
+	}
+
+	for _, attribute := range []string{"title", "alt", "value"} {
+		if s.Is("abbr,img,area,data,input") {
+			if value, exists := s.Attr(attribute); exists {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+
+	return strings.Join(strings.Fields(s.Text()), " ")
+}
+
+func propertyURLs(item *goquery.Selection, name string) []string {
+	var urls []string
+	property(item, name).Each(func(i int, s *goquery.Selection) {
+		for _, attribute := range []string{"href", "src", "value"} {
+			if value, exists := s.Attr(attribute); exists && strings.TrimSpace(value) != "" {
+				urls = append(urls, strings.TrimSpace(value))
+				return
+			}
+		}
+		if value := strings.TrimSpace(s.Text()); value != "" {
+			urls = append(urls, value)
+		}
+	})
+	return urls
+}
+
+func propertyDate(item *goquery.Selection, name string) string {
+	s := property(item, name).First()
+	if s.Length() == 0 {
+		return ""
+	}
+
+	for _, attribute := range []string{"datetime", "value", "title"} {
+		if value, exists := s.Attr(attribute); exists && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return strings.TrimSpace(s.Text())
+}
+
+// propertyAuthor returns the name of the author, either a plain p-author or a nested h-card.
+func propertyAuthor(item *goquery.Selection) string {
+	author := property(item, "p-author").First()
+	if author.Length() == 0 {
+		return ""
+	}
+
+	if author.HasClass("h-card") {
+		if name := propertyText(author, "p-name"); name != "" {
+			return name
+		}
+	}
+
+	return strings.Join(strings.Fields(author.Text()), " ")
+}
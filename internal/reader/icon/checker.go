@@ -29,6 +29,8 @@ func (c *iconChecker) fetchAndStoreIcon() {
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithUserAgent(c.feed.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(c.feed.Cookie)
+	requestBuilder.WithCustomHeaders(c.feed.CustomHeaders, c.feed.FeedURL)
+	requestBuilder.WithClientCertificate(c.feed.ClientCertificate.Certificate, c.feed.ClientCertificate.PrivateKey, c.feed.ClientCertificate.CABundle, c.feed.FeedURL)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(c.feed.ProxyURL)
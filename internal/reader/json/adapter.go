@@ -65,6 +65,24 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Populate the WebSub hub URL if present.
+	for _, hub := range j.jsonFeed.Hubs {
+		hubURL := strings.TrimSpace(hub.URL)
+		if hubURL != "" && strings.EqualFold(hub.Type, "WebSub") {
+			if absoluteHubURL, err := urllib.AbsoluteURL(baseURL, hubURL); err == nil {
+				feed.HubURL = absoluteHubURL
+				break
+			}
+		}
+	}
+
+	// Populate the URL of the page with older items.
+	if nextURL := strings.TrimSpace(j.jsonFeed.NextURL); nextURL != "" {
+		if absoluteNextURL, err := urllib.AbsoluteURL(baseURL, nextURL); err == nil {
+			feed.ArchiveURL = absoluteNextURL
+		}
+	}
+
 	for _, item := range j.jsonFeed.Items {
 		entry := model.NewEntry()
 		entry.Title = strings.TrimSpace(item.Title)
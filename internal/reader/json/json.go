@@ -47,6 +47,9 @@ type JSONFeed struct {
 	// Items is an array, each representing an individual item in the feed.
 	Items []JSONItem `json:"items"`
 
+	// NextURL is the URL of a feed that provides the next n items, where n is determined by the publisher.
+	NextURL string `json:"next_url"`
+
 	// Hubs  describes endpoints that can be used to subscribe to real-time notifications from the publisher of this feed.
 	Hubs []JSONHub `json:"hubs"`
 }
@@ -239,6 +239,49 @@ func TestParseFeedWithRelativeFeedURL(t *testing.T) {
 	}
 }
 
+func TestParseFeedWithWebSubHub(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"feed_url": "https://example.org/feed.json",
+		"hubs": [
+			{"type": "rssCloud", "url": "https://cloud.example.org/"},
+			{"type": "WebSub", "url": "https://hub.example.org/"}
+		],
+		"items": []
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
+func TestParseFeedWithNextURL(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"home_page_url": "https://example.org/",
+		"feed_url": "https://example.org/feed.json",
+		"next_url": "/feed.json?page=2",
+		"items": []
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.ArchiveURL != "https://example.org/feed.json?page=2" {
+		t.Errorf("Incorrect archive URL, got: %s", feed.ArchiveURL)
+	}
+}
+
 func TestParseFeedSiteURLWithTrailingSpace(t *testing.T) {
 	data := `{
 		"version": "https://jsonfeed.org/version/1",
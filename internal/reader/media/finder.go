@@ -67,6 +67,8 @@ func FetchMedia(media *model.Media, r *http.Request) (*http.Response, error) {
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
 	requestBuilder.WithUserAgent("", config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCustomHeaders(media.CustomHeaders, media.FeedURL)
+	requestBuilder.WithClientCertificate(media.ClientCertificate.Certificate, media.ClientCertificate.PrivateKey, media.ClientCertificate.CABundle, media.FeedURL)
 	
 	// req.Header.Add("Connection", "close")
 	
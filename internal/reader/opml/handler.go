@@ -26,11 +26,12 @@ func (h *Handler) Export(userID int64) (string, error) {
 	subscriptions := make([]subcription, 0, len(feeds))
 	for _, feed := range feeds {
 		subscriptions = append(subscriptions, subcription{
-			Title:        feed.Title,
-			FeedURL:      feed.FeedURL,
-			SiteURL:      feed.SiteURL,
-			Description:  feed.Description,
-			CategoryName: feed.Category.Title,
+			Title:         feed.Title,
+			FeedURL:       feed.FeedURL,
+			SiteURL:       feed.SiteURL,
+			Description:   feed.Description,
+			CategoryName:  feed.Category.Title,
+			CustomHeaders: feed.CustomHeaders,
 		})
 	}
 
@@ -69,12 +70,13 @@ func (h *Handler) Import(userID int64, data io.Reader) error {
 			}
 
 			feed := &model.Feed{
-				UserID:      userID,
-				Title:       subscription.Title,
-				FeedURL:     subscription.FeedURL,
-				SiteURL:     subscription.SiteURL,
-				Description: subscription.Description,
-				Category:    category,
+				UserID:        userID,
+				Title:         subscription.Title,
+				FeedURL:       subscription.FeedURL,
+				SiteURL:       subscription.SiteURL,
+				Description:   subscription.Description,
+				Category:      category,
+				CustomHeaders: subscription.CustomHeaders,
 			}
 
 			if err := h.store.CreateFeed(feed); err != nil {
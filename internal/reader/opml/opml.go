@@ -8,12 +8,16 @@ import (
 	"strings"
 )
 
+// minifluxNamespace is used for outline attributes that only Miniflux understands.
+const minifluxNamespace = "https://miniflux.app/opml"
+
 // Specs: http://opml.org/spec2.opml
 type opmlDocument struct {
-	XMLName  xml.Name              `xml:"opml"`
-	Version  string                `xml:"version,attr"`
-	Header   opmlHeader            `xml:"head"`
-	Outlines opmlOutlineCollection `xml:"body>outline"`
+	XMLName           xml.Name              `xml:"opml"`
+	Version           string                `xml:"version,attr"`
+	MinifluxNamespace string                `xml:"xmlns:miniflux,attr,omitempty"`
+	Header            opmlHeader            `xml:"head"`
+	Outlines          opmlOutlineCollection `xml:"body>outline"`
 }
 
 type opmlHeader struct {
@@ -29,6 +33,9 @@ type opmlOutline struct {
 	SiteURL     string                `xml:"htmlUrl,attr,omitempty"`
 	Description string                `xml:"description,attr,omitempty"`
 	Outlines    opmlOutlineCollection `xml:"outline,omitempty"`
+
+	// CustomHeaders is read from the Miniflux namespace, whatever prefix the document uses.
+	CustomHeaders string `xml:"https://miniflux.app/opml customHeaders,attr,omitempty"`
 }
 
 func (o opmlOutline) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -39,12 +46,19 @@ func (o opmlOutline) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		outlineType = "rss"
 	}
 
+	// The encoder would generate its own prefix for the namespaced field,
+	// so the attribute is written with the prefix declared on the document.
+	customHeaders := o.CustomHeaders
+	o.CustomHeaders = ""
+
 	return e.EncodeElement(struct {
 		opmlOutlineXml
-		Type string `xml:"type,attr,omitempty"`
+		Type          string `xml:"type,attr,omitempty"`
+		CustomHeaders string `xml:"miniflux:customHeaders,attr,omitempty"`
 	}{
 		opmlOutlineXml: opmlOutlineXml(o),
 		Type:           outlineType,
+		CustomHeaders:  customHeaders,
 	}, start)
 }
 
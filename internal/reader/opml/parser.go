@@ -33,11 +33,12 @@ func getSubscriptionsFromOutlines(outlines opmlOutlineCollection, category strin
 	for _, outline := range outlines {
 		if outline.IsSubscription() {
 			subscriptions = append(subscriptions, subcription{
-				Title:        outline.GetTitle(),
-				FeedURL:      outline.FeedURL,
-				SiteURL:      outline.GetSiteURL(),
-				Description:  outline.Description,
-				CategoryName: category,
+				Title:         outline.GetTitle(),
+				FeedURL:       outline.FeedURL,
+				SiteURL:       outline.GetSiteURL(),
+				Description:   outline.Description,
+				CategoryName:  category,
+				CustomHeaders: outline.CustomHeaders,
 			})
 		} else if outline.Outlines.HasChildren() {
 			subscriptions = append(subscriptions, getSubscriptionsFromOutlines(outline.Outlines, outline.GetTitle())...)
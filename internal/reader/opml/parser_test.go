@@ -12,7 +12,7 @@ import (
 func (s subcription) equals(subscription subcription) bool {
 	return s.Title == subscription.Title && s.SiteURL == subscription.SiteURL &&
 		s.FeedURL == subscription.FeedURL && s.CategoryName == subscription.CategoryName &&
-		s.Description == subscription.Description
+		s.Description == subscription.Description && s.CustomHeaders == subscription.CustomHeaders
 }
 
 func TestParseOpmlWithoutCategories(t *testing.T) {
@@ -282,3 +282,33 @@ func TestParseInvalidXML(t *testing.T) {
 		t.Error("Parse should generate an error")
 	}
 }
+
+func TestParseOpmlWithMinifluxNamespace(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<opml version="2.0" xmlns:mf="https://miniflux.app/opml">
+		<body>
+			<outline text="Feed 1" xmlUrl="http://example.org/feed1/" mf:customHeaders="Authorization: Bearer token"></outline>
+			<outline text="Feed 2" xmlUrl="http://example.org/feed2/" customHeaders="X-Ignored: true"></outline>
+		</body>
+	</opml>
+	`
+
+	var expected []subcription
+	expected = append(expected, subcription{Title: "Feed 1", FeedURL: "http://example.org/feed1/", SiteURL: "http://example.org/feed1/", CustomHeaders: "Authorization: Bearer token"})
+	expected = append(expected, subcription{Title: "Feed 2", FeedURL: "http://example.org/feed2/", SiteURL: "http://example.org/feed2/"})
+
+	subscriptions, err := parse(bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(subscriptions) != 2 {
+		t.Fatalf("Wrong number of subscriptions: %d instead of %d", len(subscriptions), 2)
+	}
+
+	for i := range len(subscriptions) {
+		if !subscriptions[i].equals(expected[i]) {
+			t.Errorf(`Subscription is different: "%v" vs "%v"`, subscriptions[i], expected[i])
+		}
+	}
+}
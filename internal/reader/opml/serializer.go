@@ -48,12 +48,17 @@ func convertSubscriptionsToOPML(subscriptions []subcription) *opmlDocument {
 		category := opmlOutline{Text: categoryName, Outlines: make(opmlOutlineCollection, 0, len(groupedSubs[categoryName]))}
 		for _, subscription := range groupedSubs[categoryName] {
 			category.Outlines = append(category.Outlines, opmlOutline{
-				Title:       subscription.Title,
-				Text:        subscription.Title,
-				FeedURL:     subscription.FeedURL,
-				SiteURL:     subscription.SiteURL,
-				Description: subscription.Description,
+				Title:         subscription.Title,
+				Text:          subscription.Title,
+				FeedURL:       subscription.FeedURL,
+				SiteURL:       subscription.SiteURL,
+				Description:   subscription.Description,
+				CustomHeaders: subscription.CustomHeaders,
 			})
+
+			if subscription.CustomHeaders != "" {
+				opmlDocument.MinifluxNamespace = minifluxNamespace
+			}
 		}
 
 		opmlDocument.Outlines = append(opmlDocument.Outlines, category)
@@ -5,6 +5,7 @@ package opml // import "miniflux.app/v2/internal/reader/opml"
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 )
 
@@ -61,3 +62,34 @@ func TestNormalizedCategoriesOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestSerializeWithCustomHeaders(t *testing.T) {
+	var subscriptions []subcription
+	subscriptions = append(subscriptions, subcription{Title: "Feed 1", FeedURL: "http://example.org/feed/1", SiteURL: "http://example.org/1", CategoryName: "Category 1", CustomHeaders: "Authorization: Bearer token\n?key=value"})
+	subscriptions = append(subscriptions, subcription{Title: "Feed 2", FeedURL: "http://example.org/feed/2", SiteURL: "http://example.org/2", CategoryName: "Category 1"})
+
+	output := serialize(subscriptions)
+	if !strings.Contains(output, `xmlns:miniflux="https://miniflux.app/opml"`) {
+		t.Errorf("The Miniflux namespace is not declared: %s", output)
+	}
+
+	feeds, err := parse(bytes.NewBufferString(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, feed := range feeds {
+		if !feed.equals(subscriptions[0]) && !feed.equals(subscriptions[1]) {
+			t.Errorf("Serialized feed is incorrect: %v", feed)
+		}
+	}
+}
+
+func TestSerializeWithoutCustomHeaders(t *testing.T) {
+	subscriptions := []subcription{{Title: "Feed 1", FeedURL: "http://example.org/feed/1", SiteURL: "http://example.org/1", CategoryName: "Category 1"}}
+
+	output := serialize(subscriptions)
+	if strings.Contains(output, "miniflux:") || strings.Contains(output, "customHeaders") {
+		t.Errorf("The Miniflux namespace should not be used: %s", output)
+	}
+}
@@ -10,4 +10,7 @@ type subcription struct {
 	FeedURL      string
 	CategoryName string
 	Description  string
+
+	// CustomHeaders is exported in clear text, the OPML file must be kept private.
+	CustomHeaders string
 }
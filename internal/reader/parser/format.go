@@ -4,10 +4,13 @@
 package parser // import "miniflux.app/v2/internal/reader/parser"
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"io"
 	"unicode"
 
+	"miniflux.app/v2/internal/reader/activitystreams"
+	"miniflux.app/v2/internal/reader/hfeed"
 	rxml "miniflux.app/v2/internal/reader/xml"
 )
 
@@ -18,6 +21,9 @@ const (
 	FormatAtom    = "atom"
 	FormatJSON    = "json"
 	FormatUnknown = "unknown"
+
+	FormatHFeed           = "hfeed"
+	FormatActivityStreams = "activitystreams"
 )
 
 // DetectFeedFormat tries to guess the feed format from input data.
@@ -26,7 +32,8 @@ func DetectFeedFormat(r io.ReadSeeker) (string, string) {
 	defer r.Seek(0, io.SeekStart)
 
 	if isJSON, err := detectJSONFormat(r); err == nil && isJSON {
-		return FormatJSON, ""
+		r.Seek(0, io.SeekStart)
+		return detectActivityStreams(r), ""
 	}
 
 	r.Seek(0, io.SeekStart)
@@ -55,9 +62,29 @@ func DetectFeedFormat(r io.ReadSeeker) (string, string) {
 		}
 	}
 
+	r.Seek(0, io.SeekStart)
+	if hfeed.IsHFeed(r) {
+		return FormatHFeed, ""
+	}
+
 	return FormatUnknown, ""
 }
 
+// detectActivityStreams tells apart JSON Feeds from ActivityStreams documents.
+// Only collections can be used as feeds, other ActivityStreams documents (actors, notes) are unknown formats.
+func detectActivityStreams(r io.Reader) string {
+	var document activitystreams.Document
+	if err := json.NewDecoder(r).Decode(&document); err != nil || !document.IsActivityStreams() {
+		return FormatJSON
+	}
+
+	if document.IsCollection() {
+		return FormatActivityStreams
+	}
+
+	return FormatUnknown
+}
+
 // detectJSONFormat checks if the reader contains JSON by reading until it finds
 // the first non-whitespace character or reaches EOF/error.
 func detectJSONFormat(r io.ReadSeeker) (bool, error) {
@@ -130,3 +130,30 @@ func TestDetectJSONWithWhitespaceSmallerThanBuffer(t *testing.T) {
 		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatJSON)
 	}
 }
+
+func TestDetectHFeed(t *testing.T) {
+	data := `<!DOCTYPE html><html><body><div class="h-feed"><article class="h-entry"><p class="p-name">Note</p></article></div></body></html>`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatHFeed {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatHFeed)
+	}
+}
+
+func TestDetectActivityStreamsCollection(t *testing.T) {
+	data := `{"@context": ["https://www.w3.org/ns/activitystreams"], "type": "OrderedCollectionPage", "orderedItems": []}`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatActivityStreams {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatActivityStreams)
+	}
+}
+
+func TestDetectActivityStreamsActor(t *testing.T) {
+	data := `{"@context": "https://www.w3.org/ns/activitystreams", "type": "Person", "outbox": "https://example.org/outbox"}`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatUnknown {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatUnknown)
+	}
+}
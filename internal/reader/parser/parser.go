@@ -8,7 +8,9 @@ import (
 	"io"
 
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/activitystreams"
 	"miniflux.app/v2/internal/reader/atom"
+	"miniflux.app/v2/internal/reader/hfeed"
 	"miniflux.app/v2/internal/reader/json"
 	"miniflux.app/v2/internal/reader/rdf"
 	"miniflux.app/v2/internal/reader/rss"
@@ -28,6 +30,10 @@ func ParseFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
 		return json.Parse(baseURL, r)
 	case FormatRDF:
 		return rdf.Parse(baseURL, r)
+	case FormatHFeed:
+		return hfeed.Parse(baseURL, r)
+	case FormatActivityStreams:
+		return activitystreams.Parse(baseURL, r)
 	default:
 		return nil, ErrFeedFormatNotDetected
 	}
@@ -4,9 +4,11 @@
 package parser // import "miniflux.app/v2/internal/reader/parser"
 
 import (
+	"bytes"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func BenchmarkParse(b *testing.B) {
@@ -403,3 +405,162 @@ func TestParseEmptyFeed(t *testing.T) {
 		t.Error("ParseFeed must returns an error")
 	}
 }
+
+func TestParseHFeed(t *testing.T) {
+	data, err := os.ReadFile("./testdata/hfeed.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := ParseFeed("https://alice.example.org/", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "Alice's Notes" {
+		t.Errorf(`Incorrect title, got: %q`, feed.Title)
+	}
+
+	if feed.SiteURL != "https://alice.example.org/" {
+		t.Errorf(`Incorrect site URL, got: %q`, feed.SiteURL)
+	}
+
+	if feed.IconURL != "https://alice.example.org/favicon.png" {
+		t.Errorf(`Incorrect icon URL, got: %q`, feed.IconURL)
+	}
+
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	article := feed.Entries[0]
+	if article.Title != "Joining the IndieWeb" {
+		t.Errorf(`Incorrect entry title, got: %q`, article.Title)
+	}
+
+	if article.URL != "https://alice.example.org/2024/05/indieweb" {
+		t.Errorf(`Incorrect entry URL, got: %q`, article.URL)
+	}
+
+	if !strings.Contains(article.Content, "<strong>microformats</strong>") {
+		t.Errorf(`Incorrect entry content, got: %q`, article.Content)
+	}
+
+	if article.Author != "Alice" {
+		t.Errorf(`The feed author should be used, got: %q`, article.Author)
+	}
+
+	if !article.Date.Equal(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Incorrect entry date, got: %v`, article.Date)
+	}
+
+	if len(article.Tags) != 2 || article.Tags[0] != "indieweb" || article.Tags[1] != "web" {
+		t.Errorf(`Incorrect entry tags, got: %v`, article.Tags)
+	}
+
+	note := feed.Entries[1]
+	if note.Title != "Just a short note without a title." {
+		t.Errorf(`Incorrect note title, got: %q`, note.Title)
+	}
+
+	if note.URL != "https://alice.example.org/notes/2" {
+		t.Errorf(`Incorrect note URL, got: %q`, note.URL)
+	}
+
+	if note.Author != "Bob" {
+		t.Errorf(`Incorrect note author, got: %q`, note.Author)
+	}
+
+	if len(note.Enclosures) != 1 || note.Enclosures[0].URL != "https://alice.example.org/photos/cat.jpg" {
+		t.Errorf(`Incorrect note enclosures, got: %v`, note.Enclosures)
+	}
+
+	if article.Hash == note.Hash {
+		t.Error(`Entries should have different hashes`)
+	}
+}
+
+func TestParseActivityStreamsOutbox(t *testing.T) {
+	data, err := os.ReadFile("./testdata/activitystreams_outbox.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := ParseFeed("https://social.example.org/users/alice/outbox?page=true", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "alice@social.example.org" {
+		t.Errorf(`Incorrect title, got: %q`, feed.Title)
+	}
+
+	if feed.SiteURL != "https://social.example.org/users/alice" {
+		t.Errorf(`Incorrect site URL, got: %q`, feed.SiteURL)
+	}
+
+	// Boosts of remote statuses are skipped because their content is not embedded.
+	if len(feed.Entries) != 2 {
+		t.Fatalf(`Incorrect number of entries, got: %d`, len(feed.Entries))
+	}
+
+	note := feed.Entries[0]
+	if note.URL != "https://social.example.org/@alice/2" {
+		t.Errorf(`Incorrect note URL, got: %q`, note.URL)
+	}
+
+	if note.Title != "Spoilers" {
+		t.Errorf(`The content warning should be used as title, got: %q`, note.Title)
+	}
+
+	if !strings.HasPrefix(note.Content, "<p><strong>Spoilers</strong></p><p>The butler did it.") {
+		t.Errorf(`Incorrect note content, got: %q`, note.Content)
+	}
+
+	if note.Author != "alice@social.example.org" {
+		t.Errorf(`Incorrect note author, got: %q`, note.Author)
+	}
+
+	if len(note.Tags) != 1 || note.Tags[0] != "books" {
+		t.Errorf(`Incorrect note tags, got: %v`, note.Tags)
+	}
+
+	if len(note.Enclosures) != 1 || note.Enclosures[0].MimeType != "image/jpeg" {
+		t.Errorf(`Incorrect note enclosures, got: %v`, note.Enclosures)
+	}
+
+	article := feed.Entries[1]
+	if article.Title != "A longer post" {
+		t.Errorf(`Incorrect article title, got: %q`, article.Title)
+	}
+
+	if article.URL != "https://social.example.org/@alice/0" {
+		t.Errorf(`The HTML link should be preferred, got: %q`, article.URL)
+	}
+
+	if article.Content != "<p>Long form content.</p>" {
+		t.Errorf(`Incorrect article content, got: %q`, article.Content)
+	}
+
+	if article.Author != "Alice Example" {
+		t.Errorf(`Incorrect article author, got: %q`, article.Author)
+	}
+
+	if !article.Date.Equal(time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Incorrect article date, got: %v`, article.Date)
+	}
+}
+
+func TestParseActivityStreamsOutboxWithoutEmbeddedItems(t *testing.T) {
+	data := `{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://social.example.org/users/alice/outbox",
+		"type": "OrderedCollection",
+		"totalItems": 10,
+		"first": "https://social.example.org/users/alice/outbox?page=true"
+	}`
+
+	if _, err := ParseFeed("https://social.example.org/users/alice/outbox", strings.NewReader(data)); err == nil {
+		t.Error(`Parsing an outbox without embedded items should return an error`)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"miniflux.app/v2/internal/reader/scraper"
 	"miniflux.app/v2/internal/reader/urlcleaner"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/urllib"
 )
 
 // ProcessFeedEntries downloads original web page for entries and apply filters.
@@ -53,6 +54,8 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithCustomHeaders(feed.CustomHeaders, feed.FeedURL)
+	requestBuilder.WithClientCertificate(feed.ClientCertificate.Certificate, feed.ClientCertificate.PrivateKey, feed.ClientCertificate.CABundle, feed.FeedURL)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
@@ -61,6 +64,11 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
 
+	// The entries of a feed mostly share a host, the domain rules are fetched once per domain.
+	domainRule := newDomainRuleLookup(func(entryURL string) (*model.DomainRule, error) {
+		return store.DomainRuleForURL(feed.UserID, entryURL)
+	})
+
 	// Processing older entries first ensures that their creation timestamp is lower than newer entries.
 	for _, entry := range slices.Backward(feed.Entries) {
 		slog.Debug("Processing entry",
@@ -92,6 +100,7 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 		webpageBaseURL := ""
 		entry.URL = rewrite.RewriteEntryURL(feed, entry)
 		entryIsNew := store.IsNewEntry(feed.ID, entry.Hash)
+		scraperRules, rewriteRules := entryRules(domainRule, feed, entry.URL)
 		if feed.Crawler && (entryIsNew || forceRefresh) {
 			slog.Debug("Scraping entry",
 				slog.Int64("user_id", user.ID),
@@ -109,13 +118,19 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 			extractedContent, scraperErr := scraper.ScrapeWebsite(
 				requestBuilder,
 				entry.URL,
-				feed.ScraperRules,
+				scraperRules,
+				feed.Extractor,
+				feed.DetectedExtractor,
 			)
 
 			if extractedContent != nil && extractedContent.BaseURL != "" {
 				webpageBaseURL = extractedContent.BaseURL
 			}
 
+			if extractedContent != nil && feed.Extractor == "" && extractedContent.Extractor != "" {
+				feed.DetectedExtractor = extractedContent.Extractor
+			}
+
 			if config.Opts.HasMetricsCollector() {
 				status := "success"
 				if scraperErr != nil {
@@ -138,7 +153,7 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 			}
 		}
 
-		rewrite.ApplyContentRewriteRules(entry, feed.RewriteRules)
+		rewrite.ApplyContentRewriteRules(entry, rewriteRules)
 
 		if webpageBaseURL == "" {
 			webpageBaseURL = entry.URL
@@ -160,13 +175,19 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 }
 
 // ProcessEntryWebPage downloads the entry web page and apply rewrite rules.
-func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User) error {
+// A user is waiting for the content, the request fails instead of waiting for the host limits.
+func ProcessEntryWebPage(store *storage.Storage, feed *model.Feed, entry *model.Entry, user *model.User) error {
 	startTime := time.Now()
 	entry.URL = rewrite.RewriteEntryURL(feed, entry)
+	scraperRules, rewriteRules := entryRules(func(entryURL string) (*model.DomainRule, error) {
+		return store.DomainRuleForURL(feed.UserID, entryURL)
+	}, feed, entry.URL)
 
-	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder := fetcher.NewRequestBuilder().Interactive()
 	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithCustomHeaders(feed.CustomHeaders, feed.FeedURL)
+	requestBuilder.WithClientCertificate(feed.ClientCertificate.Certificate, feed.ClientCertificate.PrivateKey, feed.ClientCertificate.CABundle, feed.FeedURL)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
@@ -178,7 +199,9 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 	extractedContent, scraperErr := scraper.ScrapeWebsite(
 		requestBuilder,
 		entry.URL,
-		feed.ScraperRules,
+		scraperRules,
+		feed.Extractor,
+		feed.DetectedExtractor,
 	)
 	var pageBaseURL string
 	if extractedContent != nil && extractedContent.BaseURL != "" {
@@ -197,6 +220,13 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 		return scraperErr
 	}
 
+	if feed.Extractor == "" && extractedContent.Extractor != "" && extractedContent.Extractor != feed.DetectedExtractor {
+		feed.DetectedExtractor = extractedContent.Extractor
+		if err := store.UpdateFeedDetectedExtractor(feed.ID, feed.DetectedExtractor); err != nil {
+			return err
+		}
+	}
+
 	if extractedContent != nil && extractedContent.Content != "" {
 		entry.Content = minifyContent(extractedContent.Content)
 		if user.ShowReadingTime {
@@ -204,8 +234,60 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 		}
 	}
 
-	rewrite.ApplyContentRewriteRules(entry, entry.Feed.RewriteRules)
+	rewrite.ApplyContentRewriteRules(entry, rewriteRules)
 	entry.Content = sanitizer.SanitizeHTML(pageBaseURL, entry.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab})
 
 	return nil
 }
+
+// domainRuleLookup returns the domain rule of the user to apply to an entry URL, nil when there is none.
+type domainRuleLookup func(entryURL string) (*model.DomainRule, error)
+
+// newDomainRuleLookup memoizes the domain rules by domain, the errors are not cached.
+func newDomainRuleLookup(lookupDomainRule domainRuleLookup) domainRuleLookup {
+	domainRules := make(map[string]*model.DomainRule)
+	return func(entryURL string) (*model.DomainRule, error) {
+		domain := urllib.DomainWithoutWWW(entryURL)
+		if domainRule, found := domainRules[domain]; found {
+			return domainRule, nil
+		}
+
+		domainRule, err := lookupDomainRule(entryURL)
+		if err != nil {
+			return nil, err
+		}
+		domainRules[domain] = domainRule
+		return domainRule, nil
+	}
+}
+
+// entryRules returns the scraper and rewrite rules to apply to the given entry URL.
+// Rules defined on the feed come first, then the domain rules registry.
+// When both are empty, the scraper and rewrite packages fall back to their built-in rules.
+func entryRules(lookupDomainRule domainRuleLookup, feed *model.Feed, entryURL string) (scraperRules, rewriteRules string) {
+	scraperRules, rewriteRules = feed.ScraperRules, feed.RewriteRules
+	if scraperRules != "" && rewriteRules != "" {
+		return scraperRules, rewriteRules
+	}
+
+	domainRule, err := lookupDomainRule(entryURL)
+	if err != nil {
+		slog.Error("Unable to fetch domain rules",
+			slog.Int64("user_id", feed.UserID),
+			slog.String("entry_url", entryURL),
+			slog.Any("error", err),
+		)
+		return scraperRules, rewriteRules
+	}
+
+	if domainRule != nil {
+		if scraperRules == "" {
+			scraperRules = domainRule.ScraperRules
+		}
+		if rewriteRules == "" {
+			rewriteRules = domainRule.RewriteRules
+		}
+	}
+
+	return scraperRules, rewriteRules
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"errors"
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestDomainRuleLookupIsMemoizedByDomain(t *testing.T) {
+	lookups := 0
+	lookupDomainRule := newDomainRuleLookup(func(entryURL string) (*model.DomainRule, error) {
+		lookups++
+		if entryURL == "https://example.org/1" {
+			return &model.DomainRule{Domain: "example.org", ScraperRules: "article"}, nil
+		}
+		return nil, nil
+	})
+
+	feed := &model.Feed{RewriteRules: "add_image_title"}
+	for _, entryURL := range []string{"https://example.org/1", "https://www.example.org/2", "https://example.org/3"} {
+		scraperRules, rewriteRules := entryRules(lookupDomainRule, feed, entryURL)
+		if scraperRules != "article" || rewriteRules != "add_image_title" {
+			t.Errorf(`Unexpected rules for %s: %q and %q`, entryURL, scraperRules, rewriteRules)
+		}
+	}
+
+	// The domains without rules are cached too.
+	for _, entryURL := range []string{"https://other.example.org/1", "https://other.example.org/2"} {
+		if scraperRules, _ := entryRules(lookupDomainRule, feed, entryURL); scraperRules != "" {
+			t.Errorf(`Unexpected scraper rules for %s: %q`, entryURL, scraperRules)
+		}
+	}
+
+	if lookups != 2 {
+		t.Errorf(`Expected one lookup per domain, got %d`, lookups)
+	}
+}
+
+func TestDomainRuleLookupErrorsAreNotCached(t *testing.T) {
+	lookups := 0
+	lookupDomainRule := newDomainRuleLookup(func(entryURL string) (*model.DomainRule, error) {
+		lookups++
+		return nil, errors.New("database error")
+	})
+
+	for range 2 {
+		if _, err := lookupDomainRule("https://example.org/1"); err == nil {
+			t.Fatal(`The error should be returned`)
+		}
+	}
+
+	if lookups != 2 {
+		t.Errorf(`The failed lookups should be retried, got %d lookups`, lookups)
+	}
+}
@@ -69,22 +69,42 @@ func (c candidateList) String() string {
 	return strings.Join(output, ", ")
 }
 
+// Article represents the content extracted from a web page.
+type Article struct {
+	BaseURL string
+	Content string
+
+	// Score is the score of the top candidate, zero when no candidate was found.
+	Score float32
+}
+
 // ExtractContent returns relevant content.
 func ExtractContent(page io.Reader) (baseURL string, extractedContent string, err error) {
-	document, err := goquery.NewDocumentFromReader(page)
+	article, err := ExtractArticle(page)
 	if err != nil {
 		return "", "", err
 	}
+	return article.BaseURL, article.Content, nil
+}
 
+// ExtractArticle returns relevant content along with the score of the selected candidate.
+func ExtractArticle(page io.Reader) (*Article, error) {
+	document, err := goquery.NewDocumentFromReader(page)
+	if err != nil {
+		return nil, err
+	}
+
+	article := &Article{}
 	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
 		hrefValue = strings.TrimSpace(hrefValue)
 		if urllib.IsAbsoluteURL(hrefValue) {
-			baseURL = hrefValue
+			article.BaseURL = hrefValue
 		}
 	}
 
 	document.Find("script,style").Remove()
 
+	removeHiddenNodes(document)
 	removeUnlikelyCandidates(document)
 	transformMisusedDivsIntoParagraphs(document)
 
@@ -92,13 +112,14 @@ func ExtractContent(page io.Reader) (baseURL string, extractedContent string, er
 	topCandidate := getTopCandidate(document, candidates)
 
 	slog.Debug("Readability parsing",
-		slog.String("base_url", baseURL),
+		slog.String("base_url", article.BaseURL),
 		slog.String("candidates", candidates.String()),
 		slog.String("topCandidate", topCandidate.String()),
 	)
 
-	extractedContent = getArticle(topCandidate, candidates)
-	return baseURL, extractedContent, nil
+	article.Content = getArticle(topCandidate, candidates)
+	article.Score = topCandidate.score
+	return article, nil
 }
 
 func getSelectionLength(s *goquery.Selection) int {
@@ -206,6 +227,32 @@ func shouldRemoveCandidate(str string) bool {
 	return false
 }
 
+// Hidden elements are never part of the visible article, but they often contain
+// enough text (menus, modals, cookie banners) to win over the real content.
+func removeHiddenNodes(document *goquery.Document) {
+	document.Find("[hidden],[aria-hidden=true],[style]").Each(func(i int, s *goquery.Selection) {
+		if s.Is("body,html") || s.Closest("pre,code").Length() > 0 {
+			return
+		}
+
+		if _, hidden := s.Attr("hidden"); hidden {
+			s.Remove()
+			return
+		}
+
+		if ariaHidden, _ := s.Attr("aria-hidden"); ariaHidden == "true" {
+			s.Remove()
+			return
+		}
+
+		style, _ := s.Attr("style")
+		style = strings.ToLower(strings.ReplaceAll(style, " ", ""))
+		if strings.Contains(style, "display:none") || strings.Contains(style, "visibility:hidden") {
+			s.Remove()
+		}
+	})
+}
+
 func removeUnlikelyCandidates(document *goquery.Document) {
 	// Only select tags with either a class or an id attribute,
 	// and never the html nor body tags, as we don't want to ever remove them.
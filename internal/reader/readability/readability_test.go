@@ -220,6 +220,70 @@ func TestRemoveBlacklist(t *testing.T) {
 	}
 }
 
+func TestRemoveHiddenNodes(t *testing.T) {
+	html := `
+		<html>
+			<head>
+				<title>Test</title>
+			</head>
+			<body>
+				<article hidden>Hidden content</article>
+				<article aria-hidden="true">Hidden from screen readers</article>
+				<article style="display: none">Not displayed</article>
+				<article style="visibility:hidden">Not visible</article>
+				<article>Visible content</article>
+			</body>
+		</html>`
+	want := `<div><div><article>Visiblecontent</article></div></div>`
+
+	_, content, err := ExtractContent(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content = strings.ReplaceAll(content, "\n", "")
+	content = strings.ReplaceAll(content, " ", "")
+	content = strings.ReplaceAll(content, "\t", "")
+
+	if content != want {
+		t.Errorf(`Invalid content, got %s instead of %s`, content, want)
+	}
+}
+
+func TestExtractArticleScore(t *testing.T) {
+	html := `
+		<html>
+			<body>
+				<div class="article-content">
+					<p>This is the first paragraph of a long article, with commas, and enough words to be scored.</p>
+					<p>This is the second paragraph of a long article, with more commas, and more words to be scored.</p>
+				</div>
+			</body>
+		</html>`
+
+	article, err := ExtractArticle(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if article.Score <= 0 {
+		t.Errorf(`The top candidate should have a positive score, got %f`, article.Score)
+	}
+
+	if !strings.Contains(article.Content, "second paragraph") {
+		t.Errorf(`Unexpected content: %s`, article.Content)
+	}
+
+	emptyArticle, err := ExtractArticle(strings.NewReader(`<html><body></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if emptyArticle.Score != 0 {
+		t.Errorf(`An empty page should have a zero score, got %f`, emptyArticle.Score)
+	}
+}
+
 func TestNestedSpanInCodeBlock(t *testing.T) {
 	html := `
 		<html>
@@ -6,6 +6,7 @@ package rss // import "miniflux.app/v2/internal/reader/rss"
 import (
 	"html"
 	"log/slog"
+	"net/url"
 	"path"
 	"slices"
 	"strconv"
@@ -47,6 +48,33 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 		}
 	}
 
+	// Populate the WebSub hub URL from the Atom links.
+	for _, atomLink := range r.rss.Channel.Links {
+		atomLinkHref := strings.TrimSpace(atomLink.Href)
+		if atomLinkHref != "" && atomLink.Rel == "hub" {
+			if absoluteHubURL, err := urllib.AbsoluteURL(baseURL, atomLinkHref); err == nil {
+				feed.HubURL = absoluteHubURL
+				break
+			}
+		}
+	}
+
+	// Populate the URL of the page with older entries (RFC 5005 paged or archived feeds).
+	for _, atomLink := range r.rss.Channel.Links {
+		atomLinkHref := strings.TrimSpace(atomLink.Href)
+		if atomLinkHref != "" && (atomLink.Rel == "next" || atomLink.Rel == "prev-archive") {
+			if absoluteArchiveURL, err := urllib.AbsoluteURL(baseURL, atomLinkHref); err == nil {
+				feed.ArchiveURL = absoluteArchiveURL
+				break
+			}
+		}
+	}
+
+	// WordPress feeds do not advertise their archives, but they are paginated with the "paged" query parameter.
+	if feed.ArchiveURL == "" && len(r.rss.Channel.Items) > 0 && strings.Contains(r.rss.Channel.Generator, "wordpress.org") {
+		feed.ArchiveURL = nextWordPressPageURL(baseURL)
+	}
+
 	// Fallback to the site URL if the title is empty.
 	if feed.Title == "" {
 		feed.Title = feed.SiteURL
@@ -406,3 +434,20 @@ func findEntryEnclosures(rssItem *rssItem, siteURL string) model.EnclosureList {
 
 	return enclosures
 }
+
+func nextWordPressPageURL(feedURL string) string {
+	parsedURL, err := url.Parse(feedURL)
+	if err != nil {
+		return ""
+	}
+
+	page := 1
+	values := parsedURL.Query()
+	if currentPage, err := strconv.Atoi(values.Get("paged")); err == nil && currentPage > 0 {
+		page = currentPage
+	}
+
+	values.Set("paged", strconv.Itoa(page+1))
+	parsedURL.RawQuery = values.Encode()
+	return parsedURL.String()
+}
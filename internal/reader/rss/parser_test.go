@@ -684,6 +684,78 @@ func TestParseFeedURLWithAtomLink(t *testing.T) {
 	}
 }
 
+func TestParseFeedHubURLWithAtomLink(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss xmlns:atom="http://www.w3.org/2005/Atom" version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link href="https://example.org/rss" type="application/rss+xml" rel="self"></atom:link>
+			<atom:link href="https://hub.example.org/" rel="hub"></atom:link>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.HubURL != "https://hub.example.org/" {
+		t.Errorf("Incorrect hub URL, got: %s", feed.HubURL)
+	}
+}
+
+func TestParseFeedArchiveURLWithAtomLink(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss xmlns:atom="http://www.w3.org/2005/Atom" version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<atom:link href="/rss?page=2" rel="next"></atom:link>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/rss", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.ArchiveURL != "https://example.org/rss?page=2" {
+		t.Errorf("Incorrect archive URL, got: %s", feed.ArchiveURL)
+	}
+}
+
+func TestParseWordPressFeedArchiveURL(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<generator>https://wordpress.org/?v=6.5</generator>
+			<item>
+				<title>Item</title>
+				<link>https://example.org/item</link>
+			</item>
+		</channel>
+		</rss>`
+
+	scenarios := map[string]string{
+		"https://example.org/feed/":         "https://example.org/feed/?paged=2",
+		"https://example.org/feed/?paged=2": "https://example.org/feed/?paged=3",
+	}
+
+	for feedURL, expected := range scenarios {
+		feed, err := Parse(feedURL, bytes.NewReader([]byte(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if feed.ArchiveURL != expected {
+			t.Errorf("Incorrect archive URL for %s, got: %s", feedURL, feed.ArchiveURL)
+		}
+	}
+}
+
 func TestParseFeedWithWebmaster(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0">
@@ -22,7 +22,7 @@ import (
 
 // FetchEntry downloads a web page and returns an Entry.
 func FetchEntry(user *model.User, websiteURL, rules, userAgent string, cookie string) (*model.Entry, error) {
-	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder := fetcher.NewRequestBuilder().Interactive()
 	if userAgent != "" {
 		requestBuilder.WithUserAgent(userAgent, config.Opts.HTTPClientUserAgent())
 	}
@@ -35,6 +35,8 @@ func FetchEntry(user *model.User, websiteURL, rules, userAgent string, cookie st
 		requestBuilder,
 		websiteURL,
 		rules,
+		"",
+		"",
 	)
 
 	if config.Opts.HasMetricsCollector() {
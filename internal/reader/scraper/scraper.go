@@ -12,8 +12,8 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/reader/encoding"
+	"miniflux.app/v2/internal/reader/extractor"
 	"miniflux.app/v2/internal/reader/fetcher"
-	"miniflux.app/v2/internal/reader/readability"
 	"miniflux.app/v2/internal/urllib"
 
 	"github.com/PuerkitoBio/goquery"
@@ -26,9 +26,15 @@ type ScrapeWebsiteResult struct {
 	Header     http.Header
 	Body       []byte
 	Content    string
+
+	// Extractor is the name of the extractor used, empty when custom rules were applied.
+	Extractor string
 }
 
-func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string) (*ScrapeWebsiteResult, error) {
+// ScrapeWebsite downloads the page and extracts its content with the given scraper rules.
+// Without rules, the content extractor named extractorName is used, or the best one when empty.
+// The rememberedExtractor is tried first in that case.
+func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules, extractorName, rememberedExtractor string) (*ScrapeWebsiteResult, error) {
 	resp, reqErr := requestBuilder.ExecuteRequest(pageURL)
 	responseHandler := fetcher.NewResponseHandler(resp, reqErr)
 	defer responseHandler.Close()
@@ -64,7 +70,7 @@ func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string
 	}
 	htmlDocumentReader = strings.NewReader(string(body))
 
-	var baseURL, extractedContent string
+	var baseURL, extractedContent, extractorUsed string
 	if sameSite && rules != "" {
 		slog.Debug("Extracting content with custom rules",
 			"url", pageURL,
@@ -72,10 +78,23 @@ func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string
 		)
 		baseURL, extractedContent, err = findContentUsingCustomRules(htmlDocumentReader, rules)
 	} else {
-		slog.Debug("Extracting content with readability",
+		slog.Debug("Extracting content with extractors",
 			"url", pageURL,
+			"extractor", extractorName,
+			"remembered_extractor", rememberedExtractor,
 		)
-		baseURL, extractedContent, err = readability.ExtractContent(htmlDocumentReader)
+		page := &extractor.Page{
+			URL:  pageURL,
+			Body: body,
+			Fetch: func(url string) ([]byte, string, error) {
+				return fetchHTMLDocument(requestBuilder, url)
+			},
+		}
+
+		var result *extractor.Result
+		if result, err = extractor.Extract(page, extractorName, rememberedExtractor); err == nil {
+			baseURL, extractedContent, extractorUsed = result.BaseURL, result.Content, result.Extractor
+		}
 	}
 
 	if err != nil {
@@ -93,9 +112,40 @@ func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string
 		Header:     resp.Header,
 		Body:       body,
 		Content:    extractedContent,
+		Extractor:  extractorUsed,
 	}, nil
 }
 
+// fetchHTMLDocument downloads an additional page requested by an extractor.
+func fetchHTMLDocument(requestBuilder *fetcher.RequestBuilder, pageURL string) ([]byte, string, error) {
+	resp, reqErr := requestBuilder.ExecuteRequest(pageURL)
+	responseHandler := fetcher.NewResponseHandler(resp, reqErr)
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, "", localizedError.Error()
+	}
+
+	if !isAllowedContentType(responseHandler.ContentType()) {
+		return nil, "", fmt.Errorf("scraper: this resource is not a HTML document (%s)", responseHandler.ContentType())
+	}
+
+	htmlDocumentReader, err := encoding.NewCharsetReader(
+		responseHandler.Body(config.Opts.HTTPClientMaxBodySize()),
+		responseHandler.ContentType(),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("scraper: unable to read HTML document: %v", err)
+	}
+
+	body, err := io.ReadAll(htmlDocumentReader)
+	if err != nil {
+		return nil, "", fmt.Errorf("scraper: unable to read HTML document: %v", err)
+	}
+
+	return body, responseHandler.EffectiveURL(), nil
+}
+
 func findContentUsingCustomRules(page io.Reader, rules string) (baseURL string, extractedContent string, err error) {
 	document, err := goquery.NewDocumentFromReader(page)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"miniflux.app/v2/internal/integration/rssbridge"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/activitystreams"
 	"miniflux.app/v2/internal/reader/encoding"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/parser"
@@ -64,11 +65,30 @@ func (f *subscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string,
 	}
 
 	// Step 1) Check if the website URL is already a feed.
-	if feedFormat, _ := parser.DetectFeedFormat(f.feedResponseInfo.Content); feedFormat != parser.FormatUnknown {
+	// Pages with h-entry microformats are only used when no other feed is found, see step 7.
+	feedFormat, _ := parser.DetectFeedFormat(f.feedResponseInfo.Content)
+	switch feedFormat {
+	case parser.FormatUnknown, parser.FormatHFeed:
+	case parser.FormatActivityStreams:
+		// Outboxes usually link to their first page instead of embedding the items.
+		if firstPageURL := activitystreams.FirstPageURL(bytes.NewReader(responseBody)); firstPageURL != "" {
+			return Subscriptions{NewSubscription(responseHandler.EffectiveURL(), firstPageURL, feedFormat)}, nil
+		}
+		f.feedDownloaded = true
+		return Subscriptions{NewSubscription(responseHandler.EffectiveURL(), responseHandler.EffectiveURL(), feedFormat)}, nil
+	default:
 		f.feedDownloaded = true
 		return Subscriptions{NewSubscription(responseHandler.EffectiveURL(), responseHandler.EffectiveURL(), feedFormat)}, nil
 	}
 
+	// The website URL can be an ActivityPub actor when the server returns JSON.
+	if actor, err := activitystreams.ParseActor(bytes.NewReader(responseBody)); err == nil {
+		slog.Debug("Try to find the outbox of an ActivityPub actor", slog.String("website_url", websiteURL))
+		if subscription := f.findSubscriptionFromActivityPubOutbox(actor); subscription != nil {
+			return Subscriptions{subscription}, nil
+		}
+	}
+
 	// Step 2) Find the canonical URL of the website.
 	slog.Debug("Try to find the canonical URL of the website", slog.String("website_url", websiteURL))
 	websiteURL = f.findCanonicalURL(websiteURL, responseHandler.ContentType(), bytes.NewReader(responseBody))
@@ -114,6 +134,13 @@ func (f *subscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string,
 		return subscriptions, nil
 	}
 
+	// Step 7) Use the h-entry microformats of the web page.
+	if feedFormat == parser.FormatHFeed {
+		slog.Debug("Subscription found from h-entry microformats", slog.String("website_url", websiteURL))
+		f.feedDownloaded = true
+		return Subscriptions{NewSubscription(responseHandler.EffectiveURL(), responseHandler.EffectiveURL(), feedFormat)}, nil
+	}
+
 	return nil, nil
 }
 
@@ -122,6 +149,7 @@ func (f *subscriptionFinder) findSubscriptionsFromWebPage(websiteURL, contentTyp
 		"link[type='application/rss+xml']":                                  parser.FormatRSS,
 		"link[type='application/atom+xml']":                                 parser.FormatAtom,
 		"link[type='application/json'], link[type='application/feed+json']": parser.FormatJSON,
+		"link[rel='feed']:not([type*='xml']):not([type*='json'])":           parser.FormatHFeed,
 	}
 
 	htmlDocumentReader, err := encoding.NewCharsetReader(body, contentType)
@@ -172,9 +200,87 @@ func (f *subscriptionFinder) findSubscriptionsFromWebPage(websiteURL, contentTyp
 		})
 	}
 
+	// ActivityPub actors are advertised by Fediverse profiles, their outbox is used as feed.
+	doc.Find("link[rel='alternate'][type='application/activity+json']").Each(func(i int, s *goquery.Selection) {
+		actorURL, err := urllib.AbsoluteURL(websiteURL, s.AttrOr("href", ""))
+		if err != nil || subscriptionURLs[actorURL] {
+			return
+		}
+		subscriptionURLs[actorURL] = true
+
+		if subscription := f.findSubscriptionFromActivityPubActor(actorURL); subscription != nil && !subscriptionURLs[subscription.URL] {
+			subscriptionURLs[subscription.URL] = true
+			subscriptions = append(subscriptions, subscription)
+		}
+	})
+
 	return subscriptions, nil
 }
 
+func (f *subscriptionFinder) findSubscriptionFromActivityPubActor(actorURL string) *subscription {
+	body, err := f.fetchActivityStreamsDocument(actorURL)
+	if err != nil {
+		slog.Debug("Unable to fetch ActivityPub actor", slog.String("actor_url", actorURL), slog.Any("error", err))
+		return nil
+	}
+
+	actor, err := activitystreams.ParseActor(bytes.NewReader(body))
+	if err != nil {
+		slog.Debug("Unable to parse ActivityPub actor", slog.String("actor_url", actorURL), slog.Any("error", err))
+		return nil
+	}
+
+	return f.findSubscriptionFromActivityPubOutbox(actor)
+}
+
+func (f *subscriptionFinder) findSubscriptionFromActivityPubOutbox(actor *activitystreams.Actor) *subscription {
+	title := actor.Name
+	if title == "" {
+		title = actor.PreferredUsername
+	}
+	if title == "" {
+		title = actor.Outbox
+	}
+
+	body, err := f.fetchActivityStreamsDocument(actor.Outbox)
+	if err != nil {
+		slog.Debug("Unable to fetch ActivityPub outbox", slog.String("outbox_url", actor.Outbox), slog.Any("error", err))
+		return nil
+	}
+
+	if firstPageURL := activitystreams.FirstPageURL(bytes.NewReader(body)); firstPageURL != "" {
+		return NewSubscription(title, firstPageURL, parser.FormatActivityStreams)
+	}
+
+	return NewSubscription(title, actor.Outbox, parser.FormatActivityStreams)
+}
+
+// fetchActivityStreamsDocument downloads a document with content negotiation,
+// because ActivityPub servers return HTML to regular clients.
+func (f *subscriptionFinder) fetchActivityStreamsDocument(documentURL string) ([]byte, error) {
+	// The builder is shared by the other discovery requests, the previous Accept header is restored.
+	if previousAccept := f.requestBuilder.Header("Accept"); previousAccept != "" {
+		defer f.requestBuilder.WithHeader("Accept", previousAccept)
+	} else {
+		defer f.requestBuilder.WithoutHeader("Accept")
+	}
+	f.requestBuilder.WithHeader("Accept", `application/activity+json, application/ld+json; profile="https://www.w3.org/ns/activitystreams"`)
+
+	responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(documentURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	body, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	return body, nil
+}
+
 func (f *subscriptionFinder) findSubscriptionsFromWellKnownURLs(websiteURL string) (Subscriptions, *locale.LocalizedErrorWrapper) {
 	knownURLs := map[string]string{
 		"atom.xml":     parser.FormatAtom,
@@ -4,8 +4,14 @@
 package subscription
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
 )
 
 func TestFindYoutubeFeed(t *testing.T) {
@@ -437,3 +443,104 @@ func TestFindCanonicalURLNotFound(t *testing.T) {
 		t.Errorf(`Expected effective URL when canonical not found, got %q`, canonicalURL)
 	}
 }
+
+func TestParseWebPageWithHFeedLink(t *testing.T) {
+	htmlPage := `
+	<!doctype html>
+	<html>
+		<head>
+			<link rel="feed" type="text/html" href="/notes" title="Notes">
+			<link rel="feed" type="application/rss+xml" href="/rss">
+		</head>
+		<body>
+		</body>
+	</html>`
+
+	subscriptions, err := NewSubscriptionFinder(nil).findSubscriptionsFromWebPage("http://example.org/", "text/html", strings.NewReader(htmlPage))
+	if err != nil {
+		t.Fatalf(`Parsing a correctly formatted HTML page should not return any error: %v`, err)
+	}
+
+	if len(subscriptions) != 2 {
+		t.Fatalf(`Incorrect number of subscriptions returned: %d`, len(subscriptions))
+	}
+
+	for _, subscription := range subscriptions {
+		switch subscription.URL {
+		case "http://example.org/notes":
+			if subscription.Type != "hfeed" || subscription.Title != "Notes" {
+				t.Errorf(`Incorrect h-feed subscription: %v`, subscription)
+			}
+		case "http://example.org/rss":
+			if subscription.Type != "rss" {
+				t.Errorf(`Incorrect RSS subscription: %v`, subscription)
+			}
+		default:
+			t.Errorf(`Unexpected subscription: %v`, subscription)
+		}
+	}
+}
+
+func TestParseWebPageWithActivityPubActor(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "application/activity+json") {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html></html>`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/activity+json")
+		switch r.URL.Path {
+		case "/users/alice":
+			w.Write([]byte(`{"@context":"https://www.w3.org/ns/activitystreams","type":"Person","name":"Alice","outbox":"` + server.URL + `/users/alice/outbox"}`))
+		case "/users/alice/outbox":
+			w.Write([]byte(`{"@context":"https://www.w3.org/ns/activitystreams","type":"OrderedCollection","first":"` + server.URL + `/users/alice/outbox?page=true"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	htmlPage := `
+	<!doctype html>
+	<html>
+		<head>
+			<link href="` + server.URL + `/users/alice" rel="alternate" type="application/activity+json">
+		</head>
+	</html>`
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	subscriptions, localizedError := NewSubscriptionFinder(requestBuilder).findSubscriptionsFromWebPage(server.URL+"/@alice", "text/html", strings.NewReader(htmlPage))
+	if localizedError != nil {
+		t.Fatalf(`Parsing a correctly formatted HTML page should not return any error: %v`, localizedError)
+	}
+
+	if len(subscriptions) != 1 {
+		t.Fatalf(`Incorrect number of subscriptions returned: %d`, len(subscriptions))
+	}
+
+	if subscriptions[0].Title != "Alice" {
+		t.Errorf(`Incorrect subscription title: %q`, subscriptions[0].Title)
+	}
+
+	if subscriptions[0].URL != server.URL+"/users/alice/outbox?page=true" {
+		t.Errorf(`Incorrect subscription URL: %q`, subscriptions[0].URL)
+	}
+
+	if subscriptions[0].Type != "activitystreams" {
+		t.Errorf(`Incorrect subscription type: %q`, subscriptions[0].Type)
+	}
+	// The following discovery requests use the default Accept header.
+	if accept := requestBuilder.Header("Accept"); accept != "" {
+		t.Errorf(`The Accept header of the ActivityStreams documents must be removed, got %q`, accept)
+	}
+}
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// ActivityPubUserID returns the user having enabled an ActivityPub actor with this username, 0 if there is none.
+func (s *Storage) ActivityPubUserID(username string) (int64, error) {
+	query := `
+		SELECT
+			user_id
+		FROM
+			integration_providers
+		WHERE
+			provider='activitypub' AND enabled='t' AND settings->>'username'=$1
+		ORDER BY user_id ASC
+		LIMIT 1
+	`
+	var userID int64
+	err := s.db.QueryRow(query, username).Scan(&userID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to fetch the user of the activitypub actor %q: %v`, username, err)
+	}
+
+	return userID, nil
+}
+
+// HasDuplicateActivityPubUsername checks if another user have the same ActivityPub username.
+func (s *Storage) HasDuplicateActivityPubUsername(userID int64, username string) bool {
+	query := `SELECT true FROM integration_providers WHERE user_id != $1 AND provider='activitypub' AND settings->>'username'=$2 LIMIT 1`
+	var result bool
+	s.db.QueryRow(query, userID, username).Scan(&result)
+	return result
+}
+
+// ActivityPubKey returns the key pair of the ActivityPub actor of a user, nil if it was never generated.
+func (s *Storage) ActivityPubKey(userID int64) (*model.ActivityPubKey, error) {
+	query := `SELECT user_id, public_key, private_key, created_at FROM activitypub_keys WHERE user_id=$1`
+
+	var key model.ActivityPubKey
+	err := s.db.QueryRow(query, userID).Scan(&key.UserID, &key.PublicKey, &key.PrivateKey, &key.CreatedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch the activitypub key of user #%d: %v`, userID, err)
+	}
+
+	if key.PrivateKey, err = decryptSecret(key.PrivateKey); err != nil {
+		return nil, fmt.Errorf(`store: unable to decrypt the activitypub key of user #%d: %v`, userID, err)
+	}
+
+	return &key, nil
+}
+
+// CreateActivityPubKey saves the key pair of the ActivityPub actor of a user.
+// The key saved first is kept and returned when two keys are generated at the same time.
+func (s *Storage) CreateActivityPubKey(key *model.ActivityPubKey) (*model.ActivityPubKey, error) {
+	privateKey, err := encryptSecret(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to encrypt the activitypub key of user #%d: %v`, key.UserID, err)
+	}
+
+	query := `
+		INSERT INTO activitypub_keys
+			(user_id, public_key, private_key)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	if _, err := s.db.Exec(query, key.UserID, key.PublicKey, privateKey); err != nil {
+		return nil, fmt.Errorf(`store: unable to create the activitypub key of user #%d: %v`, key.UserID, err)
+	}
+
+	return s.ActivityPubKey(key.UserID)
+}
+
+// ActivityPubFollowers returns the followers of the ActivityPub actor of a user.
+func (s *Storage) ActivityPubFollowers(userID int64) ([]*model.ActivityPubFollower, error) {
+	query := `
+		SELECT
+			id, user_id, actor_url, inbox_url, shared_inbox_url, created_at
+		FROM
+			activitypub_followers
+		WHERE
+			user_id=$1
+		ORDER BY id ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch activitypub followers: %v`, err)
+	}
+	defer rows.Close()
+
+	followers := make([]*model.ActivityPubFollower, 0)
+	for rows.Next() {
+		var follower model.ActivityPubFollower
+		if err := rows.Scan(
+			&follower.ID,
+			&follower.UserID,
+			&follower.ActorURL,
+			&follower.InboxURL,
+			&follower.SharedInboxURL,
+			&follower.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch activitypub follower row: %v`, err)
+		}
+		followers = append(followers, &follower)
+	}
+
+	return followers, nil
+}
+
+// CountActivityPubFollowers returns the number of followers of the ActivityPub actor of a user.
+func (s *Storage) CountActivityPubFollowers(userID int64) (int, error) {
+	var count int
+	if err := s.db.QueryRow(`SELECT count(*) FROM activitypub_followers WHERE user_id=$1`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf(`store: unable to count activitypub followers: %v`, err)
+	}
+	return count, nil
+}
+
+// CreateActivityPubFollower adds a follower to the ActivityPub actor of a user, the inboxes of an existing follower are updated.
+func (s *Storage) CreateActivityPubFollower(follower *model.ActivityPubFollower) error {
+	query := `
+		INSERT INTO activitypub_followers
+			(user_id, actor_url, inbox_url, shared_inbox_url)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (user_id, actor_url) DO UPDATE
+			SET inbox_url=excluded.inbox_url, shared_inbox_url=excluded.shared_inbox_url
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		follower.UserID,
+		follower.ActorURL,
+		follower.InboxURL,
+		follower.SharedInboxURL,
+	).Scan(&follower.ID, &follower.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create activitypub follower for user #%d: %v`, follower.UserID, err)
+	}
+
+	return nil
+}
+
+// RemoveActivityPubFollower removes a follower of the ActivityPub actor of a user.
+func (s *Storage) RemoveActivityPubFollower(userID int64, actorURL string) error {
+	query := `DELETE FROM activitypub_followers WHERE user_id=$1 AND actor_url=$2`
+	if _, err := s.db.Exec(query, userID, actorURL); err != nil {
+		return fmt.Errorf(`store: unable to remove activitypub follower for user #%d: %v`, userID, err)
+	}
+
+	return nil
+}
+
+const activityPubObjectColumns = `user_id, entry_id, share_code, object_type, starred, published_at`
+
+// ActivityPubObject returns the object published for an entry, nil if the entry is not published.
+func (s *Storage) ActivityPubObject(userID, entryID int64) (*model.ActivityPubObject, error) {
+	query := `SELECT ` + activityPubObjectColumns + ` FROM activitypub_objects WHERE user_id=$1 AND entry_id=$2`
+	return scanActivityPubObject(s.db.QueryRow(query, userID, entryID))
+}
+
+// ActivityPubObjectByShareCode returns the object published for a shared entry, nil if the entry is not published.
+func (s *Storage) ActivityPubObjectByShareCode(shareCode string) (*model.ActivityPubObject, error) {
+	query := `SELECT ` + activityPubObjectColumns + ` FROM activitypub_objects WHERE share_code=$1`
+	return scanActivityPubObject(s.db.QueryRow(query, shareCode))
+}
+
+// ActivityPubObjects returns the objects published by the ActivityPub actor of a user, the most recent first.
+func (s *Storage) ActivityPubObjects(userID int64, offset, limit int) ([]*model.ActivityPubObject, error) {
+	query := `
+		SELECT
+			` + activityPubObjectColumns + `
+		FROM
+			activitypub_objects
+		WHERE
+			user_id=$1
+		ORDER BY published_at DESC, entry_id DESC
+		OFFSET $2
+		LIMIT $3
+	`
+	rows, err := s.db.Query(query, userID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch activitypub objects: %v`, err)
+	}
+	defer rows.Close()
+
+	objects := make([]*model.ActivityPubObject, 0)
+	for rows.Next() {
+		object, err := scanActivityPubObject(rows)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// CountActivityPubObjects returns the number of objects published by the ActivityPub actor of a user.
+func (s *Storage) CountActivityPubObjects(userID int64) (int, error) {
+	var count int
+	if err := s.db.QueryRow(`SELECT count(*) FROM activitypub_objects WHERE user_id=$1`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf(`store: unable to count activitypub objects: %v`, err)
+	}
+	return count, nil
+}
+
+// CreateActivityPubObject records an entry published by the ActivityPub actor of a user, an entry is published once.
+// An entry starred and shared by the user is not retracted when unstarred.
+func (s *Storage) CreateActivityPubObject(object *model.ActivityPubObject) error {
+	query := `
+		INSERT INTO activitypub_objects
+			(user_id, entry_id, share_code, object_type, starred, published_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, entry_id) DO UPDATE SET
+			starred = activitypub_objects.starred AND excluded.starred
+	`
+	_, err := s.db.Exec(query, object.UserID, object.EntryID, object.ShareCode, object.ObjectType, object.Starred, object.PublishedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create activitypub object for entry #%d: %v`, object.EntryID, err)
+	}
+
+	return nil
+}
+
+// RemoveActivityPubObject removes an entry published by the ActivityPub actor of a user.
+func (s *Storage) RemoveActivityPubObject(userID, entryID int64) error {
+	query := `DELETE FROM activitypub_objects WHERE user_id=$1 AND entry_id=$2`
+	if _, err := s.db.Exec(query, userID, entryID); err != nil {
+		return fmt.Errorf(`store: unable to remove activitypub object for entry #%d: %v`, entryID, err)
+	}
+
+	return nil
+}
+
+type activityPubObjectScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanActivityPubObject(row activityPubObjectScanner) (*model.ActivityPubObject, error) {
+	var object model.ActivityPubObject
+	err := row.Scan(&object.UserID, &object.EntryID, &object.ShareCode, &object.ObjectType, &object.Starred, &object.PublishedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch activitypub object row: %v`, err)
+	}
+
+	return &object, nil
+}
@@ -9,9 +9,12 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/urllib"
+
+	"github.com/lib/pq"
 )
 
 type BatchBuilder struct {
@@ -63,6 +66,15 @@ func (b *BatchBuilder) WithoutDisabledFeeds() *BatchBuilder {
 	return b
 }
 
+// WithoutThrottledHosts skips the feeds hosted on a server that asked to slow down.
+func (b *BatchBuilder) WithoutThrottledHosts() *BatchBuilder {
+	b.conditions = append(b.conditions, `NOT EXISTS (
+		SELECT 1 FROM host_limits hl
+		WHERE hl.blocked_until > now() AND hl.host = substring(feed_url from '^[^:]+://([^/?#]+)')
+	)`)
+	return b
+}
+
 func (b *BatchBuilder) WithLimitPerHost(limit int) *BatchBuilder {
 	if limit > 0 {
 		b.limitPerHost = limit
@@ -70,9 +82,52 @@ func (b *BatchBuilder) WithLimitPerHost(limit int) *BatchBuilder {
 	return b
 }
 
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
 // FetchJobs retrieves a batch of jobs based on the conditions set in the builder.
 // When limitPerHost is set, it limits the number of jobs per feed hostname to prevent overwhelming a single host.
 func (b *BatchBuilder) FetchJobs() (model.JobList, error) {
+	return b.fetchJobs(b.db, "")
+}
+
+// ClaimJobs retrieves a batch of jobs like FetchJobs and postpones the next check of the selected feeds,
+// so the other instances sharing the database do not fetch the same batch.
+// Feeds locked by a concurrent claim are skipped.
+func (b *BatchBuilder) ClaimJobs(claimDuration time.Duration) (model.JobList, error) {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	jobs, err := b.fetchJobs(tx, " FOR UPDATE SKIP LOCKED")
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(jobs) > 0 {
+		feedIDs := make([]int64, len(jobs))
+		for i, job := range jobs {
+			feedIDs[i] = job.FeedID
+		}
+
+		query := `UPDATE feeds SET next_check_at = now() + make_interval(secs => $1) WHERE id = ANY($2)`
+		if _, err := tx.Exec(query, claimDuration.Seconds(), pq.Array(feedIDs)); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf(`store: unable to claim batch of jobs: %v`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return jobs, nil
+}
+
+func (b *BatchBuilder) fetchJobs(q queryer, lockingClause string) (model.JobList, error) {
 	query := `SELECT id, user_id, feed_url FROM feeds`
 
 	if len(b.conditions) > 0 {
@@ -85,7 +140,9 @@ func (b *BatchBuilder) FetchJobs() (model.JobList, error) {
 		query += " LIMIT " + strconv.Itoa(b.batchSize)
 	}
 
-	rows, err := b.db.Query(query, b.args...)
+	query += lockingClause
+
+	rows, err := q.Query(query, b.args...)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch batch of jobs: %v`, err)
 	}
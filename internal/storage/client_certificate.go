@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"miniflux.app/v2/internal/model"
+)
+
+var ErrClientCertificateNotFound = errors.New("store: client certificate not found")
+
+// ClientCertificateExists checks if a client certificate with the same name exists.
+func (s *Storage) ClientCertificateExists(userID int64, name string) bool {
+	var result bool
+	query := `SELECT true FROM client_certificates WHERE user_id=$1 AND lower(name)=lower($2) LIMIT 1`
+	s.db.QueryRow(query, userID, name).Scan(&result)
+	return result
+}
+
+// ClientCertificateIDExists checks if the given client certificate exists and belongs to the user.
+func (s *Storage) ClientCertificateIDExists(userID, certificateID int64) bool {
+	var result bool
+	query := `SELECT true FROM client_certificates WHERE user_id=$1 AND id=$2 LIMIT 1`
+	s.db.QueryRow(query, userID, certificateID).Scan(&result)
+	return result
+}
+
+// ClientCertificates returns the client certificates of the given user.
+func (s *Storage) ClientCertificates(userID int64) (model.ClientCertificates, error) {
+	query := `
+		SELECT
+			id, user_id, name, certificate, private_key, ca_bundle, created_at
+		FROM
+			client_certificates
+		WHERE
+			user_id=$1
+		ORDER BY name ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch client certificates: %v`, err)
+	}
+	defer rows.Close()
+
+	certificates := make(model.ClientCertificates, 0)
+	for rows.Next() {
+		var certificate model.ClientCertificate
+		if err := rows.Scan(
+			&certificate.ID,
+			&certificate.UserID,
+			&certificate.Name,
+			&certificate.Certificate,
+			&certificate.PrivateKey,
+			&certificate.CABundle,
+			&certificate.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch client certificate row: %v`, err)
+		}
+
+		// The certificates that cannot be decrypted with the current key are still listed, so they can be removed.
+		if err := decryptClientCertificate(&certificate); err != nil {
+			slog.Error("Unable to decrypt the client certificate", slog.Int64("certificate_id", certificate.ID), slog.Any("error", err))
+			certificate.Certificate, certificate.PrivateKey, certificate.CABundle = "", "", ""
+		}
+
+		certificates = append(certificates, &certificate)
+	}
+
+	return certificates, nil
+}
+
+// ClientCertificate returns a client certificate of the given user.
+func (s *Storage) ClientCertificate(userID, certificateID int64) (*model.ClientCertificate, error) {
+	query := `
+		SELECT
+			id, user_id, name, certificate, private_key, ca_bundle, created_at
+		FROM
+			client_certificates
+		WHERE
+			id=$1 AND user_id=$2
+	`
+	var certificate model.ClientCertificate
+	err := s.db.QueryRow(query, certificateID, userID).Scan(
+		&certificate.ID,
+		&certificate.UserID,
+		&certificate.Name,
+		&certificate.Certificate,
+		&certificate.PrivateKey,
+		&certificate.CABundle,
+		&certificate.CreatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch client certificate #%d: %v`, certificateID, err)
+	}
+
+	if err := decryptClientCertificate(&certificate); err != nil {
+		return nil, fmt.Errorf(`store: unable to decrypt client certificate #%d: %v`, certificateID, err)
+	}
+
+	return &certificate, nil
+}
+
+// CreateClientCertificate stores a client certificate, the certificate, its key and the CA bundle are encrypted
+// when an encryption key is configured. The saved certificates are never modified, only removed.
+func (s *Storage) CreateClientCertificate(userID int64, request *model.ClientCertificateCreationRequest) (*model.ClientCertificate, error) {
+	certificate := &model.ClientCertificate{
+		UserID:      userID,
+		Name:        request.Name,
+		Certificate: request.Certificate,
+		PrivateKey:  request.PrivateKey,
+		CABundle:    request.CABundle,
+	}
+
+	var encrypted [3]string
+	for i, value := range []string{certificate.Certificate, certificate.PrivateKey, certificate.CABundle} {
+		var err error
+		if encrypted[i], err = encryptSecret(value); err != nil {
+			return nil, fmt.Errorf(`store: unable to encrypt client certificate %q: %v`, certificate.Name, err)
+		}
+	}
+
+	query := `
+		INSERT INTO client_certificates
+			(user_id, name, certificate, private_key, ca_bundle)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		userID,
+		certificate.Name,
+		encrypted[0],
+		encrypted[1],
+		encrypted[2],
+	).Scan(&certificate.ID, &certificate.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to create client certificate %q: %v`, certificate.Name, err)
+	}
+
+	return certificate, nil
+}
+
+// RemoveClientCertificate deletes a client certificate, the feeds using it are fetched without it.
+func (s *Storage) RemoveClientCertificate(userID, certificateID int64) error {
+	result, err := s.db.Exec(`DELETE FROM client_certificates WHERE id=$1 AND user_id=$2`, certificateID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove client certificate #%d: %v`, certificateID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove client certificate #%d: %v`, certificateID, err)
+	}
+
+	if count == 0 {
+		return ErrClientCertificateNotFound
+	}
+
+	return nil
+}
+
+func decryptClientCertificate(certificate *model.ClientCertificate) error {
+	var err error
+	if certificate.Certificate, err = decryptSecret(certificate.Certificate); err != nil {
+		return err
+	}
+	if certificate.PrivateKey, err = decryptSecret(certificate.PrivateKey); err != nil {
+		return err
+	}
+	certificate.CABundle, err = decryptSecret(certificate.CABundle)
+	return err
+}
@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/urllib"
+)
+
+var ErrDomainRuleNotFound = errors.New("store: domain rule not found")
+
+// DomainRuleExists checks if a rule for the given domain exists in the same scope.
+// A zero ownerID refers to the global rules.
+func (s *Storage) DomainRuleExists(ownerID int64, domain string) bool {
+	var result bool
+	query := `SELECT true FROM domain_rules WHERE coalesce(user_id, 0)=$1 AND domain=$2 LIMIT 1`
+	s.db.QueryRow(query, ownerID, domain).Scan(&result)
+	return result
+}
+
+// AnotherDomainRuleExists checks if another rule for the given domain exists in the same scope.
+func (s *Storage) AnotherDomainRuleExists(ownerID, ruleID int64, domain string) bool {
+	var result bool
+	query := `SELECT true FROM domain_rules WHERE coalesce(user_id, 0)=$1 AND id <> $2 AND domain=$3 LIMIT 1`
+	s.db.QueryRow(query, ownerID, ruleID, domain).Scan(&result)
+	return result
+}
+
+// DomainRules returns the global rules and the personal rules of the given user.
+func (s *Storage) DomainRules(userID int64) (model.DomainRules, error) {
+	query := `
+		SELECT
+			id, coalesce(user_id, 0), domain, scraper_rules, rewrite_rules, created_at, updated_at
+		FROM
+			domain_rules
+		WHERE
+			user_id IS NULL OR user_id=$1
+		ORDER BY
+			user_id NULLS FIRST, domain ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch domain rules: %v`, err)
+	}
+	defer rows.Close()
+
+	rules := make(model.DomainRules, 0)
+	for rows.Next() {
+		var rule model.DomainRule
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.UserID,
+			&rule.Domain,
+			&rule.ScraperRules,
+			&rule.RewriteRules,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch domain rule row: %v`, err)
+		}
+
+		rules = append(rules, &rule)
+	}
+
+	return rules, nil
+}
+
+// DomainRule returns a rule visible by the given user.
+func (s *Storage) DomainRule(userID, ruleID int64) (*model.DomainRule, error) {
+	query := `
+		SELECT
+			id, coalesce(user_id, 0), domain, scraper_rules, rewrite_rules, created_at, updated_at
+		FROM
+			domain_rules
+		WHERE
+			id=$1 AND (user_id IS NULL OR user_id=$2)
+	`
+	var rule model.DomainRule
+	err := s.db.QueryRow(query, ruleID, userID).Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Domain,
+		&rule.ScraperRules,
+		&rule.RewriteRules,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch domain rule #%d: %v`, ruleID, err)
+	}
+
+	return &rule, nil
+}
+
+// DomainRuleForURL returns the rules to apply to the given website URL.
+// Personal rules take precedence over global rules, field by field.
+// It returns nil when the registry has no rule for this domain.
+func (s *Storage) DomainRuleForURL(userID int64, websiteURL string) (*model.DomainRule, error) {
+	domain := urllib.DomainWithoutWWW(websiteURL)
+	if domain == "" {
+		return nil, nil
+	}
+
+	query := `
+		SELECT
+			coalesce(user_id, 0), scraper_rules, rewrite_rules
+		FROM
+			domain_rules
+		WHERE
+			domain=$1 AND (user_id IS NULL OR user_id=$2)
+		ORDER BY
+			user_id NULLS LAST
+	`
+	rows, err := s.db.Query(query, domain, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch domain rules for %q: %v`, domain, err)
+	}
+	defer rows.Close()
+
+	var rule *model.DomainRule
+	for rows.Next() {
+		var ownerID int64
+		var scraperRules, rewriteRules string
+		if err := rows.Scan(&ownerID, &scraperRules, &rewriteRules); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch domain rule row: %v`, err)
+		}
+
+		if rule == nil {
+			rule = &model.DomainRule{UserID: ownerID, Domain: domain}
+		}
+		if rule.ScraperRules == "" {
+			rule.ScraperRules = scraperRules
+		}
+		if rule.RewriteRules == "" {
+			rule.RewriteRules = rewriteRules
+		}
+	}
+
+	return rule, nil
+}
+
+// CreateDomainRule inserts a new domain rule.
+func (s *Storage) CreateDomainRule(rule *model.DomainRule) error {
+	query := `
+		INSERT INTO domain_rules
+			(user_id, domain, scraper_rules, rewrite_rules)
+		VALUES
+			(nullif($1, 0), $2, $3, $4)
+		RETURNING
+			id, created_at, updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		rule.UserID,
+		rule.Domain,
+		rule.ScraperRules,
+		rule.RewriteRules,
+	).Scan(
+		&rule.ID,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create domain rule %q: %v`, rule.Domain, err)
+	}
+
+	return nil
+}
+
+// UpdateDomainRule updates an existing domain rule.
+func (s *Storage) UpdateDomainRule(rule *model.DomainRule) error {
+	query := `
+		UPDATE
+			domain_rules
+		SET
+			domain=$1,
+			scraper_rules=$2,
+			rewrite_rules=$3,
+			updated_at=now()
+		WHERE
+			id=$4 AND coalesce(user_id, 0)=$5
+		RETURNING
+			updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		rule.Domain,
+		rule.ScraperRules,
+		rule.RewriteRules,
+		rule.ID,
+		rule.UserID,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update domain rule #%d: %v`, rule.ID, err)
+	}
+
+	return nil
+}
+
+// ImportDomainRules creates or replaces the given rules in a single transaction.
+func (s *Storage) ImportDomainRules(rules model.DomainRules) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
+		INSERT INTO domain_rules
+			(user_id, domain, scraper_rules, rewrite_rules)
+		VALUES
+			(nullif($1, 0), $2, $3, $4)
+		ON CONFLICT ((coalesce(user_id, 0)), domain) DO UPDATE SET
+			scraper_rules=EXCLUDED.scraper_rules,
+			rewrite_rules=EXCLUDED.rewrite_rules,
+			updated_at=now()
+	`
+	for _, rule := range rules {
+		if _, err := tx.Exec(query, rule.UserID, rule.Domain, rule.ScraperRules, rule.RewriteRules); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to import domain rule %q: %v`, rule.Domain, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveDomainRule deletes a domain rule.
+// A zero ownerID refers to the global rules.
+func (s *Storage) RemoveDomainRule(ownerID, ruleID int64) error {
+	result, err := s.db.Exec(`DELETE FROM domain_rules WHERE id=$1 AND coalesce(user_id, 0)=$2`, ruleID, ownerID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove domain rule #%d: %v`, ruleID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove domain rule #%d: %v`, ruleID, err)
+	}
+
+	if count == 0 {
+		return ErrDomainRuleNotFound
+	}
+
+	return nil
+}
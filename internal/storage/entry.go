@@ -210,12 +210,12 @@ func (s *Storage) CreateEntry(tx *sql.Tx, entry *model.Entry) error {
 	return err
 }
 
-// updateEntry updates an entry when a feed is refreshed.
+// updateEntry updates an entry when a feed is refreshed and returns whether its content and its tags changed.
 // Note: we do not update the published date because some feeds do not contains any date,
 // it default to time.Now() which could change the order of items on the history page.
-func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
+func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) (contentChanged, tagsChanged bool, err error) {
 	if err := s.updateEntryMedia(tx, entry); err != nil {
-		return fmt.Errorf(`unable to update entry medias %q: %v`, entry.URL, err)
+		return false, false, fmt.Errorf(`unable to update entry medias %q: %v`, entry.URL, err)
 	}
 
 	truncatedTitle, truncatedContent := truncateTitleAndContentForTSVectorField(entry.Title, entry.Content)
@@ -233,12 +233,22 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			cover_image=$9, 
 			image_count=$10,
 			tags=$14
+		FROM
+			(
+				SELECT id, title, url, comments_url, content, author, reading_time, cover_image, image_count, tags
+				FROM entries
+				WHERE user_id=$11 AND feed_id=$12 AND hash=$13
+			) AS previous
 		WHERE
-			user_id=$11 AND feed_id=$12 AND hash=$13
+			entries.id=previous.id
 		RETURNING
-			id
+			entries.id,
+			(previous.title, previous.url, previous.comments_url, previous.content, previous.author, previous.reading_time, previous.cover_image, previous.image_count)
+				IS DISTINCT FROM
+			(entries.title, entries.url, entries.comments_url, entries.content, entries.author, entries.reading_time, entries.cover_image, entries.image_count),
+			previous.tags IS DISTINCT FROM entries.tags
 	`
-	err := tx.QueryRow(
+	err = tx.QueryRow(
 		query,
 		entry.Title,
 		entry.URL,
@@ -254,9 +264,9 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.Hash,
 		pq.Array(entry.Tags),
-	).Scan(&entry.ID)
+	).Scan(&entry.ID, &contentChanged, &tagsChanged)
 	if err != nil {
-		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
+		return false, false, fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
 	}
 
 	for _, enclosure := range entry.Enclosures {
@@ -264,7 +274,7 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		enclosure.EntryID = entry.ID
 	}
 
-	return s.updateEnclosures(tx, entry)
+	return contentChanged, tagsChanged, s.updateEnclosures(tx, entry)
 }
 
 // EditEntry updates an entry when a feed is edited.
@@ -294,7 +304,7 @@ func (s *Storage) EditEntry(entry *model.Entry) error {
 	if err != nil {
 		return err
 	}
-	err = s.updateEntry(tx, entry)
+	_, _, err = s.updateEntry(tx, entry)
 	if err != nil {
 		return err
 	}
@@ -313,6 +323,37 @@ func (s *Storage) EntryExists(entry *model.Entry) bool {
 	return result
 }
 
+// EntriesByURL returns the most recent entry of the user for each URL, the removed entries are ignored.
+// Only the ID, the feed ID, the URL, the status and the starred flag of the entries are set.
+func (s *Storage) EntriesByURL(userID int64, entryURLs []string) (map[string]*model.Entry, error) {
+	query := `
+		SELECT DISTINCT ON (url)
+			id, feed_id, url, status, starred
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND url=ANY($2) AND status <> $3
+		ORDER BY
+			url, published_at DESC, id DESC
+	`
+	rows, err := s.db.Query(query, userID, pq.Array(entryURLs), model.EntryStatusRemoved)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entries by URL: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(map[string]*model.Entry)
+	for rows.Next() {
+		entry := &model.Entry{UserID: userID}
+		if err := rows.Scan(&entry.ID, &entry.FeedID, &entry.URL, &entry.Status, &entry.Starred); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entries by URL: %v`, err)
+		}
+		entries[entry.URL] = entry
+	}
+
+	return entries, nil
+}
+
 func (s *Storage) entryExists(tx *sql.Tx, entry *model.Entry) (bool, error) {
 	var result bool
 
@@ -424,29 +465,65 @@ func (s *Storage) ClearRemovedEntriesContent(limit int) (int64, error) {
 }
 
 // RefreshFeedEntries updates feed entries while refreshing a feed.
-func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, err error) {
+// It returns the new entries, the number of existing entries whose content changed, and the IDs of the existing entries whose tags changed.
+func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, updatedEntries int, retaggedEntryIDs []int64, err error) {
+	newEntries, updatedEntries, retaggedEntryIDs, err = s.storeFeedEntries(userID, feedID, entries, updateExistingEntries)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
 	entryHashes := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entryHashes = append(entryHashes, entry.Hash)
+	}
+
+	go func() {
+		if err := s.cleanupRemovedEntriesNotInFeed(feedID, entryHashes); err != nil {
+			slog.Error("Unable to cleanup removed entries",
+				slog.Int64("user_id", userID),
+				slog.Int64("feed_id", feedID),
+				slog.Any("error", err),
+			)
+		}
+	}()
+
+	return newEntries, updatedEntries, retaggedEntryIDs, nil
+}
 
+// AppendFeedEntries stores a partial list of entries of a feed, like a pushed update or an archive page.
+// Unlike RefreshFeedEntries, removed entries missing from the list are kept.
+func (s *Storage) AppendFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, updatedEntries int, retaggedEntryIDs []int64, err error) {
+	return s.storeFeedEntries(userID, feedID, entries, updateExistingEntries)
+}
+
+func (s *Storage) storeFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, updatedEntries int, retaggedEntryIDs []int64, err error) {
 	for _, entry := range entries {
 		entry.UserID = userID
 		entry.FeedID = feedID
 
 		tx, err := s.db.Begin()
 		if err != nil {
-			return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
+			return nil, 0, nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
 		}
 
 		entryExists, err := s.entryExists(tx, entry)
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				return nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
+				return nil, 0, nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
 			}
-			return nil, err
+			return nil, 0, nil, err
 		}
 
 		if entryExists {
 			if updateExistingEntries {
-				err = s.updateEntry(tx, entry)
+				var contentChanged, tagsChanged bool
+				contentChanged, tagsChanged, err = s.updateEntry(tx, entry)
+				if err == nil && contentChanged {
+					updatedEntries++
+				}
+				if err == nil && tagsChanged {
+					retaggedEntryIDs = append(retaggedEntryIDs, entry.ID)
+				}
 			}
 		} else {
 			err = s.CreateEntry(tx, entry)
@@ -457,29 +534,17 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
-				return nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
+				return nil, 0, nil, fmt.Errorf(`store: unable to rollback transaction: %v (rolled back due to: %v)`, rollbackErr, err)
 			}
-			return nil, err
+			return nil, 0, nil, err
 		}
 
 		if err := tx.Commit(); err != nil {
-			return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+			return nil, 0, nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
 		}
-
-		entryHashes = append(entryHashes, entry.Hash)
 	}
 
-	go func() {
-		if err := s.cleanupRemovedEntriesNotInFeed(feedID, entryHashes); err != nil {
-			slog.Error("Unable to cleanup removed entries",
-				slog.Int64("user_id", userID),
-				slog.Int64("feed_id", feedID),
-				slog.Any("error", err),
-			)
-		}
-	}()
-
-	return newEntries, nil
+	return newEntries, updatedEntries, retaggedEntryIDs, nil
 }
 
 // ArchiveEntries changes the status of entries to "removed" after the interval (24h minimum).
@@ -629,25 +694,24 @@ func (s *Storage) FlushHistory(userID int64) error {
 	return nil
 }
 
-// MarkAllAsRead updates all user entries to the read status.
-func (s *Storage) MarkAllAsRead(userID int64) error {
-	query := `UPDATE entries SET status=$1, changed_at=now() WHERE user_id=$2 AND status=$3`
-	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread)
+// MarkAllAsRead updates all user entries to the read status and returns their IDs.
+func (s *Storage) MarkAllAsRead(userID int64) ([]int64, error) {
+	query := `UPDATE entries SET status=$1, changed_at=now() WHERE user_id=$2 AND status=$3 RETURNING id`
+	entryIDs, err := s.fetchUpdatedEntryIDs(query, model.EntryStatusRead, userID, model.EntryStatusUnread)
 	if err != nil {
-		return fmt.Errorf(`store: unable to mark all entries as read: %v`, err)
+		return nil, fmt.Errorf(`store: unable to mark all entries as read: %v`, err)
 	}
 
-	count, _ := result.RowsAffected()
 	slog.Debug("Marked all entries as read",
 		slog.Int64("user_id", userID),
-		slog.Int64("nb_entries", count),
+		slog.Int("nb_entries", len(entryIDs)),
 	)
 
-	return nil
+	return entryIDs, nil
 }
 
-// MarkAllAsReadBeforeDate updates all user entries to the read status before the given date.
-func (s *Storage) MarkAllAsReadBeforeDate(userID int64, before time.Time) error {
+// MarkAllAsReadBeforeDate updates all user entries to the read status before the given date and returns their IDs.
+func (s *Storage) MarkAllAsReadBeforeDate(userID int64, before time.Time) ([]int64, error) {
 	query := `
 		UPDATE
 			entries
@@ -656,22 +720,23 @@ func (s *Storage) MarkAllAsReadBeforeDate(userID int64, before time.Time) error
 			changed_at=now()
 		WHERE
 			user_id=$2 AND status=$3 AND published_at < $4
+		RETURNING
+			id
 	`
-	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread, before)
+	entryIDs, err := s.fetchUpdatedEntryIDs(query, model.EntryStatusRead, userID, model.EntryStatusUnread, before)
 	if err != nil {
-		return fmt.Errorf(`store: unable to mark all entries as read before %s: %v`, before.Format(time.RFC3339), err)
+		return nil, fmt.Errorf(`store: unable to mark all entries as read before %s: %v`, before.Format(time.RFC3339), err)
 	}
-	count, _ := result.RowsAffected()
 	slog.Debug("Marked all entries as read before date",
 		slog.Int64("user_id", userID),
-		slog.Int64("nb_entries", count),
+		slog.Int("nb_entries", len(entryIDs)),
 		slog.String("before", before.Format(time.RFC3339)),
 	)
-	return nil
+	return entryIDs, nil
 }
 
-// MarkAllAsReadExceptNSFW updates all user entries except nsfw ones to the read status
-func (s *Storage) MarkAllAsReadExceptNSFW(userID int64) error {
+// MarkAllAsReadExceptNSFW updates all user entries except nsfw ones to the read status and returns their IDs.
+func (s *Storage) MarkAllAsReadExceptNSFW(userID int64) ([]int64, error) {
 	query := `
 		UPDATE entries 
 		SET status=$1
@@ -681,23 +746,23 @@ func (s *Storage) MarkAllAsReadExceptNSFW(userID int64) error {
 			INNER JOIN entries e ON f.id = e.feed_id
 			WHERE e.user_id=$2 AND e.status=$3 AND f.nsfw = 'f'
 		)
+		RETURNING id
 	`
-	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread)
+	entryIDs, err := s.fetchUpdatedEntryIDs(query, model.EntryStatusRead, userID, model.EntryStatusUnread)
 	if err != nil {
-		return fmt.Errorf(`store: unable to mark all entries as read: %v`, err)
+		return nil, fmt.Errorf(`store: unable to mark all entries as read: %v`, err)
 	}
 
-	count, _ := result.RowsAffected()
 	slog.Debug(
 		"Storage:MarkAllAsReadExceptNSFW",
-		slog.Int64("user_id", userID), slog.Int64("nb_entries", count),
+		slog.Int64("user_id", userID), slog.Int("nb_entries", len(entryIDs)),
 	)
 
-	return nil
+	return entryIDs, nil
 }
 
-// MarkFeedAsRead updates all feed entries to the read status.
-func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
+// MarkFeedAsRead updates all feed entries to the read status and returns their IDs.
+func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) ([]int64, error) {
 	query := `
 		UPDATE
 			entries
@@ -706,25 +771,26 @@ func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
 			changed_at=now()
 		WHERE
 			user_id=$2 AND feed_id=$3 AND status=$4 AND published_at < $5
+		RETURNING
+			id
 	`
-	result, err := s.db.Exec(query, model.EntryStatusRead, userID, feedID, model.EntryStatusUnread, before)
+	entryIDs, err := s.fetchUpdatedEntryIDs(query, model.EntryStatusRead, userID, feedID, model.EntryStatusUnread, before)
 	if err != nil {
-		return fmt.Errorf(`store: unable to mark feed entries as read: %v`, err)
+		return nil, fmt.Errorf(`store: unable to mark feed entries as read: %v`, err)
 	}
 
-	count, _ := result.RowsAffected()
 	slog.Debug("Marked feed entries as read",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", feedID),
-		slog.Int64("nb_entries", count),
+		slog.Int("nb_entries", len(entryIDs)),
 		slog.String("before", before.Format(time.RFC3339)),
 	)
 
-	return nil
+	return entryIDs, nil
 }
 
-// MarkCategoryAsRead updates all category entries to the read status.
-func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time) error {
+// MarkCategoryAsRead updates all category entries to the read status and returns their IDs.
+func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time) ([]int64, error) {
 	query := `
 		UPDATE
 			entries
@@ -743,21 +809,42 @@ func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time)
 			published_at < $4
 		AND
 			feeds.category_id=$5
+		RETURNING
+			entries.id
 	`
-	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread, before, categoryID)
+	entryIDs, err := s.fetchUpdatedEntryIDs(query, model.EntryStatusRead, userID, model.EntryStatusUnread, before, categoryID)
 	if err != nil {
-		return fmt.Errorf(`store: unable to mark category entries as read: %v`, err)
+		return nil, fmt.Errorf(`store: unable to mark category entries as read: %v`, err)
 	}
 
-	count, _ := result.RowsAffected()
 	slog.Debug("Marked category entries as read",
 		slog.Int64("user_id", userID),
 		slog.Int64("category_id", categoryID),
-		slog.Int64("nb_entries", count),
+		slog.Int("nb_entries", len(entryIDs)),
 		slog.String("before", before.Format(time.RFC3339)),
 	)
 
-	return nil
+	return entryIDs, nil
+}
+
+// fetchUpdatedEntryIDs runs an update query returning the IDs of the updated entries.
+func (s *Storage) fetchUpdatedEntryIDs(query string, args ...any) ([]int64, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entryIDs []int64
+	for rows.Next() {
+		var entryID int64
+		if err := rows.Scan(&entryID); err != nil {
+			return nil, err
+		}
+		entryIDs = append(entryIDs, entryID)
+	}
+
+	return entryIDs, rows.Err()
 }
 
 // EntryShareCode returns the share code of the provided entry.
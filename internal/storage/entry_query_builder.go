@@ -73,6 +73,13 @@ func (e *EntryQueryBuilder) AfterChangedDate(date time.Time) *EntryQueryBuilder
 	return e
 }
 
+// AfterCreatedDate adds a condition > created_at
+func (e *EntryQueryBuilder) AfterCreatedDate(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.created_at > $"+strconv.Itoa(len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
 // BeforePublishedDate adds a condition < published_at
 func (e *EntryQueryBuilder) BeforePublishedDate(date time.Time) *EntryQueryBuilder {
 	e.conditions = append(e.conditions, "e.published_at < $"+strconv.Itoa(len(e.args)+1))
@@ -177,6 +184,29 @@ func (e *EntryQueryBuilder) WithTags(tags []string) *EntryQueryBuilder {
 	return e
 }
 
+// WithCategoriesFeedsOrTags filters the entries of one of the categories or feeds, or having one of the tags.
+// The titles and the tags are not case-sensitive, no filter is added when all the lists are empty.
+func (e *EntryQueryBuilder) WithCategoriesFeedsOrTags(categoryTitles, feedTitles, tags []string) *EntryQueryBuilder {
+	if len(categoryTitles) == 0 && len(feedTitles) == 0 && len(tags) == 0 {
+		return e
+	}
+
+	lower := func(values []string) pq.StringArray {
+		lowered := make(pq.StringArray, len(values))
+		for i, value := range values {
+			lowered[i] = strings.ToLower(value)
+		}
+		return lowered
+	}
+
+	e.conditions = append(e.conditions, fmt.Sprintf(
+		"(LOWER(c.title) = ANY($%d) OR LOWER(f.title) = ANY($%d) OR $%d::text[] && LOWER(e.tags::text)::text[])",
+		len(e.args)+1, len(e.args)+2, len(e.args)+3,
+	))
+	e.args = append(e.args, lower(categoryTitles), lower(feedTitles), lower(tags))
+	return e
+}
+
 // WithoutStatus set the entry status that should not be returned.
 func (e *EntryQueryBuilder) WithoutStatus(status string) *EntryQueryBuilder {
 	if status != "" {
@@ -303,7 +333,6 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			f.cookie,
 			f.nsfw,
 			f.no_media_player,
-			f.webhook_url,
 			f.cache_media,
 			fi.icon_id,
 			i.external_id AS icon_external_id,
@@ -374,7 +403,6 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.Feed.Cookie,
 			&entry.Feed.NSFW,
 			&entry.Feed.NoMediaPlayer,
-			&entry.Feed.WebhookURL,
 			&entry.Feed.CacheMedia,
 			&iconID,
 			&externalIconID,
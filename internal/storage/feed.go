@@ -68,6 +68,22 @@ func (s *Storage) FeedURLExists(userID int64, feedURL string) bool {
 	return result
 }
 
+// FeedIDByURL returns the ID of the feed of the user with the given URL, 0 if the feed does not exist.
+func (s *Storage) FeedIDByURL(userID int64, feedURL string) (int64, error) {
+	var feedID int64
+	query := `SELECT id FROM feeds WHERE user_id=$1 AND feed_url=$2 LIMIT 1`
+	err := s.db.QueryRow(query, userID, feedURL).Scan(&feedID)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to fetch feed %q: %v`, feedURL, err)
+	}
+
+	return feedID, nil
+}
+
 // AnotherFeedURLExists checks if the user a duplicated feed.
 func (s *Storage) AnotherFeedURLExists(userID, feedID int64, feedURL string) bool {
 	var result bool
@@ -152,6 +168,17 @@ func (s *Storage) CountAllFeedsWithErrors() int {
 	return result
 }
 
+// CountAllFeedsWithCustomHeaders returns the number of feeds with custom headers.
+func (s *Storage) CountAllFeedsWithCustomHeaders() (int, error) {
+	query := `SELECT count(*) FROM feeds WHERE custom_headers <> ''`
+	var result int
+	if err := s.db.QueryRow(query).Scan(&result); err != nil {
+		return 0, fmt.Errorf(`store: unable to count feeds with custom headers: %v`, err)
+	}
+
+	return result, nil
+}
+
 // Feeds returns all feeds that belongs to the given user.
 func (s *Storage) Feeds(userID int64, nsfw bool) (model.Feeds, error) {
 	builder := NewFeedQueryBuilder(s, userID)
@@ -202,6 +229,50 @@ func (s *Storage) FeedsByCategoryWithCounters(userID, categoryID int64, nsfw boo
 	return getFeedsSorted(builder)
 }
 
+// FeedPublishingProfile counts the entries published by a feed during the last weeks,
+// by day of the week and hour of the day in the given timezone.
+func (s *Storage) FeedPublishingProfile(userID, feedID int64, timezone string) (*model.PublishingProfile, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	query := `
+		SELECT
+			EXTRACT(dow FROM published_at AT TIME ZONE $3)::int,
+			EXTRACT(hour FROM published_at AT TIME ZONE $3)::int,
+			count(*),
+			EXTRACT(epoch FROM now() - min(min(published_at)) OVER ())
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND
+			feed_id=$2 AND
+			published_at > now() - make_interval(weeks => $4) AND
+			published_at <= now()
+		GROUP BY 1, 2
+	`
+	rows, err := s.db.Query(query, userID, feedID, location.String(), model.PublishingProfileWeeks)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch publishing profile for feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	var counts [7][24]int
+	var observedSeconds float64
+	for rows.Next() {
+		var day, hour, count int
+		if err := rows.Scan(&day, &hour, &count, &observedSeconds); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch publishing profile row: %v`, err)
+		}
+		counts[day][hour] = count
+	}
+
+	profile := model.NewPublishingProfile(observedSeconds/(7*24*time.Hour).Seconds(), location)
+	profile.Counts = counts
+	return profile, nil
+}
+
 // WeeklyFeedEntryCount returns the weekly entry count for a feed.
 func (s *Storage) WeeklyFeedEntryCount(userID, feedID int64) (int, error) {
 	// Calculate a virtual weekly count based on the average updating frequency.
@@ -279,18 +350,25 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			nsfw,
 			url_rewrite_rules,
 			no_media_player,
-			apprise_service_urls,
-			webhook_url,
 			disable_http2,
 			description,
-			proxy_url
+			proxy_url,
+			extractor,
+			detected_extractor,
+			custom_headers,
+			client_certificate_id
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, nullif($32, 0))
 		RETURNING
 			id
 	`
-	err := s.db.QueryRow(
+	customHeaders, err := encryptSecret(feed.CustomHeaders)
+	if err != nil {
+		return fmt.Errorf(`store: unable to encrypt custom headers of feed %q: %v`, feed.FeedURL, err)
+	}
+
+	err = s.db.QueryRow(
 		sql,
 		feed.FeedURL,
 		feed.SiteURL,
@@ -317,11 +395,13 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.NSFW,
 		feed.UrlRewriteRules,
 		feed.NoMediaPlayer,
-		feed.AppriseServiceURLs,
-		feed.WebhookURL,
 		feed.DisableHTTP2,
 		feed.Description,
 		feed.ProxyURL,
+		feed.Extractor,
+		feed.DetectedExtractor,
+		customHeaders,
+		feed.ClientCertificateID,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -395,21 +475,24 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			nsfw=$26,
 			url_rewrite_rules=$27,
 			no_media_player=$28,
-			apprise_service_urls=$29,
-			webhook_url=$30,
-			disable_http2=$31,
-			description=$32,
-			ntfy_enabled=$33,
-			ntfy_priority=$34,
-			ntfy_topic=$35,
-			pushover_enabled=$36,
-			pushover_priority=$37,
-			proxy_url=$38,
-			cache_media=$39,
-			view=$40
+			disable_http2=$29,
+			description=$30,
+			proxy_url=$31,
+			cache_media=$32,
+			view=$33,
+			extractor=$34,
+			detected_extractor=$35,
+			custom_headers=CASE WHEN $40 AND $36 = '' THEN custom_headers ELSE $36 END,
+			client_certificate_id=nullif($37, 0)
 		WHERE
-			id=$41 AND user_id=$42
+			id=$38 AND user_id=$39
 	`
+	// The custom headers that could not be decrypted are kept until new ones are set or they are cleared explicitly.
+	customHeaders, err := encryptSecret(feed.CustomHeaders)
+	if err != nil {
+		return fmt.Errorf(`store: unable to encrypt custom headers of feed #%d: %v`, feed.ID, err)
+	}
+
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
 		feed.SiteURL,
@@ -439,20 +522,18 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.NSFW,
 		feed.UrlRewriteRules,
 		feed.NoMediaPlayer,
-		feed.AppriseServiceURLs,
-		feed.WebhookURL,
 		feed.DisableHTTP2,
 		feed.Description,
-		feed.NtfyEnabled,
-		feed.NtfyPriority,
-		feed.NtfyTopic,
-		feed.PushoverEnabled,
-		feed.PushoverPriority,
 		feed.ProxyURL,
 		feed.CacheMedia,
 		feed.View,
+		feed.Extractor,
+		feed.DetectedExtractor,
+		customHeaders,
+		feed.ClientCertificateID,
 		feed.ID,
 		feed.UserID,
+		feed.CustomHeadersUnreadable,
 	)
 
 	if err != nil {
@@ -462,6 +543,37 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 	return nil
 }
 
+// UpdateFeedDetectedExtractor remembers the content extractor that works best for the feed.
+func (s *Storage) UpdateFeedDetectedExtractor(feedID int64, extractor string) error {
+	query := `UPDATE feeds SET detected_extractor=$1 WHERE id=$2`
+	if _, err := s.db.Exec(query, extractor, feedID); err != nil {
+		return fmt.Errorf(`store: unable to update detected extractor of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// FeedBackfillURL returns the archive page where the next backfill of the feed resumes, empty to start from the feed.
+func (s *Storage) FeedBackfillURL(userID, feedID int64) (string, error) {
+	var backfillURL string
+	query := `SELECT backfill_url FROM feeds WHERE user_id=$1 AND id=$2`
+	if err := s.db.QueryRow(query, userID, feedID).Scan(&backfillURL); err != nil {
+		return "", fmt.Errorf(`store: unable to fetch backfill URL of feed #%d: %v`, feedID, err)
+	}
+
+	return backfillURL, nil
+}
+
+// UpdateFeedBackfillURL remembers the archive page where the next backfill of the feed resumes.
+func (s *Storage) UpdateFeedBackfillURL(userID, feedID int64, backfillURL string) error {
+	query := `UPDATE feeds SET backfill_url=$1 WHERE user_id=$2 AND id=$3`
+	if _, err := s.db.Exec(query, backfillURL, userID, feedID); err != nil {
+		return fmt.Errorf(`store: unable to update backfill URL of feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
 // UpdateFeedError updates feed errors.
 func (s *Storage) UpdateFeedError(feed *model.Feed) (err error) {
 	query := `
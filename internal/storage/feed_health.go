@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// FeedHealth returns the redirect and failure tracking of a feed.
+func (s *Storage) FeedHealth(userID, feedID int64) (*model.FeedHealth, error) {
+	query := `
+		SELECT
+			h.feed_id, h.redirect_url, h.redirect_count, h.previous_feed_url, h.moved_at, h.dead_since, h.dead_notified_at, h.alternative_urls
+		FROM
+			feed_health h
+		JOIN
+			feeds f ON f.id=h.feed_id
+		WHERE
+			f.user_id=$1 AND h.feed_id=$2
+	`
+	var health model.FeedHealth
+	err := s.db.QueryRow(query, userID, feedID).Scan(
+		&health.FeedID,
+		&health.RedirectURL,
+		&health.RedirectCount,
+		&health.PreviousFeedURL,
+		&health.MovedAt,
+		&health.DeadSince,
+		&health.DeadNotifiedAt,
+		pq.Array(&health.AlternativeURLs),
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch health of feed #%d: %v`, feedID, err)
+	}
+
+	return &health, nil
+}
+
+// SaveFeedHealth creates or updates the redirect and failure tracking of a feed.
+func (s *Storage) SaveFeedHealth(health *model.FeedHealth) error {
+	query := `
+		INSERT INTO feed_health
+			(feed_id, redirect_url, redirect_count, previous_feed_url, moved_at, dead_since, dead_notified_at, alternative_urls)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (feed_id) DO UPDATE SET
+			redirect_url = EXCLUDED.redirect_url,
+			redirect_count = EXCLUDED.redirect_count,
+			previous_feed_url = EXCLUDED.previous_feed_url,
+			moved_at = EXCLUDED.moved_at,
+			dead_since = EXCLUDED.dead_since,
+			dead_notified_at = EXCLUDED.dead_notified_at,
+			alternative_urls = EXCLUDED.alternative_urls
+	`
+	alternativeURLs := health.AlternativeURLs
+	if alternativeURLs == nil {
+		alternativeURLs = []string{}
+	}
+
+	if _, err := s.db.Exec(
+		query,
+		health.FeedID,
+		health.RedirectURL,
+		health.RedirectCount,
+		health.PreviousFeedURL,
+		health.MovedAt,
+		health.DeadSince,
+		health.DeadNotifiedAt,
+		pq.Array(alternativeURLs),
+	); err != nil {
+		return fmt.Errorf(`store: unable to save health of feed #%d: %v`, health.FeedID, err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ package storage // import "miniflux.app/v2/internal/storage"
 import (
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"strconv"
 	"strings"
 
@@ -175,19 +176,21 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			fi.icon_id,
 			i.external_id,
 			u.timezone,
-			f.apprise_service_urls,
-			f.webhook_url,
 			f.disable_http2,
-			f.ntfy_enabled,
-			f.ntfy_priority,
-			f.ntfy_topic,
-			f.pushover_enabled,
-			f.pushover_priority,
-			f.proxy_url
+			f.proxy_url,
+			f.extractor,
+			f.detected_extractor,
+			f.custom_headers,
+			coalesce(f.client_certificate_id, 0),
+			coalesce(cc.certificate, ''),
+			coalesce(cc.private_key, ''),
+			coalesce(cc.ca_bundle, '')
 		FROM
 			feeds f
 		LEFT JOIN
 			categories c ON c.id=f.category_id
+		LEFT JOIN
+			client_certificates cc ON cc.id=f.client_certificate_id
 		LEFT JOIN
 			feed_icons fi ON fi.feed_id=f.id
 		LEFT JOIN
@@ -211,6 +214,11 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 		return nil, err
 	}
 
+	notificationRoutes, err := f.fetchFeedNotificationRoutes()
+	if err != nil {
+		return nil, err
+	}
+
 	feeds := make(model.Feeds, 0)
 	for rows.Next() {
 		var feed model.Feed
@@ -257,21 +265,33 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&iconID,
 			&externalIconID,
 			&tz,
-			&feed.AppriseServiceURLs,
-			&feed.WebhookURL,
 			&feed.DisableHTTP2,
-			&feed.NtfyEnabled,
-			&feed.NtfyPriority,
-			&feed.NtfyTopic,
-			&feed.PushoverEnabled,
-			&feed.PushoverPriority,
 			&feed.ProxyURL,
+			&feed.Extractor,
+			&feed.DetectedExtractor,
+			&feed.CustomHeaders,
+			&feed.ClientCertificateID,
+			&feed.ClientCertificate.Certificate,
+			&feed.ClientCertificate.PrivateKey,
+			&feed.ClientCertificate.CABundle,
 		)
 
 		if err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch feeds row: %w`, err)
 		}
 
+		if feed.CustomHeaders, err = decryptSecret(feed.CustomHeaders); err != nil {
+			slog.Error("Unable to decrypt the custom headers of the feed", slog.Int64("feed_id", feed.ID), slog.Any("error", err))
+			feed.CustomHeaders = ""
+			feed.CustomHeadersUnreadable = true
+		}
+
+		feed.ClientCertificate.ID = feed.ClientCertificateID
+		if err := decryptClientCertificate(&feed.ClientCertificate); err != nil {
+			slog.Error("Unable to decrypt the client certificate of the feed", slog.Int64("feed_id", feed.ID), slog.Any("error", err))
+			feed.ClientCertificate = model.ClientCertificate{}
+		}
+
 		if iconID.Valid && externalIconID.Valid {
 			feed.Icon = &model.FeedIcon{FeedID: feed.ID, IconID: iconID.Int64, ExternalIconID: externalIconID.String}
 		} else {
@@ -289,6 +309,7 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			}
 		}
 
+		feed.WithNotificationRoutes(notificationRoutes[feed.ID])
 		feed.NumberOfVisibleEntries = feed.ReadCount + feed.UnreadCount
 		feed.CheckedAt = timezone.Convert(tz, feed.CheckedAt)
 		feed.NextCheckAt = timezone.Convert(tz, feed.NextCheckAt)
@@ -348,3 +369,36 @@ func (f *FeedQueryBuilder) fetchFeedCounter() (unreadCounters map[int64]int, rea
 
 	return readCounters, unreadCounters, nil
 }
+
+// fetchFeedNotificationRoutes returns the notification routes of the user restricted to a feed, by feed.
+func (f *FeedQueryBuilder) fetchFeedNotificationRoutes() (map[int64]model.NotificationRoutes, error) {
+	query := `
+		SELECT
+			feed_id,
+			provider,
+			target,
+			priority
+		FROM
+			notification_routes
+		WHERE
+			user_id=$1 AND feed_id IS NOT NULL
+		ORDER BY
+			id ASC
+	`
+	rows, err := f.store.db.Query(query, f.args[0])
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch feed notification routes: %w`, err)
+	}
+	defer rows.Close()
+
+	routes := make(map[int64]model.NotificationRoutes)
+	for rows.Next() {
+		var route model.NotificationRoute
+		if err := rows.Scan(&route.FeedID, &route.Provider, &route.Target, &route.Priority); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed notification route row: %w`, err)
+		}
+		routes[route.FeedID] = append(routes[route.FeedID], &route)
+	}
+
+	return routes, nil
+}
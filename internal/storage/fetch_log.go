@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// CreateFeedFetchLog records a feed refresh and keeps only the most recent logs of the feed.
+func (s *Storage) CreateFeedFetchLog(fetchLog *model.FeedFetchLog, keep int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
+		INSERT INTO feed_fetch_logs
+			(feed_id, fetched_at, status_code, effective_url, redirects, not_modified, etag, last_modified, content_length, duration_ms, proxy_url, new_entries, updated_entries, error_message)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING
+			id
+	`
+	if err := tx.QueryRow(
+		query,
+		fetchLog.FeedID,
+		fetchLog.FetchedAt,
+		fetchLog.StatusCode,
+		fetchLog.EffectiveURL,
+		pq.Array(fetchLog.Redirects),
+		fetchLog.NotModified,
+		fetchLog.ETag,
+		fetchLog.LastModified,
+		fetchLog.ContentLength,
+		fetchLog.DurationMs,
+		fetchLog.ProxyURL,
+		fetchLog.NewEntries,
+		fetchLog.UpdatedEntries,
+		fetchLog.ErrorMessage,
+	).Scan(&fetchLog.ID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to create fetch log for feed #%d: %v`, fetchLog.FeedID, err)
+	}
+
+	query = `
+		DELETE FROM feed_fetch_logs
+		WHERE feed_id=$1 AND id NOT IN (
+			SELECT id FROM feed_fetch_logs WHERE feed_id=$1 ORDER BY id DESC LIMIT $2
+		)
+	`
+	if _, err := tx.Exec(query, fetchLog.FeedID, keep); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to trim fetch logs for feed #%d: %v`, fetchLog.FeedID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// FeedFetchLogs returns the fetch logs of a feed, the most recent first.
+func (s *Storage) FeedFetchLogs(userID, feedID int64) (model.FeedFetchLogs, error) {
+	query := `
+		SELECT
+			l.id, l.feed_id, l.fetched_at, l.status_code, l.effective_url, l.redirects, l.not_modified, l.etag, l.last_modified,
+			l.content_length, l.duration_ms, l.proxy_url, l.new_entries, l.updated_entries, l.error_message
+		FROM
+			feed_fetch_logs l
+		JOIN
+			feeds f ON f.id=l.feed_id
+		WHERE
+			f.user_id=$1 AND l.feed_id=$2
+		ORDER BY
+			l.id DESC
+	`
+	rows, err := s.db.Query(query, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch fetch logs of feed #%d: %v`, feedID, err)
+	}
+	defer rows.Close()
+
+	fetchLogs := make(model.FeedFetchLogs, 0)
+	for rows.Next() {
+		var fetchLog model.FeedFetchLog
+		if err := rows.Scan(
+			&fetchLog.ID,
+			&fetchLog.FeedID,
+			&fetchLog.FetchedAt,
+			&fetchLog.StatusCode,
+			&fetchLog.EffectiveURL,
+			pq.Array(&fetchLog.Redirects),
+			&fetchLog.NotModified,
+			&fetchLog.ETag,
+			&fetchLog.LastModified,
+			&fetchLog.ContentLength,
+			&fetchLog.DurationMs,
+			&fetchLog.ProxyURL,
+			&fetchLog.NewEntries,
+			&fetchLog.UpdatedEntries,
+			&fetchLog.ErrorMessage,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch fetch log row: %v`, err)
+		}
+		fetchLogs = append(fetchLogs, &fetchLog)
+	}
+
+	return fetchLogs, nil
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// HostLimits returns the hosts that are still blocked.
+func (s *Storage) HostLimits() ([]*model.HostLimit, error) {
+	query := `
+		SELECT
+			host, blocked_until, backoff_seconds, updated_at
+		FROM
+			host_limits
+		WHERE
+			blocked_until > now()
+		ORDER BY
+			host ASC
+	`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch host limits: %v`, err)
+	}
+	defer rows.Close()
+
+	var limits []*model.HostLimit
+	for rows.Next() {
+		var limit model.HostLimit
+		var backoffSeconds int
+		if err := rows.Scan(&limit.Host, &limit.BlockedUntil, &backoffSeconds, &limit.UpdatedAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch host limit row: %v`, err)
+		}
+		limit.Backoff = time.Duration(backoffSeconds) * time.Second
+		limits = append(limits, &limit)
+	}
+
+	return limits, nil
+}
+
+// SaveHostLimit creates or updates the rate limiting state of a host.
+func (s *Storage) SaveHostLimit(limit *model.HostLimit) error {
+	query := `
+		INSERT INTO host_limits
+			(host, blocked_until, backoff_seconds)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (host) DO UPDATE SET
+			blocked_until = EXCLUDED.blocked_until,
+			backoff_seconds = EXCLUDED.backoff_seconds,
+			updated_at = now()
+	`
+	if _, err := s.db.Exec(query, limit.Host, limit.BlockedUntil, int(limit.Backoff.Seconds())); err != nil {
+		return fmt.Errorf(`store: unable to save host limit for %q: %v`, limit.Host, err)
+	}
+
+	return nil
+}
+
+// RemoveHostLimit deletes the rate limiting state of a host.
+func (s *Storage) RemoveHostLimit(host string) error {
+	if _, err := s.db.Exec(`DELETE FROM host_limits WHERE host=$1`, host); err != nil {
+		return fmt.Errorf(`store: unable to remove host limit for %q: %v`, host, err)
+	}
+
+	return nil
+}
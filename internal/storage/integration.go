@@ -4,9 +4,13 @@
 package storage // import "miniflux.app/v2/internal/storage"
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 	"miniflux.app/v2/internal/model"
 )
@@ -110,126 +114,15 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 	query := `
 		SELECT
 			user_id,
-			pinboard_enabled,
-			pinboard_token,
-			pinboard_tags,
-			pinboard_mark_as_unread,
-			instapaper_enabled,
-			instapaper_username,
-			instapaper_password,
 			fever_enabled,
 			fever_username,
 			fever_token,
 			googlereader_enabled,
 			googlereader_username,
 			googlereader_password,
-			wallabag_enabled,
-			wallabag_only_url,
-			wallabag_url,
-			wallabag_client_id,
-			wallabag_client_secret,
-			wallabag_username,
-			wallabag_password,
-			wallabag_tags,
-			notion_enabled,
-			notion_token,
-			notion_page_id,
-			nunux_keeper_enabled,
-			nunux_keeper_url,
-			nunux_keeper_api_key,
-			espial_enabled,
-			espial_url,
-			espial_api_key,
-			espial_tags,
-			readwise_enabled,
-			readwise_api_key,
-			telegram_bot_enabled,
-			telegram_bot_token,
-			telegram_bot_chat_id,
-			telegram_bot_topic_id,
-			telegram_bot_disable_web_page_preview,
-			telegram_bot_disable_notification,
-			telegram_bot_disable_buttons,
-			linkace_enabled,
-			linkace_url,
-			linkace_api_key,
-			linkace_tags,
-			linkace_is_private,
-			linkace_check_disabled,
-			linkding_enabled,
-			linkding_url,
-			linkding_api_key,
-			linkding_tags,
-			linkding_mark_as_unread,
-			linkwarden_enabled,
-			linkwarden_url,
-			linkwarden_api_key,
-			linkwarden_collection_id,
-			matrix_bot_enabled,
-			matrix_bot_user,
-			matrix_bot_password,
-			matrix_bot_url,
-			matrix_bot_chat_id,
-			apprise_enabled,
-			apprise_url,
-			apprise_services_url,
-			readeck_enabled,
-			readeck_url,
-			readeck_api_key,
-			readeck_labels,
-			readeck_only_url,
-			shiori_enabled,
-			shiori_url,
-			shiori_username,
-			shiori_password,
-			shaarli_enabled,
-			shaarli_url,
-			shaarli_api_secret,
-			webhook_enabled,
-			webhook_url,
-			webhook_secret,
 			rssbridge_enabled,
 			rssbridge_url,
-			omnivore_enabled,
-			omnivore_api_key,
-			omnivore_url,
-			raindrop_enabled,
-			raindrop_token,
-			raindrop_collection_id,
-			raindrop_tags,
-			betula_enabled,
-			betula_url,
-			betula_token,
-			ntfy_enabled,
-			ntfy_topic,
-			ntfy_url,
-			ntfy_api_token,
-			ntfy_username,
-			ntfy_password,
-			ntfy_icon_url,
-			ntfy_internal_links,
-			cubox_enabled,
-			cubox_api_link,
-			discord_enabled,
-			discord_webhook_link,
-			slack_enabled,
-			slack_webhook_link,
-			pushover_enabled,
-			pushover_user,
-			pushover_token,
-			pushover_device,
-			pushover_prefix,
-			rssbridge_token,
-			karakeep_enabled,
-			karakeep_api_key,
-			karakeep_url,
-			karakeep_tags,
-			linktaco_enabled,
-			linktaco_api_token,
-			linktaco_org_slug,
-			linktaco_tags,
-			linktaco_visibility,
-			archiveorg_enabled
+			rssbridge_token
 		FROM
 			integrations
 		WHERE
@@ -238,126 +131,15 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 	var integration model.Integration
 	err := s.db.QueryRow(query, userID).Scan(
 		&integration.UserID,
-		&integration.PinboardEnabled,
-		&integration.PinboardToken,
-		&integration.PinboardTags,
-		&integration.PinboardMarkAsUnread,
-		&integration.InstapaperEnabled,
-		&integration.InstapaperUsername,
-		&integration.InstapaperPassword,
 		&integration.FeverEnabled,
 		&integration.FeverUsername,
 		&integration.FeverToken,
 		&integration.GoogleReaderEnabled,
 		&integration.GoogleReaderUsername,
 		&integration.GoogleReaderPassword,
-		&integration.WallabagEnabled,
-		&integration.WallabagOnlyURL,
-		&integration.WallabagURL,
-		&integration.WallabagClientID,
-		&integration.WallabagClientSecret,
-		&integration.WallabagUsername,
-		&integration.WallabagPassword,
-		&integration.WallabagTags,
-		&integration.NotionEnabled,
-		&integration.NotionToken,
-		&integration.NotionPageID,
-		&integration.NunuxKeeperEnabled,
-		&integration.NunuxKeeperURL,
-		&integration.NunuxKeeperAPIKey,
-		&integration.EspialEnabled,
-		&integration.EspialURL,
-		&integration.EspialAPIKey,
-		&integration.EspialTags,
-		&integration.ReadwiseEnabled,
-		&integration.ReadwiseAPIKey,
-		&integration.TelegramBotEnabled,
-		&integration.TelegramBotToken,
-		&integration.TelegramBotChatID,
-		&integration.TelegramBotTopicID,
-		&integration.TelegramBotDisableWebPagePreview,
-		&integration.TelegramBotDisableNotification,
-		&integration.TelegramBotDisableButtons,
-		&integration.LinkAceEnabled,
-		&integration.LinkAceURL,
-		&integration.LinkAceAPIKey,
-		&integration.LinkAceTags,
-		&integration.LinkAcePrivate,
-		&integration.LinkAceCheckDisabled,
-		&integration.LinkdingEnabled,
-		&integration.LinkdingURL,
-		&integration.LinkdingAPIKey,
-		&integration.LinkdingTags,
-		&integration.LinkdingMarkAsUnread,
-		&integration.LinkwardenEnabled,
-		&integration.LinkwardenURL,
-		&integration.LinkwardenAPIKey,
-		&integration.LinkwardenCollectionID,
-		&integration.MatrixBotEnabled,
-		&integration.MatrixBotUser,
-		&integration.MatrixBotPassword,
-		&integration.MatrixBotURL,
-		&integration.MatrixBotChatID,
-		&integration.AppriseEnabled,
-		&integration.AppriseURL,
-		&integration.AppriseServicesURL,
-		&integration.ReadeckEnabled,
-		&integration.ReadeckURL,
-		&integration.ReadeckAPIKey,
-		&integration.ReadeckLabels,
-		&integration.ReadeckOnlyURL,
-		&integration.ShioriEnabled,
-		&integration.ShioriURL,
-		&integration.ShioriUsername,
-		&integration.ShioriPassword,
-		&integration.ShaarliEnabled,
-		&integration.ShaarliURL,
-		&integration.ShaarliAPISecret,
-		&integration.WebhookEnabled,
-		&integration.WebhookURL,
-		&integration.WebhookSecret,
 		&integration.RSSBridgeEnabled,
 		&integration.RSSBridgeURL,
-		&integration.OmnivoreEnabled,
-		&integration.OmnivoreAPIKey,
-		&integration.OmnivoreURL,
-		&integration.RaindropEnabled,
-		&integration.RaindropToken,
-		&integration.RaindropCollectionID,
-		&integration.RaindropTags,
-		&integration.BetulaEnabled,
-		&integration.BetulaURL,
-		&integration.BetulaToken,
-		&integration.NtfyEnabled,
-		&integration.NtfyTopic,
-		&integration.NtfyURL,
-		&integration.NtfyAPIToken,
-		&integration.NtfyUsername,
-		&integration.NtfyPassword,
-		&integration.NtfyIconURL,
-		&integration.NtfyInternalLinks,
-		&integration.CuboxEnabled,
-		&integration.CuboxAPILink,
-		&integration.DiscordEnabled,
-		&integration.DiscordWebhookLink,
-		&integration.SlackEnabled,
-		&integration.SlackWebhookLink,
-		&integration.PushoverEnabled,
-		&integration.PushoverUser,
-		&integration.PushoverToken,
-		&integration.PushoverDevice,
-		&integration.PushoverPrefix,
 		&integration.RSSBridgeToken,
-		&integration.KarakeepEnabled,
-		&integration.KarakeepAPIKey,
-		&integration.KarakeepURL,
-		&integration.KarakeepTags,
-		&integration.LinktacoEnabled,
-		&integration.LinktacoAPIToken,
-		&integration.LinktacoOrgSlug,
-		&integration.LinktacoTags,
-		&integration.LinktacoVisibility,
-		&integration.ArchiveorgEnabled,
 	)
 	switch {
 	case err == sql.ErrNoRows:
@@ -375,251 +157,29 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 		UPDATE
 			integrations
 		SET
-			pinboard_enabled=$1,
-			pinboard_token=$2,
-			pinboard_tags=$3,
-			pinboard_mark_as_unread=$4,
-			instapaper_enabled=$5,
-			instapaper_username=$6,
-			instapaper_password=$7,
-			fever_enabled=$8,
-			fever_username=$9,
-			fever_token=$10,
-			wallabag_enabled=$11,
-			wallabag_only_url=$12,
-			wallabag_url=$13,
-			wallabag_client_id=$14,
-			wallabag_client_secret=$15,
-			wallabag_username=$16,
-			wallabag_password=$17,
-			wallabag_tags=$18,
-			nunux_keeper_enabled=$19,
-			nunux_keeper_url=$20,
-			nunux_keeper_api_key=$21,
-			googlereader_enabled=$22,
-			googlereader_username=$23,
-			googlereader_password=$24,
-			telegram_bot_enabled=$25,
-			telegram_bot_token=$26,
-			telegram_bot_chat_id=$27,
-			telegram_bot_topic_id=$28,
-			telegram_bot_disable_web_page_preview=$29,
-			telegram_bot_disable_notification=$30,
-			telegram_bot_disable_buttons=$31,
-			espial_enabled=$32,
-			espial_url=$33,
-			espial_api_key=$34,
-			espial_tags=$35,
-			linkace_enabled=$36,
-			linkace_url=$37,
-			linkace_api_key=$38,
-			linkace_tags=$39,
-			linkace_is_private=$40,
-			linkace_check_disabled=$41,
-			linkding_enabled=$42,
-			linkding_url=$43,
-			linkding_api_key=$44,
-			linkding_tags=$45,
-			linkding_mark_as_unread=$46,
-			matrix_bot_enabled=$47,
-			matrix_bot_user=$48,
-			matrix_bot_password=$49,
-			matrix_bot_url=$50,
-			matrix_bot_chat_id=$51,
-			notion_enabled=$52,
-			notion_token=$53,
-			notion_page_id=$54,
-			readwise_enabled=$55,
-			readwise_api_key=$56,
-			apprise_enabled=$57,
-			apprise_url=$58,
-			apprise_services_url=$59,
-			readeck_enabled=$60,
-			readeck_url=$61,
-			readeck_api_key=$62,
-			readeck_labels=$63,
-			readeck_only_url=$64,
-			shiori_enabled=$65,
-			shiori_url=$66,
-			shiori_username=$67,
-			shiori_password=$68,
-			shaarli_enabled=$69,
-			shaarli_url=$70,
-			shaarli_api_secret=$71,
-			webhook_enabled=$72,
-			webhook_url=$73,
-			webhook_secret=$74,
-			rssbridge_enabled=$75,
-			rssbridge_url=$76,
-			omnivore_enabled=$77,
-			omnivore_api_key=$78,
-			omnivore_url=$79,
-			linkwarden_enabled=$80,
-			linkwarden_url=$81,
-			linkwarden_api_key=$82,
-			raindrop_enabled=$83,
-			raindrop_token=$84,
-			raindrop_collection_id=$85,
-			raindrop_tags=$86,
-			betula_enabled=$87,
-			betula_url=$88,
-			betula_token=$89,
-			ntfy_enabled=$90,
-			ntfy_topic=$91,
-			ntfy_url=$92,
-			ntfy_api_token=$93,
-			ntfy_username=$94,
-			ntfy_password=$95,
-			ntfy_icon_url=$96,
-			ntfy_internal_links=$97,
-			cubox_enabled=$98,
-			cubox_api_link=$99,
-			discord_enabled=$100,
-			discord_webhook_link=$101,
-			slack_enabled=$102,
-			slack_webhook_link=$103,
-			pushover_enabled=$104,
-			pushover_user=$105,
-			pushover_token=$106,
-			pushover_device=$107,
-			pushover_prefix=$108,
-			rssbridge_token=$109,
-			karakeep_enabled=$110,
-			karakeep_api_key=$111,
-			karakeep_url=$112,
-			karakeep_tags=$113,
-			linktaco_enabled=$114,
-			linktaco_api_token=$115,
-			linktaco_org_slug=$116,
-			linktaco_tags=$117,
-			linktaco_visibility=$118,
-			archiveorg_enabled=$119,
-			linkwarden_collection_id=$120
+			fever_enabled=$1,
+			fever_username=$2,
+			fever_token=$3,
+			googlereader_enabled=$4,
+			googlereader_username=$5,
+			googlereader_password=$6,
+			rssbridge_enabled=$7,
+			rssbridge_url=$8,
+			rssbridge_token=$9
 		WHERE
-			user_id=$121
+			user_id=$10
 	`
 	_, err := s.db.Exec(
 		query,
-		integration.PinboardEnabled,
-		integration.PinboardToken,
-		integration.PinboardTags,
-		integration.PinboardMarkAsUnread,
-		integration.InstapaperEnabled,
-		integration.InstapaperUsername,
-		integration.InstapaperPassword,
 		integration.FeverEnabled,
 		integration.FeverUsername,
 		integration.FeverToken,
-		integration.WallabagEnabled,
-		integration.WallabagOnlyURL,
-		integration.WallabagURL,
-		integration.WallabagClientID,
-		integration.WallabagClientSecret,
-		integration.WallabagUsername,
-		integration.WallabagPassword,
-		integration.WallabagTags,
-		integration.NunuxKeeperEnabled,
-		integration.NunuxKeeperURL,
-		integration.NunuxKeeperAPIKey,
 		integration.GoogleReaderEnabled,
 		integration.GoogleReaderUsername,
 		integration.GoogleReaderPassword,
-		integration.TelegramBotEnabled,
-		integration.TelegramBotToken,
-		integration.TelegramBotChatID,
-		integration.TelegramBotTopicID,
-		integration.TelegramBotDisableWebPagePreview,
-		integration.TelegramBotDisableNotification,
-		integration.TelegramBotDisableButtons,
-		integration.EspialEnabled,
-		integration.EspialURL,
-		integration.EspialAPIKey,
-		integration.EspialTags,
-		integration.LinkAceEnabled,
-		integration.LinkAceURL,
-		integration.LinkAceAPIKey,
-		integration.LinkAceTags,
-		integration.LinkAcePrivate,
-		integration.LinkAceCheckDisabled,
-		integration.LinkdingEnabled,
-		integration.LinkdingURL,
-		integration.LinkdingAPIKey,
-		integration.LinkdingTags,
-		integration.LinkdingMarkAsUnread,
-		integration.MatrixBotEnabled,
-		integration.MatrixBotUser,
-		integration.MatrixBotPassword,
-		integration.MatrixBotURL,
-		integration.MatrixBotChatID,
-		integration.NotionEnabled,
-		integration.NotionToken,
-		integration.NotionPageID,
-		integration.ReadwiseEnabled,
-		integration.ReadwiseAPIKey,
-		integration.AppriseEnabled,
-		integration.AppriseURL,
-		integration.AppriseServicesURL,
-		integration.ReadeckEnabled,
-		integration.ReadeckURL,
-		integration.ReadeckAPIKey,
-		integration.ReadeckLabels,
-		integration.ReadeckOnlyURL,
-		integration.ShioriEnabled,
-		integration.ShioriURL,
-		integration.ShioriUsername,
-		integration.ShioriPassword,
-		integration.ShaarliEnabled,
-		integration.ShaarliURL,
-		integration.ShaarliAPISecret,
-		integration.WebhookEnabled,
-		integration.WebhookURL,
-		integration.WebhookSecret,
 		integration.RSSBridgeEnabled,
 		integration.RSSBridgeURL,
-		integration.OmnivoreEnabled,
-		integration.OmnivoreAPIKey,
-		integration.OmnivoreURL,
-		integration.LinkwardenEnabled,
-		integration.LinkwardenURL,
-		integration.LinkwardenAPIKey,
-		integration.RaindropEnabled,
-		integration.RaindropToken,
-		integration.RaindropCollectionID,
-		integration.RaindropTags,
-		integration.BetulaEnabled,
-		integration.BetulaURL,
-		integration.BetulaToken,
-		integration.NtfyEnabled,
-		integration.NtfyTopic,
-		integration.NtfyURL,
-		integration.NtfyAPIToken,
-		integration.NtfyUsername,
-		integration.NtfyPassword,
-		integration.NtfyIconURL,
-		integration.NtfyInternalLinks,
-		integration.CuboxEnabled,
-		integration.CuboxAPILink,
-		integration.DiscordEnabled,
-		integration.DiscordWebhookLink,
-		integration.SlackEnabled,
-		integration.SlackWebhookLink,
-		integration.PushoverEnabled,
-		integration.PushoverUser,
-		integration.PushoverToken,
-		integration.PushoverDevice,
-		integration.PushoverPrefix,
 		integration.RSSBridgeToken,
-		integration.KarakeepEnabled,
-		integration.KarakeepAPIKey,
-		integration.KarakeepURL,
-		integration.KarakeepTags,
-		integration.LinktacoEnabled,
-		integration.LinktacoAPIToken,
-		integration.LinktacoOrgSlug,
-		integration.LinktacoTags,
-		integration.LinktacoVisibility,
-		integration.ArchiveorgEnabled,
-		integration.LinkwardenCollectionID,
 		integration.UserID,
 	)
 
@@ -630,46 +190,149 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 	return nil
 }
 
-// HasSaveEntry returns true if the given user can save articles to third-parties.
-func (s *Storage) HasSaveEntry(userID int64) (result bool) {
+// IntegrationSettings returns the settings of the user for each integration provider.
+func (s *Storage) IntegrationSettings(userID int64) ([]*model.IntegrationSettings, error) {
 	query := `
 		SELECT
-			true
+			user_id, provider, enabled, settings, last_digest_at, last_sync_at
 		FROM
-			integrations
+			integration_providers
 		WHERE
 			user_id=$1
-		AND
-			(
-				pinboard_enabled='t' OR
-				instapaper_enabled='t' OR
-				wallabag_enabled='t' OR
-				notion_enabled='t' OR
-				nunux_keeper_enabled='t' OR
-				espial_enabled='t' OR
-				readwise_enabled='t' OR
-				linkace_enabled='t' OR
-				linkding_enabled='t' OR
-				linktaco_enabled='t' OR
-				linkwarden_enabled='t' OR
-				apprise_enabled='t' OR
-				shiori_enabled='t' OR
-				readeck_enabled='t' OR
-				shaarli_enabled='t' OR
-				webhook_enabled='t' OR
-				omnivore_enabled='t' OR
-				karakeep_enabled='t' OR
-				raindrop_enabled='t' OR
-				betula_enabled='t' OR
-				cubox_enabled='t' OR
-				discord_enabled='t' OR
-				slack_enabled='t' OR
-				archiveorg_enabled='t'
-			)
+		ORDER BY provider ASC
 	`
-	if err := s.db.QueryRow(query, userID).Scan(&result); err != nil {
-		result = false
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration settings: %v`, err)
 	}
+	defer rows.Close()
 
-	return result
+	return scanIntegrationSettings(rows)
+}
+
+// EnabledIntegrationSettings returns the settings of all the users who enabled one of the providers.
+func (s *Storage) EnabledIntegrationSettings(providers []string) ([]*model.IntegrationSettings, error) {
+	query := `
+		SELECT
+			user_id, provider, enabled, settings, last_digest_at, last_sync_at
+		FROM
+			integration_providers
+		WHERE
+			enabled='t' AND provider = ANY($1)
+		ORDER BY user_id ASC, provider ASC
+	`
+	rows, err := s.db.Query(query, pq.StringArray(providers))
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch enabled integration settings: %v`, err)
+	}
+	defer rows.Close()
+
+	return scanIntegrationSettings(rows)
+}
+
+// UpdateIntegrationDigestDate records the date of the last digest of a provider.
+// It returns false if the date was changed since previous was read, another instance is sending the digest.
+func (s *Storage) UpdateIntegrationDigestDate(settings *model.IntegrationSettings, previous *time.Time, date time.Time) (bool, error) {
+	query := `
+		UPDATE
+			integration_providers
+		SET
+			last_digest_at=$4
+		WHERE
+			user_id=$1 AND provider=$2 AND last_digest_at IS NOT DISTINCT FROM $3
+	`
+	result, err := s.db.Exec(query, settings.UserID, settings.Provider, previous, date)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update the digest date of the integration %q: %v`, settings.Provider, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update the digest date of the integration %q: %v`, settings.Provider, err)
+	}
+
+	if count == 1 {
+		settings.LastDigestAt = &date
+	}
+	return count == 1, nil
+}
+
+// UpdateIntegrationSyncDate records the date of the last import of the bookmarks of a provider.
+// It returns false if the date was changed since previous was read, another instance is importing the bookmarks.
+// A nil date imports all the bookmarks again.
+func (s *Storage) UpdateIntegrationSyncDate(settings *model.IntegrationSettings, previous, date *time.Time) (bool, error) {
+	query := `
+		UPDATE
+			integration_providers
+		SET
+			last_sync_at=$4
+		WHERE
+			user_id=$1 AND provider=$2 AND last_sync_at IS NOT DISTINCT FROM $3
+	`
+	result, err := s.db.Exec(query, settings.UserID, settings.Provider, previous, date)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update the sync date of the integration %q: %v`, settings.Provider, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update the sync date of the integration %q: %v`, settings.Provider, err)
+	}
+
+	if count == 1 {
+		settings.LastSyncAt = date
+	}
+	return count == 1, nil
+}
+
+// UpdateIntegrationSettings creates or updates the settings of the user for an integration provider.
+func (s *Storage) UpdateIntegrationSettings(settings *model.IntegrationSettings) error {
+	values := settings.Values
+	if values == nil {
+		values = make(model.IntegrationValues)
+	}
+
+	encodedValues, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf(`store: unable to encode the settings of the integration %q: %v`, settings.Provider, err)
+	}
+
+	query := `
+		INSERT INTO integration_providers
+			(user_id, provider, enabled, settings)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (user_id, provider) DO UPDATE
+			SET enabled=excluded.enabled, settings=excluded.settings
+	`
+	if _, err := s.db.Exec(query, settings.UserID, settings.Provider, settings.Enabled, encodedValues); err != nil {
+		return fmt.Errorf(`store: unable to update the settings of the integration %q: %v`, settings.Provider, err)
+	}
+
+	return nil
+}
+
+func scanIntegrationSettings(rows *sql.Rows) ([]*model.IntegrationSettings, error) {
+	settingsList := make([]*model.IntegrationSettings, 0)
+	for rows.Next() {
+		var settings model.IntegrationSettings
+		var values []byte
+		if err := rows.Scan(&settings.UserID, &settings.Provider, &settings.Enabled, &values, &settings.LastDigestAt, &settings.LastSyncAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch integration settings row: %v`, err)
+		}
+
+		// Keep the integers exact, the values are typed by the schema of the provider.
+		decoder := json.NewDecoder(bytes.NewReader(values))
+		decoder.UseNumber()
+		if err := decoder.Decode(&settings.Values); err != nil {
+			return nil, fmt.Errorf(`store: unable to decode the settings of the integration %q: %v`, settings.Provider, err)
+		}
+		if settings.Values == nil {
+			settings.Values = make(model.IntegrationValues)
+		}
+
+		settingsList = append(settingsList, &settings)
+	}
+
+	return settingsList, nil
 }
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// SaveIntegrationBookmark links a bookmark imported from a provider to an entry.
+func (s *Storage) SaveIntegrationBookmark(userID int64, provider, bookmarkID string, entryID int64) error {
+	query := `
+		INSERT INTO integration_bookmarks
+			(user_id, provider, bookmark_id, entry_id)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (user_id, provider, bookmark_id) DO UPDATE
+			SET entry_id=excluded.entry_id
+	`
+	if _, err := s.db.Exec(query, userID, provider, bookmarkID, entryID); err != nil {
+		return fmt.Errorf(`store: unable to save the bookmark %q of the integration %q: %v`, bookmarkID, provider, err)
+	}
+	return nil
+}
+
+// IntegrationBookmarkIDs returns the IDs of the bookmarks of a provider linked to the entries, indexed by entry ID.
+func (s *Storage) IntegrationBookmarkIDs(userID int64, provider string, entryIDs []int64) (map[int64][]string, error) {
+	query := `
+		SELECT
+			entry_id, bookmark_id
+		FROM
+			integration_bookmarks
+		WHERE
+			user_id=$1 AND provider=$2 AND entry_id=ANY($3)
+		ORDER BY
+			entry_id, bookmark_id
+	`
+	rows, err := s.db.Query(query, userID, provider, pq.Array(entryIDs))
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the bookmarks of the integration %q: %v`, provider, err)
+	}
+	defer rows.Close()
+
+	bookmarkIDs := make(map[int64][]string)
+	for rows.Next() {
+		var entryID int64
+		var bookmarkID string
+		if err := rows.Scan(&entryID, &bookmarkID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch the bookmarks of the integration %q: %v`, provider, err)
+		}
+		bookmarkIDs[entryID] = append(bookmarkIDs[entryID], bookmarkID)
+	}
+
+	return bookmarkIDs, nil
+}
@@ -0,0 +1,274 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+const integrationDeliveryColumns = `
+	d.id, d.user_id, d.provider, d.kind, coalesce(d.feed_id, 0), d.entry_ids, d.status, d.attempts, d.last_error,
+	d.next_attempt_at, d.created_at, d.updated_at, coalesce(d.route_id, 0)
+`
+
+// CreateIntegrationDelivery records a pending delivery, the first attempt is made at NextAttemptAt.
+func (s *Storage) CreateIntegrationDelivery(delivery *model.IntegrationDelivery) error {
+	query := `
+		INSERT INTO integration_deliveries
+			(user_id, provider, kind, feed_id, entry_ids, next_attempt_at, route_id)
+		VALUES
+			($1, $2, $3, nullif($4::bigint, 0), $5, $6, nullif($7::bigint, 0))
+		RETURNING
+			id, status, created_at, updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		delivery.UserID,
+		delivery.Provider,
+		delivery.Kind,
+		delivery.FeedID,
+		pq.Array(delivery.EntryIDs),
+		delivery.NextAttemptAt,
+		delivery.RouteID,
+	).Scan(&delivery.ID, &delivery.Status, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create integration delivery for user #%d: %v`, delivery.UserID, err)
+	}
+
+	return nil
+}
+
+// ClaimIntegrationDeliveries returns the pending deliveries due for a new attempt and postpones them,
+// so the other instances sharing the database do not send them twice.
+func (s *Storage) ClaimIntegrationDeliveries(limit int, claimDuration time.Duration) (model.IntegrationDeliveries, error) {
+	query := `
+		UPDATE
+			integration_deliveries
+		SET
+			next_attempt_at = now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id
+			FROM integration_deliveries
+			WHERE status = $1 AND next_attempt_at <= now()
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING
+			id, user_id, provider, kind, coalesce(feed_id, 0), entry_ids, status, attempts, last_error,
+			next_attempt_at, created_at, updated_at, coalesce(route_id, 0)
+	`
+	rows, err := s.db.Query(query, model.DeliveryStatusPending, claimDuration.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to claim integration deliveries: %v`, err)
+	}
+	defer rows.Close()
+
+	deliveries := make(model.IntegrationDeliveries, 0)
+	for rows.Next() {
+		var delivery model.IntegrationDelivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.UserID,
+			&delivery.Provider,
+			&delivery.Kind,
+			&delivery.FeedID,
+			pq.Array(&delivery.EntryIDs),
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&delivery.RouteID,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch integration delivery row: %v`, err)
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateIntegrationDelivery saves the outcome of a delivery attempt.
+func (s *Storage) UpdateIntegrationDelivery(delivery *model.IntegrationDelivery) error {
+	query := `
+		UPDATE
+			integration_deliveries
+		SET
+			status=$1,
+			attempts=$2,
+			last_error=$3,
+			next_attempt_at=$4,
+			updated_at=now()
+		WHERE
+			id=$5 AND user_id=$6
+	`
+	_, err := s.db.Exec(
+		query,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+		delivery.ID,
+		delivery.UserID,
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update integration delivery #%d: %v`, delivery.ID, err)
+	}
+
+	return nil
+}
+
+// IntegrationDeliveries returns the most recent deliveries of a user.
+func (s *Storage) IntegrationDeliveries(userID int64, limit int) (model.IntegrationDeliveries, error) {
+	query := `
+		SELECT
+			` + integrationDeliveryColumns + `,
+			CASE WHEN d.kind = $2 THEN coalesce(e.title, '') ELSE coalesce(f.title, '') END
+		FROM
+			integration_deliveries d
+		LEFT JOIN
+			feeds f ON f.id=d.feed_id
+		LEFT JOIN
+			entries e ON e.id=d.entry_ids[1] AND e.user_id=d.user_id
+		WHERE
+			d.user_id=$1
+		ORDER BY
+			d.id DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(query, userID, model.DeliveryKindSaveEntry, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration deliveries: %v`, err)
+	}
+	defer rows.Close()
+
+	deliveries := make(model.IntegrationDeliveries, 0)
+	for rows.Next() {
+		delivery, err := scanIntegrationDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// IntegrationDeliveryByID returns a delivery of a user, nil if the delivery does not exist.
+func (s *Storage) IntegrationDeliveryByID(userID, deliveryID int64) (*model.IntegrationDelivery, error) {
+	query := `
+		SELECT
+			` + integrationDeliveryColumns + `,
+			CASE WHEN d.kind = $3 THEN coalesce(e.title, '') ELSE coalesce(f.title, '') END
+		FROM
+			integration_deliveries d
+		LEFT JOIN
+			feeds f ON f.id=d.feed_id
+		LEFT JOIN
+			entries e ON e.id=d.entry_ids[1] AND e.user_id=d.user_id
+		WHERE
+			d.user_id=$1 AND d.id=$2
+	`
+	delivery, err := scanIntegrationDelivery(s.db.QueryRow(query, userID, deliveryID, model.DeliveryKindSaveEntry))
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// IntegrationDeliveryCounts returns the number of deliveries of a user waiting for a retry and dead-lettered.
+func (s *Storage) IntegrationDeliveryCounts(userID int64) (retrying, failed int, err error) {
+	query := `
+		SELECT
+			count(*) FILTER (WHERE status=$2 AND attempts > 0),
+			count(*) FILTER (WHERE status=$3)
+		FROM
+			integration_deliveries
+		WHERE
+			user_id=$1
+	`
+	err = s.db.QueryRow(query, userID, model.DeliveryStatusPending, model.DeliveryStatusDead).Scan(&retrying, &failed)
+	if err != nil {
+		return 0, 0, fmt.Errorf(`store: unable to count integration deliveries: %v`, err)
+	}
+
+	return retrying, failed, nil
+}
+
+// CountNotifiedEntries returns the number of entries sent by a provider to a user since the given date.
+func (s *Storage) CountNotifiedEntries(userID int64, provider string, since time.Time) (int, error) {
+	query := `
+		SELECT
+			coalesce(sum(cardinality(entry_ids)), 0)
+		FROM
+			integration_deliveries
+		WHERE
+			user_id=$1 AND provider=$2 AND kind=$3 AND attempts > 0 AND updated_at >= $4
+	`
+	var count int
+	if err := s.db.QueryRow(query, userID, provider, model.DeliveryKindPushEntries, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf(`store: unable to count the entries sent to %s: %v`, provider, err)
+	}
+
+	return count, nil
+}
+
+// CleanOldIntegrationDeliveries removes the delivered and dead-lettered deliveries older than the specified interval.
+func (s *Storage) CleanOldIntegrationDeliveries(interval time.Duration) (int64, error) {
+	query := `
+		DELETE FROM
+			integration_deliveries
+		WHERE
+			status <> $1 AND updated_at < now() - make_interval(secs => $2)
+	`
+	result, err := s.db.Exec(query, model.DeliveryStatusPending, interval.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove old integration deliveries: %v`, err)
+	}
+
+	return result.RowsAffected()
+}
+
+type integrationDeliveryScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanIntegrationDelivery(row integrationDeliveryScanner) (*model.IntegrationDelivery, error) {
+	var delivery model.IntegrationDelivery
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.UserID,
+		&delivery.Provider,
+		&delivery.Kind,
+		&delivery.FeedID,
+		pq.Array(&delivery.EntryIDs),
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.LastError,
+		&delivery.NextAttemptAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+		&delivery.RouteID,
+		&delivery.Title,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration delivery row: %v`, err)
+	}
+	return &delivery, nil
+}
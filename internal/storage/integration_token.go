@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/lib/pq"
+	"miniflux.app/v2/internal/model"
+)
+
+// IntegrationToken returns the OAuth2 token of the user for a provider, nil if the user is not connected.
+func (s *Storage) IntegrationToken(userID int64, provider string) (*model.IntegrationToken, error) {
+	query := `
+		SELECT
+			user_id, provider, access_token, refresh_token, token_type, expires_at, created_at
+		FROM
+			integration_tokens
+		WHERE
+			user_id=$1 AND provider=$2
+	`
+	var token model.IntegrationToken
+	err := s.db.QueryRow(query, userID, provider).Scan(
+		&token.UserID,
+		&token.Provider,
+		&token.AccessToken,
+		&token.RefreshToken,
+		&token.TokenType,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch the token of the integration %q: %v`, provider, err)
+	}
+
+	accessToken, accessErr := decryptSecret(token.AccessToken)
+	refreshToken, refreshErr := decryptSecret(token.RefreshToken)
+	if err := errors.Join(accessErr, refreshErr); err != nil {
+		slog.Error("Unable to decrypt the token of the integration",
+			slog.Int64("user_id", userID),
+			slog.String("provider", provider),
+			slog.Any("error", err),
+		)
+		token.AccessToken, token.RefreshToken = "", ""
+		token.Unreadable = true
+		return &token, nil
+	}
+
+	token.AccessToken, token.RefreshToken = accessToken, refreshToken
+	return &token, nil
+}
+
+// ConnectedIntegrations returns the providers the user is connected to with OAuth2.
+func (s *Storage) ConnectedIntegrations(userID int64) ([]string, error) {
+	var providers pq.StringArray
+	query := `SELECT coalesce(array_agg(provider ORDER BY provider), '{}') FROM integration_tokens WHERE user_id=$1`
+	if err := s.db.QueryRow(query, userID).Scan(&providers); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the connected integrations: %v`, err)
+	}
+	return providers, nil
+}
+
+// SaveIntegrationToken creates or replaces the OAuth2 token of the user for a provider.
+// A token that could not be decrypted is never saved back, the stored token is kept.
+func (s *Storage) SaveIntegrationToken(token *model.IntegrationToken) error {
+	if token.Unreadable {
+		return fmt.Errorf(`store: the token of the integration %q cannot be decrypted with the current key`, token.Provider)
+	}
+
+	accessToken, err := encryptSecret(token.AccessToken)
+	if err != nil {
+		return fmt.Errorf(`store: unable to encrypt the token of the integration %q: %v`, token.Provider, err)
+	}
+	refreshToken, err := encryptSecret(token.RefreshToken)
+	if err != nil {
+		return fmt.Errorf(`store: unable to encrypt the token of the integration %q: %v`, token.Provider, err)
+	}
+
+	query := `
+		INSERT INTO integration_tokens
+			(user_id, provider, access_token, refresh_token, token_type, expires_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, provider) DO UPDATE
+			SET access_token=excluded.access_token, refresh_token=excluded.refresh_token,
+				token_type=excluded.token_type, expires_at=excluded.expires_at, updated_at=now()
+	`
+	_, err = s.db.Exec(query, token.UserID, token.Provider, accessToken, refreshToken, token.TokenType, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to save the token of the integration %q: %v`, token.Provider, err)
+	}
+
+	return nil
+}
+
+// RemoveIntegrationToken disconnects the user from a provider.
+func (s *Storage) RemoveIntegrationToken(userID int64, provider string) error {
+	query := `DELETE FROM integration_tokens WHERE user_id=$1 AND provider=$2`
+	if _, err := s.db.Exec(query, userID, provider); err != nil {
+		return fmt.Errorf(`store: unable to remove the token of the integration %q: %v`, provider, err)
+	}
+	return nil
+}
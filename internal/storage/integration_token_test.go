@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestSaveUnreadableIntegrationToken(t *testing.T) {
+	// The token is refused before reaching the database, the saved token is kept.
+	store := &Storage{}
+	token := &model.IntegrationToken{UserID: 1, Provider: "raindrop", Unreadable: true}
+	if err := store.SaveIntegrationToken(token); err == nil {
+		t.Error(`A token that could not be decrypted must not be saved`)
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// AcquireLease takes or renews a lease for the given holder.
+// It returns false when the lease is held by another instance and has not expired.
+func (s *Storage) AcquireLease(name, holder string, duration time.Duration) (bool, error) {
+	query := `
+		INSERT INTO scheduler_leases
+			(name, holder, expires_at)
+		VALUES
+			($1, $2, now() + make_interval(secs => $3))
+		ON CONFLICT (name) DO UPDATE SET
+			acquired_at = CASE WHEN scheduler_leases.holder = EXCLUDED.holder THEN scheduler_leases.acquired_at ELSE now() END,
+			holder = EXCLUDED.holder,
+			expires_at = EXCLUDED.expires_at
+		WHERE
+			scheduler_leases.holder = EXCLUDED.holder OR scheduler_leases.expires_at < now()
+		RETURNING
+			holder
+	`
+	var currentHolder string
+	err := s.db.QueryRow(query, name, holder, duration.Seconds()).Scan(&currentHolder)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf(`store: unable to acquire lease %q: %v`, name, err)
+	}
+
+	return true, nil
+}
+
+// ReleaseLease gives up a lease so another instance can take it immediately.
+func (s *Storage) ReleaseLease(name, holder string) error {
+	if _, err := s.db.Exec(`DELETE FROM scheduler_leases WHERE name=$1 AND holder=$2`, name, holder); err != nil {
+		return fmt.Errorf(`store: unable to release lease %q: %v`, name, err)
+	}
+
+	return nil
+}
+
+// Leases returns the leases that have not expired.
+func (s *Storage) Leases() ([]*model.Lease, error) {
+	query := `SELECT name, holder, expires_at, acquired_at FROM scheduler_leases WHERE expires_at > now() ORDER BY name ASC`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch leases: %v`, err)
+	}
+	defer rows.Close()
+
+	var leases []*model.Lease
+	for rows.Next() {
+		var lease model.Lease
+		if err := rows.Scan(&lease.Name, &lease.Holder, &lease.ExpiresAt, &lease.AcquiredAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch lease row: %v`, err)
+		}
+		leases = append(leases, &lease)
+	}
+
+	return leases, nil
+}
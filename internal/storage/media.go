@@ -297,6 +297,7 @@ func (s *Storage) UpdateMedia(media *model.Media) error {
 			media.Content,
 			media.Size,
 			media.Cached,
+			media.ErrorCount,
 		)
 	}
 
@@ -92,8 +92,14 @@ func (s *Storage) getUncachedMedias(offset int, limit int) (model.Medias, map[in
 		m.cached,
 		m.error_count,
 		max(e.url) as referrer,
-		string_agg(cast(e.id as TEXT),',') as eids
+		string_agg(cast(e.id as TEXT),',') as eids,
+		(array_agg(f.feed_url ORDER BY f.id))[1] as feed_url,
+		(array_agg(f.custom_headers ORDER BY f.id))[1] as custom_headers,
+		(array_agg(coalesce(cc.certificate, '') ORDER BY f.id))[1] as certificate,
+		(array_agg(coalesce(cc.private_key, '') ORDER BY f.id))[1] as private_key,
+		(array_agg(coalesce(cc.ca_bundle, '') ORDER BY f.id))[1] as ca_bundle
     FROM feeds f
+        LEFT JOIN client_certificates cc ON cc.id=f.client_certificate_id
         INNER JOIN entries e ON f.id=e.feed_id
         INNER JOIN entry_medias em ON e.id=em.entry_id
         INNER JOIN medias m ON em.media_id=m.id
@@ -128,10 +134,22 @@ func (s *Storage) getUncachedMedias(offset int, limit int) (model.Medias, map[in
 			&media.ErrorCount,
 			&media.Referrer,
 			&entryIDs,
+			&media.FeedURL,
+			&media.CustomHeaders,
+			&media.ClientCertificate.Certificate,
+			&media.ClientCertificate.PrivateKey,
+			&media.ClientCertificate.CABundle,
 		)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to fetch uncached medias row: %v", err)
 		}
+		if media.CustomHeaders, err = decryptSecret(media.CustomHeaders); err != nil {
+			slog.Error("Unable to decrypt the custom headers of the feed", slog.String("feed_url", media.FeedURL), slog.Any("error", err))
+		}
+		if err := decryptClientCertificate(&media.ClientCertificate); err != nil {
+			slog.Error("Unable to decrypt the client certificate of the feed", slog.String("feed_url", media.FeedURL), slog.Any("error", err))
+			media.ClientCertificate = model.ClientCertificate{}
+		}
 		medias = append(medias, &media)
 		mediaEntries[media.ID] = entryIDs
 
@@ -139,6 +157,77 @@ func (s *Storage) getUncachedMedias(offset int, limit int) (model.Medias, map[in
 	return medias, mediaEntries, nil
 }
 
+// UncachedProxyMedia returns the media with the given URL hash when it is not cached yet
+// and belongs to an entry of a feed with media caching enabled, nil otherwise.
+func (s *Storage) UncachedProxyMedia(urlHash string) (*model.Media, error) {
+	query := `
+		SELECT m.id, m.url, m.url_hash, m.error_count, e.url, f.feed_url, f.custom_headers,
+			coalesce(cc.certificate, ''), coalesce(cc.private_key, ''), coalesce(cc.ca_bundle, '')
+		FROM medias m
+			INNER JOIN entry_medias em ON em.media_id=m.id
+			INNER JOIN entries e ON e.id=em.entry_id
+			INNER JOIN feeds f ON f.id=e.feed_id
+			LEFT JOIN client_certificates cc ON cc.id=f.client_certificate_id
+		WHERE m.url_hash=$1 AND m.cached='F' AND m.error_count < $2 AND f.cache_media='T'
+		LIMIT 1
+	`
+	var media model.Media
+	err := s.db.QueryRow(query, urlHash, maxCachingError).Scan(
+		&media.ID,
+		&media.URL,
+		&media.URLHash,
+		&media.ErrorCount,
+		&media.Referrer,
+		&media.FeedURL,
+		&media.CustomHeaders,
+		&media.ClientCertificate.Certificate,
+		&media.ClientCertificate.PrivateKey,
+		&media.ClientCertificate.CABundle,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch uncached media: %v`, err)
+	}
+
+	if media.CustomHeaders, err = decryptSecret(media.CustomHeaders); err != nil {
+		return nil, fmt.Errorf(`store: unable to decrypt the custom headers of the feed: %v`, err)
+	}
+	if err := decryptClientCertificate(&media.ClientCertificate); err != nil {
+		return nil, fmt.Errorf(`store: unable to decrypt the client certificate of the feed: %v`, err)
+	}
+
+	return &media, nil
+}
+
+// CacheProxyMedia saves a media downloaded by the media proxy, and makes the entries
+// of the feeds with media caching enabled claim it, so it is not removed by CleanupMedia().
+func (s *Storage) CacheProxyMedia(media *model.Media) error {
+	media.Cached = true
+	media.ErrorCount = 0
+	if err := s.UpdateMedia(media); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE entry_medias SET use_cache='T'
+		WHERE media_id=$1 AND entry_id IN (
+			SELECT e.id
+			FROM entries e
+				INNER JOIN feeds f ON f.id=e.feed_id
+				INNER JOIN entry_medias em ON em.entry_id=e.id
+			WHERE em.media_id=$1 AND f.cache_media='T'
+		)
+	`
+	if _, err := s.db.Exec(query, media.ID); err != nil {
+		return fmt.Errorf(`store: unable to update cache references of media #%d: %v`, media.ID, err)
+	}
+
+	return nil
+}
+
 // HasEntryCache indicates if an entry has cache.
 func (s *Storage) HasEntryCache(entryID int64) bool {
 	var result bool
@@ -203,8 +292,10 @@ func (s *Storage) CacheEntryMedias(userID, entryID int64) error {
 
 func (s *Storage) getEntryMedias(userID, EntryID int64) (model.Medias, error) {
 	query := `
-		SELECT m.id, m.url, m.url_hash, m.mime_type , m.cached, e.url
+		SELECT m.id, m.url, m.url_hash, m.mime_type , m.cached, e.url, f.feed_url, f.custom_headers,
+			coalesce(cc.certificate, ''), coalesce(cc.private_key, ''), coalesce(cc.ca_bundle, '')
 		FROM feeds f
+			LEFT JOIN client_certificates cc on cc.id=f.client_certificate_id
 			INNER JOIN entries e on f.id=e.feed_id
 			INNER JOIN entry_medias em on e.id=em.entry_id
 			INNER JOIN medias m on m.id=em.media_id
@@ -221,10 +312,18 @@ func (s *Storage) getEntryMedias(userID, EntryID int64) (model.Medias, error) {
 
 	for rows.Next() {
 		var media model.Media
-		err := rows.Scan(&media.ID, &media.URL, &media.URLHash, &media.MimeType, &media.Cached, &media.Referrer)
+		err := rows.Scan(&media.ID, &media.URL, &media.URLHash, &media.MimeType, &media.Cached, &media.Referrer, &media.FeedURL, &media.CustomHeaders,
+			&media.ClientCertificate.Certificate, &media.ClientCertificate.PrivateKey, &media.ClientCertificate.CABundle)
 		if err != nil {
 			return nil, fmt.Errorf("unable to fetch entry medias row: %v", err)
 		}
+		if media.CustomHeaders, err = decryptSecret(media.CustomHeaders); err != nil {
+			slog.Error("Unable to decrypt the custom headers of the feed", slog.String("feed_url", media.FeedURL), slog.Any("error", err))
+		}
+		if err := decryptClientCertificate(&media.ClientCertificate); err != nil {
+			slog.Error("Unable to decrypt the client certificate of the feed", slog.String("feed_url", media.FeedURL), slog.Any("error", err))
+			media.ClientCertificate = model.ClientCertificate{}
+		}
 		medias = append(medias, &media)
 
 	}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+const notificationRouteColumns = `
+	r.id, r.user_id, r.provider, coalesce(r.category_id, 0), coalesce(r.feed_id, 0), r.filter_rules, r.target, r.priority,
+	r.created_at, r.updated_at, coalesce(c.title, ''), coalesce(f.title, '')
+`
+
+// NotificationRoutes returns the notification routes of a user, the most specific ones first:
+// the routes of a feed, then the routes of a category, then the others in the order of creation.
+func (s *Storage) NotificationRoutes(userID int64) (model.NotificationRoutes, error) {
+	query := `
+		SELECT
+			` + notificationRouteColumns + `
+		FROM
+			notification_routes r
+		LEFT JOIN
+			categories c ON c.id=r.category_id
+		LEFT JOIN
+			feeds f ON f.id=r.feed_id
+		WHERE
+			r.user_id=$1
+		ORDER BY
+			r.feed_id IS NULL, r.category_id IS NULL, r.id ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch notification routes: %v`, err)
+	}
+	defer rows.Close()
+
+	routes := make(model.NotificationRoutes, 0)
+	for rows.Next() {
+		route, err := scanNotificationRoute(rows)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+// NotificationRoute returns a notification route of a user, nil if the route does not exist.
+func (s *Storage) NotificationRoute(userID, routeID int64) (*model.NotificationRoute, error) {
+	query := `
+		SELECT
+			` + notificationRouteColumns + `
+		FROM
+			notification_routes r
+		LEFT JOIN
+			categories c ON c.id=r.category_id
+		LEFT JOIN
+			feeds f ON f.id=r.feed_id
+		WHERE
+			r.user_id=$1 AND r.id=$2
+	`
+	route, err := scanNotificationRoute(s.db.QueryRow(query, userID, routeID))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// CreateNotificationRoute creates a notification route.
+func (s *Storage) CreateNotificationRoute(route *model.NotificationRoute) error {
+	query := `
+		INSERT INTO notification_routes
+			(user_id, provider, category_id, feed_id, filter_rules, target, priority)
+		VALUES
+			($1, $2, nullif($3::int, 0), nullif($4::bigint, 0), $5, $6, $7)
+		RETURNING
+			id, created_at, updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		route.UserID,
+		route.Provider,
+		route.CategoryID,
+		route.FeedID,
+		route.FilterRules,
+		route.Target,
+		route.Priority,
+	).Scan(&route.ID, &route.CreatedAt, &route.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create notification route for user #%d: %v`, route.UserID, err)
+	}
+
+	return nil
+}
+
+// UpdateNotificationRoute updates a notification route.
+func (s *Storage) UpdateNotificationRoute(route *model.NotificationRoute) error {
+	query := `
+		UPDATE
+			notification_routes
+		SET
+			provider=$1,
+			category_id=nullif($2::int, 0),
+			feed_id=nullif($3::bigint, 0),
+			filter_rules=$4,
+			target=$5,
+			priority=$6,
+			updated_at=now()
+		WHERE
+			id=$7 AND user_id=$8
+		RETURNING
+			updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		route.Provider,
+		route.CategoryID,
+		route.FeedID,
+		route.FilterRules,
+		route.Target,
+		route.Priority,
+		route.ID,
+		route.UserID,
+	).Scan(&route.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update notification route #%d: %v`, route.ID, err)
+	}
+
+	return nil
+}
+
+// RemoveNotificationRoute deletes a notification route of a user.
+func (s *Storage) RemoveNotificationRoute(userID, routeID int64) error {
+	query := `DELETE FROM notification_routes WHERE id=$1 AND user_id=$2`
+	if _, err := s.db.Exec(query, routeID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove notification route #%d: %v`, routeID, err)
+	}
+
+	return nil
+}
+
+type notificationRouteScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNotificationRoute(row notificationRouteScanner) (*model.NotificationRoute, error) {
+	var route model.NotificationRoute
+	err := row.Scan(
+		&route.ID,
+		&route.UserID,
+		&route.Provider,
+		&route.CategoryID,
+		&route.FeedID,
+		&route.FilterRules,
+		&route.Target,
+		&route.Priority,
+		&route.CreatedAt,
+		&route.UpdatedAt,
+		&route.CategoryTitle,
+		&route.FeedTitle,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch notification route row: %v`, err)
+	}
+	return &route, nil
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"errors"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+)
+
+// Prefix of the values encrypted with the configured key, other values are stored in clear text.
+const encryptedValuePrefix = "encrypted:"
+
+// encryptSecret encrypts a sensitive value before saving it, when an encryption key is configured.
+func encryptSecret(value string) (string, error) {
+	key := config.Opts.EncryptionKey()
+	if value == "" || key == "" {
+		return value, nil
+	}
+
+	encrypted, err := crypto.Encrypt(key, value)
+	if err != nil {
+		return "", err
+	}
+	return encryptedValuePrefix + encrypted, nil
+}
+
+// decryptSecret decrypts a value saved by encryptSecret, values saved in clear text are returned as is.
+func decryptSecret(value string) (string, error) {
+	encrypted, found := strings.CutPrefix(value, encryptedValuePrefix)
+	if !found {
+		return value, nil
+	}
+
+	key := config.Opts.EncryptionKey()
+	if key == "" {
+		return "", errors.New("the value is encrypted but no encryption key is configured")
+	}
+	return crypto.Decrypt(key, encrypted)
+}
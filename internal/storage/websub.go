@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// UpdateFeedWebSubHub records the hub advertised by a feed.
+// The subscription goes back to the pending state when the hub or the topic changes.
+// An empty hub URL removes the subscription.
+func (s *Storage) UpdateFeedWebSubHub(feedID int64, hubURL, topicURL string) error {
+	if hubURL == "" {
+		if _, err := s.db.Exec(`DELETE FROM websub_subscriptions WHERE feed_id=$1`, feedID); err != nil {
+			return fmt.Errorf(`store: unable to remove websub subscription #%d: %v`, feedID, err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO websub_subscriptions
+			(feed_id, hub_url, topic_url)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (feed_id) DO UPDATE SET
+			state = CASE
+				WHEN websub_subscriptions.hub_url = EXCLUDED.hub_url AND websub_subscriptions.topic_url = EXCLUDED.topic_url
+				THEN websub_subscriptions.state
+				ELSE 'pending'
+			END,
+			lease_expires_at = CASE
+				WHEN websub_subscriptions.hub_url = EXCLUDED.hub_url AND websub_subscriptions.topic_url = EXCLUDED.topic_url
+				THEN websub_subscriptions.lease_expires_at
+				ELSE NULL
+			END,
+			hub_url = EXCLUDED.hub_url,
+			topic_url = EXCLUDED.topic_url,
+			updated_at = CASE
+				WHEN websub_subscriptions.hub_url = EXCLUDED.hub_url AND websub_subscriptions.topic_url = EXCLUDED.topic_url
+				THEN websub_subscriptions.updated_at
+				ELSE now()
+			END
+	`
+	if _, err := s.db.Exec(query, feedID, hubURL, topicURL); err != nil {
+		return fmt.Errorf(`store: unable to update websub hub for feed #%d: %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// WebSubSubscription returns the push subscription of a feed.
+func (s *Storage) WebSubSubscription(feedID int64) (*model.WebSubSubscription, error) {
+	query := `
+		SELECT
+			w.feed_id, f.user_id, w.hub_url, w.topic_url, w.secret, w.state, w.lease_expires_at, w.created_at, w.updated_at
+		FROM
+			websub_subscriptions w
+		JOIN
+			feeds f ON f.id=w.feed_id
+		WHERE
+			w.feed_id=$1
+	`
+	subscription, err := scanWebSubSubscription(s.db.QueryRow(query, feedID))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch websub subscription #%d: %v`, feedID, err)
+	}
+
+	return subscription, nil
+}
+
+// WebSubSubscriptionsToRenew returns the subscriptions that must be (re)sent to their hub:
+// new ones, those with a lease expiring before the given date, and requests the hub never answered.
+func (s *Storage) WebSubSubscriptionsToRenew(leaseExpiresBefore time.Time, limit int) ([]*model.WebSubSubscription, error) {
+	query := `
+		SELECT
+			w.feed_id, f.user_id, w.hub_url, w.topic_url, w.secret, w.state, w.lease_expires_at, w.created_at, w.updated_at
+		FROM
+			websub_subscriptions w
+		JOIN
+			feeds f ON f.id=w.feed_id
+		WHERE
+			f.disabled IS false AND (
+				w.state='pending' OR
+				(w.state='verified' AND w.lease_expires_at < $1) OR
+				(w.state IN ('requested', 'denied') AND w.updated_at < now() - interval '1 day')
+			)
+		ORDER BY
+			w.updated_at ASC
+		LIMIT $2
+	`
+	rows, err := s.db.Query(query, leaseExpiresBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch websub subscriptions to renew: %v`, err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*model.WebSubSubscription
+	for rows.Next() {
+		subscription, err := scanWebSubSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch websub subscription row: %v`, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateWebSubSubscription saves the secret, the state and the lease of a push subscription.
+func (s *Storage) UpdateWebSubSubscription(subscription *model.WebSubSubscription) error {
+	var leaseExpiresAt any
+	if !subscription.LeaseExpiresAt.IsZero() {
+		leaseExpiresAt = subscription.LeaseExpiresAt
+	}
+
+	query := `
+		UPDATE websub_subscriptions SET
+			secret=$1,
+			state=$2,
+			lease_expires_at=$3,
+			updated_at=now()
+		WHERE
+			feed_id=$4
+	`
+	if _, err := s.db.Exec(query, subscription.Secret, subscription.State, leaseExpiresAt, subscription.FeedID); err != nil {
+		return fmt.Errorf(`store: unable to update websub subscription #%d: %v`, subscription.FeedID, err)
+	}
+
+	return nil
+}
+
+// HasActiveWebSubSubscription returns true if the hub of this feed is pushing updates.
+func (s *Storage) HasActiveWebSubSubscription(feedID int64) bool {
+	var result bool
+	query := `SELECT true FROM websub_subscriptions WHERE feed_id=$1 AND state='verified' AND lease_expires_at > now()`
+	s.db.QueryRow(query, feedID).Scan(&result)
+	return result
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWebSubSubscription(row rowScanner) (*model.WebSubSubscription, error) {
+	var subscription model.WebSubSubscription
+	var leaseExpiresAt sql.NullTime
+	if err := row.Scan(
+		&subscription.FeedID,
+		&subscription.UserID,
+		&subscription.HubURL,
+		&subscription.TopicURL,
+		&subscription.Secret,
+		&subscription.State,
+		&leaseExpiresAt,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if leaseExpiresAt.Valid {
+		subscription.LeaseExpiresAt = leaseExpiresAt.Time
+	}
+
+	return &subscription, nil
+}
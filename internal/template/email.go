@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package template // import "miniflux.app/v2/internal/template"
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	html_template "html/template"
+	text_template "text/template"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/timezone"
+	"miniflux.app/v2/internal/urllib"
+)
+
+//go:embed templates/emails/*
+var emailTemplateFiles embed.FS
+
+// RenderEmail renders the HTML and the plain text versions of an email,
+// the templates are name.html and name.txt, they are translated in data["language"].
+func RenderEmail(name string, data map[string]any) (htmlBody, textBody string, err error) {
+	language, _ := data["language"].(string)
+	printer := locale.NewPrinter(language)
+
+	funcs := map[string]any{
+		"t":        printer.Printf,
+		"plural":   printer.Plural,
+		"rootURL":  config.Opts.RootURL,
+		"domain":   urllib.Domain,
+		"truncate": truncate,
+		"date": func(tz string, t time.Time) string {
+			return timezone.Convert(tz, t).Format("2006-01-02 15:04")
+		},
+	}
+
+	htmlTemplate, err := html_template.New("").Funcs(funcs).ParseFS(emailTemplateFiles, "templates/emails/"+name+".html")
+	if err != nil {
+		return "", "", fmt.Errorf("template: unable to parse email %q: %v", name, err)
+	}
+
+	textTemplate, err := text_template.New("").Funcs(funcs).ParseFS(emailTemplateFiles, "templates/emails/"+name+".txt")
+	if err != nil {
+		return "", "", fmt.Errorf("template: unable to parse email %q: %v", name, err)
+	}
+
+	var htmlBuffer, textBuffer bytes.Buffer
+	if err := htmlTemplate.ExecuteTemplate(&htmlBuffer, "base", data); err != nil {
+		return "", "", fmt.Errorf("template: unable to render email %q: %v", name, err)
+	}
+	if err := textTemplate.ExecuteTemplate(&textBuffer, "base", data); err != nil {
+		return "", "", fmt.Errorf("template: unable to render email %q: %v", name, err)
+	}
+
+	return htmlBuffer.String(), textBuffer.String(), nil
+}
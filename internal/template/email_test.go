@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package template // import "miniflux.app/v2/internal/template"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestRenderDigestEmail(t *testing.T) {
+	config.Opts = config.NewConfigOptions()
+
+	feed := &model.Feed{ID: 1, Title: "Example <News>"}
+	entries := model.Entries{
+		{ID: 10, FeedID: 1, Title: "First & foremost", URL: "https://example.org/first", Author: "Alice", Date: time.Date(2026, time.October, 17, 22, 30, 0, 0, time.UTC)},
+	}
+	data := map[string]any{
+		"language": "en_US",
+		"timezone": "Europe/Paris",
+		"title":    "Digest",
+		"feeds": []struct {
+			Feed    *model.Feed
+			Entries model.Entries
+		}{{feed, entries}},
+		"entryCount": len(entries),
+	}
+
+	htmlBody, textBody, err := RenderEmail("digest", data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"Example &lt;News&gt;",
+		`<a href="https://example.org/first"`,
+		"First &amp; foremost",
+		"2026-10-18 00:30",
+		"/feed/1/entry/10",
+		"1 entry",
+	} {
+		if !strings.Contains(htmlBody, expected) {
+			t.Errorf("Expected %q in the HTML version:\n%s", expected, htmlBody)
+		}
+	}
+
+	for _, expected := range []string{"## Example <News>", "- First & foremost", "https://example.org/first", "2026-10-18 00:30 · Alice"} {
+		if !strings.Contains(textBody, expected) {
+			t.Errorf("Expected %q in the text version:\n%s", expected, textBody)
+		}
+	}
+}
+
+func TestRenderUnknownEmail(t *testing.T) {
+	if _, _, err := RenderEmail("unknown", map[string]any{"language": "en_US"}); err == nil {
+		t.Error("Expected an error for an unknown email template")
+	}
+}
@@ -70,16 +70,26 @@ func (e *Engine) ParseTemplates() {
 	}
 
 	templatesFork := map[string][]string{
-		"stat.html":             {"layout.html"},
-		"unread_entries.html":   {"item.html"},
-		"starred_entries.html":  {"item.html"},
-		"category_entries.html": {"item.html"},
-		"feed_entries.html":     {"item.html"},
-		"history_entries.html":  {"item.html"},
-		"search.html":           {"item.html"},
-		"tag_entries.html":      {"item.html"},
-		"edit_entry.html":       {"layout.html"},
-		"add_entry.html":        {"layout.html"},
+		"stat.html":                      {"layout.html"},
+		"unread_entries.html":            {"item.html"},
+		"starred_entries.html":           {"item.html"},
+		"category_entries.html":          {"item.html"},
+		"feed_entries.html":              {"item.html"},
+		"history_entries.html":           {"item.html"},
+		"search.html":                    {"item.html"},
+		"tag_entries.html":               {"item.html"},
+		"edit_entry.html":                {"layout.html"},
+		"add_entry.html":                 {"layout.html"},
+		"domain_rules.html":              {"layout.html", "settings_menu.html"},
+		"create_domain_rule.html":        {"domain_rule_fields.html", "layout.html", "settings_menu.html"},
+		"edit_domain_rule.html":          {"domain_rule_fields.html", "layout.html", "settings_menu.html"},
+		"notification_routes.html":       {"layout.html", "settings_menu.html"},
+		"create_notification_route.html": {"layout.html", "notification_route_fields.html", "settings_menu.html"},
+		"edit_notification_route.html":   {"layout.html", "notification_route_fields.html", "settings_menu.html"},
+		"refresh_queue.html":             {"layout.html", "settings_menu.html"},
+		"client_certificates.html":       {"layout.html", "settings_menu.html"},
+		"create_client_certificate.html": {"layout.html", "settings_menu.html"},
+		"integration_deliveries.html":    {"layout.html", "settings_menu.html"},
 	}
 	for name, dependencies := range templatesFork {
 		if _, exists := templates[name]; exists {
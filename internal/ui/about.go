@@ -10,6 +10,7 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/proxyrotator"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
 	"miniflux.app/v2/internal/version"
@@ -38,6 +39,20 @@ func (h *handler) showAboutPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("postgres_version", h.store.DatabaseVersion())
 	view.Set("go_version", runtime.Version())
 
+	if user.IsAdmin {
+		leases, err := h.store.Leases()
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+		view.Set("instanceID", config.Opts.InstanceID())
+		view.Set("leases", leases)
+	}
+
+	if proxyrotator.ProxyRotatorInstance != nil {
+		view.Set("proxies", proxyrotator.ProxyRotatorInstance.Stats())
+	}
+
 	if dbErr != nil {
 		view.Set("db_usage", dbErr)
 	} else {
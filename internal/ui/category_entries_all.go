@@ -69,7 +69,7 @@ func (h *handler) showCategoryEntriesAllPage(w http.ResponseWriter, r *http.Requ
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.hasSaveEntry(user.ID))
 	view.Set("showOnlyUnreadEntries", false)
 	view.Set("pageEntriesType", "all")
 	if category.View != model.ViewDefault {
@@ -71,7 +71,7 @@ func (h *handler) showCategoryEntriesStarredPage(w http.ResponseWriter, r *http.
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.hasSaveEntry(user.ID))
 	view.Set("showOnlyStarredEntries", true)
 	view.Set("pageEntriesType", "starred")
 	if category.View != model.ViewDefault {
@@ -10,6 +10,8 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
 )
 
 func (h *handler) markCategoryAsRead(w http.ResponseWriter, r *http.Request) {
@@ -27,10 +29,13 @@ func (h *handler) markCategoryAsRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = h.store.MarkCategoryAsRead(userID, categoryID, time.Now()); err != nil {
+	entryIDs, err := h.store.MarkCategoryAsRead(userID, categoryID, time.Now())
+	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
+
 	html.Redirect(w, r, route.Path(h.router, "categories"))
 }
@@ -14,6 +14,7 @@ import (
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/worker"
 )
 
 func (h *handler) refreshCategoryEntriesPage(w http.ResponseWriter, r *http.Request) {
@@ -58,7 +59,7 @@ func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) int64
 			slog.Int("nb_jobs", len(jobs)),
 		)
 
-		go h.pool.Push(jobs)
+		h.pool.PushWithPriority(jobs, worker.PriorityUser)
 
 		sess.SetLastForceRefresh()
 		sess.NewFlashMessage(printer.Print("alert.background_feed_refresh"))
@@ -9,6 +9,8 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
 )
 
 func (h *handler) removeCategoryFeed(w http.ResponseWriter, r *http.Request) {
@@ -20,10 +22,20 @@ func (h *handler) removeCategoryFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	feed, err := h.store.FeedByID(request.UserID(r), feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	if err := h.store.RemoveFeed(request.UserID(r), feedID); err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
+	if feed != nil {
+		integration.SendFeedEvent(h.store, model.EventFeedRemoved, feed)
+	}
+
 	html.Redirect(w, r, route.Path(h.router, "categoryFeeds", "categoryID", categoryID))
 }
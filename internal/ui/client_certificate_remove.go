@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/storage"
+)
+
+func (h *handler) removeClientCertificate(w http.ResponseWriter, r *http.Request) {
+	clientCertificateID := request.RouteInt64Param(r, "clientCertificateID")
+	if err := h.store.RemoveClientCertificate(request.UserID(r), clientCertificateID); err != nil {
+		if errors.Is(err, storage.ErrClientCertificateNotFound) {
+			html.NotFound(w, r)
+			return
+		}
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "clientCertificates"))
+}
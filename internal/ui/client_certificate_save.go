@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) saveClientCertificate(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	clientCertificateForm := form.NewClientCertificateForm(r)
+	clientCertificateCreationRequest := &model.ClientCertificateCreationRequest{
+		Name:        clientCertificateForm.Name,
+		Certificate: clientCertificateForm.Certificate,
+		PrivateKey:  clientCertificateForm.PrivateKey,
+		CABundle:    clientCertificateForm.CABundle,
+	}
+
+	if validationErr := validator.ValidateClientCertificateCreation(h.store, user.ID, clientCertificateCreationRequest); validationErr != nil {
+		nsfw := request.IsNSFWEnabled(r)
+		sess := session.New(h.store, request.SessionID(r))
+		view := view.New(h.tpl, r, sess)
+		view.Set("form", clientCertificateForm)
+		view.Set("menu", "settings")
+		view.Set("user", user)
+		view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
+		view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
+		view.Set("errorMessage", validationErr.Translate(user.Language))
+		html.OK(w, r, view.Render("create_client_certificate"))
+		return
+	}
+
+	if _, err := h.store.CreateClientCertificate(user.ID, clientCertificateCreationRequest); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "clientCertificates"))
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showEditDomainRulePage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	domainRule, err := h.store.DomainRule(user.ID, request.RouteInt64Param(r, "domainRuleID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if domainRule == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if !domainRule.IsEditableBy(user) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	domainRuleForm := form.DomainRuleForm{
+		Domain:       domainRule.Domain,
+		ScraperRules: domainRule.ScraperRules,
+		RewriteRules: domainRule.RewriteRules,
+		Global:       domainRule.IsGlobal(),
+	}
+
+	nsfw := request.IsNSFWEnabled(r)
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", domainRuleForm)
+	view.Set("domainRule", domainRule)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
+
+	html.OK(w, r, view.Render("edit_domain_rule"))
+}
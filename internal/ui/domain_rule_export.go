@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/response/json"
+)
+
+func (h *handler) exportDomainRules(w http.ResponseWriter, r *http.Request) {
+	domainRules, err := h.store.DomainRules(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	json.Attachment(w, r, "domain_rules.json", domainRules.Export(request.IsAdminUser(r)))
+}
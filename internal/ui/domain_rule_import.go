@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	json_parser "encoding/json"
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) importDomainRules(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		slog.Error("Domain rules file upload error",
+			slog.Int64("user_id", user.ID),
+			slog.Any("error", err),
+		)
+
+		html.Redirect(w, r, route.Path(h.router, "domainRules"))
+		return
+	}
+	defer file.Close()
+
+	domainRules, err := h.store.DomainRules(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	nsfw := request.IsNSFWEnabled(r)
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("domainRules", domainRules)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
+
+	if fileHeader.Size == 0 {
+		view.Set("errorMessage", locale.NewLocalizedError("error.empty_file").Translate(user.Language))
+		html.OK(w, r, view.Render("domain_rules"))
+		return
+	}
+
+	var domainRuleCreationRequests []model.DomainRuleCreationRequest
+	if err := json_parser.NewDecoder(file).Decode(&domainRuleCreationRequests); err != nil {
+		view.Set("errorMessage", locale.NewLocalizedError("error.unable_to_parse_domain_rules", err).Translate(user.Language))
+		html.OK(w, r, view.Render("domain_rules"))
+		return
+	}
+
+	if validationErr := validator.ValidateDomainRulesImport(user, domainRuleCreationRequests); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(user.Language))
+		html.OK(w, r, view.Render("domain_rules"))
+		return
+	}
+
+	rules := make(model.DomainRules, 0, len(domainRuleCreationRequests))
+	for i := range domainRuleCreationRequests {
+		rules = append(rules, model.NewDomainRule(user.ID, &domainRuleCreationRequests[i]))
+	}
+
+	if err := h.store.ImportDomainRules(rules); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	slog.Info("Domain rules imported",
+		slog.Int64("user_id", user.ID),
+		slog.Int("rules_count", len(rules)),
+	)
+
+	html.Redirect(w, r, route.Path(h.router, "domainRules"))
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+)
+
+func (h *handler) removeDomainRule(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	domainRule, err := h.store.DomainRule(user.ID, request.RouteInt64Param(r, "domainRuleID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if domainRule == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if !domainRule.IsEditableBy(user) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	if err := h.store.RemoveDomainRule(domainRule.UserID, domainRule.ID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "domainRules"))
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) saveDomainRule(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	domainRuleForm := form.NewDomainRuleForm(r)
+	domainRuleCreationRequest := &model.DomainRuleCreationRequest{
+		Domain:       domainRuleForm.Domain,
+		ScraperRules: domainRuleForm.ScraperRules,
+		RewriteRules: domainRuleForm.RewriteRules,
+		Global:       domainRuleForm.Global,
+	}
+
+	if validationErr := validator.ValidateDomainRuleCreation(h.store, user, domainRuleCreationRequest); validationErr != nil {
+		nsfw := request.IsNSFWEnabled(r)
+		sess := session.New(h.store, request.SessionID(r))
+		view := view.New(h.tpl, r, sess)
+		view.Set("form", domainRuleForm)
+		view.Set("menu", "settings")
+		view.Set("user", user)
+		view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
+		view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
+		view.Set("errorMessage", validationErr.Translate(user.Language))
+		html.OK(w, r, view.Render("create_domain_rule"))
+		return
+	}
+
+	if err := h.store.CreateDomainRule(model.NewDomainRule(user.ID, domainRuleCreationRequest)); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "domainRules"))
+}
@@ -29,13 +29,13 @@ func (h *handler) saveEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userIntegrations, err := h.store.Integration(request.UserID(r))
+	userIntegrations, err := h.store.IntegrationSettings(request.UserID(r))
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
-	go integration.SendEntry(entry, userIntegrations)
+	integration.SendEntry(h.store, entry, userIntegrations)
 
 	json.Created(w, r, map[string]string{"message": "saved"})
 }
@@ -9,6 +9,7 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/rewrite"
 	"miniflux.app/v2/internal/reader/scraper"
 
 	"miniflux.app/v2/internal/http/request"
@@ -59,12 +60,24 @@ func (h *handler) submitEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	entry, err := scraper.FetchEntry(user, entryForm.URL, "", entryForm.UserAgent, entryForm.Cookies)
+	domainRule, err := h.store.DomainRuleForURL(user.ID, entryForm.URL)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	var scraperRules, rewriteRules string
+	if domainRule != nil {
+		scraperRules, rewriteRules = domainRule.ScraperRules, domainRule.RewriteRules
+	}
+
+	entry, err := scraper.FetchEntry(user, entryForm.URL, scraperRules, entryForm.UserAgent, entryForm.Cookies)
 	if err != nil {
 		v.Set("errorMessage", err.Error())
 		html.OK(w, r, v.Render("add_entry"))
 		return
 	}
+	rewrite.ApplyContentRewriteRules(entry, rewriteRules)
 
 	entryForm.Title = entry.Title
 	entryForm.Content = entry.Content
@@ -10,6 +10,7 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui/session"
@@ -54,6 +55,7 @@ func (h *handler) showTagEntryPage(w http.ResponseWriter, r *http.Request) {
 		}
 
 		entry.Status = model.EntryStatusRead
+		integration.SendEntriesStatusEvent(h.store, user.ID, []int64{entry.ID}, model.EntryStatusRead)
 	}
 
 	nsfw := request.IsNSFWEnabled(r)
@@ -88,7 +90,7 @@ func (h *handler) showTagEntryPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.hasSaveEntry(user.ID))
 
 	html.OK(w, r, view.Render("entry"))
 }
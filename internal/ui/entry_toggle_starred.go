@@ -8,14 +8,17 @@ import (
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
 )
 
 func (h *handler) toggleStarred(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
 	entryID := request.RouteInt64Param(r, "entryID")
-	if err := h.store.ToggleStarred(request.UserID(r), entryID); err != nil {
+	if err := h.store.ToggleStarred(userID, entryID); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
+	integration.SendEntriesStarredEvent(h.store, userID, []int64{entryID})
 
 	json.OK(w, r, "OK")
 }
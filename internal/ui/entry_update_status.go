@@ -9,6 +9,7 @@ import (
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/validator"
 )
@@ -31,6 +32,8 @@ func (h *handler) updateEntriesStatus(w http.ResponseWriter, r *http.Request) {
 		json.ServerError(w, r, err)
 		return
 	}
+	integration.SendEntriesStatusEvent(h.store, userID, entriesStatusUpdateRequest.EntryIDs, entriesStatusUpdateRequest.Status)
+
 	nsfw := request.IsNSFWEnabled(r)
 	count := h.store.CountUnreadEntries(userID, nsfw)
 
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	feedHandler "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) backfillFeed(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+
+	addedEntries, localizedError := feedHandler.BackfillFeed(h.store, request.UserID(r), feedID)
+	if localizedError != nil {
+		slog.Warn("Unable to backfill feed",
+			slog.Int64("user_id", request.UserID(r)),
+			slog.Int64("feed_id", feedID),
+			slog.Any("error", localizedError.Error()),
+		)
+		sess.NewFlashErrorMessage(printer.Printf("alert.feed_backfill_failed", localizedError.Translate(request.UserLanguage(r))))
+	} else {
+		sess.NewFlashMessage(printer.Printf("alert.feed_backfilled", addedEntries))
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "feedEntries", "feedID", feedID))
+}
@@ -11,6 +11,7 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/extractor"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
@@ -41,6 +42,12 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	clientCertificates, err := h.store.ClientCertificates(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	feedForm := form.FeedForm{
 		SiteURL:                     feed.SiteURL,
 		FeedURL:                     feed.FeedURL,
@@ -67,15 +74,11 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		Disabled:                    feed.Disabled,
 		NoMediaPlayer:               feed.NoMediaPlayer,
 		NSFW:                        feed.NSFW,
-		AppriseServiceURLs:          feed.AppriseServiceURLs,
-		WebhookURL:                  feed.WebhookURL,
 		DisableHTTP2:                feed.DisableHTTP2,
-		NtfyEnabled:                 feed.NtfyEnabled,
-		NtfyPriority:                feed.NtfyPriority,
-		NtfyTopic:                   feed.NtfyTopic,
-		PushoverEnabled:             feed.PushoverEnabled,
-		PushoverPriority:            feed.PushoverPriority,
 		ProxyURL:                    feed.ProxyURL,
+		Extractor:                   feed.Extractor,
+		CustomHeaders:               feed.CustomHeaders,
+		ClientCertificateID:         feed.ClientCertificateID,
 	}
 
 	all, count, size, err := h.store.MediaStatisticsByFeed(feedID)
@@ -84,12 +87,32 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	publishingProfile, err := h.store.FeedPublishingProfile(user.ID, feedID, user.Timezone)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	fetchLogs, err := h.store.FeedFetchLogs(user.ID, feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedHealth, err := h.store.FeedHealth(user.ID, feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	nsfw := request.IsNSFWEnabled(r)
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", feedForm)
 	view.Set("categories", categories)
+	view.Set("clientCertificates", clientCertificates)
 	view.Set("views", model.Views())
+	view.Set("extractors", extractor.Names())
 	view.Set("feed", feed)
 	view.Set("menu", "feeds")
 	view.Set("user", user)
@@ -99,6 +122,9 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("mediaCount", all)
 	view.Set("cacheCount", count)
 	view.Set("cacheSize", byteSizeHumanReadable(size))
+	view.Set("publishingProfile", publishingProfile)
+	view.Set("fetchLogs", fetchLogs)
+	view.Set("feedHealth", feedHealth)
 	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyURLConfigured())
 
 	html.OK(w, r, view.Render("edit_feed"))
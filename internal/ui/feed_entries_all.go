@@ -6,6 +6,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"net/http"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
@@ -54,10 +55,17 @@ func (h *handler) showFeedEntriesAllPage(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	feedHealth, err := h.store.FeedHealth(user.ID, feed.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	nsfw := request.IsNSFWEnabled(r)
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("feed", feed)
+	view.Set("feedHealth", feedHealth)
 	view.Set("entries", entries)
 	view.Set("total", count)
 	view.Set("pagination", getPagination(route.Path(h.router, "feedEntriesAll", "feedID", feed.ID), count, offset, user.EntriesPerPage))
@@ -65,7 +73,8 @@ func (h *handler) showFeedEntriesAllPage(w http.ResponseWriter, r *http.Request)
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.hasSaveEntry(user.ID))
+	view.Set("backfillMaxEntries", config.Opts.BackfillMaxEntries())
 	view.Set("showOnlyUnreadEntries", false)
 	view.Set("pageEntriesType", "all")
 	if feed.View != model.ViewDefault {
@@ -7,6 +7,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"net/http"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
@@ -56,10 +57,17 @@ func (h *handler) showFeedEntriesStarredPage(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	feedHealth, err := h.store.FeedHealth(user.ID, feed.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	nsfw := request.IsNSFWEnabled(r)
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("feed", feed)
+	view.Set("feedHealth", feedHealth)
 	view.Set("entries", entries)
 	view.Set("total", count)
 	view.Set("pagination", getPagination(route.Path(h.router, "feedEntriesStarred", "feedID", feed.ID), count, offset, user.EntriesPerPage))
@@ -67,7 +75,8 @@ func (h *handler) showFeedEntriesStarredPage(w http.ResponseWriter, r *http.Requ
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.hasSaveEntry(user.ID))
+	view.Set("backfillMaxEntries", config.Opts.BackfillMaxEntries())
 	view.Set("showOnlyStarredEntries", true)
 	view.Set("pageEntriesType", "starred")
 	if feed.View != model.ViewDefault {
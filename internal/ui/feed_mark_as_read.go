@@ -9,6 +9,8 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
 )
 
 func (h *handler) markFeedAsRead(w http.ResponseWriter, r *http.Request) {
@@ -21,10 +23,13 @@ func (h *handler) markFeedAsRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = h.store.MarkFeedAsRead(userID, feedID, checkedAt); err != nil {
+	entryIDs, err := h.store.MarkFeedAsRead(userID, feedID, checkedAt)
+	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
+	integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
+
 	html.Redirect(w, r, route.Path(h.router, "feeds"))
 }
@@ -15,6 +15,7 @@ import (
 	"miniflux.app/v2/internal/locale"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/worker"
 )
 
 func (h *handler) refreshFeed(w http.ResponseWriter, r *http.Request) {
@@ -61,7 +62,7 @@ func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 			slog.Int("nb_jobs", len(jobs)),
 		)
 
-		go h.pool.Push(jobs)
+		h.pool.PushWithPriority(jobs, worker.PriorityUser)
 
 		sess.SetLastForceRefresh()
 		sess.NewFlashMessage(printer.Print("alert.background_feed_refresh"))
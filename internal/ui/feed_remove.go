@@ -9,12 +9,20 @@ import (
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
 )
 
 func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
 	feedID := request.RouteInt64Param(r, "feedID")
 
-	if !h.store.FeedExists(request.UserID(r), feedID) {
+	feed, err := h.store.FeedByID(request.UserID(r), feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
 		html.NotFound(w, r)
 		return
 	}
@@ -24,5 +32,7 @@ func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	integration.SendFeedEvent(h.store, model.EventFeedRemoved, feed)
+
 	html.Redirect(w, r, route.Path(h.router, "feeds"))
 }
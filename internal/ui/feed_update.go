@@ -11,6 +11,7 @@ import (
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/extractor"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
@@ -42,6 +43,12 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	clientCertificates, err := h.store.ClientCertificates(loggedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	feedForm := form.NewFeedForm(r)
 
 	nsfw := request.IsNSFWEnabled(r)
@@ -49,6 +56,8 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", feedForm)
 	view.Set("categories", categories)
+	view.Set("clientCertificates", clientCertificates)
+	view.Set("extractors", extractor.Names())
 	view.Set("feed", feed)
 	view.Set("menu", "feeds")
 	view.Set("user", loggedUser)
@@ -66,6 +75,10 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
 		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
 		ProxyURL:        model.OptionalString(feedForm.ProxyURL),
+		Extractor:       model.OptionalString(feedForm.Extractor),
+		CustomHeaders:   model.OptionalString(feedForm.CustomHeaders),
+
+		ClientCertificateID: &feedForm.ClientCertificateID,
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feed.ID, feedModificationRequest); validationErr != nil {
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ClientCertificateForm represents the client certificate upload form.
+type ClientCertificateForm struct {
+	Name        string
+	Certificate string
+	PrivateKey  string
+	CABundle    string
+}
+
+// NewClientCertificateForm returns a new ClientCertificateForm.
+func NewClientCertificateForm(r *http.Request) *ClientCertificateForm {
+	return &ClientCertificateForm{
+		Name:        strings.TrimSpace(r.FormValue("name")),
+		Certificate: strings.TrimSpace(r.FormValue("certificate")),
+		PrivateKey:  strings.TrimSpace(r.FormValue("private_key")),
+		CABundle:    strings.TrimSpace(r.FormValue("ca_bundle")),
+	}
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DomainRuleForm represents a domain rule form in the UI.
+type DomainRuleForm struct {
+	Domain       string
+	ScraperRules string
+	RewriteRules string
+	Global       bool
+}
+
+// NewDomainRuleForm returns a new DomainRuleForm.
+func NewDomainRuleForm(r *http.Request) *DomainRuleForm {
+	return &DomainRuleForm{
+		Domain:       strings.TrimSpace(r.FormValue("domain")),
+		ScraperRules: strings.TrimSpace(r.FormValue("scraper_rules")),
+		RewriteRules: strings.TrimSpace(r.FormValue("rewrite_rules")),
+		Global:       r.FormValue("global") == "1",
+	}
+}
@@ -37,15 +37,12 @@ type FeedForm struct {
 	Disabled                    bool
 	NoMediaPlayer               bool
 	NSFW                        bool
-	AppriseServiceURLs          string
-	WebhookURL                  string
 	DisableHTTP2                bool
-	NtfyEnabled                 bool
-	NtfyPriority                int
-	NtfyTopic                   string
-	PushoverEnabled             bool
-	PushoverPriority            int
 	ProxyURL                    string
+	Extractor                   string
+	CustomHeaders               string
+	ClearCustomHeaders          bool
+	ClientCertificateID         int64
 }
 
 // Merge updates the fields of the given feed.
@@ -77,15 +74,15 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.Disabled = f.Disabled
 	feed.NoMediaPlayer = f.NoMediaPlayer
 	feed.NSFW = f.NSFW
-	feed.AppriseServiceURLs = f.AppriseServiceURLs
-	feed.WebhookURL = f.WebhookURL
 	feed.DisableHTTP2 = f.DisableHTTP2
-	feed.NtfyEnabled = f.NtfyEnabled
-	feed.NtfyPriority = f.NtfyPriority
-	feed.NtfyTopic = f.NtfyTopic
-	feed.PushoverEnabled = f.PushoverEnabled
-	feed.PushoverPriority = f.PushoverPriority
 	feed.ProxyURL = f.ProxyURL
+	feed.Extractor = f.Extractor
+	feed.CustomHeaders = f.CustomHeaders
+	if f.ClearCustomHeaders {
+		feed.CustomHeaders = ""
+		feed.CustomHeadersUnreadable = false
+	}
+	feed.ClientCertificateID = f.ClientCertificateID
 	return feed
 }
 
@@ -100,14 +97,9 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		view = model.ViewDefault
 	}
 
-	ntfyPriority, err := strconv.Atoi(r.FormValue("ntfy_priority"))
-	if err != nil {
-		ntfyPriority = 0
-	}
-
-	pushoverPriority, err := strconv.Atoi(r.FormValue("pushover_priority"))
+	clientCertificateID, err := strconv.ParseInt(r.FormValue("client_certificate_id"), 10, 64)
 	if err != nil {
-		pushoverPriority = 0
+		clientCertificateID = 0
 	}
 
 	return &FeedForm{
@@ -136,14 +128,11 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		Disabled:                    r.FormValue("disabled") == "1",
 		NoMediaPlayer:               r.FormValue("no_media_player") == "1",
 		NSFW:                        r.FormValue("nsfw") == "1",
-		AppriseServiceURLs:          r.FormValue("apprise_service_urls"),
-		WebhookURL:                  r.FormValue("webhook_url"),
 		DisableHTTP2:                r.FormValue("disable_http2") == "1",
-		NtfyEnabled:                 r.FormValue("ntfy_enabled") == "1",
-		NtfyPriority:                ntfyPriority,
-		NtfyTopic:                   r.FormValue("ntfy_topic"),
-		PushoverEnabled:             r.FormValue("pushover_enabled") == "1",
-		PushoverPriority:            pushoverPriority,
 		ProxyURL:                    r.FormValue("proxy_url"),
+		Extractor:                   r.FormValue("extractor"),
+		CustomHeaders:               r.FormValue("custom_headers"),
+		ClearCustomHeaders:          r.FormValue("clear_custom_headers") == "1",
+		ClientCertificateID:         clientCertificateID,
 	}
 }
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// NotificationRouteForm represents a notification route form in the UI.
+type NotificationRouteForm struct {
+	Provider    string
+	CategoryID  int64
+	FeedID      int64
+	FilterRules string
+	Target      string
+	Priority    int
+}
+
+// Merge updates the fields of the given notification route.
+func (f NotificationRouteForm) Merge(route *model.NotificationRoute) *model.NotificationRoute {
+	route.Provider = f.Provider
+	route.CategoryID = f.CategoryID
+	route.FeedID = f.FeedID
+	route.FilterRules = f.FilterRules
+	route.Target = f.Target
+	route.Priority = f.Priority
+	return route
+}
+
+// NewNotificationRouteForm returns a new NotificationRouteForm.
+func NewNotificationRouteForm(r *http.Request) *NotificationRouteForm {
+	categoryID, _ := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+	feedID, _ := strconv.ParseInt(r.FormValue("feed_id"), 10, 64)
+	priority, _ := strconv.Atoi(r.FormValue("priority"))
+
+	return &NotificationRouteForm{
+		Provider:    r.FormValue("provider"),
+		CategoryID:  categoryID,
+		FeedID:      feedID,
+		FilterRules: strings.TrimSpace(r.FormValue("filter_rules")),
+		Target:      strings.TrimSpace(r.FormValue("target")),
+		Priority:    priority,
+	}
+}
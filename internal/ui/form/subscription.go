@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/validator"
 )
 
@@ -31,6 +32,8 @@ type SubscriptionForm struct {
 	KeepFilterEntryRules        string
 	DisableHTTP2                bool
 	ProxyURL                    string
+	CustomHeaders               string
+	ClientCertificateID         int64
 
 	NSFW bool
 }
@@ -61,6 +64,10 @@ func (s *SubscriptionForm) Validate() *locale.LocalizedError {
 		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
 	}
 
+	if _, _, err := fetcher.ParseCustomHeaders(s.CustomHeaders); err != nil {
+		return locale.NewLocalizedError("error.feed_invalid_custom_headers")
+	}
+
 	return nil
 }
 
@@ -71,6 +78,11 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 		categoryID = 0
 	}
 
+	clientCertificateID, err := strconv.ParseInt(r.FormValue("client_certificate_id"), 10, 64)
+	if err != nil {
+		clientCertificateID = 0
+	}
+
 	return &SubscriptionForm{
 		URL:                         r.FormValue("url"),
 		CategoryID:                  int64(categoryID),
@@ -90,6 +102,8 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 		BlockFilterEntryRules:       r.FormValue("block_filter_entry_rules"),
 		DisableHTTP2:                r.FormValue("disable_http2") == "1",
 		ProxyURL:                    r.FormValue("proxy_url"),
+		CustomHeaders:               r.FormValue("custom_headers"),
+		ClientCertificateID:         clientCertificateID,
 		NSFW:                        r.FormValue("nsfw") == "1",
 	}
 }
@@ -4,6 +4,11 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/template"
 	"miniflux.app/v2/internal/worker"
@@ -17,3 +22,33 @@ type handler struct {
 	tpl    *template.Engine
 	pool   *worker.Pool
 }
+
+// hasSaveEntry returns true if the user can save entries to third-party providers.
+func (h *handler) hasSaveEntry(userID int64) bool {
+	settings, err := h.store.IntegrationSettings(userID)
+	if err != nil {
+		slog.Error("Unable to fetch integration settings",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	return integration.HasSaveEntry(settings)
+}
+
+func (h *handler) hasBookSender(userID int64) bool {
+	settings, err := h.store.IntegrationSettings(userID)
+	if err != nil {
+		slog.Error("Unable to fetch integration settings",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	return integration.HasBookSender(settings)
+}
+
+// integrationOAuth2RedirectURL returns the callback URL registered in the OAuth2 application of a provider.
+func (h *handler) integrationOAuth2RedirectURL(provider string) string {
+	return config.Opts.RootURL() + route.Path(h.router, "integrationOAuth2Callback", "provider", provider)
+}
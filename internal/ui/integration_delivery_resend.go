@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) resendIntegrationDelivery(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.store.IntegrationDeliveryByID(request.UserID(r), request.RouteInt64Param(r, "deliveryID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if delivery == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+
+	// Pending deliveries are already being sent or waiting for their next attempt.
+	if delivery.Status == model.DeliveryStatusPending && delivery.Attempts == 0 {
+		sess.NewFlashErrorMessage(printer.Print("error.integration_delivery_in_progress"))
+	} else if err := integration.Redeliver(h.store, delivery); err != nil {
+		html.ServerError(w, r, err)
+		return
+	} else {
+		sess.NewFlashMessage(printer.Print("alert.integration_delivery_resent"))
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "integrationDeliveries"))
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) integrationOAuth2Callback(w http.ResponseWriter, r *http.Request) {
+	provider := request.RouteStringParam(r, "provider")
+	if integration.OAuth2Config(provider, "") == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+
+	// The user denied the access or the provider failed.
+	code := request.QueryStringParam(r, "code", "")
+	if code == "" {
+		slog.Warn("No code received on integration OAuth2 callback",
+			slog.Int64("user_id", request.UserID(r)),
+			slog.String("provider", provider),
+			slog.String("error", request.QueryStringParam(r, "error", "")),
+		)
+		sess.NewFlashErrorMessage(printer.Print("error.integration_oauth2_failed"))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	if !isValidOAuth2State(request.QueryStringParam(r, "state", ""), request.OAuth2State(r)) {
+		slog.Warn("Invalid OAuth2 state value received on integration callback",
+			slog.Int64("user_id", request.UserID(r)),
+			slog.String("provider", provider),
+		)
+		sess.NewFlashErrorMessage(printer.Print("error.integration_oauth2_failed"))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	// The state and the code verifier are used once, a failed or replayed callback cannot use them again.
+	codeVerifier := request.OAuth2CodeVerifier(r)
+	sess.SetOAuth2State("")
+	sess.SetOAuth2CodeVerifier("")
+
+	err := integration.ConnectOAuth2(r.Context(), h.store, request.UserID(r), provider, h.integrationOAuth2RedirectURL(provider), code, codeVerifier)
+	if err != nil {
+		slog.Warn("Unable to connect integration with OAuth2",
+			slog.Int64("user_id", request.UserID(r)),
+			slog.String("provider", provider),
+			slog.Any("error", err),
+		)
+		sess.NewFlashErrorMessage(printer.Print("error.integration_oauth2_failed"))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	sess.NewFlashMessage(printer.Print("alert.integration_oauth2_connected"))
+	html.Redirect(w, r, route.Path(h.router, "integrations"))
+}
+
+// isValidOAuth2State compares the state received on the callback with the state saved in the session.
+// The saved state is cleared once used, an empty state never matches.
+func isValidOAuth2State(state, expectedState string) bool {
+	if state == "" || expectedState == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(state), []byte(expectedState)) == 1
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import "testing"
+
+func TestIsValidOAuth2State(t *testing.T) {
+	scenarios := []struct {
+		state, expectedState string
+		valid                bool
+	}{
+		{"abc123", "abc123", true},
+		{"abc123", "xyz789", false},
+		{"", "abc123", false},
+		{"abc123", "", false},
+		// The saved state is cleared after a successful connection.
+		{"", "", false},
+	}
+
+	for _, scenario := range scenarios {
+		if valid := isValidOAuth2State(scenario.state, scenario.expectedState); valid != scenario.valid {
+			t.Errorf(`Unexpected result for the state %q and the expected state %q: got %v`, scenario.state, scenario.expectedState, valid)
+		}
+	}
+}
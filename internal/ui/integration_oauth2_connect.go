@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/oauth2"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) connectIntegrationOAuth2(w http.ResponseWriter, r *http.Request) {
+	provider := request.RouteStringParam(r, "provider")
+	oauth2Config := integration.OAuth2Config(provider, h.integrationOAuth2RedirectURL(provider))
+	if oauth2Config == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	auth := oauth2.GenerateAuthorization(oauth2Config, integration.OAuth2AuthCodeOptions(provider)...)
+
+	sess := session.New(h.store, request.SessionID(r))
+	sess.SetOAuth2State(auth.State())
+	sess.SetOAuth2CodeVerifier(auth.CodeVerifier())
+
+	html.Redirect(w, r, auth.RedirectURL())
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) disconnectIntegrationOAuth2(w http.ResponseWriter, r *http.Request) {
+	provider := request.RouteStringParam(r, "provider")
+	if err := h.store.RemoveIntegrationToken(request.UserID(r), provider); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	sess := session.New(h.store, request.SessionID(r))
+	sess.NewFlashMessage(printer.Print("alert.integration_oauth2_disconnected"))
+	html.Redirect(w, r, route.Path(h.router, "integrations"))
+}
@@ -8,11 +8,14 @@ import (
 	"fmt"
 	"net/http"
 
+	"miniflux.app/v2/internal/activitypub"
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/session"
 )
@@ -22,76 +25,94 @@ func (h *handler) updateIntegration(w http.ResponseWriter, r *http.Request) {
 	sess := session.New(h.store, request.SessionID(r))
 	userID := request.UserID(r)
 
-	integration, err := h.store.Integration(userID)
+	userIntegration, err := h.store.Integration(userID)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
 	integrationForm := form.NewIntegrationForm(r)
-	integrationForm.Merge(integration)
+	integrationForm.Merge(userIntegration)
 
-	if integration.FeverUsername != "" && h.store.HasDuplicateFeverUsername(userID, integration.FeverUsername) {
+	if userIntegration.FeverUsername != "" && h.store.HasDuplicateFeverUsername(userID, userIntegration.FeverUsername) {
 		sess.NewFlashErrorMessage(printer.Print("error.duplicate_fever_username"))
 		html.Redirect(w, r, route.Path(h.router, "integrations"))
 		return
 	}
 
-	if integration.FeverEnabled {
+	if userIntegration.FeverEnabled {
 		if integrationForm.FeverPassword != "" {
-			integration.FeverToken = fmt.Sprintf("%x", md5.Sum([]byte(integration.FeverUsername+":"+integrationForm.FeverPassword)))
+			userIntegration.FeverToken = fmt.Sprintf("%x", md5.Sum([]byte(userIntegration.FeverUsername+":"+integrationForm.FeverPassword)))
 		}
 	} else {
-		integration.FeverToken = ""
+		userIntegration.FeverToken = ""
 	}
 
-	if integration.GoogleReaderUsername != "" && h.store.HasDuplicateGoogleReaderUsername(userID, integration.GoogleReaderUsername) {
+	if userIntegration.GoogleReaderUsername != "" && h.store.HasDuplicateGoogleReaderUsername(userID, userIntegration.GoogleReaderUsername) {
 		sess.NewFlashErrorMessage(printer.Print("error.duplicate_googlereader_username"))
 		html.Redirect(w, r, route.Path(h.router, "integrations"))
 		return
 	}
 
-	if integration.GoogleReaderEnabled {
+	if userIntegration.GoogleReaderEnabled {
 		if integrationForm.GoogleReaderPassword != "" {
-			integration.GoogleReaderPassword, err = crypto.HashPassword(integrationForm.GoogleReaderPassword)
+			userIntegration.GoogleReaderPassword, err = crypto.HashPassword(integrationForm.GoogleReaderPassword)
 			if err != nil {
 				html.ServerError(w, r, err)
 				return
 			}
 		}
 	} else {
-		integration.GoogleReaderPassword = ""
+		userIntegration.GoogleReaderPassword = ""
 	}
 
-	if integrationForm.WebhookEnabled {
-		if integrationForm.WebhookURL == "" {
-			integration.WebhookEnabled = false
-			integration.WebhookSecret = ""
-		} else if integration.WebhookSecret == "" {
-			integration.WebhookSecret = crypto.GenerateRandomStringHex(32)
-		}
-	} else {
-		integration.WebhookURL = ""
-		integration.WebhookSecret = ""
+	integrationSettings, err := h.store.IntegrationSettings(userID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
 	}
 
-	if integrationForm.LinktacoEnabled {
-		if integrationForm.LinktacoAPIToken == "" || integrationForm.LinktacoOrgSlug == "" {
-			sess.NewFlashErrorMessage(printer.Print("error.linktaco_missing_required_fields"))
+	settingsByProvider := make(map[string]*model.IntegrationSettings, len(integrationSettings))
+	storedProviders := make(map[string]bool, len(integrationSettings))
+	for _, settings := range integrationSettings {
+		settingsByProvider[settings.Provider] = settings
+		storedProviders[settings.Provider] = true
+	}
+	integrationForm.MergeProviders(userID, settingsByProvider)
+
+	for _, provider := range integration.Providers() {
+		settings := settingsByProvider[provider.Name()]
+		if validationErr := provider.Validate(settings); validationErr != nil {
+			sess.NewFlashErrorMessage(validationErr.Translate(request.UserLanguage(r)))
 			html.Redirect(w, r, route.Path(h.router, "integrations"))
 			return
 		}
-		if integration.LinktacoVisibility == "" {
-			integration.LinktacoVisibility = "PUBLIC"
-		}
 	}
 
-	err = h.store.UpdateIntegration(integration)
+	if settings := settingsByProvider[activitypub.ProviderName]; settings != nil && settings.Enabled &&
+		h.store.HasDuplicateActivityPubUsername(userID, settings.Values.String(activitypub.UsernameField)) {
+		sess.NewFlashErrorMessage(printer.Print("error.duplicate_activitypub_username"))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	err = h.store.UpdateIntegration(userIntegration)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
+	for _, settings := range settingsByProvider {
+		// Avoid storing a row for each provider the user never configured.
+		if !settings.Enabled && len(settings.Values) == 0 && !storedProviders[settings.Provider] {
+			continue
+		}
+		if err := h.store.UpdateIntegrationSettings(settings); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
 	sess.NewFlashMessage(printer.Print("alert.prefs_saved"))
 	html.Redirect(w, r, route.Path(h.router, "integrations"))
 }
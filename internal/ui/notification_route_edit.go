@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/session"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showEditNotificationRoutePage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	notificationRoute, err := h.store.NotificationRoute(user.ID, request.RouteInt64Param(r, "routeID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if notificationRoute == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feeds, err := h.store.Feeds(user.ID, false)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	routeForm := form.NotificationRouteForm{
+		Provider:    notificationRoute.Provider,
+		CategoryID:  notificationRoute.CategoryID,
+		FeedID:      notificationRoute.FeedID,
+		FilterRules: notificationRoute.FilterRules,
+		Target:      notificationRoute.Target,
+		Priority:    notificationRoute.Priority,
+	}
+
+	nsfw := request.IsNSFWEnabled(r)
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", routeForm)
+	view.Set("notificationRoute", notificationRoute)
+	view.Set("providers", integration.NotifierProviders())
+	view.Set("categories", categories)
+	view.Set("feeds", feeds)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
+
+	html.OK(w, r, view.Render("edit_notification_route"))
+}
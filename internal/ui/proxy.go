@@ -4,16 +4,17 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
-	"net/http/httputil"
 	"net/http"
+	"net/http/httputil"
 	"net/url"
-	"os"
 	"path"
 	"time"
 
@@ -21,17 +22,17 @@ import (
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/filesystem"
 	"miniflux.app/v2/internal/http/request"
-	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/media"
 )
 
-func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
-	// If we receive a "If-None-Match" header, we assume the media is already stored in browser cache.
-	if r.Header.Get("If-None-Match") != "" {
-		w.WriteHeader(http.StatusNotModified)
-		return
-	}
+const mediaProxyCacheDuration = 72 * time.Hour
 
+// Request headers forwarded to the origin, the cookies and credentials of the user are never sent.
+var mediaProxyForwardedHeaders = []string{"User-Agent", "Range", "Accept", "If-None-Match", "If-Modified-Since"}
+
+func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 	encodedDigest := request.RouteStringParam(r, "encodedDigest")
 	encodedURL := request.RouteStringParam(r, "encodedURL")
 	if encodedURL == "" {
@@ -82,96 +83,145 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	mediaURL := string(decodedURL)
+	if serveCachedMedia(w, r, h.store, mediaURL, parsedMediaURL) {
+		return
+	}
+
 	slog.Debug("MediaProxy: Fetching remote resource",
 		slog.String("media_url", mediaURL),
 	)
-	etag := crypto.HashFromBytes(decodedURL)
 
-	m, err := h.store.MediaByURL(mediaURL)
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			targetURL := *parsedMediaURL
+			req.URL = &targetURL
+			req.Host = parsedMediaURL.Host
+
+			header := make(http.Header)
+			for _, name := range mediaProxyForwardedHeaders {
+				if value := r.Header.Get(name); value != "" {
+					header.Set(name, value)
+				}
+			}
+			if header.Get("User-Agent") == "" {
+				header.Set("User-Agent", config.Opts.HTTPClientUserAgent())
+			}
+			// A nil value prevents the reverse proxy from disclosing the client address.
+			header["X-Forwarded-For"] = nil
+			req.Header = header
+		},
+		ModifyResponse: func(res *http.Response) error {
+			setMediaProxyHeaders(res.Header, parsedMediaURL)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			slog.Error("MediaProxy: Unable to fetch remote resource",
+				slog.String("media_url", mediaURL),
+				slog.Any("error", err),
+			)
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// mediaCacheStore is the part of the storage used to serve the cached media.
+type mediaCacheStore interface {
+	MediaByURL(URL string) (*model.Media, error)
+	UncachedProxyMedia(urlHash string) (*model.Media, error)
+	UpdateMediaError(m *model.Media) error
+	CacheProxyMedia(media *model.Media) error
+}
+
+// serveCachedMedia writes the media from the local cache, it returns false when the media is not cached.
+// When write-through is enabled, the media of the feeds caching their media is downloaded and cached first.
+func serveCachedMedia(w http.ResponseWriter, r *http.Request, store mediaCacheStore, mediaURL string, parsedMediaURL *url.URL) bool {
+	m, err := store.MediaByURL(mediaURL)
 	if err != nil {
-		goto FETCH
-	}
-	if m.Content != nil {
-		slog.Debug(`proxy from database`, slog.String("media_url", mediaURL))
-		response.New(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
-			b.WithHeader("Content-Type", m.MimeType)
-			b.WithBody(m.Content)
-			b.WithoutCompression()
-			b.Write()
-		})
-		return
+		slog.Error("MediaProxy: Unable to fetch media from the database",
+			slog.String("media_url", mediaURL),
+			slog.Any("error", err),
+		)
+		return false
 	}
 
-	if m.Cached {
-		// cache is located in file system
-		var file *os.File
-		file, err = filesystem.MediaFileByHash(m.URLHash)
-		if err != nil {
-			slog.Error("Unable to fetch media from file system: %s", slog.Any("error", err))
-			goto FETCH
-		}
+	if len(m.Content) > 0 {
+		slog.Debug("MediaProxy: Serving media from the database", slog.String("media_url", mediaURL))
+		serveMediaContent(w, r, m.MimeType, crypto.HashFromBytes(m.Content), time.Time{}, bytes.NewReader(m.Content), parsedMediaURL)
+		return true
+	}
+
+	if file, err := filesystem.MediaFileByHash(m.URLHash); err == nil {
 		defer file.Close()
-		slog.Debug(`proxy from filesystem`, slog.String("media_url", mediaURL))
-		response.New(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
-			b.WithHeader("Content-Type", m.MimeType)
-			b.WithBody(file)
-			b.WithoutCompression()
-			b.Write()
-		})
-		return
+		if stat, err := file.Stat(); err == nil && stat.Mode().IsRegular() && stat.Size() > 0 {
+			slog.Debug("MediaProxy: Serving media from the file system", slog.String("media_url", mediaURL))
+			etag := crypto.SHA256(fmt.Sprintf("%s-%d-%d", m.URLHash, stat.Size(), stat.ModTime().UnixNano()))
+			serveMediaContent(w, r, m.MimeType, etag, stat.ModTime(), file, parsedMediaURL)
+			return true
+		}
+	}
+
+	// A partial request is streamed, the whole media is downloaded on the next full request.
+	if !config.Opts.MediaProxyWriteThrough() || r.Header.Get("Range") != "" {
+		return false
+	}
+
+	uncached, err := store.UncachedProxyMedia(m.URLHash)
+	if err != nil {
+		slog.Error("MediaProxy: Unable to fetch uncached media",
+			slog.String("media_url", mediaURL),
+			slog.Any("error", err),
+		)
+		return false
+	}
+	if uncached == nil {
+		return false
+	}
+
+	if err := media.FindMedia(uncached); err != nil {
+		slog.Warn("MediaProxy: Unable to download media for caching",
+			slog.String("media_url", mediaURL),
+			slog.Any("error", err),
+		)
+		uncached.ErrorCount++
+		if err := store.UpdateMediaError(uncached); err != nil {
+			slog.Error("MediaProxy: Unable to update media error", slog.Any("error", err))
+		}
+		return false
 	}
 
-FETCH:
-    slog.Debug("fetch and proxy", slog.String("media_url", mediaURL))
-
-    // 官方 Miniflux v2 成熟反向代理实现
-    // 完美支持视频 Range 请求、流式传输、206 Partial Content
-    // 彻底解决 mp4 视频 500 Internal Server Error
-    // 完全兼容 qjebbs fork 的 WithCaching 机制
-
-    director := func(req *http.Request) {
-        req.URL.Scheme = parsedMediaURL.Scheme
-        req.URL.Host = parsedMediaURL.Host
-        req.URL.Path = parsedMediaURL.Path
-        req.URL.RawQuery = parsedMediaURL.RawQuery
-        req.Host = parsedMediaURL.Host
-
-        if ua := r.Header.Get("User-Agent"); ua != "" {
-            req.Header.Set("User-Agent", ua)
-        } else {
-            req.Header.Set("User-Agent", "Miniflux/MediaProxy")
-        }
-
-        if rangeVal := r.Header.Get("Range"); rangeVal != "" {
-            req.Header.Set("Range", rangeVal)
-        }
-    }
-
-    proxy := &httputil.ReverseProxy{
-        Director: director,
-        ModifyResponse: func(res *http.Response) error {
-            res.Header.Set("Content-Security-Policy", "default-src 'self'")
-
-            if filename := path.Base(parsedMediaURL.Path); filename != "" && filename != "." && filename != "/" {
-                res.Header.Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
-            }
-
-            res.Header.Set("Accept-Ranges", "bytes")
-            return nil
-        },
-        ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
-            slog.Error("MediaProxy: ReverseProxy failed",
-                slog.String("media_url", mediaURL),
-                slog.Any("error", err))
-            http.Error(w, "Bad Gateway", http.StatusBadGateway)
-        },
-    }
-
-    // 关键修改：WithCaching 回调中直接用原始 w 执行代理
-    // 这样兼容 qjebbs 的 Builder（无 Writer() 方法），同时保留 ETag 判断
-    response.New(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
-        proxy.ServeHTTP(w, r)  // 直接用 w，不用 b.Writer()
-    })
-
-    return
+	if err := store.CacheProxyMedia(uncached); err != nil {
+		slog.Error("MediaProxy: Unable to cache media",
+			slog.String("media_url", mediaURL),
+			slog.Any("error", err),
+		)
+	} else {
+		slog.Debug("MediaProxy: Cached media on first access", slog.String("media_url", mediaURL))
+	}
+
+	serveMediaContent(w, r, uncached.MimeType, crypto.HashFromBytes(uncached.Content), time.Time{}, bytes.NewReader(uncached.Content), parsedMediaURL)
+	return true
+}
+
+// serveMediaContent writes a media with its validators, http.ServeContent handles
+// conditional and range requests, and sniffs the content type when it is unknown.
+func serveMediaContent(w http.ResponseWriter, r *http.Request, mimeType, etag string, modTime time.Time, content io.ReadSeeker, parsedMediaURL *url.URL) {
+	header := w.Header()
+	setMediaProxyHeaders(header, parsedMediaURL)
+	header.Set("ETag", `"`+etag+`"`)
+	header.Set("Cache-Control", "public")
+	header.Set("Expires", time.Now().Add(mediaProxyCacheDuration).UTC().Format(http.TimeFormat))
+	header.Set("X-Content-Type-Options", "nosniff")
+	if mimeType != "" {
+		header.Set("Content-Type", mimeType)
+	}
+
+	http.ServeContent(w, r, path.Base(parsedMediaURL.Path), modTime, content)
+}
+
+func setMediaProxyHeaders(header http.Header, parsedMediaURL *url.URL) {
+	header.Set("Content-Security-Policy", "default-src 'self'")
+	if filename := path.Base(parsedMediaURL.Path); filename != "" && filename != "." && filename != "/" {
+		header.Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, filename))
+	}
 }
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/filesystem"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/media"
+)
+
+type fakeMediaCacheStore struct {
+	media        *model.Media
+	uncached     *model.Media
+	cachedMedias []*model.Media
+}
+
+func (s *fakeMediaCacheStore) MediaByURL(URL string) (*model.Media, error) {
+	if s.media == nil {
+		return &model.Media{URLHash: media.URLHash(URL)}, nil
+	}
+	return s.media, nil
+}
+
+func (s *fakeMediaCacheStore) UncachedProxyMedia(urlHash string) (*model.Media, error) {
+	return s.uncached, nil
+}
+
+func (s *fakeMediaCacheStore) UpdateMediaError(m *model.Media) error {
+	return nil
+}
+
+func (s *fakeMediaCacheStore) CacheProxyMedia(m *model.Media) error {
+	s.cachedMedias = append(s.cachedMedias, m)
+	return nil
+}
+
+func setupMediaProxyConfig(t *testing.T, writeThrough bool) {
+	t.Helper()
+
+	os.Clearenv()
+	os.Setenv("DISK_STORAGE_ROOT", t.TempDir())
+	if writeThrough {
+		os.Setenv("MEDIA_PROXY_WRITE_THROUGH", "1")
+	}
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+}
+
+func serveTestMedia(t *testing.T, store mediaCacheStore, mediaURL string, header http.Header) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+
+	parsedMediaURL, err := url.Parse(mediaURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/proxy/digest/encoded", nil)
+	for name, values := range header {
+		r.Header[name] = values
+	}
+
+	w := httptest.NewRecorder()
+	served := serveCachedMedia(w, r, store, mediaURL, parsedMediaURL)
+	return w, served
+}
+
+func TestServeCachedMediaFromDatabase(t *testing.T) {
+	setupMediaProxyConfig(t, false)
+
+	mediaURL := "https://example.org/images/photo.jpg"
+	store := &fakeMediaCacheStore{media: &model.Media{URLHash: media.URLHash(mediaURL), MimeType: "image/jpeg", Content: []byte("jpeg content")}}
+
+	w, served := serveTestMedia(t, store, mediaURL, nil)
+	if !served {
+		t.Fatal(`The media should be served from the database`)
+	}
+
+	if w.Code != http.StatusOK || w.Body.String() != "jpeg content" {
+		t.Fatalf(`Unexpected response: %d %q`, w.Code, w.Body.String())
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "image/jpeg" {
+		t.Errorf(`Unexpected content type: %q`, contentType)
+	}
+
+	if disposition := w.Header().Get("Content-Disposition"); disposition != `inline; filename="photo.jpg"` {
+		t.Errorf(`Unexpected content disposition: %q`, disposition)
+	}
+
+	if w.Header().Get("ETag") == "" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf(`Missing cache headers: %v`, w.Header())
+	}
+}
+
+func TestServeCachedMediaFromDisk(t *testing.T) {
+	setupMediaProxyConfig(t, false)
+
+	mediaURL := "https://example.org/images/photo.png"
+	urlHash := media.URLHash(mediaURL)
+	mediaPath := filesystem.MediaFilePath(urlHash)
+	if err := os.MkdirAll(filepath.Dir(mediaPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mediaPath, []byte("png content"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &fakeMediaCacheStore{media: &model.Media{URLHash: urlHash, MimeType: "image/png"}}
+	w, served := serveTestMedia(t, store, mediaURL, nil)
+	if !served {
+		t.Fatal(`The media should be served from the file system`)
+	}
+
+	if w.Code != http.StatusOK || w.Body.String() != "png content" {
+		t.Fatalf(`Unexpected response: %d %q`, w.Code, w.Body.String())
+	}
+
+	if w.Header().Get("Last-Modified") == "" {
+		t.Errorf(`The modification date of the file should be sent`)
+	}
+}
+
+func TestServeCachedMediaNotCached(t *testing.T) {
+	setupMediaProxyConfig(t, false)
+
+	if _, served := serveTestMedia(t, &fakeMediaCacheStore{}, "https://example.org/images/photo.png", nil); served {
+		t.Fatal(`A media missing from the cache should be proxied`)
+	}
+}
+
+func TestServeCachedMediaNotModified(t *testing.T) {
+	setupMediaProxyConfig(t, false)
+
+	mediaURL := "https://example.org/images/photo.jpg"
+	store := &fakeMediaCacheStore{media: &model.Media{URLHash: media.URLHash(mediaURL), MimeType: "image/jpeg", Content: []byte("jpeg content")}}
+
+	w, _ := serveTestMedia(t, store, mediaURL, nil)
+	etag := w.Header().Get("ETag")
+
+	w, served := serveTestMedia(t, store, mediaURL, http.Header{"If-None-Match": {etag}})
+	if !served {
+		t.Fatal(`The media should be served from the database`)
+	}
+
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf(`Expected a 304 response without body, got %d %q`, w.Code, w.Body.String())
+	}
+}
+
+func TestServeCachedMediaRange(t *testing.T) {
+	setupMediaProxyConfig(t, false)
+
+	mediaURL := "https://example.org/videos/clip.mp4"
+	store := &fakeMediaCacheStore{media: &model.Media{URLHash: media.URLHash(mediaURL), MimeType: "video/mp4", Content: []byte("0123456789")}}
+
+	w, served := serveTestMedia(t, store, mediaURL, http.Header{"Range": {"bytes=2-5"}})
+	if !served {
+		t.Fatal(`The media should be served from the database`)
+	}
+
+	if w.Code != http.StatusPartialContent || w.Body.String() != "2345" {
+		t.Fatalf(`Unexpected response: %d %q`, w.Code, w.Body.String())
+	}
+
+	if contentRange := w.Header().Get("Content-Range"); contentRange != "bytes 2-5/10" {
+		t.Errorf(`Unexpected content range: %q`, contentRange)
+	}
+}
+
+func TestServeCachedMediaWriteThrough(t *testing.T) {
+	setupMediaProxyConfig(t, true)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/gif")
+		w.Write([]byte("gif content"))
+	}))
+	defer origin.Close()
+
+	mediaURL := origin.URL + "/images/animation.gif"
+	store := &fakeMediaCacheStore{uncached: &model.Media{ID: 1, URL: mediaURL, URLHash: media.URLHash(mediaURL)}}
+
+	// Partial requests are proxied, the whole media is cached on the next full request.
+	if _, served := serveTestMedia(t, store, mediaURL, http.Header{"Range": {"bytes=0-1"}}); served || len(store.cachedMedias) != 0 {
+		t.Fatal(`A partial request should not cache the media`)
+	}
+
+	w, served := serveTestMedia(t, store, mediaURL, nil)
+	if !served {
+		t.Fatal(`The media should be downloaded and served`)
+	}
+
+	if w.Code != http.StatusOK || w.Body.String() != "gif content" {
+		t.Fatalf(`Unexpected response: %d %q`, w.Code, w.Body.String())
+	}
+
+	if contentType := w.Header().Get("Content-Type"); contentType != "image/gif" {
+		t.Errorf(`Unexpected content type: %q`, contentType)
+	}
+
+	if len(store.cachedMedias) != 1 || string(store.cachedMedias[0].Content) != "gif content" {
+		t.Fatalf(`The media should be cached: %+v`, store.cachedMedias)
+	}
+}
+
+func TestServeCachedMediaWriteThroughDisabled(t *testing.T) {
+	setupMediaProxyConfig(t, false)
+
+	mediaURL := "https://example.org/images/animation.gif"
+	store := &fakeMediaCacheStore{uncached: &model.Media{ID: 1, URL: mediaURL, URLHash: media.URLHash(mediaURL)}}
+
+	if _, served := serveTestMedia(t, store, mediaURL, nil); served || len(store.cachedMedias) != 0 {
+		t.Fatal(`The media should be proxied without being cached`)
+	}
+}
@@ -7,32 +7,39 @@ import (
 	"net/http"
 	"time"
 
+	"miniflux.app/v2/internal/activitypub"
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/http/response/html"
 	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/ui/session"
 	"miniflux.app/v2/internal/ui/view"
 )
 
 func (h *handler) createSharedEntry(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
 	entryID := request.RouteInt64Param(r, "entryID")
-	shareCode, err := h.store.EntryShareCode(request.UserID(r), entryID)
+	shareCode, err := h.store.EntryShareCode(userID, entryID)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
+	integration.SendEntriesSharedEvent(h.store, userID, []int64{entryID})
 
 	html.Redirect(w, r, route.Path(h.router, "sharedEntry", "shareCode", shareCode))
 }
 
 func (h *handler) unshareEntry(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
 	entryID := request.RouteInt64Param(r, "entryID")
-	if err := h.store.UnshareEntry(request.UserID(r), entryID); err != nil {
+	if err := h.store.UnshareEntry(userID, entryID); err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
+	integration.SendEntriesSharedEvent(h.store, userID, []int64{entryID})
 
 	html.Redirect(w, r, route.Path(h.router, "sharedEntries"))
 }
@@ -44,6 +51,15 @@ func (h *handler) sharedEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The shared entries are also the objects published by the ActivityPub actors.
+	if config.Opts.HasActivityPub() {
+		w.Header().Add("Vary", "Accept")
+		if activitypub.AcceptsActivityJSON(r) {
+			activitypub.ServeObject(w, r, h.store, shareCode)
+			return
+		}
+	}
+
 	etag := shareCode
 	response.New(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
 		builder := storage.NewAnonymousQueryBuilder(h.store)
@@ -55,7 +55,8 @@ func (h *handler) showStarredPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.hasSaveEntry(user.ID))
+	view.Set("hasBookSender", h.hasBookSender(user.ID))
 	view.Set("pageEntriesType", "starred")
 
 	html.OK(w, r, view.Render("starred_entries"))
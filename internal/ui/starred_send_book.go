@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/http/route"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/ui/session"
+)
+
+func (h *handler) sendStarredBook(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(user.Language)
+	sess := session.New(h.store, request.SessionID(r))
+
+	count, err := integration.SendStarredBook(h.store, user)
+	switch {
+	case errors.Is(err, integration.ErrNoBookIntegration):
+		sess.NewFlashErrorMessage(printer.Print("alert.no_ereader_integration"))
+	case err != nil:
+		slog.Error("Unable to send the starred entries to the e-reader",
+			slog.Int64("user_id", user.ID),
+			slog.Any("error", err),
+		)
+		sess.NewFlashErrorMessage(printer.Print("alert.starred_book_failed"))
+	case count == 0:
+		sess.NewFlashErrorMessage(printer.Print("alert.no_starred"))
+	default:
+		sess.NewFlashMessage(printer.Plural("alert.starred_book_sent", count, count))
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "starred"))
+}
@@ -93,7 +93,7 @@ func (h *handler) showStatPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("countUnread", countUnread)
 	view.Set("countStarred", countStarred)
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID, nsfw))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.hasSaveEntry(user.ID))
 
 	html.OK(w, r, view.Render("stat"))
 }
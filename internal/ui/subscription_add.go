@@ -27,10 +27,17 @@ func (h *handler) showAddSubscriptionPage(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	clientCertificates, err := h.store.ClientCertificates(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	nsfw := request.IsNSFWEnabled(r)
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("categories", categories)
+	view.Set("clientCertificates", clientCertificates)
 	view.Set("menu", "feeds")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
@@ -31,6 +31,12 @@ func (h *handler) bookmarklet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	clientCertificates, err := h.store.ClientCertificates(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	bookmarkletURL := request.QueryStringParam(r, "uri", "")
 
 	// Extract URL from text supplied by Web Share Target API.
@@ -49,6 +55,7 @@ func (h *handler) bookmarklet(w http.ResponseWriter, r *http.Request) {
 	view := view.New(h.tpl, r, sess)
 	view.Set("form", form.SubscriptionForm{URL: bookmarkletURL})
 	view.Set("categories", categories)
+	view.Set("clientCertificates", clientCertificates)
 	view.Set("menu", "feeds")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
@@ -30,10 +30,17 @@ func (h *handler) showChooseSubscriptionPage(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	clientCertificates, err := h.store.ClientCertificates(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	nsfw := request.IsNSFWEnabled(r)
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
 	view.Set("categories", categories)
+	view.Set("clientCertificates", clientCertificates)
 	view.Set("menu", "feeds")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
@@ -68,6 +75,8 @@ func (h *handler) showChooseSubscriptionPage(w http.ResponseWriter, r *http.Requ
 		NSFW:                        subscriptionForm.NSFW,
 		DisableHTTP2:                subscriptionForm.DisableHTTP2,
 		ProxyURL:                    subscriptionForm.ProxyURL,
+		CustomHeaders:               subscriptionForm.CustomHeaders,
+		ClientCertificateID:         subscriptionForm.ClientCertificateID,
 	})
 	if localizedError != nil {
 		view.Set("form", subscriptionForm)
@@ -34,10 +34,17 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	clientCertificates, err := h.store.ClientCertificates(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	nsfw := request.IsNSFWEnabled(r)
 	sess := session.New(h.store, request.SessionID(r))
 	v := view.New(h.tpl, r, sess)
 	v.Set("categories", categories)
+	v.Set("clientCertificates", clientCertificates)
 	v.Set("menu", "feeds")
 	v.Set("user", user)
 	v.Set("countUnread", h.store.CountUnreadEntries(user.ID, nsfw))
@@ -60,7 +67,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 		rssBridgeToken = intg.RSSBridgeToken
 	}
 
-	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder := fetcher.NewRequestBuilder().Interactive()
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 	requestBuilder.WithCustomFeedProxyURL(subscriptionForm.ProxyURL)
@@ -68,6 +75,12 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 	requestBuilder.UseCustomApplicationProxyURL(subscriptionForm.FetchViaProxy)
 	requestBuilder.WithUserAgent(subscriptionForm.UserAgent, config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(subscriptionForm.Cookie)
+	requestBuilder.WithCustomHeaders(subscriptionForm.CustomHeaders, subscriptionForm.URL)
+	for _, clientCertificate := range clientCertificates {
+		if clientCertificate.ID == subscriptionForm.ClientCertificateID {
+			requestBuilder.WithClientCertificate(clientCertificate.Certificate, clientCertificate.PrivateKey, clientCertificate.CABundle, subscriptionForm.URL)
+		}
+	}
 	requestBuilder.WithUsernameAndPassword(subscriptionForm.Username, subscriptionForm.Password)
 	requestBuilder.IgnoreTLSErrors(subscriptionForm.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(subscriptionForm.DisableHTTP2)
@@ -116,6 +129,8 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 				NSFW:                        subscriptionForm.NSFW,
 				DisableHTTP2:                subscriptionForm.DisableHTTP2,
 				ProxyURL:                    subscriptionForm.ProxyURL,
+				CustomHeaders:               subscriptionForm.CustomHeaders,
+				ClientCertificateID:         subscriptionForm.ClientCertificateID,
 			},
 		})
 		if localizedError != nil {
@@ -146,6 +161,8 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 			FetchViaProxy:               subscriptionForm.FetchViaProxy,
 			DisableHTTP2:                subscriptionForm.DisableHTTP2,
 			ProxyURL:                    subscriptionForm.ProxyURL,
+			CustomHeaders:               subscriptionForm.CustomHeaders,
+			ClientCertificateID:         subscriptionForm.ClientCertificateID,
 		})
 		if localizedError != nil {
 			v.Set("form", subscriptionForm)
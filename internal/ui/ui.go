@@ -55,6 +55,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Starred pages.
 	uiRouter.HandleFunc("/starred", handler.showStarredPage).Name("starred").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/starred/entry/{entryID}", handler.showStarredEntryPage).Name("starredEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/starred/ereader", handler.sendStarredBook).Name("sendStarredBook").Methods(http.MethodPost)
 
 	// Search pages.
 	uiRouter.HandleFunc("/search", handler.showSearchPage).Name("search").Methods(http.MethodGet)
@@ -74,6 +75,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Individual feed pages.
 	uiRouter.HandleFunc("/feed/{feedID}/refresh", handler.refreshFeed).Name("refreshFeed").Methods(http.MethodGet, http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/refresh", handler.refreshFeed).Queries("forceRefresh", "{forceRefresh:true|false}").Name("refreshFeed").Methods(http.MethodGet, http.MethodPost)
+	uiRouter.HandleFunc("/feed/{feedID}/backfill", handler.backfillFeed).Name("backfillFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/edit", handler.showEditFeedPage).Name("editFeed").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feed/{feedID}/remove", handler.removeFeed).Name("removeFeed").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/feed/{feedID}/update", handler.updateFeed).Name("updateFeed").Methods(http.MethodPost)
@@ -139,11 +141,20 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/users/{userID}/update", handler.updateUser).Name("updateUser").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/users/{userID}/remove", handler.removeUser).Name("removeUser").Methods(http.MethodPost)
 
+	// Refresh queue pages.
+	uiRouter.HandleFunc("/refresh-queue", handler.showRefreshQueuePage).Name("refreshQueue").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/refresh-queue/{feedID}/cancel", handler.cancelRefreshJob).Name("cancelRefreshJob").Methods(http.MethodPost)
+
 	// Settings pages.
 	uiRouter.HandleFunc("/settings", handler.showSettingsPage).Name("settings").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/settings", handler.updateSettings).Name("updateSettings").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/integrations", handler.showIntegrationPage).Name("integrations").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/integration", handler.updateIntegration).Name("updateIntegration").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/integrations/deliveries", handler.showIntegrationDeliveriesPage).Name("integrationDeliveries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/integrations/deliveries/{deliveryID}/resend", handler.resendIntegrationDelivery).Name("resendIntegrationDelivery").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/integrations/{provider}/connect", handler.connectIntegrationOAuth2).Name("connectIntegrationOAuth2").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/integrations/{provider}/callback", handler.integrationOAuth2Callback).Name("integrationOAuth2Callback").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/integrations/{provider}/disconnect", handler.disconnectIntegrationOAuth2).Name("disconnectIntegrationOAuth2").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/about", handler.showAboutPage).Name("about").Methods(http.MethodGet)
 
 	// Session pages.
@@ -158,6 +169,30 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 		uiRouter.HandleFunc("/keys/save", handler.saveAPIKey).Name("saveAPIKey").Methods(http.MethodPost)
 	}
 
+	// Domain rules pages.
+	uiRouter.HandleFunc("/domain-rules", handler.showDomainRulesPage).Name("domainRules").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/domain-rules/create", handler.showCreateDomainRulePage).Name("createDomainRule").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/domain-rules/save", handler.saveDomainRule).Name("saveDomainRule").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/domain-rules/export", handler.exportDomainRules).Name("exportDomainRules").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/domain-rules/import", handler.importDomainRules).Name("importDomainRules").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/domain-rules/{domainRuleID}/edit", handler.showEditDomainRulePage).Name("editDomainRule").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/domain-rules/{domainRuleID}/update", handler.updateDomainRule).Name("updateDomainRule").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/domain-rules/{domainRuleID}/remove", handler.removeDomainRule).Name("removeDomainRule").Methods(http.MethodPost)
+
+	// Notification routes pages.
+	uiRouter.HandleFunc("/notification-routes", handler.showNotificationRoutesPage).Name("notificationRoutes").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/notification-routes/create", handler.showCreateNotificationRoutePage).Name("createNotificationRoute").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/notification-routes/save", handler.saveNotificationRoute).Name("saveNotificationRoute").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/notification-routes/{routeID}/edit", handler.showEditNotificationRoutePage).Name("editNotificationRoute").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/notification-routes/{routeID}/update", handler.updateNotificationRoute).Name("updateNotificationRoute").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/notification-routes/{routeID}/remove", handler.removeNotificationRoute).Name("removeNotificationRoute").Methods(http.MethodPost)
+
+	// Client certificates pages.
+	uiRouter.HandleFunc("/client-certificates", handler.showClientCertificatesPage).Name("clientCertificates").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/client-certificates/create", handler.showCreateClientCertificatePage).Name("createClientCertificate").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/client-certificates/save", handler.saveClientCertificate).Name("saveClientCertificate").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/client-certificates/{clientCertificateID}/remove", handler.removeClientCertificate).Name("removeClientCertificate").Methods(http.MethodPost)
+
 	// OPML pages.
 	uiRouter.HandleFunc("/export", handler.exportFeeds).Name("export").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/import", handler.showImportPage).Name("import").Methods(http.MethodGet)
@@ -8,19 +8,26 @@ import (
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response/json"
+	"miniflux.app/v2/internal/integration"
+	"miniflux.app/v2/internal/model"
 )
 
 func (h *handler) markAllAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var entryIDs []int64
 	var err error
 	if request.IsNSFWEnabled(r) {
-		err = h.store.MarkAllAsReadExceptNSFW(request.UserID(r))
+		entryIDs, err = h.store.MarkAllAsReadExceptNSFW(userID)
 	} else {
-		err = h.store.MarkAllAsRead(request.UserID(r))
+		entryIDs, err = h.store.MarkAllAsRead(userID)
 	}
 
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
+
+	integration.SendEntriesStatusEvent(h.store, userID, entryIDs, model.EntryStatusRead)
 	json.OK(w, r, "OK")
 }
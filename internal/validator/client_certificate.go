@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateClientCertificateCreation validates the upload of a client certificate and its CA bundle.
+func ValidateClientCertificateCreation(store *storage.Storage, userID int64, request *model.ClientCertificateCreationRequest) *locale.LocalizedError {
+	if request.Name == "" {
+		return locale.NewLocalizedError("error.fields_mandatory")
+	}
+
+	if store.ClientCertificateExists(userID, request.Name) {
+		return locale.NewLocalizedError("error.client_certificate_already_exists")
+	}
+
+	if _, _, err := fetcher.ParseClientCertificate(request.Certificate, request.PrivateKey, request.CABundle); err != nil {
+		return locale.NewLocalizedError("error.invalid_client_certificate", err)
+	}
+
+	return nil
+}
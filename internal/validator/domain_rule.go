@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateDomainRuleCreation validates domain rule creation.
+func ValidateDomainRuleCreation(store *storage.Storage, user *model.User, request *model.DomainRuleCreationRequest) *locale.LocalizedError {
+	if err := validateDomainRuleFields(user, request.Global, request.Domain, request.ScraperRules, request.RewriteRules); err != nil {
+		return err
+	}
+
+	ownerID := user.ID
+	if request.Global {
+		ownerID = 0
+	}
+
+	if store.DomainRuleExists(ownerID, model.NormalizeRuleDomain(request.Domain)) {
+		return locale.NewLocalizedError("error.domain_rule_already_exists")
+	}
+
+	return nil
+}
+
+// ValidateDomainRuleModification validates domain rule modification.
+func ValidateDomainRuleModification(store *storage.Storage, user *model.User, rule *model.DomainRule, request *model.DomainRuleModificationRequest) *locale.LocalizedError {
+	domain := rule.Domain
+	if request.Domain != nil {
+		domain = *request.Domain
+	}
+
+	scraperRules := rule.ScraperRules
+	if request.ScraperRules != nil {
+		scraperRules = *request.ScraperRules
+	}
+
+	rewriteRules := rule.RewriteRules
+	if request.RewriteRules != nil {
+		rewriteRules = *request.RewriteRules
+	}
+
+	if err := validateDomainRuleFields(user, rule.IsGlobal(), domain, scraperRules, rewriteRules); err != nil {
+		return err
+	}
+
+	if store.AnotherDomainRuleExists(rule.UserID, rule.ID, model.NormalizeRuleDomain(domain)) {
+		return locale.NewLocalizedError("error.domain_rule_already_exists")
+	}
+
+	return nil
+}
+
+// ValidateDomainRulesImport validates a list of domain rules to import.
+func ValidateDomainRulesImport(user *model.User, requests []model.DomainRuleCreationRequest) *locale.LocalizedError {
+	for _, request := range requests {
+		if err := validateDomainRuleFields(user, request.Global, request.Domain, request.ScraperRules, request.RewriteRules); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDomainRuleFields(user *model.User, global bool, domain, scraperRules, rewriteRules string) *locale.LocalizedError {
+	if global && !user.IsAdmin {
+		return locale.NewLocalizedError("error.domain_rule_global_forbidden")
+	}
+
+	if domain == "" {
+		return locale.NewLocalizedError("error.fields_mandatory")
+	}
+
+	if !IsValidDomain(model.NormalizeRuleDomain(domain)) {
+		return locale.NewLocalizedError("error.invalid_domain_rule_domain")
+	}
+
+	if scraperRules == "" && rewriteRules == "" {
+		return locale.NewLocalizedError("error.domain_rule_empty")
+	}
+
+	return nil
+}
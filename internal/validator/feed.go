@@ -6,6 +6,8 @@ package validator // import "miniflux.app/v2/internal/validator"
 import (
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/extractor"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/storage"
 )
 
@@ -39,6 +41,14 @@ func ValidateFeedCreation(store *storage.Storage, userID int64, request *model.F
 		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
 	}
 
+	if _, _, err := fetcher.ParseCustomHeaders(request.CustomHeaders); err != nil {
+		return locale.NewLocalizedError("error.feed_invalid_custom_headers")
+	}
+
+	if request.ClientCertificateID != 0 && !store.ClientCertificateIDExists(userID, request.ClientCertificateID) {
+		return locale.NewLocalizedError("error.client_certificate_not_found")
+	}
+
 	return nil
 }
 
@@ -102,5 +112,21 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	if request.Extractor != nil && !extractor.IsValid(*request.Extractor) {
+		return locale.NewLocalizedError("error.feed_invalid_extractor")
+	}
+
+	if request.CustomHeaders != nil {
+		if _, _, err := fetcher.ParseCustomHeaders(*request.CustomHeaders); err != nil {
+			return locale.NewLocalizedError("error.feed_invalid_custom_headers")
+		}
+	}
+
+	if request.ClientCertificateID != nil && *request.ClientCertificateID != 0 {
+		if !store.ClientCertificateIDExists(userID, *request.ClientCertificateID) {
+			return locale.NewLocalizedError("error.client_certificate_not_found")
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateNotificationRoute validates the category, the feed, the filter rules and the priority of a notification route.
+func ValidateNotificationRoute(store *storage.Storage, route *model.NotificationRoute) *locale.LocalizedError {
+	if route.CategoryID != 0 && !store.CategoryIDExists(route.UserID, route.CategoryID) {
+		return locale.NewLocalizedError("error.category_not_found")
+	}
+
+	if route.FeedID != 0 && !store.FeedExists(route.UserID, route.FeedID) {
+		return locale.NewLocalizedError("error.feed_not_found")
+	}
+
+	if route.FilterRules != "" {
+		if err := isValidFilterRules(route.FilterRules, "notification"); err != nil {
+			return err
+		}
+	}
+
+	if route.Priority < model.NotificationPriorityMin || route.Priority > model.NotificationPriorityMax {
+		return locale.NewLocalizedError("error.notification_route_invalid_priority")
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ package validator // import "miniflux.app/v2/internal/validator"
 import (
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
 )
 
 // ValidateSubscriptionDiscovery validates subscription discovery requests.
@@ -18,5 +19,9 @@ func ValidateSubscriptionDiscovery(request *model.SubscriptionDiscoveryRequest)
 		return locale.NewLocalizedError("error.invalid_proxy_url")
 	}
 
+	if _, _, err := fetcher.ParseCustomHeaders(request.CustomHeaders); err != nil {
+		return locale.NewLocalizedError("error.feed_invalid_custom_headers")
+	}
+
 	return nil
 }
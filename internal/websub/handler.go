@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/websub"
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/http/response/html"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/storage"
+
+	"github.com/gorilla/mux"
+)
+
+var errUnexpectedIntent = errors.New("websub: unexpected intent verification")
+
+// Serve handles the callbacks of WebSub hubs.
+func Serve(router *mux.Router, store *storage.Storage) {
+	h := &callbackHandler{store}
+
+	sr := router.PathPrefix("/websub").Subrouter()
+	sr.HandleFunc("/{feedID}", h.verifyIntent).Name("webSubVerifyIntent").Methods(http.MethodGet)
+	sr.HandleFunc("/{feedID}", h.receiveContent).Name("webSubReceiveContent").Methods(http.MethodPost)
+}
+
+type callbackHandler struct {
+	store *storage.Storage
+}
+
+func (h *callbackHandler) verifyIntent(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	subscription, err := h.store.WebSubSubscription(feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	mode := r.URL.Query().Get("hub.mode")
+	if mode == "unsubscribe" && subscription == nil {
+		// The feed or its hub is gone: confirm the hub can stop pushing.
+		writeChallenge(w, r, r.URL.Query().Get("hub.challenge"))
+		return
+	}
+
+	if subscription == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	challenge, err := applyIntent(subscription, r.URL.Query(), time.Now())
+	if err != nil {
+		slog.Warn("Refusing WebSub intent verification",
+			slog.Int64("feed_id", feedID),
+			slog.String("hub_mode", mode),
+			slog.String("hub_topic", r.URL.Query().Get("hub.topic")),
+			slog.Any("error", err),
+		)
+		html.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.UpdateWebSubSubscription(subscription); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	slog.Debug("WebSub intent verified",
+		slog.Int64("feed_id", feedID),
+		slog.String("hub_mode", mode),
+		slog.String("state", subscription.State),
+		slog.Time("lease_expires_at", subscription.LeaseExpiresAt),
+	)
+
+	writeChallenge(w, r, challenge)
+}
+
+func (h *callbackHandler) receiveContent(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+
+	subscription, err := h.store.WebSubSubscription(feedID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if subscription == nil || subscription.State != model.WebSubStateVerified {
+		html.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, config.Opts.HTTPClientMaxBodySize()))
+	if err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	// The hub expects a success response even when the signature does not match, the content is simply dropped.
+	if !VerifySignature(subscription.Secret, body, r.Header.Get("X-Hub-Signature")) {
+		slog.Warn("Ignoring WebSub content with an invalid signature",
+			slog.Int64("feed_id", feedID),
+			slog.String("hub_url", subscription.HubURL),
+		)
+		response.New(w, r).WithStatus(http.StatusAccepted).Write()
+		return
+	}
+
+	go func() {
+		if localizedError := handler.RefreshFeedFromContent(h.store, subscription.UserID, feedID, body); localizedError != nil {
+			slog.Warn("Unable to process WebSub content",
+				slog.Int64("user_id", subscription.UserID),
+				slog.Int64("feed_id", feedID),
+				slog.Any("error", localizedError.Error()),
+			)
+		}
+	}()
+
+	response.New(w, r).WithStatus(http.StatusAccepted).Write()
+}
+
+// applyIntent validates an intent verification request from the hub and updates the subscription accordingly.
+// It returns the challenge to echo back.
+func applyIntent(subscription *model.WebSubSubscription, values url.Values, now time.Time) (string, error) {
+	if values.Get("hub.topic") != subscription.TopicURL {
+		return "", errUnexpectedIntent
+	}
+
+	switch values.Get("hub.mode") {
+	case "subscribe":
+		if subscription.State != model.WebSubStateRequested && subscription.State != model.WebSubStateVerified {
+			return "", errUnexpectedIntent
+		}
+
+		challenge := values.Get("hub.challenge")
+		if challenge == "" {
+			return "", errUnexpectedIntent
+		}
+
+		leaseDuration := config.Opts.WebSubLeaseDuration()
+		if leaseSeconds, err := strconv.Atoi(values.Get("hub.lease_seconds")); err == nil && leaseSeconds > 0 {
+			leaseDuration = time.Duration(leaseSeconds) * time.Second
+		}
+
+		subscription.State = model.WebSubStateVerified
+		subscription.LeaseExpiresAt = now.Add(leaseDuration)
+		return challenge, nil
+	case "denied":
+		subscription.State = model.WebSubStateDenied
+		subscription.LeaseExpiresAt = time.Time{}
+		return "", nil
+	default:
+		return "", errUnexpectedIntent
+	}
+}
+
+func writeChallenge(w http.ResponseWriter, r *http.Request, challenge string) {
+	builder := response.New(w, r)
+	builder.WithHeader("Content-Type", "text/plain; charset=utf-8")
+	builder.WithBody(challenge)
+	builder.WithoutCompression()
+	builder.Write()
+}
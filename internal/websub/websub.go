@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/storage"
+)
+
+const (
+	defaultClientTimeout = 10 * time.Second
+
+	// Subscriptions are renewed when their lease expires within this delay.
+	renewalMargin = 24 * time.Hour
+)
+
+// CallbackURL returns the URL used by hubs to verify intents and deliver content for a feed.
+func CallbackURL(feedID int64) string {
+	return fmt.Sprintf("%s/websub/%d", config.Opts.BaseURL(), feedID)
+}
+
+// Subscribe sends a subscription request to the hub.
+// The hub verifies the intent asynchronously by calling the callback URL.
+// The request builder holds the proxy and TLS settings of the feed.
+func Subscribe(requestBuilder *fetcher.RequestBuilder, hubURL, topicURL, callbackURL, secret string, leaseDuration time.Duration) error {
+	values := url.Values{}
+	values.Set("hub.mode", "subscribe")
+	values.Set("hub.topic", topicURL)
+	values.Set("hub.callback", callbackURL)
+	values.Set("hub.secret", secret)
+	values.Set("hub.lease_seconds", strconv.Itoa(int(leaseDuration.Seconds())))
+
+	response, err := requestBuilder.ExecutePostRequest(hubURL, "application/x-www-form-urlencoded", []byte(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("websub: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("websub: incorrect response status code %d for hub %s", response.StatusCode, hubURL)
+	}
+
+	return nil
+}
+
+// newRequestBuilder returns a request builder with the proxy and TLS settings of the feed.
+// The credentials and the custom headers of the feed are not sent to the hub.
+func newRequestBuilder(feed *model.Feed) *fetcher.RequestBuilder {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithTimeout(defaultClientTimeout)
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+	return requestBuilder
+}
+
+// VerifySignature checks the X-Hub-Signature header of a content distribution request.
+// The header has the form "method=signature", where method is sha1, sha256, sha384 or sha512.
+func VerifySignature(secret string, body []byte, signatureHeader string) bool {
+	if secret == "" {
+		return false
+	}
+
+	method, signature, found := strings.Cut(strings.TrimSpace(signatureHeader), "=")
+	if !found {
+		return false
+	}
+
+	var newHash func() hash.Hash
+	switch strings.ToLower(method) {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return false
+	}
+
+	expectedSignature, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expectedSignature)
+}
+
+// RenewSubscriptions sends the pending subscriptions and renews the leases about to expire.
+func RenewSubscriptions(store *storage.Storage, limit int) {
+	subscriptions, err := store.WebSubSubscriptionsToRenew(time.Now().Add(renewalMargin), limit)
+	if err != nil {
+		slog.Error("Unable to fetch WebSub subscriptions to renew", slog.Any("error", err))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		renewSubscription(store, subscription)
+	}
+}
+
+func renewSubscription(store *storage.Storage, subscription *model.WebSubSubscription) {
+	feed, err := store.FeedByID(subscription.UserID, subscription.FeedID)
+	if err != nil || feed == nil {
+		slog.Error("Unable to load the feed of the WebSub subscription", slog.Int64("feed_id", subscription.FeedID), slog.Any("error", err))
+		return
+	}
+
+	// Keep the secret across renewals: the hub keeps signing with the previous one until the new intent is verified.
+	if subscription.Secret == "" {
+		subscription.Secret = crypto.GenerateRandomStringHex(32)
+	}
+
+	if err := Subscribe(newRequestBuilder(feed), subscription.HubURL, subscription.TopicURL, CallbackURL(subscription.FeedID), subscription.Secret, config.Opts.WebSubLeaseDuration()); err != nil {
+		slog.Warn("Unable to subscribe to WebSub hub",
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.String("topic_url", subscription.TopicURL),
+			slog.Any("error", err),
+		)
+		subscription.State = model.WebSubStateDenied
+	} else {
+		slog.Debug("WebSub subscription requested",
+			slog.Int64("feed_id", subscription.FeedID),
+			slog.String("hub_url", subscription.HubURL),
+			slog.String("topic_url", subscription.TopicURL),
+		)
+		subscription.State = model.WebSubStateRequested
+	}
+
+	if err := store.UpdateWebSubSubscription(subscription); err != nil {
+		slog.Error("Unable to update WebSub subscription", slog.Int64("feed_id", subscription.FeedID), slog.Any("error", err))
+	}
+}
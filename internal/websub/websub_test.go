@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package websub // import "miniflux.app/v2/internal/websub"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+func TestMain(m *testing.M) {
+	os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestSubscribeSendsFormToHub(t *testing.T) {
+	var received url.Values
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		received = r.PostForm
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer hub.Close()
+
+	if err := Subscribe(fetcher.NewRequestBuilder(), hub.URL, "https://example.org/feed", "https://reader.example.org/websub/1", "secret", 48*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"hub.mode":          "subscribe",
+		"hub.topic":         "https://example.org/feed",
+		"hub.callback":      "https://reader.example.org/websub/1",
+		"hub.secret":        "secret",
+		"hub.lease_seconds": "172800",
+	}
+	for key, value := range expected {
+		if received.Get(key) != value {
+			t.Errorf(`Unexpected value for %q, got %q instead of %q`, key, received.Get(key), value)
+		}
+	}
+}
+
+func TestSubscribeWithHubError(t *testing.T) {
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "topic not allowed", http.StatusBadRequest)
+	}))
+	defer hub.Close()
+
+	if err := Subscribe(fetcher.NewRequestBuilder(), hub.URL, "https://example.org/feed", "https://reader.example.org/websub/1", "secret", time.Hour); err == nil {
+		t.Fatal(`A hub error should be returned`)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`<feed xmlns="http://www.w3.org/2005/Atom"></feed>`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	scenarios := []struct {
+		secret string
+		header string
+		valid  bool
+	}{
+		{"secret", "sha256=" + signature, true},
+		{"secret", "SHA256=" + signature, true},
+		{"another secret", "sha256=" + signature, false},
+		{"secret", "sha1=" + signature, false},
+		{"secret", "md5=" + signature, false},
+		{"secret", signature, false},
+		{"secret", "sha256=not-hex", false},
+		{"secret", "", false},
+		{"", "sha256=" + signature, false},
+	}
+
+	for _, scenario := range scenarios {
+		if valid := VerifySignature(scenario.secret, body, scenario.header); valid != scenario.valid {
+			t.Errorf(`Unexpected result for secret %q and header %q, got %v instead of %v`, scenario.secret, scenario.header, valid, scenario.valid)
+		}
+	}
+}
+
+func TestApplySubscribeIntent(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	subscription := &model.WebSubSubscription{TopicURL: "https://example.org/feed", State: model.WebSubStateRequested}
+
+	values := url.Values{}
+	values.Set("hub.mode", "subscribe")
+	values.Set("hub.topic", "https://example.org/feed")
+	values.Set("hub.challenge", "abc")
+	values.Set("hub.lease_seconds", "3600")
+
+	challenge, err := applyIntent(subscription, values, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if challenge != "abc" {
+		t.Errorf(`Unexpected challenge, got %q`, challenge)
+	}
+
+	if subscription.State != model.WebSubStateVerified {
+		t.Errorf(`Unexpected state, got %q`, subscription.State)
+	}
+
+	if !subscription.LeaseExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf(`Unexpected lease expiration, got %v`, subscription.LeaseExpiresAt)
+	}
+}
+
+func TestApplySubscribeIntentWithDefaultLease(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	subscription := &model.WebSubSubscription{TopicURL: "https://example.org/feed", State: model.WebSubStateVerified}
+
+	values := url.Values{}
+	values.Set("hub.mode", "subscribe")
+	values.Set("hub.topic", "https://example.org/feed")
+	values.Set("hub.challenge", "abc")
+
+	if _, err := applyIntent(subscription, values, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if !subscription.LeaseExpiresAt.Equal(now.Add(config.Opts.WebSubLeaseDuration())) {
+		t.Errorf(`Unexpected lease expiration, got %v`, subscription.LeaseExpiresAt)
+	}
+}
+
+func TestApplyIntentRefusesUnexpectedRequests(t *testing.T) {
+	scenarios := []struct {
+		state string
+		mode  string
+		topic string
+	}{
+		{model.WebSubStateRequested, "subscribe", "https://example.org/other"},
+		{model.WebSubStatePending, "subscribe", "https://example.org/feed"},
+		{model.WebSubStateVerified, "unsubscribe", "https://example.org/feed"},
+		{model.WebSubStateVerified, "", "https://example.org/feed"},
+	}
+
+	for _, scenario := range scenarios {
+		subscription := &model.WebSubSubscription{TopicURL: "https://example.org/feed", State: scenario.state}
+
+		values := url.Values{}
+		values.Set("hub.mode", scenario.mode)
+		values.Set("hub.topic", scenario.topic)
+		values.Set("hub.challenge", "abc")
+
+		if _, err := applyIntent(subscription, values, time.Now()); err == nil {
+			t.Errorf(`The intent %q for topic %q in state %q should be refused`, scenario.mode, scenario.topic, scenario.state)
+		}
+
+		if subscription.State != scenario.state {
+			t.Errorf(`The state should not change, got %q instead of %q`, subscription.State, scenario.state)
+		}
+	}
+}
+
+func TestApplyDeniedIntent(t *testing.T) {
+	subscription := &model.WebSubSubscription{TopicURL: "https://example.org/feed", State: model.WebSubStateRequested}
+
+	values := url.Values{}
+	values.Set("hub.mode", "denied")
+	values.Set("hub.topic", "https://example.org/feed")
+
+	if _, err := applyIntent(subscription, values, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if subscription.State != model.WebSubStateDenied {
+		t.Errorf(`Unexpected state, got %q`, subscription.State)
+	}
+}
+
+func TestCallbackURL(t *testing.T) {
+	if callbackURL := CallbackURL(42); callbackURL != "http://localhost/websub/42" {
+		t.Errorf(`Unexpected callback URL, got %q`, callbackURL)
+	}
+}
+
+func TestSubscribeUsesFeedProxy(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer proxy.Close()
+
+	feed := &model.Feed{ProxyURL: proxy.URL}
+	if err := Subscribe(newRequestBuilder(feed), "http://hub.example.org/", "https://example.org/feed", "https://reader.example.org/websub/1", "secret", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if proxiedURL != "http://hub.example.org/" {
+		t.Errorf(`The subscription request should be sent through the proxy of the feed, got %q`, proxiedURL)
+	}
+}
@@ -4,31 +4,287 @@
 package worker // import "miniflux.app/v2/internal/worker"
 
 import (
+	"container/list"
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
 )
 
-// Pool handles a pool of workers.
+// Priority defines the order in which the queued jobs are processed.
+type Priority int
+
+const (
+	// PriorityScheduled is used for the jobs created by the background scheduler.
+	PriorityScheduled Priority = iota
+
+	// PriorityUser is used for the refreshes requested by a user, they are processed first.
+	PriorityUser
+)
+
+// Scheduled jobs are dropped when this many jobs are queued, the scheduler picks up the feeds again later.
+const defaultMaxQueuedJobs = 10000
+
+func (p Priority) String() string {
+	if p == PriorityUser {
+		return "user"
+	}
+	return "scheduled"
+}
+
+// JobStatus describes a job waiting in the queue or being processed.
+type JobStatus struct {
+	model.Job
+	Priority  Priority
+	QueuedAt  time.Time
+	StartedAt time.Time
+	WorkerID  int
+
+	// rerun is set when a user asks to refresh the feed while it is being processed.
+	rerun bool
+}
+
+// Pool handles a pool of workers fed by a prioritized queue.
+// A feed is never queued twice: pushing a job for a feed already queued only raises its priority.
+// Scheduled jobs for feeds being refreshed are ignored, while user jobs run again once the current refresh is done.
 type Pool struct {
-	queue chan model.Job
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	queues    [PriorityUser + 1]*list.List
+	queued    map[int64]*list.Element
+	inFlight  map[int64]*JobStatus
+	maxQueued int
+	closed    bool
+	workers   sync.WaitGroup
 }
 
-// Push send a list of jobs to the queue.
+// Push sends a list of scheduled jobs to the queue.
 func (p *Pool) Push(jobs model.JobList) {
+	p.PushWithPriority(jobs, PriorityScheduled)
+}
+
+// PushWithPriority sends a list of jobs to the queue with the given priority.
+func (p *Pool) PushWithPriority(jobs model.JobList, priority Priority) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		slog.Warn("Jobs discarded, the worker pool is shutting down", slog.Int("nb_jobs", len(jobs)))
+		return
+	}
+
+	now := time.Now()
+	droppedJobs := 0
 	for _, job := range jobs {
-		p.queue <- job
+		if status, found := p.inFlight[job.FeedID]; found {
+			// The refresh in progress may have started before the change the user expects to see.
+			if priority == PriorityUser {
+				status.rerun = true
+			}
+			continue
+		}
+
+		if element, found := p.queued[job.FeedID]; found {
+			status := element.Value.(*JobStatus)
+			if status.Priority >= priority {
+				continue
+			}
+
+			p.queues[status.Priority].Remove(element)
+			status.Priority = priority
+			p.queued[job.FeedID] = p.queues[priority].PushBack(status)
+			continue
+		}
+
+		if priority == PriorityScheduled && len(p.queued) >= p.maxQueued {
+			droppedJobs++
+			continue
+		}
+
+		p.queued[job.FeedID] = p.queues[priority].PushBack(&JobStatus{Job: job, Priority: priority, QueuedAt: now})
+		p.cond.Signal()
+	}
+
+	if droppedJobs > 0 {
+		slog.Warn("Jobs discarded, the worker queue is full",
+			slog.Int("nb_jobs", droppedJobs),
+			slog.Int("max_queued_jobs", p.maxQueued),
+		)
+	}
+
+	p.updateMetrics()
+}
+
+// Cancel removes the job of the given feed from the queue.
+// Jobs already processed by a worker cannot be canceled.
+func (p *Pool) Cancel(feedID int64) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	element, found := p.queued[feedID]
+	if !found {
+		return false
+	}
+
+	p.queues[element.Value.(*JobStatus).Priority].Remove(element)
+	delete(p.queued, feedID)
+	p.updateMetrics()
+	return true
+}
+
+// QueuedJobs returns the jobs waiting in the queue, in processing order.
+func (p *Pool) QueuedJobs() []JobStatus {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	jobs := make([]JobStatus, 0, len(p.queued))
+	for priority := len(p.queues) - 1; priority >= 0; priority-- {
+		for element := p.queues[priority].Front(); element != nil; element = element.Next() {
+			jobs = append(jobs, *element.Value.(*JobStatus))
+		}
+	}
+	return jobs
+}
+
+// InFlightJobs returns the jobs being processed, the oldest first.
+func (p *Pool) InFlightJobs() []JobStatus {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	jobs := make([]JobStatus, 0, len(p.inFlight))
+	for _, status := range p.inFlight {
+		jobs = append(jobs, *status)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt.Before(jobs[j].StartedAt)
+	})
+	return jobs
+}
+
+// Shutdown stops accepting new jobs and lets the workers drain the queue.
+// Once the context is done, the remaining queued jobs are dropped and the jobs in flight are abandoned,
+// the scheduler will pick up the feeds again after a restart.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mutex.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		p.mutex.Lock()
+		droppedJobs := len(p.queued)
+		for _, queue := range p.queues {
+			queue.Init()
+		}
+		clear(p.queued)
+		p.updateMetrics()
+		p.mutex.Unlock()
+
+		slog.Warn("Worker pool shutdown timed out",
+			slog.Int("dropped_jobs", droppedJobs),
+			slog.Int("jobs_in_flight", len(p.InFlightJobs())),
+		)
+		return ctx.Err()
+	}
+}
+
+// next waits for a job to process, it returns false when the pool is closed and the queue is empty.
+func (p *Pool) next(workerID int) (model.Job, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for len(p.queued) == 0 {
+		if p.closed {
+			return model.Job{}, false
+		}
+		p.cond.Wait()
+	}
+
+	var status *JobStatus
+	for priority := len(p.queues) - 1; priority >= 0; priority-- {
+		if element := p.queues[priority].Front(); element != nil {
+			status = p.queues[priority].Remove(element).(*JobStatus)
+			break
+		}
+	}
+
+	delete(p.queued, status.FeedID)
+	status.StartedAt = time.Now()
+	status.WorkerID = workerID
+	p.inFlight[status.FeedID] = status
+	p.updateMetrics()
+
+	return status.Job, true
+}
+
+// done marks the job of the given feed as processed, the job is queued again when a user asked for a new refresh meanwhile.
+func (p *Pool) done(feedID int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	status := p.inFlight[feedID]
+	delete(p.inFlight, feedID)
+
+	if status != nil && status.rerun && !p.closed {
+		p.queued[feedID] = p.queues[PriorityUser].PushBack(&JobStatus{Job: status.Job, Priority: PriorityUser, QueuedAt: time.Now()})
+		p.cond.Signal()
 	}
+
+	p.updateMetrics()
+}
+
+// updateMetrics must be called with the mutex locked.
+func (p *Pool) updateMetrics() {
+	if config.Opts == nil || !config.Opts.HasMetricsCollector() {
+		return
+	}
+
+	for priority, queue := range p.queues {
+		metric.WorkerQueueDepth.WithLabelValues(Priority(priority).String()).Set(float64(queue.Len()))
+	}
+	metric.WorkerJobsInFlight.Set(float64(len(p.inFlight)))
+}
+
+func newPool() *Pool {
+	pool := &Pool{
+		queued:    make(map[int64]*list.Element),
+		inFlight:  make(map[int64]*JobStatus),
+		maxQueued: defaultMaxQueuedJobs,
+	}
+	pool.cond = sync.NewCond(&pool.mutex)
+	for i := range pool.queues {
+		pool.queues[i] = list.New()
+	}
+	return pool
 }
 
 // NewPool creates a pool of background workers.
 func NewPool(store *storage.Storage, nbWorkers int) *Pool {
-	workerPool := &Pool{
-		queue: make(chan model.Job),
-	}
+	workerPool := newPool()
 
 	for i := range nbWorkers {
 		worker := &worker{id: i, store: store}
-		go worker.Run(workerPool.queue)
+		workerPool.workers.Add(1)
+		go func() {
+			defer workerPool.workers.Done()
+			worker.Run(workerPool)
+		}()
 	}
 
 	return workerPool
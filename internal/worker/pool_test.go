@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package worker // import "miniflux.app/v2/internal/worker"
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func feedIDs(jobs []JobStatus) []int64 {
+	ids := make([]int64, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.FeedID
+	}
+	return ids
+}
+
+func equalIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestUserJobsAreProcessedFirst(t *testing.T) {
+	pool := newPool()
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}, {FeedID: 3}})
+	pool.PushWithPriority(model.JobList{{FeedID: 4}}, PriorityUser)
+
+	var processed []int64
+	for range 4 {
+		job, ok := pool.next(0)
+		if !ok {
+			t.Fatal(`Expected a job`)
+		}
+		processed = append(processed, job.FeedID)
+		pool.done(job.FeedID)
+	}
+
+	if expected := []int64{4, 1, 2, 3}; !equalIDs(processed, expected) {
+		t.Errorf(`Unexpected processing order: got %v instead of %v`, processed, expected)
+	}
+}
+
+func TestJobsAreDeduplicated(t *testing.T) {
+	pool := newPool()
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}})
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}})
+
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{1, 2}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+
+	// A user refresh raises the priority of a queued job.
+	pool.PushWithPriority(model.JobList{{FeedID: 2}}, PriorityUser)
+	queuedJobs := pool.QueuedJobs()
+	if queued := feedIDs(queuedJobs); !equalIDs(queued, []int64{2, 1}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+
+	if queuedJobs[0].Priority != PriorityUser {
+		t.Errorf(`The job priority should be raised`)
+	}
+
+	// A scheduled job does not lower the priority.
+	pool.Push(model.JobList{{FeedID: 2}})
+	if queuedJobs := pool.QueuedJobs(); queuedJobs[0].FeedID != 2 || queuedJobs[0].Priority != PriorityUser {
+		t.Errorf(`The job priority should not be lowered`)
+	}
+
+	// Scheduled jobs being processed are not queued again.
+	job, _ := pool.next(7)
+	pool.Push(model.JobList{{FeedID: job.FeedID}})
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{1}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+
+	inFlightJobs := pool.InFlightJobs()
+	if len(inFlightJobs) != 1 || inFlightJobs[0].FeedID != job.FeedID || inFlightJobs[0].WorkerID != 7 {
+		t.Fatalf(`Unexpected jobs in flight: %+v`, inFlightJobs)
+	}
+
+	pool.done(job.FeedID)
+	if len(pool.InFlightJobs()) != 0 {
+		t.Fatal(`The job should not be in flight anymore`)
+	}
+
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{1}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+}
+
+func TestUserJobsRerunWhenInFlight(t *testing.T) {
+	pool := newPool()
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}})
+
+	job, _ := pool.next(0)
+	pool.PushWithPriority(model.JobList{{FeedID: job.FeedID}}, PriorityUser)
+	pool.PushWithPriority(model.JobList{{FeedID: job.FeedID}}, PriorityUser)
+
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{2}) {
+		t.Fatalf(`The feed being refreshed should not be queued yet: %v`, queued)
+	}
+
+	pool.done(job.FeedID)
+	queuedJobs := pool.QueuedJobs()
+	if queued := feedIDs(queuedJobs); !equalIDs(queued, []int64{job.FeedID, 2}) {
+		t.Fatalf(`The feed should be queued again once: %v`, queued)
+	}
+
+	if queuedJobs[0].Priority != PriorityUser {
+		t.Errorf(`The job should keep the user priority`)
+	}
+
+	// The job runs only once more.
+	job, _ = pool.next(0)
+	pool.done(job.FeedID)
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{2}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+}
+
+func TestScheduledJobsAreDroppedWhenQueueIsFull(t *testing.T) {
+	pool := newPool()
+	pool.maxQueued = 2
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}, {FeedID: 3}})
+
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{1, 2}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+
+	// User jobs are always accepted.
+	pool.PushWithPriority(model.JobList{{FeedID: 4}}, PriorityUser)
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{4, 1, 2}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	pool := newPool()
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}})
+
+	if !pool.Cancel(1) {
+		t.Fatal(`The job should be canceled`)
+	}
+
+	if pool.Cancel(1) {
+		t.Fatal(`The job should not be found`)
+	}
+
+	if queued := feedIDs(pool.QueuedJobs()); !equalIDs(queued, []int64{2}) {
+		t.Fatalf(`Unexpected queued jobs: %v`, queued)
+	}
+}
+
+func TestNextWaitsForJobs(t *testing.T) {
+	pool := newPool()
+
+	received := make(chan int64)
+	go func() {
+		job, _ := pool.next(0)
+		received <- job.FeedID
+	}()
+
+	pool.Push(model.JobList{{FeedID: 42}})
+
+	select {
+	case feedID := <-received:
+		if feedID != 42 {
+			t.Fatalf(`Unexpected job: %d`, feedID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal(`The waiting worker should receive the job`)
+	}
+}
+
+func TestShutdownDrainsQueue(t *testing.T) {
+	pool := newPool()
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}})
+
+	var processed []int64
+	pool.workers.Add(1)
+	go func() {
+		defer pool.workers.Done()
+		for {
+			job, ok := pool.next(0)
+			if !ok {
+				return
+			}
+			processed = append(processed, job.FeedID)
+			pool.done(job.FeedID)
+		}
+	}()
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if !equalIDs(processed, []int64{1, 2}) {
+		t.Fatalf(`The queue should be drained, processed %v`, processed)
+	}
+
+	pool.Push(model.JobList{{FeedID: 3}})
+	if len(pool.QueuedJobs()) != 0 {
+		t.Fatal(`No job should be accepted after the shutdown`)
+	}
+}
+
+func TestShutdownTimeoutDropsQueuedJobs(t *testing.T) {
+	pool := newPool()
+	pool.Push(model.JobList{{FeedID: 1}, {FeedID: 2}})
+
+	// A worker stuck on its first job.
+	pool.next(0)
+	pool.workers.Add(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := pool.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf(`Expected a timeout, got %v`, err)
+	}
+
+	if len(pool.QueuedJobs()) != 0 {
+		t.Fatal(`The queued jobs should be dropped`)
+	}
+}
@@ -9,7 +9,6 @@ import (
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/metric"
-	"miniflux.app/v2/internal/model"
 	feedHandler "miniflux.app/v2/internal/reader/handler"
 	"miniflux.app/v2/internal/storage"
 )
@@ -20,14 +19,19 @@ type worker struct {
 	store *storage.Storage
 }
 
-// Run wait for a job and refresh the given feed.
-func (w *worker) Run(c <-chan model.Job) {
+// Run waits for a job and refreshes the given feed until the pool is shut down.
+func (w *worker) Run(pool *Pool) {
 	slog.Debug("Worker started",
 		slog.Int("worker_id", w.id),
 	)
 
 	for {
-		job := <-c
+		job, ok := pool.next(w.id)
+		if !ok {
+			slog.Debug("Worker stopped", slog.Int("worker_id", w.id))
+			return
+		}
+
 		slog.Debug("Job received by worker",
 			slog.Int("worker_id", w.id),
 			slog.Int64("user_id", job.UserID),
@@ -45,5 +49,7 @@ func (w *worker) Run(c <-chan model.Job) {
 			}
 			metric.BackgroundFeedRefreshDuration.WithLabelValues(status).Observe(time.Since(startTime).Seconds())
 		}
+
+		pool.done(job.FeedID)
 	}
 }